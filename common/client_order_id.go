@@ -0,0 +1,141 @@
+package common
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 客户自定义订单ID格式: <策略标签>-<会话>-<序号>, 满足 ^[\.A-Z\:/a-z0-9_-]{1,36}$ 的限制
+//   策略标签: 字母、数字和下划线, 最长 12 位
+//   会话:     生成器创建时间的毫秒时间戳和 2 位随机数, 36 进制共 10 位, 重启后不会重复
+//   序号:     会话内自增, 36 进制
+
+const (
+	// MaxClientOrderIDLength max length of a client order id accepted by Binance | 客户自定义订单ID的最大长度
+	MaxClientOrderIDLength = 36
+	// MaxStrategyTagLength max length of the strategy tag in generated ids | 策略标签的最大长度
+	MaxStrategyTagLength = 12
+
+	clientOrderIDSeparator = "-"
+	sessionTimeLength      = 8
+	sessionRandomLength    = 2
+	sessionLength          = sessionTimeLength + sessionRandomLength
+)
+
+// ClientOrderID define the decoded parts of a generated client order id | 解析后的客户自定义订单ID
+type ClientOrderID struct {
+	Tag     string // 策略标签
+	Session string // 会话
+	Seq     int64  // 会话内序号, 从 1 开始
+	Time    int64  // 会话开始时间(毫秒)
+}
+
+// ClientOrderIDGenerator generate client order ids carrying a strategy tag, unique across restarts | 客户自定义订单ID生成器
+type ClientOrderIDGenerator struct {
+	tag     string
+	session string
+	mu      sync.Mutex
+	seq     int64
+}
+
+// NewClientOrderIDGenerator create a generator tagging ids with tag. Characters other than letters, digits and
+// underscores are dropped and the tag is cut to MaxStrategyTagLength | 创建客户自定义订单ID生成器
+func NewClientOrderIDGenerator(tag string) *ClientOrderIDGenerator {
+	random, err := rand.Int(rand.Reader, big.NewInt(36*36))
+	if err != nil {
+		random = big.NewInt(time.Now().UnixNano() % (36 * 36))
+	}
+	session := padBase36(time.Now().UnixMilli(), sessionTimeLength) + padBase36(random.Int64(), sessionRandomLength)
+	return &ClientOrderIDGenerator{tag: NormalizeStrategyTag(tag), session: session}
+}
+
+// Tag return the default strategy tag | 默认策略标签
+func (g *ClientOrderIDGenerator) Tag() string {
+	return g.tag
+}
+
+// Session return the session of the generator | 会话
+func (g *ClientOrderIDGenerator) Session() string {
+	return g.session
+}
+
+// Next return a new id with the default strategy tag | 生成客户自定义订单ID
+func (g *ClientOrderIDGenerator) Next() string {
+	return g.next(g.tag)
+}
+
+// NextTagged return a new id with the strategy tag, normalized as in NewClientOrderIDGenerator | 生成指定策略标签的客户自定义订单ID
+func (g *ClientOrderIDGenerator) NextTagged(tag string) string {
+	return g.next(NormalizeStrategyTag(tag))
+}
+
+// Owns report whether id was generated by this generator | 是否由本生成器生成
+func (g *ClientOrderIDGenerator) Owns(id string) bool {
+	parsed, ok := ParseClientOrderID(id)
+	return ok && parsed.Session == g.session
+}
+
+func (g *ClientOrderIDGenerator) next(tag string) string {
+	g.mu.Lock()
+	g.seq++
+	seq := g.seq
+	g.mu.Unlock()
+	return tag + clientOrderIDSeparator + g.session + clientOrderIDSeparator + strconv.FormatInt(seq, 36)
+}
+
+// ParseClientOrderID decode an id made by ClientOrderIDGenerator, ok is false for other ids
+// such as ids assigned by the exchange or by other tools | 解析客户自定义订单ID
+func ParseClientOrderID(id string) (res *ClientOrderID, ok bool) {
+	if len(id) > MaxClientOrderIDLength {
+		return nil, false
+	}
+	parts := strings.Split(id, clientOrderIDSeparator)
+	if len(parts) != 3 || NormalizeStrategyTag(parts[0]) != parts[0] || len(parts[1]) != sessionLength || !isBase36(parts[1]) || !isBase36(parts[2]) {
+		return nil, false
+	}
+	seq, err := strconv.ParseInt(parts[2], 36, 64)
+	if err != nil || seq <= 0 {
+		return nil, false
+	}
+	t, _ := strconv.ParseInt(parts[1][:sessionTimeLength], 36, 64)
+	return &ClientOrderID{Tag: parts[0], Session: parts[1], Seq: seq, Time: t}, true
+}
+
+// NormalizeStrategyTag drop the characters not allowed in a strategy tag and cut it to MaxStrategyTagLength | 规范化策略标签
+func NormalizeStrategyTag(tag string) string {
+	var b strings.Builder
+	for _, r := range tag {
+		if b.Len() == MaxStrategyTagLength {
+			break
+		}
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// padBase36 format v in base 36, left padded with zeros to width
+func padBase36(v int64, width int) string {
+	s := strconv.FormatInt(v, 36)
+	if len(s) < width {
+		s = strings.Repeat("0", width-len(s)) + s
+	}
+	return s
+}
+
+func isBase36(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,204 @@
+package common
+
+import (
+	"context"
+	"errors"
+)
+
+// 交易所无关的统一接口: 现货、U本位合约、币本位合约、杠杆账户通过各自包中的 Adapter 实现,
+// 执行逻辑只需针对这里的接口和模型编写一次
+
+// ErrNotSupported error returned when a venue does not support the operation | 交易场所不支持该操作时返回
+var ErrNotSupported = errors.New("operation not supported")
+
+// Side define order side | 买卖方向
+type Side string
+
+// OrderType define normalized order type, named after the futures types | 统一的订单类型, 沿用合约的命名
+type OrderType string
+
+// OrderStatus define order status | 订单状态
+type OrderStatus string
+
+// TimeInForce define time in force | 有效方式
+type TimeInForce string
+
+// PositionSide define position side | 持仓方向
+type PositionSide string
+
+const (
+	SideBuy  Side = "BUY"  // 买入
+	SideSell Side = "SELL" // 卖出
+
+	OrderTypeLimit              OrderType = "LIMIT"                // 限价单
+	OrderTypeMarket             OrderType = "MARKET"               // 市价单
+	OrderTypeLimitMaker         OrderType = "LIMIT_MAKER"          // 只做 maker 限价单, 合约对应 GTX 限价单
+	OrderTypeStop               OrderType = "STOP"                 // 止损限价单, 现货对应 STOP_LOSS_LIMIT
+	OrderTypeStopMarket         OrderType = "STOP_MARKET"          // 止损市价单, 现货对应 STOP_LOSS
+	OrderTypeTakeProfit         OrderType = "TAKE_PROFIT"          // 止盈限价单, 现货对应 TAKE_PROFIT_LIMIT
+	OrderTypeTakeProfitMarket   OrderType = "TAKE_PROFIT_MARKET"   // 止盈市价单, 现货对应 TAKE_PROFIT
+	OrderTypeTrailingStopMarket OrderType = "TRAILING_STOP_MARKET" // 跟踪止损单, 仅合约
+
+	OrderStatusNew             OrderStatus = "NEW"              // 新建订单
+	OrderStatusPartiallyFilled OrderStatus = "PARTIALLY_FILLED" // 部分成交
+	OrderStatusFilled          OrderStatus = "FILLED"           // 全部成交
+	OrderStatusCanceled        OrderStatus = "CANCELED"         // 已撤销
+	OrderStatusPendingCancel   OrderStatus = "PENDING_CANCEL"   // 撤销中
+	OrderStatusRejected        OrderStatus = "REJECTED"         // 订单被拒绝
+	OrderStatusExpired         OrderStatus = "EXPIRED"          // 订单过期
+
+	TimeInForceGTC TimeInForce = "GTC" // 成交为止
+	TimeInForceIOC TimeInForce = "IOC" // 无法立即成交的部分就撤销
+	TimeInForceFOK TimeInForce = "FOK" // 无法全部立即成交就撤销
+	TimeInForceGTX TimeInForce = "GTX" // 无法成为挂单方就撤销
+
+	PositionSideBoth  PositionSide = "BOTH"  // 单向持仓
+	PositionSideLong  PositionSide = "LONG"  // 多头
+	PositionSideShort PositionSide = "SHORT" // 空头
+)
+
+// Final report whether the order can no longer change | 订单是否已结束
+func (s OrderStatus) Final() bool {
+	switch s {
+	case OrderStatusFilled, OrderStatusCanceled, OrderStatusRejected, OrderStatusExpired:
+		return true
+	}
+	return false
+}
+
+// Kline define normalized kline | K线
+type Kline struct {
+	OpenTime    int64   // 开盘时间
+	Open        float64 // 开盘价
+	High        float64 // 最高价
+	Low         float64 // 最低价
+	Close       float64 // 收盘价
+	Volume      float64 // 成交量, 币本位合约为张数
+	CloseTime   int64   // 收盘时间
+	QuoteVolume float64 // 成交额, 币本位合约为基础资产数量
+	TradeNum    int64   // 成交笔数
+}
+
+// BookTicker define best bid and ask | 最优挂单
+type BookTicker struct {
+	Symbol      string  // 交易对
+	BidPrice    float64 // 最优买单价
+	BidQuantity float64 // 最优买单挂单量
+	AskPrice    float64 // 最优卖单价
+	AskQuantity float64 // 最优卖单挂单量
+	Time        int64   // 撮合引擎时间, 现货为 0
+}
+
+// OrderRequest define a new order | 下单请求
+type OrderRequest struct {
+	Symbol        string       // 交易对
+	Side          Side         // 买卖方向
+	PositionSide  PositionSide // 持仓方向, 仅合约; 为空时不发送
+	Type          OrderType    // 订单类型
+	TimeInForce   TimeInForce  // 有效方式, 限价单为空时使用 GTC
+	Quantity      float64      // 数量
+	Price         float64      // 价格
+	StopPrice     float64      // 触发价
+	ClientOrderID string       // 客户自定义订单ID
+	ReduceOnly    bool         // 仅减仓, 仅合约
+}
+
+// Order define normalized order | 订单
+type Order struct {
+	Symbol           string       // 交易对
+	OrderID          int64        // 系统订单号
+	ClientOrderID    string       // 客户自定义订单ID
+	Side             Side         // 买卖方向
+	PositionSide     PositionSide // 持仓方向, 现货和杠杆为空
+	Type             OrderType    // 订单类型
+	TimeInForce      TimeInForce  // 有效方式
+	Status           OrderStatus  // 订单状态
+	Price            float64      // 委托价格
+	StopPrice        float64      // 触发价
+	Quantity         float64      // 委托数量
+	ExecutedQuantity float64      // 成交量
+	QuoteQuantity    float64      // 成交额, 币本位合约为成交的基础资产数量
+	AvgPrice         float64      // 平均成交价
+	ReduceOnly       bool         // 仅减仓
+	Time             int64        // 下单时间
+	UpdateTime       int64        // 更新时间
+}
+
+// Fill define one trade of an order | 成交
+type Fill struct {
+	Symbol          string       // 交易对
+	TradeID         int64        // 成交ID
+	OrderID         int64        // 订单号
+	Side            Side         // 买卖方向
+	PositionSide    PositionSide // 持仓方向, 现货和杠杆为空
+	Price           float64      // 成交价
+	Quantity        float64      // 成交量
+	QuoteQuantity   float64      // 成交额, 币本位合约为基础资产数量
+	Commission      float64      // 手续费
+	CommissionAsset string       // 手续费资产
+	RealizedPnl     float64      // 实现盈亏, 仅合约
+	Maker           bool         // 是否是挂单方
+	Time            int64        // 成交时间
+}
+
+// Balance define normalized balance of one asset | 资产余额
+type Balance struct {
+	Asset    string  // 资产
+	Total    float64 // 总余额: 现货为 free+locked, 合约为钱包余额, 杠杆为净资产
+	Free     float64 // 可用余额
+	Locked   float64 // 冻结余额
+	Borrowed float64 // 借款, 仅杠杆
+	Interest float64 // 利息, 仅杠杆
+}
+
+// Position define normalized position | 持仓
+type Position struct {
+	Symbol           string       // 交易对
+	PositionSide     PositionSide // 持仓方向
+	Amount           float64      // 持仓数量, 符号代表多空方向
+	EntryPrice       float64      // 开仓均价
+	MarkPrice        float64      // 标记价格
+	UnrealizedProfit float64      // 未实现盈亏
+	LiquidationPrice float64      // 参考强平价格
+	Leverage         int          // 杠杆倍数
+	Isolated         bool         // 是否逐仓
+	Notional         float64      // 名义价值
+	UpdateTime       int64        // 更新时间
+}
+
+// MarketData define market data queries | 行情接口
+type MarketData interface {
+	// Klines return the latest klines of symbol, oldest first | 查询K线
+	Klines(ctx context.Context, symbol string, interval string, limit int) ([]*Kline, error)
+	// BookTicker return the best bid and ask of symbol | 查询最优挂单
+	BookTicker(ctx context.Context, symbol string) (*BookTicker, error)
+}
+
+// OrderGateway define order placement and queries. Orders are identified by orderID, or by clientOrderID when orderID is 0 | 订单接口
+type OrderGateway interface {
+	// CreateOrder place a new order | 下单
+	CreateOrder(ctx context.Context, req *OrderRequest) (*Order, error)
+	// CancelOrder cancel an open order | 撤单
+	CancelOrder(ctx context.Context, symbol string, orderID int64, clientOrderID string) (*Order, error)
+	// GetOrder query an order | 查询订单
+	GetOrder(ctx context.Context, symbol string, orderID int64, clientOrderID string) (*Order, error)
+	// ListOpenOrders query open orders of symbol, or of all symbols when symbol is empty | 查询当前挂单
+	ListOpenOrders(ctx context.Context, symbol string) ([]*Order, error)
+	// ListFills query trades of symbol between startTime and endTime, 0 for no limit | 查询成交历史
+	ListFills(ctx context.Context, symbol string, startTime, endTime int64) ([]*Fill, error)
+}
+
+// Account define balance and position queries | 账户接口
+type Account interface {
+	// Balances return balances of all assets | 查询资产余额
+	Balances(ctx context.Context) ([]*Balance, error)
+	// Positions return open positions, empty for venues without positions | 查询持仓
+	Positions(ctx context.Context) ([]*Position, error)
+}
+
+// Exchange define a venue with market data, orders and account | 完整的交易场所接口
+type Exchange interface {
+	MarketData
+	OrderGateway
+	Account
+}
@@ -0,0 +1,307 @@
+package common
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DownloadStage define the stage of an asynchronous history download | 异步下载阶段
+type DownloadStage string
+
+// 异步下载阶段
+const (
+	DownloadStageRequested   DownloadStage = "REQUESTED"   // 已获取下载ID
+	DownloadStageProcessing  DownloadStage = "PROCESSING"  // 交易所生成文件中
+	DownloadStageReady       DownloadStage = "READY"       // 下载链接已就绪
+	DownloadStageDownloading DownloadStage = "DOWNLOADING" // 下载并解析中
+	DownloadStageDone        DownloadStage = "DONE"        // 全部解析完成
+)
+
+// DownloadProgress define the progress of an asynchronous history download | 异步下载进度
+type DownloadProgress struct {
+	Stage      DownloadStage // 阶段
+	DownloadID string        // 下载ID
+	Attempt    int           // 查询下载链接的次数
+	Wait       time.Duration // 距下次查询的等待时间, 仅 PROCESSING 阶段
+	Elapsed    time.Duration // 自获取下载ID起经过的时间
+	Rows       int           // 已解析的行数, 仅 DOWNLOADING 和 DONE 阶段
+}
+
+// DownloadProgressHandler handle DownloadProgress | 异步下载进度回调
+type DownloadProgressHandler func(p *DownloadProgress)
+
+// DownloadBackoff define how the download link is polled | 下载链接轮询间隔
+type DownloadBackoff struct {
+	Initial time.Duration // 首次查询前的等待时间
+	Max     time.Duration // 最大等待时间
+	Factor  float64       // 每次未就绪后等待时间的倍数
+	Timeout time.Duration // 等待链接就绪的总时长, 0 表示只受 ctx 限制
+}
+
+// DefaultDownloadBackoff polls after 5s, doubling up to 1m, for at most 1h | 默认轮询间隔
+var DefaultDownloadBackoff = DownloadBackoff{
+	Initial: 5 * time.Second,
+	Max:     time.Minute,
+	Factor:  2,
+	Timeout: time.Hour,
+}
+
+// ErrDownloadTimeout returned when the download link is not ready within DownloadBackoff.Timeout | 等待下载链接超时
+var ErrDownloadTimeout = errors.New("download link not ready before timeout")
+
+// downloadProgressRows 下载时每解析多少行回调一次进度
+const downloadProgressRows = 1000
+
+// DownloadLinkPoller query the download link once, ready is false while the file is still processing | 查询下载链接
+type DownloadLinkPoller func(ctx context.Context) (url string, ready bool, err error)
+
+// WaitDownloadLink poll until the download link is ready, sleeping per backoff between attempts.
+// hint is the average processing time reported by the exchange, used as the first wait when longer than backoff.Initial | 等待下载链接就绪
+func WaitDownloadLink(ctx context.Context, downloadID string, hint time.Duration, poll DownloadLinkPoller, backoff DownloadBackoff, progress DownloadProgressHandler) (string, error) {
+	if backoff.Initial <= 0 {
+		backoff.Initial = DefaultDownloadBackoff.Initial
+	}
+	if backoff.Max < backoff.Initial {
+		backoff.Max = backoff.Initial
+	}
+	if backoff.Factor < 1 {
+		backoff.Factor = 1
+	}
+	start := time.Now()
+	wait := backoff.Initial
+	// 交易所给出的平均耗时只用于首次等待, 且不超过总时长
+	if hint > wait {
+		wait = hint
+		if backoff.Timeout > 0 && wait > backoff.Timeout {
+			wait = backoff.Timeout
+		}
+	}
+	for attempt := 1; ; attempt++ {
+		if backoff.Timeout > 0 && time.Since(start)+wait > backoff.Timeout {
+			return "", ErrDownloadTimeout
+		}
+		reportDownload(progress, &DownloadProgress{Stage: DownloadStageProcessing, DownloadID: downloadID, Attempt: attempt - 1, Wait: wait, Elapsed: time.Since(start)})
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", ctx.Err()
+		case <-timer.C:
+		}
+		url, ready, err := poll(ctx)
+		if err != nil {
+			return "", err
+		}
+		if ready {
+			reportDownload(progress, &DownloadProgress{Stage: DownloadStageReady, DownloadID: downloadID, Attempt: attempt, Elapsed: time.Since(start)})
+			return url, nil
+		}
+		wait = time.Duration(float64(wait) * backoff.Factor)
+		if wait > backoff.Max {
+			wait = backoff.Max
+		}
+	}
+}
+
+// StreamCSV download url and call handler with every data row of the CSV file, in order.
+// Zip archives are opened and their CSV files read in turn. Returns the number of rows handled | 下载并逐行解析CSV
+func StreamCSV(ctx context.Context, client *http.Client, downloadID string, url string, handler func(row *CSVRow) error, progress DownloadProgressHandler) (rows int, err error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("download %s: %s", url, resp.Status)
+	}
+
+	counted := func(row *CSVRow) error {
+		if err := handler(row); err != nil {
+			return err
+		}
+		rows++
+		if rows%downloadProgressRows == 0 {
+			reportDownload(progress, &DownloadProgress{Stage: DownloadStageDownloading, DownloadID: downloadID, Rows: rows})
+		}
+		return nil
+	}
+	reportDownload(progress, &DownloadProgress{Stage: DownloadStageDownloading, DownloadID: downloadID})
+
+	body := bufio.NewReader(resp.Body)
+	magic, _ := body.Peek(4)
+	if bytes.Equal(magic, []byte("PK\x03\x04")) {
+		// zip 需要随机读取, 先读入内存
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return rows, err
+		}
+		archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return rows, err
+		}
+		for _, f := range archive.File {
+			if f.FileInfo().IsDir() || !strings.HasSuffix(strings.ToLower(f.Name), ".csv") {
+				continue
+			}
+			r, err := f.Open()
+			if err != nil {
+				return rows, err
+			}
+			err = readCSV(ctx, r, counted)
+			r.Close()
+			if err != nil {
+				return rows, err
+			}
+		}
+	} else if err = readCSV(ctx, body, counted); err != nil {
+		return rows, err
+	}
+	reportDownload(progress, &DownloadProgress{Stage: DownloadStageDone, DownloadID: downloadID, Rows: rows})
+	return rows, nil
+}
+
+// readCSV 读取表头后逐行回调
+func readCSV(ctx context.Context, r io.Reader, handler func(row *CSVRow) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		columns[normalizeColumn(name)] = i
+	}
+	row := &CSVRow{columns: columns}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		row.record = record
+		if err := handler(row); err != nil {
+			return err
+		}
+	}
+}
+
+// CSVRow define one data row of a downloaded CSV file. Columns are looked up by name ignoring case,
+// spaces and punctuation, so "Date(UTC)" matches "dateutc". The row is reused, copy values out of it | CSV数据行
+type CSVRow struct {
+	columns map[string]int
+	record  []string
+}
+
+// String return the value of the first present column of names, "" if none is present | 字符串值
+func (r *CSVRow) String(names ...string) string {
+	for _, name := range names {
+		if i, ok := r.columns[normalizeColumn(name)]; ok && i < len(r.record) {
+			return strings.TrimSpace(r.record[i])
+		}
+	}
+	return ""
+}
+
+// Has report whether any column of names is present | 是否包含列
+func (r *CSVRow) Has(names ...string) bool {
+	for _, name := range names {
+		if _, ok := r.columns[normalizeColumn(name)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Int64 return the value as int64, 0 if absent or invalid | 整数值
+func (r *CSVRow) Int64(names ...string) int64 {
+	v, _ := strconv.ParseInt(r.String(names...), 10, 64)
+	return v
+}
+
+// Float64 return the value as float64, 0 if absent or invalid | 浮点值
+func (r *CSVRow) Float64(names ...string) float64 {
+	v, _ := strconv.ParseFloat(r.String(names...), 64)
+	return v
+}
+
+// Bool return the value as bool, "true", "yes" and "1" are true | 布尔值
+func (r *CSVRow) Bool(names ...string) bool {
+	switch strings.ToLower(r.String(names...)) {
+	case "true", "yes", "1":
+		return true
+	}
+	return false
+}
+
+// Time return the value as a millisecond timestamp. Both timestamps and UTC date times such as
+// "2024-01-02 15:04:05" are accepted, 0 if absent or invalid | 毫秒时间戳
+func (r *CSVRow) Time(names ...string) int64 {
+	return ParseCSVTime(r.String(names...))
+}
+
+// ParseCSVTime parse a millisecond or second timestamp, or a UTC date time, into a millisecond timestamp | 解析时间
+func ParseCSVTime(s string) int64 {
+	if s == "" {
+		return 0
+	}
+	if v, err := strconv.ParseInt(s, 10, 64); err == nil {
+		// 10 位为秒级时间戳
+		if v < 1e11 {
+			return v * 1000
+		}
+		return v
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02 15:04:05.000", "06-01-02 15:04:05", "2006/01/02 15:04:05", time.RFC3339} {
+		if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return t.UnixMilli()
+		}
+	}
+	return 0
+}
+
+// normalizeColumn 列名只保留小写字母和数字
+func normalizeColumn(name string) string {
+	var b strings.Builder
+	for _, c := range strings.ToLower(name) {
+		if c >= 'a' && c <= 'z' || c >= '0' && c <= '9' {
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
+
+func reportDownload(handler DownloadProgressHandler, p *DownloadProgress) {
+	if handler != nil {
+		handler(p)
+	}
+}
@@ -0,0 +1,819 @@
+package common
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Venue define the account a ledger record comes from | 账户类型
+type Venue string
+
+// CostMethod define how sold quantities are matched against bought lots | 成本计算方法
+type CostMethod string
+
+// FlowType define the kind of a non-trade asset flow | 资金流水类型
+type FlowType string
+
+const (
+	VenueSpot     Venue = "SPOT"     // 现货
+	VenueMargin   Venue = "MARGIN"   // 杠杆
+	VenueFutures  Venue = "FUTURES"  // U本位合约
+	VenueDelivery Venue = "DELIVERY" // 币本位合约
+
+	CostMethodFIFO    CostMethod = "FIFO"    // 先进先出
+	CostMethodLIFO    CostMethod = "LIFO"    // 后进先出
+	CostMethodAverage CostMethod = "AVERAGE" // 移动加权平均
+
+	FlowRealizedPnl FlowType = "REALIZED_PNL" // 合约已实现盈亏
+	FlowCommission  FlowType = "COMMISSION"   // 合约手续费
+	FlowFunding     FlowType = "FUNDING_FEE"  // 资金费
+	FlowDividend    FlowType = "DIVIDEND"     // 分红、空投等
+	FlowDeposit     FlowType = "DEPOSIT"      // 充值
+	FlowWithdraw    FlowType = "WITHDRAW"     // 提现, 不含手续费
+	FlowWithdrawFee FlowType = "WITHDRAW_FEE" // 提现手续费
+	FlowTransfer    FlowType = "TRANSFER"     // 账户间划转
+	FlowOther       FlowType = "OTHER"        // 其他收入或支出
+)
+
+// Flow define a non-trade change of an asset, converted by ToCommonFlow and similar functions of each venue | 资金流水
+type Flow struct {
+	Venue   Venue    // 账户类型
+	Type    FlowType // 流水类型
+	ID      string   // 流水ID, 用于去重
+	Symbol  string   // 交易对, 仅合约流水
+	Asset   string   // 资产
+	Amount  float64  // 数量, 正数流入, 负数流出
+	TradeID int64    // 引起流水的成交ID, 用于与成交去重, 仅合约
+	TxID    string   // 链上交易ID, 仅充提
+	Info    string   // 备注
+	Time    int64    // 时间
+}
+
+// Conversion define an exchange of one asset into another outside the order book, such as convert and dust | 兑换
+type Conversion struct {
+	Venue      Venue   // 账户类型
+	ID         string  // 兑换ID, 用于去重
+	FromAsset  string  // 卖出资产
+	FromAmount float64 // 卖出数量
+	ToAsset    string  // 买入资产
+	ToAmount   float64 // 扣除手续费后实际得到的数量
+	Fee        float64 // 手续费
+	FeeAsset   string  // 手续费资产
+	Time       int64   // 时间
+}
+
+// IncomeFlowType map a futures income type to FlowType | 合约资金流水类型转换
+func IncomeFlowType(incomeType string) FlowType {
+	switch incomeType {
+	case "REALIZED_PNL":
+		return FlowRealizedPnl
+	case "COMMISSION":
+		return FlowCommission
+	case "FUNDING_FEE":
+		return FlowFunding
+	case "TRANSFER", "INTERNAL_TRANSFER", "CROSS_COLLATERAL_TRANSFER", "STRATEGY_UMFUTURES_TRANSFER", "COIN_SWAP_DEPOSIT", "COIN_SWAP_WITHDRAW":
+		return FlowTransfer
+	}
+	return FlowOther
+}
+
+// SymbolResolver return the base and quote asset of symbol, ok is false for unknown symbols | 交易对资产解析
+type SymbolResolver func(symbol string) (base, quote string, ok bool)
+
+// AssetPricer return the price of asset in quote at time, ok is false when unknown | 资产估值
+type AssetPricer func(asset, quote string, time int64) (price float64, ok bool)
+
+// Ledger collect fills, conversions and flows of all venues and compute realized PnL, fees and daily snapshots.
+// Records can be added in any order and repeated records are ignored; Report processes them by time | 盈亏与手续费账本
+type Ledger struct {
+	mu          sync.Mutex
+	symbols     map[string][2]string
+	resolver    SymbolResolver
+	pricer      AssetPricer
+	fills       []*ledgerFill
+	conversions []*Conversion
+	flows       []*Flow
+	seen        map[string]bool
+}
+
+type ledgerFill struct {
+	venue Venue
+	fill  *Fill
+}
+
+// ledgerQuoteAssets 未知交易对按后缀拆分时尝试的计价资产, 靠前的优先作为计价资产
+var ledgerQuoteAssets = []string{
+	"USDT", "FDUSD", "USDC", "TUSD", "BUSD", "DAI", "EUR", "TRY", "BRL", "JPY", "ARS", "MXN", "PLN", "RON", "ZAR", "UAH", "IDR", "USD",
+	"BTC", "ETH", "BNB", "XRP", "TRX", "DOGE", "DOT",
+}
+
+// NewLedger init an empty ledger | 创建账本
+func NewLedger() *Ledger {
+	return &Ledger{
+		symbols: make(map[string][2]string),
+		seen:    make(map[string]bool),
+	}
+}
+
+// SetSymbol set the base and quote asset of symbol, e.g. from exchange info | 设置交易对资产
+func (l *Ledger) SetSymbol(symbol string, base string, quote string) *Ledger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.symbols[symbol] = [2]string{base, quote}
+	return l
+}
+
+// SetSymbolResolver set the resolver used for symbols not set by SetSymbol. Symbols unknown to both are split
+// by well known quote asset suffixes | 设置交易对资产解析
+func (l *Ledger) SetSymbolResolver(resolver SymbolResolver) *Ledger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.resolver = resolver
+	return l
+}
+
+// SetPricer set the pricer used to value fees paid in a third asset, such as BNB, in the quote asset | 设置资产估值
+func (l *Ledger) SetPricer(pricer AssetPricer) *Ledger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pricer = pricer
+	return l
+}
+
+// AddFills add fills of venue, e.g. converted by ToCommonFill | 添加成交
+func (l *Ledger) AddFills(venue Venue, fills ...*Fill) *Ledger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, f := range fills {
+		if f == nil || !l.once("F|"+string(venue)+"|"+f.Symbol+"|"+strconv.FormatInt(f.TradeID, 10)) {
+			continue
+		}
+		l.fills = append(l.fills, &ledgerFill{venue: venue, fill: f})
+	}
+	return l
+}
+
+// AddConversions add convert trades and dust conversions | 添加兑换
+func (l *Ledger) AddConversions(conversions ...*Conversion) *Ledger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, c := range conversions {
+		if c == nil || c.ID != "" && !l.once("C|"+string(c.Venue)+"|"+c.ID) {
+			continue
+		}
+		l.conversions = append(l.conversions, c)
+	}
+	return l
+}
+
+// AddFlows add income, dividends, deposits and withdrawals | 添加资金流水
+func (l *Ledger) AddFlows(flows ...*Flow) *Ledger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, f := range flows {
+		if f == nil || f.ID != "" && !l.once("L|"+string(f.Venue)+"|"+string(f.Type)+"|"+f.ID) {
+			continue
+		}
+		l.flows = append(l.flows, f)
+	}
+	return l
+}
+
+// once 记录去重键, 首次出现时返回 true. Must hold the lock
+func (l *Ledger) once(key string) bool {
+	if l.seen[key] {
+		return false
+	}
+	l.seen[key] = true
+	return true
+}
+
+// counted 合约的已实现盈亏和手续费流水是否已由添加的成交计入. Must hold the lock
+func (l *Ledger) counted(f *Flow) bool {
+	if f.Type != FlowRealizedPnl && f.Type != FlowCommission || f.TradeID == 0 {
+		return false
+	}
+	return l.seen["F|"+string(f.Venue)+"|"+f.Symbol+"|"+strconv.FormatInt(f.TradeID, 10)]
+}
+
+// assets 交易对的基础资产和计价资产. Must hold the lock
+func (l *Ledger) assets(symbol string) (base, quote string) {
+	if a, ok := l.symbols[symbol]; ok {
+		return a[0], a[1]
+	}
+	if l.resolver != nil {
+		if base, quote, ok := l.resolver(symbol); ok {
+			return base, quote
+		}
+	}
+	// 币本位合约 BTCUSD_PERP, BTCUSD_240628
+	s := symbol
+	if i := strings.IndexByte(s, '_'); i > 0 {
+		s = s[:i]
+	}
+	for _, q := range ledgerQuoteAssets {
+		if len(s) > len(q) && strings.HasSuffix(s, q) {
+			return s[:len(s)-len(q)], q
+		}
+	}
+	return s, ""
+}
+
+// known 交易对是否已知. Must hold the lock
+func (l *Ledger) known(symbol string) bool {
+	if _, ok := l.symbols[symbol]; ok {
+		return true
+	}
+	if l.resolver != nil {
+		_, _, ok := l.resolver(symbol)
+		return ok
+	}
+	return false
+}
+
+// conversionFill 将兑换视为交易对上的成交: 优先使用已知交易对, 否则以更常见的计价资产作为计价资产. Must hold the lock
+func (l *Ledger) conversionFill(c *Conversion) (fill *Fill, base string, quote string) {
+	buy := true
+	switch {
+	case l.known(c.ToAsset + c.FromAsset):
+	case l.known(c.FromAsset + c.ToAsset):
+		buy = false
+	default:
+		buy = quoteRank(c.FromAsset) <= quoteRank(c.ToAsset)
+	}
+	fill = &Fill{Commission: c.Fee, CommissionAsset: c.FeeAsset, Time: c.Time}
+	// 成交中的数量为扣除手续费前的数量
+	gross := c.ToAmount
+	if c.FeeAsset == c.ToAsset {
+		gross += c.Fee
+	}
+	if buy {
+		base, quote = c.ToAsset, c.FromAsset
+		fill.Side, fill.Quantity, fill.QuoteQuantity = SideBuy, gross, c.FromAmount
+	} else {
+		base, quote = c.FromAsset, c.ToAsset
+		fill.Side, fill.Quantity, fill.QuoteQuantity = SideSell, c.FromAmount, gross
+	}
+	fill.Symbol = base + quote
+	if fill.Quantity != 0 {
+		fill.Price = fill.QuoteQuantity / fill.Quantity
+	}
+	return fill, base, quote
+}
+
+// quoteRank 计价资产的优先级, 越小越优先
+func quoteRank(asset string) int {
+	for i, q := range ledgerQuoteAssets {
+		if q == asset {
+			return i
+		}
+	}
+	return len(ledgerQuoteAssets)
+}
+
+// AssetPnL define the realized results of one asset | 单个资产的盈亏
+type AssetPnL struct {
+	Asset       string  // 资产
+	RealizedPnl float64 // 已实现盈亏, 不含手续费
+	Funding     float64 // 资金费, 正数为收取
+	Fees        float64 // 支付的手续费
+	Income      float64 // 分红等其他收入, 负数为支出
+	Deposits    float64 // 充值
+	Withdrawals float64 // 提现, 不含手续费
+	Transfers   float64 // 账户间划转净额
+}
+
+// Net return realized PnL plus funding and income minus fees | 净收益
+func (a *AssetPnL) Net() float64 {
+	return a.RealizedPnl + a.Funding + a.Income - a.Fees
+}
+
+// SymbolPnL define the realized results of one symbol of a venue. Amounts are in the quote asset,
+// except the COIN-M futures PnL which is in the base asset | 单个交易对的盈亏
+type SymbolPnL struct {
+	Venue          Venue   // 账户类型
+	Symbol         string  // 交易对
+	BaseAsset      string  // 基础资产
+	QuoteAsset     string  // 计价资产
+	RealizedPnl    float64 // 已实现盈亏, 不含手续费
+	Funding        float64 // 资金费, 仅合约
+	FeeQuote       float64 // 手续费折合计价资产, 第三方资产的手续费在设置 SetPricer 后计入
+	FeeBNB         float64 // 以 BNB 支付的手续费
+	Trades         int     // 成交笔数
+	BuyQuantity    float64 // 买入数量
+	SellQuantity   float64 // 卖出数量
+	Volume         float64 // 成交额
+	OpenQuantity   float64 // 未卖出的持仓数量, 仅现货和杠杆
+	OpenCost       float64 // 未卖出持仓的成本
+	UnmatchedSells float64 // 超出已知买入的现货卖出数量, 按零成本计算, 通常因为历史不完整
+	ShortQuantity  float64 // 未回补的融券卖出数量, 仅杠杆
+	ShortProceeds  float64 // 未回补融券卖出的所得
+}
+
+// Disposal define a sold quantity matched against bought lots, for capital gains reports | 卖出明细
+type Disposal struct {
+	Venue        Venue   // 账户类型
+	Symbol       string  // 交易对
+	Asset        string  // 卖出的资产
+	QuoteAsset   string  // 计价资产
+	Quantity     float64 // 数量
+	Proceeds     float64 // 卖出所得
+	Cost         float64 // 成本
+	Gain         float64 // 盈亏
+	AcquiredTime int64   // 买入时间, 平均成本法为最早未卖出买入的时间, 未匹配的卖出为 0; 融券卖出为回补买入的时间
+	DisposedTime int64   // 卖出时间, 融券卖出早于回补买入
+}
+
+// LedgerDay define the results of one UTC day and the running totals at its end | 每日快照
+type LedgerDay struct {
+	Date       string               // 日期 2006-01-02
+	Time       int64                // 当日 00:00 UTC
+	Assets     map[string]*AssetPnL // 当日变动
+	Cumulative map[string]*AssetPnL // 截至当日的累计
+}
+
+// LedgerReport define the results of a ledger | 账本报表
+type LedgerReport struct {
+	Method    CostMethod           // 成本计算方法
+	Assets    map[string]*AssetPnL // 按资产汇总
+	Symbols   []*SymbolPnL         // 按交易对汇总, 按账户类型和交易对排序
+	Days      []*LedgerDay         // 每日快照, 按日期排序, 只包含有记录的日期
+	Disposals []*Disposal          // 现货和杠杆的卖出明细, 按时间排序
+}
+
+// ledgerEvent 按时间排序处理的记录
+type ledgerEvent struct {
+	time  int64
+	venue Venue
+	fill  *Fill
+	base  string
+	quote string
+	flow  *Flow
+}
+
+type ledgerLot struct {
+	quantity float64
+	cost     float64
+	time     int64
+}
+
+type lotMatch struct {
+	quantity float64
+	cost     float64
+	time     int64
+}
+
+// Report process all records in time order with method and return the results. Spot and margin lots are kept per symbol,
+// so an asset bought on one symbol and sold on another is not matched. Margin sells beyond the bought lots are short
+// sales, kept as short lots and matched with method against later buys. Futures PnL is taken from fills, and from
+// REALIZED_PNL and COMMISSION flows not matching an added fill | 生成报表
+func (l *Ledger) Report(method CostMethod) *LedgerReport {
+	l.mu.Lock()
+	events := make([]*ledgerEvent, 0, len(l.fills)+len(l.conversions)+len(l.flows))
+	for _, f := range l.fills {
+		base, quote := l.assets(f.fill.Symbol)
+		events = append(events, &ledgerEvent{time: f.fill.Time, venue: f.venue, fill: f.fill, base: base, quote: quote})
+	}
+	for _, c := range l.conversions {
+		fill, base, quote := l.conversionFill(c)
+		events = append(events, &ledgerEvent{time: c.Time, venue: c.Venue, fill: fill, base: base, quote: quote})
+	}
+	for _, f := range l.flows {
+		if l.counted(f) {
+			continue
+		}
+		e := &ledgerEvent{time: f.Time, venue: f.Venue, flow: f}
+		if f.Symbol != "" {
+			e.base, e.quote = l.assets(f.Symbol)
+		}
+		events = append(events, e)
+	}
+	pricer := l.pricer
+	l.mu.Unlock()
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].time < events[j].time })
+	b := &reportBuilder{
+		report:      &LedgerReport{Method: method, Assets: make(map[string]*AssetPnL), Symbols: make([]*SymbolPnL, 0), Days: make([]*LedgerDay, 0), Disposals: make([]*Disposal, 0)},
+		method:      method,
+		pricer:      pricer,
+		symbols:     make(map[string]*SymbolPnL),
+		inventories: make(map[string][]*ledgerLot),
+		shorts:      make(map[string][]*ledgerLot),
+		days:        make(map[string]*LedgerDay),
+	}
+	for _, e := range events {
+		if e.fill != nil {
+			b.fill(e)
+			continue
+		}
+		b.flow(e)
+	}
+	return b.finish()
+}
+
+type reportBuilder struct {
+	report      *LedgerReport
+	method      CostMethod
+	pricer      AssetPricer
+	symbols     map[string]*SymbolPnL
+	inventories map[string][]*ledgerLot
+	shorts      map[string][]*ledgerLot // 融券卖出, cost 为卖出所得
+	days        map[string]*LedgerDay
+}
+
+// add 同时更新资产汇总和当日变动
+func (b *reportBuilder) add(t int64, asset string, update func(a *AssetPnL)) {
+	if asset == "" {
+		return
+	}
+	total := b.report.Assets[asset]
+	if total == nil {
+		total = &AssetPnL{Asset: asset}
+		b.report.Assets[asset] = total
+	}
+	update(total)
+	day := time.UnixMilli(t).UTC().Truncate(24 * time.Hour)
+	date := day.Format("2006-01-02")
+	d := b.days[date]
+	if d == nil {
+		d = &LedgerDay{Date: date, Time: day.UnixMilli(), Assets: make(map[string]*AssetPnL)}
+		b.days[date] = d
+	}
+	a := d.Assets[asset]
+	if a == nil {
+		a = &AssetPnL{Asset: asset}
+		d.Assets[asset] = a
+	}
+	update(a)
+}
+
+func (b *reportBuilder) symbol(e *ledgerEvent, symbol string) *SymbolPnL {
+	key := string(e.venue) + "|" + symbol
+	s := b.symbols[key]
+	if s == nil {
+		s = &SymbolPnL{Venue: e.venue, Symbol: symbol, BaseAsset: e.base, QuoteAsset: e.quote}
+		b.symbols[key] = s
+	}
+	return s
+}
+
+func (b *reportBuilder) fill(e *ledgerEvent) {
+	f := e.fill
+	s := b.symbol(e, f.Symbol)
+	s.Trades++
+	s.Volume += f.QuoteQuantity
+	if f.Side == SideBuy {
+		s.BuyQuantity += f.Quantity
+	} else {
+		s.SellQuantity += f.Quantity
+	}
+
+	var baseFee float64
+	if f.Commission != 0 {
+		b.add(f.Time, f.CommissionAsset, func(a *AssetPnL) { a.Fees += f.Commission })
+		switch f.CommissionAsset {
+		case e.quote:
+			s.FeeQuote += f.Commission
+		case e.base:
+			baseFee = f.Commission
+			s.FeeQuote += f.Commission * f.Price
+		default:
+			if b.pricer != nil && e.quote != "" {
+				if price, ok := b.pricer(f.CommissionAsset, e.quote, f.Time); ok {
+					s.FeeQuote += f.Commission * price
+				}
+			}
+		}
+		if f.CommissionAsset == "BNB" {
+			s.FeeBNB += f.Commission
+		}
+	}
+
+	switch e.venue {
+	case VenueFutures, VenueDelivery:
+		// 币本位合约以基础资产结算
+		asset := e.quote
+		if e.venue == VenueDelivery {
+			asset = e.base
+		}
+		s.RealizedPnl += f.RealizedPnl
+		if f.RealizedPnl != 0 {
+			b.add(f.Time, asset, func(a *AssetPnL) { a.RealizedPnl += f.RealizedPnl })
+		}
+		return
+	}
+
+	key := string(e.venue) + "|" + f.Symbol
+	var gain float64
+	if f.Side == SideBuy {
+		// 以基础资产支付的手续费不计入持仓, 对应的成本计入手续费
+		quantity, cost := f.Quantity-baseFee, f.QuoteQuantity
+		if f.Quantity != 0 {
+			cost = f.QuoteQuantity * quantity / f.Quantity
+		}
+		if e.venue == VenueMargin && quantity > 0 {
+			// 先回补融券卖出, 剩余部分计入持仓
+			covers, rest := b.match(b.shorts, key, quantity)
+			for _, m := range covers {
+				c := cost * m.quantity / quantity
+				gain += m.cost - c
+				s.ShortQuantity -= m.quantity
+				s.ShortProceeds -= m.cost
+				b.report.Disposals = append(b.report.Disposals, &Disposal{
+					Venue: e.venue, Symbol: f.Symbol, Asset: e.base, QuoteAsset: e.quote, Quantity: m.quantity,
+					Proceeds: m.cost, Cost: c, Gain: m.cost - c, AcquiredTime: f.Time, DisposedTime: m.time,
+				})
+			}
+			if len(covers) > 0 {
+				s.RealizedPnl += gain
+				b.add(f.Time, e.quote, func(a *AssetPnL) { a.RealizedPnl += gain })
+			}
+			cost = cost * rest / quantity
+			quantity = rest
+			if quantity <= 0 {
+				return
+			}
+		}
+		b.push(b.inventories, key, quantity, cost, f.Time)
+		s.OpenQuantity += quantity
+		s.OpenCost += cost
+		return
+	}
+
+	// 以基础资产支付的手续费视为按成交价卖出
+	quantity, proceeds := f.Quantity+baseFee, f.QuoteQuantity+baseFee*f.Price
+	matches, unmatched := b.match(b.inventories, key, quantity)
+	for _, m := range matches {
+		p := proceeds * m.quantity / quantity
+		gain += p - m.cost
+		s.OpenQuantity -= m.quantity
+		s.OpenCost -= m.cost
+		b.report.Disposals = append(b.report.Disposals, &Disposal{
+			Venue: e.venue, Symbol: f.Symbol, Asset: e.base, QuoteAsset: e.quote, Quantity: m.quantity,
+			Proceeds: p, Cost: m.cost, Gain: p - m.cost, AcquiredTime: m.time, DisposedTime: f.Time,
+		})
+	}
+	if unmatched > 0 && e.venue == VenueMargin {
+		// 融券卖出, 回补时计算盈亏
+		p := proceeds * unmatched / quantity
+		b.push(b.shorts, key, unmatched, p, f.Time)
+		s.ShortQuantity += unmatched
+		s.ShortProceeds += p
+	} else if unmatched > 0 {
+		p := proceeds * unmatched / quantity
+		gain += p
+		s.UnmatchedSells += unmatched
+		b.report.Disposals = append(b.report.Disposals, &Disposal{
+			Venue: e.venue, Symbol: f.Symbol, Asset: e.base, QuoteAsset: e.quote, Quantity: unmatched,
+			Proceeds: p, Gain: p, DisposedTime: f.Time,
+		})
+	}
+	s.RealizedPnl += gain
+	b.add(f.Time, e.quote, func(a *AssetPnL) { a.RealizedPnl += gain })
+}
+
+// push 加入一笔持仓, 平均成本法合并为一笔
+func (b *reportBuilder) push(inventories map[string][]*ledgerLot, key string, quantity, cost float64, t int64) {
+	lots := inventories[key]
+	if b.method == CostMethodAverage && len(lots) > 0 {
+		lots[0].quantity += quantity
+		lots[0].cost += cost
+		return
+	}
+	inventories[key] = append(lots, &ledgerLot{quantity: quantity, cost: cost, time: t})
+}
+
+// match 按成本计算方法从持仓中扣除 quantity, 返回匹配的持仓和未匹配的数量
+func (b *reportBuilder) match(inventories map[string][]*ledgerLot, key string, quantity float64) (matches []*lotMatch, unmatched float64) {
+	lots := inventories[key]
+	remaining := quantity
+	for remaining > 0 && len(lots) > 0 {
+		i := 0
+		if b.method == CostMethodLIFO {
+			i = len(lots) - 1
+		}
+		lot := lots[i]
+		take := min(remaining, lot.quantity)
+		cost := 0.0
+		if lot.quantity > 0 {
+			cost = lot.cost * take / lot.quantity
+		}
+		matches = append(matches, &lotMatch{quantity: take, cost: cost, time: lot.time})
+		lot.quantity -= take
+		lot.cost -= cost
+		remaining -= take
+		if lot.quantity <= 1e-12 {
+			if b.method == CostMethodLIFO {
+				lots = lots[:i]
+			} else {
+				lots = lots[1:]
+			}
+		}
+	}
+	inventories[key] = lots
+	return matches, max(remaining, 0)
+}
+
+func (b *reportBuilder) flow(e *ledgerEvent) {
+	f := e.flow
+	var s *SymbolPnL
+	if f.Symbol != "" {
+		s = b.symbol(e, f.Symbol)
+	}
+	switch f.Type {
+	case FlowRealizedPnl:
+		b.add(f.Time, f.Asset, func(a *AssetPnL) { a.RealizedPnl += f.Amount })
+		if s != nil {
+			s.RealizedPnl += f.Amount
+		}
+	case FlowCommission:
+		b.add(f.Time, f.Asset, func(a *AssetPnL) { a.Fees -= f.Amount })
+		if s != nil {
+			if f.Asset == s.QuoteAsset {
+				s.FeeQuote -= f.Amount
+			}
+			if f.Asset == "BNB" {
+				s.FeeBNB -= f.Amount
+			}
+		}
+	case FlowFunding:
+		b.add(f.Time, f.Asset, func(a *AssetPnL) { a.Funding += f.Amount })
+		if s != nil {
+			s.Funding += f.Amount
+		}
+	case FlowDeposit:
+		b.add(f.Time, f.Asset, func(a *AssetPnL) { a.Deposits += f.Amount })
+	case FlowWithdraw:
+		b.add(f.Time, f.Asset, func(a *AssetPnL) { a.Withdrawals -= f.Amount })
+	case FlowWithdrawFee:
+		b.add(f.Time, f.Asset, func(a *AssetPnL) { a.Fees -= f.Amount })
+	case FlowTransfer:
+		b.add(f.Time, f.Asset, func(a *AssetPnL) { a.Transfers += f.Amount })
+	default:
+		b.add(f.Time, f.Asset, func(a *AssetPnL) { a.Income += f.Amount })
+	}
+}
+
+func (b *reportBuilder) finish() *LedgerReport {
+	r := b.report
+	for _, s := range b.symbols {
+		r.Symbols = append(r.Symbols, s)
+	}
+	sort.Slice(r.Symbols, func(i, j int) bool {
+		if r.Symbols[i].Venue != r.Symbols[j].Venue {
+			return r.Symbols[i].Venue < r.Symbols[j].Venue
+		}
+		return r.Symbols[i].Symbol < r.Symbols[j].Symbol
+	})
+	for _, d := range b.days {
+		r.Days = append(r.Days, d)
+	}
+	sort.Slice(r.Days, func(i, j int) bool { return r.Days[i].Time < r.Days[j].Time })
+	running := make(map[string]AssetPnL)
+	for _, d := range r.Days {
+		for asset, a := range d.Assets {
+			c := running[asset]
+			c.Asset = asset
+			c.RealizedPnl += a.RealizedPnl
+			c.Funding += a.Funding
+			c.Fees += a.Fees
+			c.Income += a.Income
+			c.Deposits += a.Deposits
+			c.Withdrawals += a.Withdrawals
+			c.Transfers += a.Transfers
+			running[asset] = c
+		}
+		d.Cumulative = make(map[string]*AssetPnL, len(running))
+		for asset, c := range running {
+			d.Cumulative[asset] = &c
+		}
+	}
+	return r
+}
+
+// ledgerCSVHeader 通用导入格式, 可被 Koinly 等报税工具直接导入
+var ledgerCSVHeader = []string{
+	"Date", "Sent Amount", "Sent Currency", "Received Amount", "Received Currency",
+	"Fee Amount", "Fee Currency", "Net Worth Amount", "Net Worth Currency", "Label", "Description", "TxHash",
+}
+
+// WriteCSV write all records as transactions in the universal CSV format accepted by common tax tools such as Koinly.
+// Amounts are before fees, fees are listed separately. Transfers between own accounts are left out | 导出报税CSV
+func (l *Ledger) WriteCSV(w io.Writer) error {
+	type row struct {
+		time   int64
+		record []string
+	}
+	l.mu.Lock()
+	rows := make([]*row, 0, len(l.fills)+len(l.conversions)+len(l.flows))
+	for _, f := range l.fills {
+		base, quote := l.assets(f.fill.Symbol)
+		rows = append(rows, &row{f.fill.Time, fillCSVRecord(f.venue, f.fill, base, quote)})
+	}
+	for _, c := range l.conversions {
+		gross := c.ToAmount
+		if c.FeeAsset == c.ToAsset {
+			gross += c.Fee
+		}
+		rows = append(rows, &row{c.Time, ledgerCSVRecord(c.Time, c.FromAmount, c.FromAsset, gross, c.ToAsset, c.Fee, c.FeeAsset, "",
+			string(c.Venue)+" convert "+c.ID, "")})
+	}
+	for _, f := range l.flows {
+		if l.counted(f) {
+			continue
+		}
+		if record := flowCSVRecord(f); record != nil {
+			rows = append(rows, &row{f.Time, record})
+		}
+	}
+	l.mu.Unlock()
+
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].time < rows[j].time })
+	cw := csv.NewWriter(w)
+	if err := cw.Write(ledgerCSVHeader); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if err := cw.Write(r.record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func fillCSVRecord(venue Venue, f *Fill, base, quote string) []string {
+	description := string(venue) + " " + f.Symbol + " trade " + strconv.FormatInt(f.TradeID, 10)
+	switch venue {
+	case VenueFutures, VenueDelivery:
+		// 合约成交只记录已实现盈亏和手续费
+		asset := quote
+		if venue == VenueDelivery {
+			asset = base
+		}
+		if f.RealizedPnl == 0 {
+			return ledgerCSVRecord(f.Time, f.Commission, f.CommissionAsset, 0, "", 0, "", "cost", description, "")
+		}
+		if f.RealizedPnl > 0 {
+			return ledgerCSVRecord(f.Time, 0, "", f.RealizedPnl, asset, f.Commission, f.CommissionAsset, "realized gain", description, "")
+		}
+		return ledgerCSVRecord(f.Time, -f.RealizedPnl, asset, 0, "", f.Commission, f.CommissionAsset, "realized gain", description, "")
+	}
+	if f.Side == SideBuy {
+		return ledgerCSVRecord(f.Time, f.QuoteQuantity, quote, f.Quantity, base, f.Commission, f.CommissionAsset, "", description, "")
+	}
+	return ledgerCSVRecord(f.Time, f.Quantity, base, f.QuoteQuantity, quote, f.Commission, f.CommissionAsset, "", description, "")
+}
+
+func flowCSVRecord(f *Flow) []string {
+	var label string
+	switch f.Type {
+	case FlowTransfer:
+		return nil
+	case FlowRealizedPnl, FlowFunding:
+		label = "realized gain"
+	case FlowCommission, FlowWithdrawFee:
+		label = "cost"
+	case FlowDividend:
+		label = "reward"
+	case FlowOther:
+		label = "income"
+		if f.Amount < 0 {
+			label = "cost"
+		}
+	}
+	description := strings.TrimSpace(string(f.Venue) + " " + string(f.Type) + " " + f.Symbol + " " + f.Info)
+	if f.Amount < 0 {
+		return ledgerCSVRecord(f.Time, -f.Amount, f.Asset, 0, "", 0, "", label, description, f.TxID)
+	}
+	return ledgerCSVRecord(f.Time, 0, "", f.Amount, f.Asset, 0, "", label, description, f.TxID)
+}
+
+func ledgerCSVRecord(t int64, sent float64, sentAsset string, received float64, receivedAsset string, fee float64, feeAsset string, label, description, txID string) []string {
+	if sent == 0 {
+		sentAsset = ""
+	}
+	if received == 0 {
+		receivedAsset = ""
+	}
+	if fee == 0 {
+		feeAsset = ""
+	}
+	return []string{
+		time.UnixMilli(t).UTC().Format("2006-01-02 15:04:05 UTC"),
+		formatLedgerAmount(sent), sentAsset, formatLedgerAmount(received), receivedAsset,
+		formatLedgerAmount(fee), feeAsset, "", "", label, description, txID,
+	}
+}
+
+func formatLedgerAmount(v float64) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,215 @@
+package common
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"reflect"
+	"testing"
+)
+
+const ledgerTestTime = 1709280000000 // 2024-03-01 08:00:00 UTC
+
+func assertLedgerFloat(t *testing.T, name string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-9*math.Max(1, math.Abs(want)) {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+func ledgerTestFill(id int64, side Side, quantity, price float64) *Fill {
+	return &Fill{
+		Symbol: "BTCUSDT", TradeID: id, Side: side, Price: price, Quantity: quantity, QuoteQuantity: quantity * price,
+		Time: ledgerTestTime + id*1000,
+	}
+}
+
+func ledgerSymbol(t *testing.T, r *LedgerReport, venue Venue, symbol string) *SymbolPnL {
+	t.Helper()
+	for _, s := range r.Symbols {
+		if s.Venue == venue && s.Symbol == symbol {
+			return s
+		}
+	}
+	t.Fatalf("%s %s not in report", venue, symbol)
+	return nil
+}
+
+func TestLedgerCostMethods(t *testing.T) {
+	// 买入 1 @ 100, 1 @ 200, 卖出 1.5 @ 300, 卖出所得 450
+	tests := []struct {
+		method    CostMethod
+		gain      float64
+		openCost  float64
+		disposals []float64 // 每笔卖出明细的成本
+	}{
+		{CostMethodFIFO, 450 - (100 + 100), 100, []float64{100, 100}},
+		{CostMethodLIFO, 450 - (200 + 50), 50, []float64{200, 50}},
+		{CostMethodAverage, 450 - 1.5*150, 75, []float64{225}},
+	}
+	l := NewLedger().SetSymbol("BTCUSDT", "BTC", "USDT")
+	l.AddFills(VenueSpot, ledgerTestFill(1, SideBuy, 1, 100), ledgerTestFill(2, SideBuy, 1, 200), ledgerTestFill(3, SideSell, 1.5, 300))
+	// 重复添加的成交被忽略
+	l.AddFills(VenueSpot, ledgerTestFill(3, SideSell, 1.5, 300))
+	for _, tt := range tests {
+		t.Run(string(tt.method), func(t *testing.T) {
+			r := l.Report(tt.method)
+			s := ledgerSymbol(t, r, VenueSpot, "BTCUSDT")
+			assertLedgerFloat(t, "RealizedPnl", s.RealizedPnl, tt.gain)
+			assertLedgerFloat(t, "USDT RealizedPnl", r.Assets["USDT"].RealizedPnl, tt.gain)
+			assertLedgerFloat(t, "OpenQuantity", s.OpenQuantity, 0.5)
+			assertLedgerFloat(t, "OpenCost", s.OpenCost, tt.openCost)
+			if s.Trades != 3 {
+				t.Errorf("Trades = %d, want 3", s.Trades)
+			}
+			if len(r.Disposals) != len(tt.disposals) {
+				t.Fatalf("got %d disposals, want %d", len(r.Disposals), len(tt.disposals))
+			}
+			for i, cost := range tt.disposals {
+				assertLedgerFloat(t, "Disposal.Cost", r.Disposals[i].Cost, cost)
+			}
+		})
+	}
+}
+
+func TestLedgerBaseAssetFee(t *testing.T) {
+	l := NewLedger().SetSymbol("BTCUSDT", "BTC", "USDT")
+	// 买入 1 BTC 支付 0.001 BTC 手续费, 持仓 0.999, 成本 99.9; 卖出 0.999 @ 200 支付 0.1998 USDT
+	buy := ledgerTestFill(1, SideBuy, 1, 100)
+	buy.Commission, buy.CommissionAsset = 0.001, "BTC"
+	sell := ledgerTestFill(2, SideSell, 0.999, 200)
+	sell.Commission, sell.CommissionAsset = 0.1998, "USDT"
+	l.AddFills(VenueSpot, buy, sell)
+
+	for _, method := range []CostMethod{CostMethodFIFO, CostMethodLIFO, CostMethodAverage} {
+		r := l.Report(method)
+		s := ledgerSymbol(t, r, VenueSpot, "BTCUSDT")
+		assertLedgerFloat(t, "RealizedPnl", s.RealizedPnl, 199.8-99.9)
+		assertLedgerFloat(t, "OpenQuantity", s.OpenQuantity, 0)
+		assertLedgerFloat(t, "FeeQuote", s.FeeQuote, 0.001*100+0.1998)
+		assertLedgerFloat(t, "BTC Fees", r.Assets["BTC"].Fees, 0.001)
+		assertLedgerFloat(t, "USDT Fees", r.Assets["USDT"].Fees, 0.1998)
+		if s.UnmatchedSells != 0 {
+			t.Errorf("UnmatchedSells = %v, want 0", s.UnmatchedSells)
+		}
+	}
+}
+
+func TestLedgerUnmatchedSpotSell(t *testing.T) {
+	l := NewLedger().SetSymbol("BTCUSDT", "BTC", "USDT")
+	l.AddFills(VenueSpot, ledgerTestFill(1, SideSell, 1, 300))
+	r := l.Report(CostMethodFIFO)
+	s := ledgerSymbol(t, r, VenueSpot, "BTCUSDT")
+	// 历史不完整时按零成本计算
+	assertLedgerFloat(t, "RealizedPnl", s.RealizedPnl, 300)
+	assertLedgerFloat(t, "UnmatchedSells", s.UnmatchedSells, 1)
+	if len(r.Disposals) != 1 || r.Disposals[0].AcquiredTime != 0 {
+		t.Errorf("unexpected disposals %+v", r.Disposals)
+	}
+}
+
+func TestLedgerMarginShortSale(t *testing.T) {
+	// 融券卖出 1 @ 300, 1 @ 200, 回补买入 1.5 @ 100, 再买入 1 @ 100: 回补 0.5, 剩余 0.5 计入持仓
+	tests := []struct {
+		method   CostMethod
+		cover    float64 // 第一次回补的盈亏
+		proceeds float64 // 第一次回补后未回补的所得
+	}{
+		{CostMethodFIFO, (300 - 100) + (100 - 50), 100},
+		{CostMethodLIFO, (200 - 100) + (150 - 50), 150},
+		{CostMethodAverage, 375 - 150, 125},
+	}
+	fills := []*Fill{
+		ledgerTestFill(1, SideSell, 1, 300),
+		ledgerTestFill(2, SideSell, 1, 200),
+		ledgerTestFill(3, SideBuy, 1.5, 100),
+		ledgerTestFill(4, SideBuy, 1, 100),
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.method), func(t *testing.T) {
+			l := NewLedger().SetSymbol("BTCUSDT", "BTC", "USDT")
+			l.AddFills(VenueMargin, fills[:3]...)
+			r := l.Report(tt.method)
+			s := ledgerSymbol(t, r, VenueMargin, "BTCUSDT")
+			assertLedgerFloat(t, "RealizedPnl", s.RealizedPnl, tt.cover)
+			assertLedgerFloat(t, "ShortQuantity", s.ShortQuantity, 0.5)
+			assertLedgerFloat(t, "ShortProceeds", s.ShortProceeds, tt.proceeds)
+			assertLedgerFloat(t, "OpenQuantity", s.OpenQuantity, 0)
+			if s.UnmatchedSells != 0 {
+				t.Errorf("margin short sale counted as unmatched sell: %v", s.UnmatchedSells)
+			}
+			for _, d := range r.Disposals {
+				if d.AcquiredTime != fills[2].Time || d.DisposedTime >= d.AcquiredTime {
+					t.Errorf("short sale disposal must be disposed before acquired: %+v", d)
+				}
+			}
+
+			l.AddFills(VenueMargin, fills[3])
+			r = l.Report(tt.method)
+			s = ledgerSymbol(t, r, VenueMargin, "BTCUSDT")
+			// 融券所得 500, 回补 2 个的成本 200
+			assertLedgerFloat(t, "RealizedPnl", s.RealizedPnl, 300)
+			assertLedgerFloat(t, "ShortQuantity", s.ShortQuantity, 0)
+			assertLedgerFloat(t, "OpenQuantity", s.OpenQuantity, 0.5)
+			assertLedgerFloat(t, "OpenCost", s.OpenCost, 50)
+		})
+	}
+}
+
+func TestLedgerFuturesFlows(t *testing.T) {
+	l := NewLedger().SetSymbol("BTCUSDT", "BTC", "USDT")
+	fill := ledgerTestFill(1, SideSell, 0.01, 60000)
+	fill.RealizedPnl, fill.Commission, fill.CommissionAsset = 10, 0.24, "USDT"
+	l.AddFills(VenueFutures, fill)
+	l.AddFlows(
+		// 已由成交计入的流水被忽略
+		&Flow{Venue: VenueFutures, Type: FlowRealizedPnl, ID: "1", Symbol: "BTCUSDT", Asset: "USDT", Amount: 10, TradeID: 1, Time: fill.Time},
+		&Flow{Venue: VenueFutures, Type: FlowCommission, ID: "2", Symbol: "BTCUSDT", Asset: "USDT", Amount: -0.24, TradeID: 1, Time: fill.Time},
+		&Flow{Venue: VenueFutures, Type: FlowFunding, ID: "3", Symbol: "BTCUSDT", Asset: "USDT", Amount: -1.5, Time: fill.Time + 1000},
+	)
+	r := l.Report(CostMethodFIFO)
+	a := r.Assets["USDT"]
+	assertLedgerFloat(t, "RealizedPnl", a.RealizedPnl, 10)
+	assertLedgerFloat(t, "Fees", a.Fees, 0.24)
+	assertLedgerFloat(t, "Funding", a.Funding, -1.5)
+	assertLedgerFloat(t, "Net", a.Net(), 10-0.24-1.5)
+	if len(r.Disposals) != 0 {
+		t.Errorf("futures fills must not make disposals: %+v", r.Disposals)
+	}
+	if len(r.Days) != 1 || r.Days[0].Date != "2024-03-01" {
+		t.Fatalf("unexpected days %+v", r.Days)
+	}
+	assertLedgerFloat(t, "Cumulative", r.Days[0].Cumulative["USDT"].Net(), a.Net())
+}
+
+func TestLedgerWriteCSV(t *testing.T) {
+	l := NewLedger().SetSymbol("BTCUSDT", "BTC", "USDT")
+	buy := ledgerTestFill(0, SideBuy, 0.5, 60000)
+	buy.Commission, buy.CommissionAsset = 0.01, "BNB"
+	loss := ledgerTestFill(1, SideBuy, 0.01, 61000)
+	loss.RealizedPnl, loss.Commission, loss.CommissionAsset = -12.5, 0.3, "USDT"
+	l.AddFills(VenueSpot, buy)
+	l.AddFills(VenueFutures, loss)
+	l.AddFlows(
+		&Flow{Venue: VenueSpot, Type: FlowTransfer, ID: "t1", Asset: "USDT", Amount: -100, Time: ledgerTestTime + 2000},
+		&Flow{Venue: VenueSpot, Type: FlowDeposit, ID: "d1", Asset: "BTC", Amount: 1, TxID: "0xabc", Time: ledgerTestTime + 3000},
+	)
+	var buf bytes.Buffer
+	if err := l.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		ledgerCSVHeader,
+		{"2024-03-01 08:00:00 UTC", "30000", "USDT", "0.5", "BTC", "0.01", "BNB", "", "", "", "SPOT BTCUSDT trade 0", ""},
+		{"2024-03-01 08:00:01 UTC", "12.5", "USDT", "", "", "0.3", "USDT", "", "", "realized gain", "FUTURES BTCUSDT trade 1", ""},
+		// 账户间划转不导出
+		{"2024-03-01 08:00:03 UTC", "", "", "1", "BTC", "", "", "", "", "", "SPOT DEPOSIT", "0xabc"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("got\n%q\nwant\n%q", records, want)
+	}
+}
@@ -0,0 +1,252 @@
+package common
+
+import (
+	"context"
+	"sync"
+)
+
+// OrderUpdate define a normalized order event of the user data stream, converted by ToCommonOrderUpdate of each venue | 订单推送
+type OrderUpdate struct {
+	Order     *Order // 推送后的订单, 成交量为累计值
+	Execution string // 本次事件的执行类型, 如 NEW/TRADE/CANCELED/EXPIRED/AMENDMENT
+	Fill      *Fill  // 本次成交, 非成交事件为 nil
+}
+
+// OrderTransition define one applied change of an open order | 订单状态变化
+type OrderTransition struct {
+	From      OrderStatus   // 变化前的状态, 新订单为空
+	Order     *TrackedOrder // 变化后的订单
+	Execution string        // 触发变化的执行类型, 对账产生的变化为 RECONCILE
+	Fill      *Fill         // 本次成交, 非成交变化为 nil
+}
+
+// OrderTransitionHandler handle OrderTransition | 订单状态变化回调
+type OrderTransitionHandler func(t *OrderTransition)
+
+// TrackedOrder define an open order with its fills | 带成交历史的挂单
+type TrackedOrder struct {
+	Order
+	Fills []*Fill // 成交历史, 按推送顺序
+}
+
+// ExecutionReconcile execution type of transitions found by Reconcile | 对账发现的变化
+const ExecutionReconcile = "RECONCILE"
+
+type orderKey struct {
+	symbol  string
+	orderID int64
+}
+
+type clientOrderKey struct {
+	symbol        string
+	clientOrderID string
+}
+
+// OrderKeeper hold the open orders of one venue, seeded by Reconcile and kept by Apply of user data order events.
+// Orders are looked up in O(1) by symbol and orderID or clientOrderID, finished orders are dropped | 挂单簿
+type OrderKeeper struct {
+	gateway OrderGateway
+	handler OrderTransitionHandler
+
+	mu       sync.Mutex
+	orders   map[orderKey]*TrackedOrder
+	clients  map[clientOrderKey]*TrackedOrder
+	trades   map[orderKey]map[int64]bool
+	finished map[orderKey]bool
+}
+
+// NewOrderKeeper init an order keeper reconciling through gateway | 创建挂单簿
+func NewOrderKeeper(gateway OrderGateway) *OrderKeeper {
+	return &OrderKeeper{
+		gateway:  gateway,
+		orders:   make(map[orderKey]*TrackedOrder),
+		clients:  make(map[clientOrderKey]*TrackedOrder),
+		trades:   make(map[orderKey]map[int64]bool),
+		finished: make(map[orderKey]bool),
+	}
+}
+
+// OnTransition set the handler called after every applied change, outside the lock | 设置状态变化回调
+func (k *OrderKeeper) OnTransition(handler OrderTransitionHandler) *OrderKeeper {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.handler = handler
+	return k
+}
+
+// Order return a copy of the open order, nil if not open | 按订单号查询挂单
+func (k *OrderKeeper) Order(symbol string, orderID int64) *TrackedOrder {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.orders[orderKey{symbol, orderID}].copy()
+}
+
+// OrderByClientID return a copy of the open order, nil if not open | 按客户自定义订单ID查询挂单
+func (k *OrderKeeper) OrderByClientID(symbol string, clientOrderID string) *TrackedOrder {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.clients[clientOrderKey{symbol, clientOrderID}].copy()
+}
+
+// Orders return copies of the open orders of symbol, or of all symbols when symbol is empty | 查询挂单
+func (k *OrderKeeper) Orders(symbol string) []*TrackedOrder {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	res := make([]*TrackedOrder, 0, len(k.orders))
+	for key, o := range k.orders {
+		if symbol == "" || key.symbol == symbol {
+			res = append(res, o.copy())
+		}
+	}
+	return res
+}
+
+// Apply apply an order event. Stale events, repeated trades and events of finished orders are ignored | 应用订单推送
+func (k *OrderKeeper) Apply(u *OrderUpdate) {
+	if u == nil || u.Order == nil {
+		return
+	}
+	k.mu.Lock()
+	t := k.apply(u.Order, u.Execution, u.Fill)
+	handler := k.handler
+	k.mu.Unlock()
+	if t != nil && handler != nil {
+		handler(t)
+	}
+}
+
+// Reconcile load the open orders of symbol, or of all symbols when symbol is empty, from the gateway and
+// fix the orders changed while the stream was down. Call it at start and after every reconnect of the stream | 对账
+func (k *OrderKeeper) Reconcile(ctx context.Context, symbol string) error {
+	open, err := k.gateway.ListOpenOrders(ctx, symbol)
+	if err != nil {
+		return err
+	}
+	seen := make(map[orderKey]bool, len(open))
+	var transitions []*OrderTransition
+	k.mu.Lock()
+	for _, o := range open {
+		seen[orderKey{o.Symbol, o.OrderID}] = true
+		if t := k.apply(o, ExecutionReconcile, nil); t != nil {
+			transitions = append(transitions, t)
+		}
+	}
+	// 已结束的订单不会再出现在挂单列表中, 对账后不再需要记录
+	for key := range k.finished {
+		if symbol == "" || key.symbol == symbol {
+			delete(k.finished, key)
+		}
+	}
+	var missing []orderKey
+	for key := range k.orders {
+		if (symbol == "" || key.symbol == symbol) && !seen[key] {
+			missing = append(missing, key)
+		}
+	}
+	k.mu.Unlock()
+
+	// 不在挂单列表中的订单可能已结束, 也可能是查询之后才下的单, 逐个查询确认
+	for _, key := range missing {
+		o, e := k.gateway.GetOrder(ctx, key.symbol, key.orderID, "")
+		if e != nil {
+			if err == nil {
+				err = e
+			}
+			continue
+		}
+		k.mu.Lock()
+		if t := k.apply(o, ExecutionReconcile, nil); t != nil {
+			transitions = append(transitions, t)
+		}
+		k.mu.Unlock()
+	}
+
+	k.mu.Lock()
+	handler := k.handler
+	k.mu.Unlock()
+	if handler != nil {
+		for _, t := range transitions {
+			handler(t)
+		}
+	}
+	return err
+}
+
+// apply merge o into the open order it refers to and return the transition, nil if nothing changed. Must hold the lock
+func (k *OrderKeeper) apply(o *Order, execution string, fill *Fill) *OrderTransition {
+	key := orderKey{o.Symbol, o.OrderID}
+	if o.OrderID == 0 {
+		if tracked := k.clients[clientOrderKey{o.Symbol, o.ClientOrderID}]; tracked != nil {
+			key.orderID = tracked.OrderID
+		}
+	}
+	if k.finished[key] {
+		return nil
+	}
+	tracked := k.orders[key]
+	if tracked == nil && o.Status.Final() && fill == nil {
+		// 未跟踪的订单结束, 没有需要更新的内容
+		return nil
+	}
+	if tracked != nil && o.UpdateTime > 0 && o.UpdateTime < tracked.UpdateTime {
+		return nil
+	}
+	if fill != nil {
+		trades := k.trades[key]
+		if trades == nil {
+			trades = make(map[int64]bool)
+			k.trades[key] = trades
+		}
+		if trades[fill.TradeID] {
+			return nil
+		}
+		trades[fill.TradeID] = true
+	}
+
+	var from OrderStatus
+	if tracked == nil {
+		tracked = &TrackedOrder{}
+	} else {
+		from = tracked.Status
+	}
+	if fill == nil && execution == ExecutionReconcile && from == o.Status && tracked.ExecutedQuantity == o.ExecutedQuantity &&
+		tracked.Price == o.Price && tracked.Quantity == o.Quantity {
+		return nil
+	}
+	delete(k.clients, clientOrderKey{key.symbol, tracked.ClientOrderID})
+	next := *o
+	next.OrderID = key.orderID
+	// 合约推送不含下单时间, 币本位合约推送不含成交额, 沿用已知的值
+	if next.Time == 0 {
+		next.Time = tracked.Time
+	}
+	if next.QuoteQuantity == 0 && next.ExecutedQuantity > 0 {
+		next.QuoteQuantity = tracked.QuoteQuantity
+	}
+	tracked.Order = next
+	if fill != nil {
+		tracked.Fills = append(tracked.Fills, fill)
+	}
+
+	if tracked.Status.Final() {
+		delete(k.orders, key)
+		delete(k.trades, key)
+		k.finished[key] = true
+	} else {
+		k.orders[key] = tracked
+		if tracked.ClientOrderID != "" {
+			k.clients[clientOrderKey{key.symbol, tracked.ClientOrderID}] = tracked
+		}
+	}
+	return &OrderTransition{From: from, Order: tracked.copy(), Execution: execution, Fill: fill}
+}
+
+// copy return a copy safe to hand out of the lock
+func (o *TrackedOrder) copy() *TrackedOrder {
+	if o == nil {
+		return nil
+	}
+	res := *o
+	res.Fills = append([]*Fill(nil), o.Fills...)
+	return &res
+}
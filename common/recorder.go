@@ -0,0 +1,289 @@
+package common
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	stdjson "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RecordSource define where a recorded message comes from | 记录消息的来源
+type RecordSource string
+
+const (
+	RecordSourceWs   RecordSource = "ws"   // websocket 推送
+	RecordSourceRest RecordSource = "rest" // REST 快照(如深度快照)
+
+	recordFileSuffix = ".jsonl.gz"
+	recordFileLayout = "20060102T15"
+)
+
+// ErrRecorderClosed error returned when writing to a closed recorder | 向已关闭的记录器写入时返回
+var ErrRecorderClosed = errors.New("recorder closed")
+
+// Record define one recorded raw message | 一条原始消息记录
+type Record struct {
+	Time   int64              `json:"t"`      // 接收时间(毫秒)
+	Source RecordSource       `json:"src"`    // 消息来源
+	Stream string             `json:"stream"` // websocket 流路径或 REST 接口路径
+	Data   stdjson.RawMessage `json:"data"`   // 原始消息
+}
+
+// Recorder write raw messages into hourly gzip compressed JSONL files | 将原始消息按小时写入 gzip 压缩的 JSONL 文件
+type Recorder struct {
+	dir    string
+	prefix string
+	mu     sync.Mutex
+	hour   string
+	file   *os.File
+	gz     *gzip.Writer
+	buf    *bufio.Writer
+	closed bool
+}
+
+// NewRecorder create a recorder writing files named <prefix>-<yyyymmddThh>.jsonl.gz into dir | 创建记录器
+func NewRecorder(dir, prefix string) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Recorder{dir: dir, prefix: prefix}, nil
+}
+
+// Record append a raw message received now | 记录一条当前收到的原始消息
+func (r *Recorder) Record(source RecordSource, stream string, data []byte) error {
+	return r.RecordAt(time.Now(), source, stream, data)
+}
+
+// RecordAt append a raw message received at t | 记录一条在 t 时刻收到的原始消息
+func (r *Recorder) RecordAt(t time.Time, source RecordSource, stream string, data []byte) error {
+	line, err := json.Marshal(&Record{
+		Time:   t.UnixMilli(),
+		Source: source,
+		Stream: stream,
+		Data:   stdjson.RawMessage(data),
+	})
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return ErrRecorderClosed
+	}
+	if err := r.rotate(t.UTC().Format(recordFileLayout)); err != nil {
+		return err
+	}
+	if _, err := r.buf.Write(line); err != nil {
+		return err
+	}
+	return r.buf.WriteByte('\n')
+}
+
+// rotate 当小时变化时切换到新的文件
+func (r *Recorder) rotate(hour string) error {
+	if r.file != nil && r.hour == hour {
+		return nil
+	}
+	if err := r.closeFile(); err != nil {
+		return err
+	}
+	name := filepath.Join(r.dir, fmt.Sprintf("%s-%s%s", r.prefix, hour, recordFileSuffix))
+	// 同一小时内重启时追加为新的 gzip member, 读取端可透明地连续解压
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	r.hour = hour
+	r.file = f
+	r.gz = gzip.NewWriter(f)
+	r.buf = bufio.NewWriter(r.gz)
+	return nil
+}
+
+// Flush flush buffered records to disk | 将缓存的记录写入磁盘
+func (r *Recorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	if err := r.buf.Flush(); err != nil {
+		return err
+	}
+	return r.gz.Flush()
+}
+
+// Close flush and close the current file | 关闭记录器
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	return r.closeFile()
+}
+
+func (r *Recorder) closeFile() error {
+	if r.file == nil {
+		return nil
+	}
+	err := r.buf.Flush()
+	if cerr := r.gz.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := r.file.Close(); err == nil {
+		err = cerr
+	}
+	r.file, r.gz, r.buf = nil, nil, nil
+	return err
+}
+
+// RecordFiles list recorded files of prefix in dir whose hour overlaps [start, end], sorted by time.
+// Zero start or end means unbounded | 列出 dir 中与 [start, end] 时间段重叠的记录文件
+func RecordFiles(dir, prefix string, start, end time.Time) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, prefix+"-*"+recordFileSuffix))
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(matches))
+	for _, m := range matches {
+		hour := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(m), prefix+"-"), recordFileSuffix)
+		t, err := time.Parse(recordFileLayout, hour)
+		if err != nil {
+			continue
+		}
+		if !start.IsZero() && t.Add(time.Hour).Before(start) {
+			continue
+		}
+		if !end.IsZero() && t.After(end) {
+			continue
+		}
+		files = append(files, m)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// RecordHandler handle a replayed record | 处理回放的记录
+type RecordHandler func(record *Record) error
+
+// Replayer read recorded files back in order | 按顺序回放记录文件
+type Replayer struct {
+	files []string
+	speed float64
+	start int64
+	end   int64
+}
+
+// NewReplayer create a replayer over files, which must be sorted by time (see RecordFiles) | 创建回放器
+func NewReplayer(files ...string) *Replayer {
+	return &Replayer{files: files}
+}
+
+// SetSpeed set replay speed: 1 is real time, 10 is ten times faster, 0 (default) replays as fast as possible | 设置回放速度
+func (p *Replayer) SetSpeed(speed float64) *Replayer {
+	p.speed = speed
+	return p
+}
+
+// SetStartTime skip records received before startTime | 设置起始时间
+func (p *Replayer) SetStartTime(startTime time.Time) *Replayer {
+	p.start = startTime.UnixMilli()
+	return p
+}
+
+// SetEndTime stop at records received after endTime | 设置结束时间
+func (p *Replayer) SetEndTime(endTime time.Time) *Replayer {
+	p.end = endTime.UnixMilli()
+	return p
+}
+
+// Run replay every record through handler, pacing them by their receive time.
+// It stops at the first handler error or when ctx is done | 回放所有记录
+func (p *Replayer) Run(ctx context.Context, handler RecordHandler) error {
+	var first int64
+	began := time.Now()
+	for _, name := range p.files {
+		stop, err := p.replayFile(ctx, name, handler, &first, began)
+		if err != nil {
+			return err
+		}
+		if stop {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (p *Replayer) replayFile(ctx context.Context, name string, handler RecordHandler, first *int64, began time.Time) (stop bool, err error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return false, err
+	}
+	defer gz.Close()
+
+	reader := bufio.NewReader(gz)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			record := new(Record)
+			if e := json.Unmarshal(line, record); e != nil {
+				return false, fmt.Errorf("%s: %w", name, e)
+			}
+			if p.start > 0 && record.Time < p.start {
+				continue
+			}
+			if p.end > 0 && record.Time > p.end {
+				return true, nil
+			}
+			if *first == 0 {
+				*first = record.Time
+			}
+			if err := p.wait(ctx, record.Time-*first, began); err != nil {
+				return false, err
+			}
+			if err := handler(record); err != nil {
+				return false, err
+			}
+		}
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+}
+
+// wait 按回放速度等待到记录的相对时间
+func (p *Replayer) wait(ctx context.Context, offset int64, began time.Time) error {
+	if p.speed <= 0 {
+		return ctx.Err()
+	}
+	due := began.Add(time.Duration(float64(offset) / p.speed * float64(time.Millisecond)))
+	d := time.Until(due)
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
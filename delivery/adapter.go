@@ -0,0 +1,384 @@
+package delivery
+
+import (
+	"context"
+	"github.com/BobHye/binance-go/common"
+	"math"
+	"strconv"
+)
+
+// Adapter implement common.Exchange over a COIN-M futures client. Quantities are in contracts | 币本位合约的统一接口实现, 数量单位为张
+type Adapter struct {
+	c *Client
+}
+
+var _ common.Exchange = (*Adapter)(nil)
+
+// NewAdapter create an adapter over client | 创建统一接口适配器
+func NewAdapter(c *Client) *Adapter {
+	return &Adapter{c: c}
+}
+
+// Klines return the latest klines of symbol | 查询K线
+func (a *Adapter) Klines(ctx context.Context, symbol string, interval string, limit int) ([]*common.Kline, error) {
+	klines, err := a.c.NewKlinesService().Symbol(symbol).Interval(interval).Limit(limit).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*common.Kline, 0, len(klines))
+	for _, k := range klines {
+		res = append(res, ToCommonKline(k))
+	}
+	return res, nil
+}
+
+// BookTicker return the best bid and ask of symbol | 查询最优挂单
+func (a *Adapter) BookTicker(ctx context.Context, symbol string) (*common.BookTicker, error) {
+	tickers, err := a.c.NewListBookTickersService().SetSymbol(symbol).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(tickers) == 0 {
+		return nil, &common.APIError{Code: -1121, Message: "Invalid symbol."}
+	}
+	return ToCommonBookTicker(tickers[0]), nil
+}
+
+// CreateOrder place a new order. LIMIT_MAKER is sent as a GTX limit order | 下单
+func (a *Adapter) CreateOrder(ctx context.Context, req *common.OrderRequest) (*common.Order, error) {
+	s := a.c.NewCreateOrderService().
+		SetSymbol(req.Symbol).
+		SetSide(SideType(req.Side)).
+		SetType(OrderType(req.Type)).
+		SetQuantity(formatFloat(req.Quantity))
+	if req.PositionSide != "" {
+		s.SetPositionSide(PositionSideType(req.PositionSide))
+	}
+	tif := req.TimeInForce
+	switch req.Type {
+	case common.OrderTypeLimitMaker:
+		s.SetType(OrderTypeLimit)
+		tif = common.TimeInForceGTX
+	case common.OrderTypeLimit, common.OrderTypeStop, common.OrderTypeTakeProfit:
+		if tif == "" {
+			tif = common.TimeInForceGTC
+		}
+	}
+	if tif != "" {
+		s.SetTimeInForce(TimeInForceType(tif))
+	}
+	if req.Price > 0 {
+		s.SetPrice(formatFloat(req.Price))
+	}
+	if req.StopPrice > 0 {
+		s.SetStopPrice(formatFloat(req.StopPrice))
+	}
+	if req.ClientOrderID != "" {
+		s.SetNewClientOrderID(req.ClientOrderID)
+	}
+	if req.ReduceOnly {
+		s.SetReduceOnly(true)
+	}
+	res, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ToCommonOrder(&Order{
+		AvgPrice:         res.AvgPrice,
+		ClientOrderID:    res.ClientOrderID,
+		CumBase:          res.CumBase,
+		ExecutedQuantity: res.ExecutedQuantity,
+		OrderID:          res.OrderID,
+		OrigQuantity:     res.OrigQuantity,
+		Price:            res.Price,
+		ReduceOnly:       res.ReduceOnly,
+		Side:             res.Side,
+		PositionSide:     res.PositionSide,
+		Status:           res.Status,
+		StopPrice:        res.StopPrice,
+		Symbol:           res.Symbol,
+		Time:             res.UpdateTime,
+		TimeInForce:      res.TimeInForce,
+		Type:             res.Type,
+		UpdateTime:       res.UpdateTime,
+	}), nil
+}
+
+// CancelOrder cancel an open order | 撤单
+func (a *Adapter) CancelOrder(ctx context.Context, symbol string, orderID int64, clientOrderID string) (*common.Order, error) {
+	s := a.c.NewCancelOrderService().SetSymbol(symbol)
+	if orderID != 0 {
+		s.SetOrderID(orderID)
+	} else {
+		s.SetOrigClientOrderID(clientOrderID)
+	}
+	res, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ToCommonOrder(&Order{
+		AvgPrice:         res.AvgPrice,
+		ClientOrderID:    res.ClientOrderID,
+		CumBase:          res.CumBase,
+		ExecutedQuantity: res.ExecutedQuantity,
+		OrderID:          res.OrderID,
+		OrigQuantity:     res.OrigQuantity,
+		Price:            res.Price,
+		ReduceOnly:       res.ReduceOnly,
+		Side:             res.Side,
+		PositionSide:     res.PositionSide,
+		Status:           res.Status,
+		StopPrice:        res.StopPrice,
+		Symbol:           res.Symbol,
+		TimeInForce:      res.TimeInForce,
+		Type:             res.Type,
+		UpdateTime:       res.UpdateTime,
+	}), nil
+}
+
+// GetOrder query an order | 查询订单
+func (a *Adapter) GetOrder(ctx context.Context, symbol string, orderID int64, clientOrderID string) (*common.Order, error) {
+	s := a.c.NewGetOrderService().SetSymbol(symbol)
+	if orderID != 0 {
+		s.SetOrderID(orderID)
+	} else {
+		s.SetOrigClientOrderID(clientOrderID)
+	}
+	o, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ToCommonOrder(o), nil
+}
+
+// ListOpenOrders query open orders | 查询当前挂单
+func (a *Adapter) ListOpenOrders(ctx context.Context, symbol string) ([]*common.Order, error) {
+	s := a.c.NewListOpenOrdersService()
+	if symbol != "" {
+		s.SetSymbol(symbol)
+	}
+	orders, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*common.Order, 0, len(orders))
+	for _, o := range orders {
+		res = append(res, ToCommonOrder(o))
+	}
+	return res, nil
+}
+
+// ListFills query trades of symbol | 查询成交历史
+func (a *Adapter) ListFills(ctx context.Context, symbol string, startTime, endTime int64) ([]*common.Fill, error) {
+	s := a.c.NewListAccountTradeService().SetSymbol(symbol)
+	if startTime > 0 {
+		s.SetStartTime(startTime)
+	}
+	if endTime > 0 {
+		s.SetEndTime(endTime)
+	}
+	trades, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*common.Fill, 0, len(trades))
+	for _, t := range trades {
+		res = append(res, ToCommonFill(t))
+	}
+	return res, nil
+}
+
+// Balances return balances of all margin assets | 查询资产余额
+func (a *Adapter) Balances(ctx context.Context) ([]*common.Balance, error) {
+	balances, err := a.c.NewGetBalanceService().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*common.Balance, 0, len(balances))
+	for _, b := range balances {
+		res = append(res, ToCommonBalance(b))
+	}
+	return res, nil
+}
+
+// Positions return positions with a non-zero amount | 查询持仓
+func (a *Adapter) Positions(ctx context.Context) ([]*common.Position, error) {
+	risks, err := a.c.NewGetPositionRiskService().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*common.Position, 0)
+	for _, p := range risks {
+		if position := ToCommonPosition(p); position.Amount != 0 {
+			res = append(res, position)
+		}
+	}
+	return res, nil
+}
+
+// ToCommonKline convert kline to the normalized model. Volume is in contracts, QuoteVolume in base asset | 转换K线
+func ToCommonKline(k *Kline) *common.Kline {
+	return &common.Kline{
+		OpenTime:    k.OpenTime,
+		Open:        k.Open,
+		High:        k.High,
+		Low:         k.Low,
+		Close:       k.Close,
+		Volume:      k.Volume,
+		CloseTime:   k.CloseTime,
+		QuoteVolume: k.QuoteAssetVolume,
+		TradeNum:    k.TradeNum,
+	}
+}
+
+// ToCommonBookTicker convert book ticker to the normalized model | 转换最优挂单
+func ToCommonBookTicker(t *BookTicker) *common.BookTicker {
+	return &common.BookTicker{
+		Symbol:      t.Symbol,
+		BidPrice:    parseFloat(t.BidPrice),
+		BidQuantity: parseFloat(t.BidQuantity),
+		AskPrice:    parseFloat(t.AskPrice),
+		AskQuantity: parseFloat(t.AskQuantity),
+	}
+}
+
+// ToCommonOrder convert order to the normalized model. QuoteQuantity is the filled base asset amount | 转换订单
+func ToCommonOrder(o *Order) *common.Order {
+	return &common.Order{
+		Symbol:           o.Symbol,
+		OrderID:          o.OrderID,
+		ClientOrderID:    o.ClientOrderID,
+		Side:             common.Side(o.Side),
+		PositionSide:     common.PositionSide(o.PositionSide),
+		Type:             common.OrderType(o.Type),
+		TimeInForce:      common.TimeInForce(o.TimeInForce),
+		Status:           common.OrderStatus(o.Status),
+		Price:            parseFloat(o.Price),
+		StopPrice:        parseFloat(o.StopPrice),
+		Quantity:         parseFloat(o.OrigQuantity),
+		ExecutedQuantity: parseFloat(o.ExecutedQuantity),
+		QuoteQuantity:    parseFloat(o.CumBase),
+		AvgPrice:         parseFloat(o.AvgPrice),
+		ReduceOnly:       o.ReduceOnly,
+		Time:             o.Time,
+		UpdateTime:       o.UpdateTime,
+	}
+}
+
+// ToCommonFill convert account trade to the normalized model. QuoteQuantity is the base asset amount | 转换成交
+func ToCommonFill(t *AccountTrade) *common.Fill {
+	return &common.Fill{
+		Symbol:          t.Symbol,
+		TradeID:         t.ID,
+		OrderID:         t.OrderID,
+		Side:            common.Side(t.Side),
+		PositionSide:    common.PositionSide(t.PositionSide),
+		Price:           parseFloat(t.Price),
+		Quantity:        parseFloat(t.Quantity),
+		QuoteQuantity:   parseFloat(t.BaseQuantity),
+		Commission:      parseFloat(t.Commission),
+		CommissionAsset: t.CommissionAsset,
+		RealizedPnl:     parseFloat(t.RealizedPnl),
+		Maker:           t.Maker,
+		Time:            t.Time,
+	}
+}
+
+// ToCommonFlow convert income history to the normalized ledger flow | 转换资金流水
+func ToCommonFlow(i *IncomeHistory) *common.Flow {
+	tradeID, _ := strconv.ParseInt(i.TradeID, 10, 64)
+	return &common.Flow{
+		Venue:   common.VenueDelivery,
+		Type:    common.IncomeFlowType(i.IncomeType),
+		ID:      i.IncomeType + "-" + strconv.FormatInt(i.TranID, 10),
+		Symbol:  i.Symbol,
+		Asset:   i.Asset,
+		Amount:  parseFloat(i.Income),
+		TradeID: tradeID,
+		Info:    i.Info,
+		Time:    i.Time,
+	}
+}
+
+// ToCommonOrderUpdate convert ORDER_TRADE_UPDATE to the normalized model. The order creation time and the filled base asset amount are not pushed and left 0 | 转换订单推送
+func ToCommonOrderUpdate(o *WsOrderTradeUpdate) *common.OrderUpdate {
+	// 条件单触发后 o 变为 LIMIT/MARKET, 与 REST 一致使用原始订单类型
+	orderType := o.OriginalType
+	if orderType == "" {
+		orderType = o.Type
+	}
+	res := &common.OrderUpdate{
+		Order: &common.Order{
+			Symbol:           o.Symbol,
+			OrderID:          o.ID,
+			ClientOrderID:    o.ClientOrderID,
+			Side:             common.Side(o.Side),
+			PositionSide:     common.PositionSide(o.PositionSide),
+			Type:             common.OrderType(orderType),
+			TimeInForce:      common.TimeInForce(o.TimeInForce),
+			Status:           common.OrderStatus(o.Status),
+			Price:            o.OriginalPrice,
+			StopPrice:        o.StopPrice,
+			Quantity:         o.OriginalQty,
+			ExecutedQuantity: o.AccumulatedFilledQty,
+			AvgPrice:         o.AveragePrice,
+			ReduceOnly:       o.IsReduceOnly,
+			UpdateTime:       o.TradeTime,
+		},
+		Execution: string(o.ExecutionType),
+	}
+	if o.ExecutionType == OrderExecutionTypeTrade {
+		res.Fill = &common.Fill{
+			Symbol:          o.Symbol,
+			TradeID:         o.TradeID,
+			OrderID:         o.ID,
+			Side:            common.Side(o.Side),
+			PositionSide:    common.PositionSide(o.PositionSide),
+			Price:           o.LastFilledPrice,
+			Quantity:        o.LastFilledQty,
+			Commission:      o.Commission,
+			CommissionAsset: o.CommissionAsset,
+			RealizedPnl:     o.RealizedPnL,
+			Maker:           o.IsMaker,
+			Time:            o.TradeTime,
+		}
+	}
+	return res
+}
+
+// ToCommonBalance convert balance to the normalized model | 转换资产余额
+func ToCommonBalance(b *Balance) *common.Balance {
+	total, available := parseFloat(b.Balance), parseFloat(b.AvailableBalance)
+	return &common.Balance{
+		Asset:  b.Asset,
+		Total:  total,
+		Free:   available,
+		Locked: math.Max(total-available, 0),
+	}
+}
+
+// ToCommonPosition convert position risk to the normalized model | 转换持仓
+func ToCommonPosition(p *PositionRisk) *common.Position {
+	leverage, _ := strconv.Atoi(p.Leverage)
+	return &common.Position{
+		Symbol:           p.Symbol,
+		PositionSide:     common.PositionSide(p.PositionSide),
+		Amount:           parseFloat(p.PositionAmt),
+		EntryPrice:       parseFloat(p.EntryPrice),
+		MarkPrice:        parseFloat(p.MarkPrice),
+		UnrealizedProfit: parseFloat(p.UnRealizedProfit),
+		LiquidationPrice: parseFloat(p.LiquidationPrice),
+		Leverage:         leverage,
+		Isolated:         p.MarginType == "isolated",
+	}
+}
+
+// parseFloat 解析数值字符串, 空字符串或非法值返回 0
+func parseFloat(s string) float64 {
+	f, _ := strconv.ParseFloat(s, 64)
+	return f
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
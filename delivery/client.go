@@ -71,6 +71,9 @@ const (
 	baseApiTestnetUrl = "https://testnet.binancefuture.com"
 )
 
+// UseTestnet switch all the API endpoints and WS streams from production to the testnet | 将所有 API 和 WS 流从生产环境切换到测试网络
+var UseTestnet = false
+
 // Global enums | 全局常量
 const (
 	SideTypeBuy  SideType = "BUY"
@@ -104,6 +107,7 @@ const (
 	OrderExecutionTypeCalculated  OrderExecutionType = "CALCULATED"
 	OrderExecutionTypeExpired     OrderExecutionType = "EXPIRED"
 	OrderExecutionTypeTrade       OrderExecutionType = "TRADE"
+	OrderExecutionTypeAmendment   OrderExecutionType = "AMENDMENT"
 
 	OrderStatusTypeNew             OrderStatusType = "NEW"
 	OrderStatusTypePartiallyFilled OrderStatusType = "PARTIALLY_FILLED"
@@ -177,7 +181,7 @@ func newJSON(data []byte) (j *simplejson.Json, err error) {
 }
 
 func getApiEndpoint() string {
-	if Uset {
+	if UseTestnet {
 		return baseApiTestnetUrl
 	}
 	return baseApiMainUrl
@@ -195,6 +199,7 @@ type Client struct {
 	Debug      bool
 	Logger     *log.Logger
 	TimeOffset int64
+	OrderIDs   *common.ClientOrderIDGenerator // 下单未指定 newClientOrderId 时用于生成, 为空则由交易所分配
 	do         doFunc
 }
 
@@ -262,7 +267,7 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 		if queryString == "" {
 			queryString = v.Encode()
 		} else {
-			queryString = fmt.Sprintf("%s%s", queryString, v.Encode())
+			queryString = fmt.Sprintf("%s&%s", queryString, v.Encode())
 		}
 	}
 	if queryString != "" {
@@ -406,6 +411,11 @@ func (c *Client) NewListLiquidationOrdersService() *ListLiquidationOrdersService
 	return &ListLiquidationOrdersService{c: c}
 }
 
+// NewListAccountTradeService init account trade list service
+func (c *Client) NewListAccountTradeService() *ListAccountTradeService {
+	return &ListAccountTradeService{c: c}
+}
+
 // NewGetAccountService init account service
 func (c *Client) NewGetAccountService() *GetAccountService {
 	return &GetAccountService{c: c}
@@ -445,3 +455,48 @@ func (c *Client) NewChangePositionModeService() *ChangePositionModeService {
 func (c *Client) NewGetPositionModeService() *GetPositionModeService {
 	return &GetPositionModeService{c: c}
 }
+
+// NewGetLeverageBracketService init leverage bracket service
+func (c *Client) NewGetLeverageBracketService() *GetLeverageBracketService {
+	return &GetLeverageBracketService{c: c}
+}
+
+// NewGetIncomeHistoryService init income history service
+func (c *Client) NewGetIncomeHistoryService() *GetIncomeHistoryService {
+	return &GetIncomeHistoryService{c: c}
+}
+
+// NewGetIncomeDownloadIDService init get income history download id service
+func (c *Client) NewGetIncomeDownloadIDService() *GetDownloadIDService {
+	return &GetDownloadIDService{c: c, downloadType: HistoryDownloadTypeIncome}
+}
+
+// NewGetIncomeDownloadLinkService init get income history download link service
+func (c *Client) NewGetIncomeDownloadLinkService() *GetDownloadLinkService {
+	return &GetDownloadLinkService{c: c, downloadType: HistoryDownloadTypeIncome}
+}
+
+// NewGetOrderDownloadIDService init get order history download id service
+func (c *Client) NewGetOrderDownloadIDService() *GetDownloadIDService {
+	return &GetDownloadIDService{c: c, downloadType: HistoryDownloadTypeOrder}
+}
+
+// NewGetOrderDownloadLinkService init get order history download link service
+func (c *Client) NewGetOrderDownloadLinkService() *GetDownloadLinkService {
+	return &GetDownloadLinkService{c: c, downloadType: HistoryDownloadTypeOrder}
+}
+
+// NewGetTradeDownloadIDService init get trade history download id service
+func (c *Client) NewGetTradeDownloadIDService() *GetDownloadIDService {
+	return &GetDownloadIDService{c: c, downloadType: HistoryDownloadTypeTrade}
+}
+
+// NewGetTradeDownloadLinkService init get trade history download link service
+func (c *Client) NewGetTradeDownloadLinkService() *GetDownloadLinkService {
+	return &GetDownloadLinkService{c: c, downloadType: HistoryDownloadTypeTrade}
+}
+
+// NewHistoryDownloadService init asynchronous history download service
+func (c *Client) NewHistoryDownloadService() *HistoryDownloadService {
+	return &HistoryDownloadService{c: c}
+}
@@ -60,7 +60,7 @@ func (s *ExchangeInfoService) Do(ctx context.Context, opts ...RequestOption) (re
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/dapi/v1/exchangeInfo",
-		secType:  SecTypeNone,
+		secType:  secTypeNone,
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -0,0 +1,331 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/BobHye/binance-go/common"
+)
+
+// HistoryDownloadType define the kind of asynchronous history download
+type HistoryDownloadType string
+
+// HistoryDownloadType define the kinds of asynchronous history download
+const (
+	HistoryDownloadTypeIncome HistoryDownloadType = "income" // 资金流水
+	HistoryDownloadTypeOrder  HistoryDownloadType = "order"  // 订单历史
+	HistoryDownloadTypeTrade  HistoryDownloadType = "trade"  // 成交历史
+)
+
+// DownloadLinkStatusCompleted status of a ready download link
+const DownloadLinkStatusCompleted = "completed"
+
+// GetDownloadIDService request an asynchronous history download
+type GetDownloadIDService struct {
+	c            *Client
+	downloadType HistoryDownloadType
+	startTime    int64
+	endTime      int64 // 与起始时间间隔不能超过1年
+}
+
+// SetStartTime set startTime
+func (s *GetDownloadIDService) SetStartTime(startTime int64) *GetDownloadIDService {
+	s.startTime = startTime
+	return s
+}
+
+// SetEndTime set endTime
+func (s *GetDownloadIDService) SetEndTime(endTime int64) *GetDownloadIDService {
+	s.endTime = endTime
+	return s
+}
+
+// Do send request
+func (s *GetDownloadIDService) Do(ctx context.Context, opts ...RequestOption) (res *DownloadID, err error) {
+	// 每月请求次数限制为5次, 网页端与REST接口共享
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/dapi/v1/" + string(s.downloadType) + "/asyn",
+		secType:  secTypeSigned,
+	}
+	r.setParams(params{
+		"startTime": s.startTime,
+		"endTime":   s.endTime,
+	})
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(DownloadID)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DownloadID define the response of an asynchronous download request
+type DownloadID struct {
+	AvgCostTimestampOfLast30d int64  `json:"avgCostTimestampOfLast30d"` // 过去30天的平均耗时(毫秒)
+	DownloadID                string `json:"downloadId"`
+}
+
+// GetDownloadLinkService get the link of an asynchronous history download
+type GetDownloadLinkService struct {
+	c            *Client
+	downloadType HistoryDownloadType
+	downloadID   string
+}
+
+// SetDownloadID set downloadId
+func (s *GetDownloadLinkService) SetDownloadID(downloadID string) *GetDownloadLinkService {
+	s.downloadID = downloadID
+	return s
+}
+
+// Do send request
+func (s *GetDownloadLinkService) Do(ctx context.Context, opts ...RequestOption) (res *DownloadLink, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/dapi/v1/" + string(s.downloadType) + "/asyn/id",
+		secType:  secTypeSigned,
+	}
+	r.setParam("downloadId", s.downloadID)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(DownloadLink)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DownloadLink define the link of an asynchronous history download
+type DownloadLink struct {
+	DownloadID          string `json:"downloadId"`
+	Status              string `json:"status"` // completed 已完成, processing 处理中
+	URL                 string `json:"url"`    // 处理中时为空, 有效期为7天
+	Notified            bool   `json:"notified"`
+	ExpirationTimestamp int64  `json:"expirationTimestamp"`
+	IsExpired           bool   `json:"isExpired"`
+}
+
+// HistoryDownloadService download the full history of a time range through the asynchronous export.
+// It requests a download id, polls the link with backoff, then streams and parses the CSV file
+type HistoryDownloadService struct {
+	c          *Client
+	startTime  int64
+	endTime    int64
+	downloadID string
+	backoff    common.DownloadBackoff
+	progress   common.DownloadProgressHandler
+}
+
+// SetStartTime set startTime
+func (s *HistoryDownloadService) SetStartTime(startTime int64) *HistoryDownloadService {
+	s.startTime = startTime
+	return s
+}
+
+// SetEndTime set endTime, at most 1 year after startTime
+func (s *HistoryDownloadService) SetEndTime(endTime int64) *HistoryDownloadService {
+	s.endTime = endTime
+	return s
+}
+
+// SetDownloadID resume an earlier download instead of requesting a new one, which is limited to 5 times a month
+func (s *HistoryDownloadService) SetDownloadID(downloadID string) *HistoryDownloadService {
+	s.downloadID = downloadID
+	return s
+}
+
+// SetBackoff set how the download link is polled, common.DefaultDownloadBackoff by default
+func (s *HistoryDownloadService) SetBackoff(backoff common.DownloadBackoff) *HistoryDownloadService {
+	s.backoff = backoff
+	return s
+}
+
+// SetProgressHandler set the handler called on every stage and every 1000 parsed rows
+func (s *HistoryDownloadService) SetProgressHandler(handler common.DownloadProgressHandler) *HistoryDownloadService {
+	s.progress = handler
+	return s
+}
+
+// Link request the download, or resume the one set by SetDownloadID, and wait until its link is ready
+func (s *HistoryDownloadService) Link(ctx context.Context, downloadType HistoryDownloadType, opts ...RequestOption) (downloadID string, url string, err error) {
+	downloadID = s.downloadID
+	var hint time.Duration
+	if downloadID == "" {
+		res, err := (&GetDownloadIDService{c: s.c, downloadType: downloadType}).SetStartTime(s.startTime).SetEndTime(s.endTime).Do(ctx, opts...)
+		if err != nil {
+			return "", "", err
+		}
+		downloadID = res.DownloadID
+		hint = time.Duration(res.AvgCostTimestampOfLast30d) * time.Millisecond
+	}
+	if s.progress != nil {
+		s.progress(&common.DownloadProgress{Stage: common.DownloadStageRequested, DownloadID: downloadID})
+	}
+	backoff := s.backoff
+	if backoff == (common.DownloadBackoff{}) {
+		backoff = common.DefaultDownloadBackoff
+	}
+	link := &GetDownloadLinkService{c: s.c, downloadType: downloadType, downloadID: downloadID}
+	url, err = common.WaitDownloadLink(ctx, downloadID, hint, func(ctx context.Context) (string, bool, error) {
+		res, err := link.Do(ctx, opts...)
+		if err != nil {
+			return "", false, err
+		}
+		return res.URL, res.Status == DownloadLinkStatusCompleted && res.URL != "", nil
+	}, backoff, s.progress)
+	return downloadID, url, err
+}
+
+func (s *HistoryDownloadService) stream(ctx context.Context, downloadType HistoryDownloadType, handler func(row *common.CSVRow) error, opts ...RequestOption) error {
+	downloadID, url, err := s.Link(ctx, downloadType, opts...)
+	if err != nil {
+		return err
+	}
+	_, err = common.StreamCSV(ctx, s.c.HTTPClient, downloadID, url, handler, s.progress)
+	return err
+}
+
+// StreamIncome download the income history and call handler with every record in file order
+func (s *HistoryDownloadService) StreamIncome(ctx context.Context, handler func(income *IncomeHistory) error, opts ...RequestOption) error {
+	return s.stream(ctx, HistoryDownloadTypeIncome, func(row *common.CSVRow) error {
+		return handler(incomeFromCSV(row))
+	}, opts...)
+}
+
+// Income download the income history
+func (s *HistoryDownloadService) Income(ctx context.Context, opts ...RequestOption) (res []*IncomeHistory, err error) {
+	res = make([]*IncomeHistory, 0)
+	err = s.StreamIncome(ctx, func(income *IncomeHistory) error {
+		res = append(res, income)
+		return nil
+	}, opts...)
+	if err != nil {
+		return []*IncomeHistory{}, err
+	}
+	return res, nil
+}
+
+// StreamOrders download the order history and call handler with every order in file order
+func (s *HistoryDownloadService) StreamOrders(ctx context.Context, handler func(order *Order) error, opts ...RequestOption) error {
+	return s.stream(ctx, HistoryDownloadTypeOrder, func(row *common.CSVRow) error {
+		return handler(orderFromCSV(row))
+	}, opts...)
+}
+
+// Orders download the order history
+func (s *HistoryDownloadService) Orders(ctx context.Context, opts ...RequestOption) (res []*Order, err error) {
+	res = make([]*Order, 0)
+	err = s.StreamOrders(ctx, func(order *Order) error {
+		res = append(res, order)
+		return nil
+	}, opts...)
+	if err != nil {
+		return []*Order{}, err
+	}
+	return res, nil
+}
+
+// StreamTrades download the trade history and call handler with every trade in file order
+func (s *HistoryDownloadService) StreamTrades(ctx context.Context, handler func(trade *AccountTrade) error, opts ...RequestOption) error {
+	return s.stream(ctx, HistoryDownloadTypeTrade, func(row *common.CSVRow) error {
+		return handler(accountTradeFromCSV(row))
+	}, opts...)
+}
+
+// Trades download the trade history
+func (s *HistoryDownloadService) Trades(ctx context.Context, opts ...RequestOption) (res []*AccountTrade, err error) {
+	res = make([]*AccountTrade, 0)
+	err = s.StreamTrades(ctx, func(trade *AccountTrade) error {
+		res = append(res, trade)
+		return nil
+	}, opts...)
+	if err != nil {
+		return []*AccountTrade{}, err
+	}
+	return res, nil
+}
+
+// 导出文件的列名与接口字段名不完全一致, 依次尝试接口字段名和网页导出的列名
+// 含义因文件而异的列名不作为备选, 如订单的 Amount 为数量而成交的 Amount 为成交额, id 只表示成交ID
+
+func incomeFromCSV(row *common.CSVRow) *IncomeHistory {
+	return &IncomeHistory{
+		Asset:      row.String("asset", "coin"),
+		Income:     row.String("income", "amount"),
+		IncomeType: strings.ToUpper(row.String("incomeType", "type")),
+		Info:       row.String("info"),
+		Symbol:     row.String("symbol"),
+		Time:       row.Time("time", "date(UTC)", "transactionTime"),
+		TranID:     row.Int64("tranId", "transactionId"),
+		TradeID:    row.String("tradeId"),
+	}
+}
+
+func orderFromCSV(row *common.CSVRow) *Order {
+	return &Order{
+		AvgPrice:         row.String("avgPrice", "avgTradingPrice", "averagePrice"),
+		ClientOrderID:    row.String("clientOrderId"),
+		CumBase:          row.String("cumBase", "total"),
+		ExecutedQuantity: row.String("executedQty", "filled", "executed"),
+		OrderID:          row.Int64("orderId", "orderNo"),
+		OrigQuantity:     row.String("origQty", "orderAmount", "quantity"),
+		OrigType:         OrderType(strings.ToUpper(row.String("origType"))),
+		Price:            row.String("price", "orderPrice"),
+		ReduceOnly:       row.Bool("reduceOnly"),
+		Side:             SideType(strings.ToUpper(row.String("side"))),
+		PositionSide:     PositionSideType(strings.ToUpper(row.String("positionSide"))),
+		Status:           OrderStatusType(strings.ToUpper(row.String("status"))),
+		StopPrice:        row.String("stopPrice", "triggerPrice"),
+		ClosePosition:    row.Bool("closePosition"),
+		Symbol:           row.String("symbol"),
+		Pair:             row.String("pair"),
+		Time:             row.Time("time", "date(UTC)", "createTime"),
+		TimeInForce:      TimeInForceType(strings.ToUpper(row.String("timeInForce"))),
+		Type:             OrderType(strings.ToUpper(row.String("type", "orderType"))),
+		ActivatePrice:    row.String("activatePrice"),
+		PriceRate:        row.String("priceRate"),
+		UpdateTime:       row.Time("updateTime"),
+		WorkingType:      WorkingType(strings.ToUpper(row.String("workingType"))),
+		PriceProtect:     row.Bool("priceProtect"),
+	}
+}
+
+func accountTradeFromCSV(row *common.CSVRow) *AccountTrade {
+	trade := &AccountTrade{
+		Symbol:          row.String("symbol"),
+		ID:              row.Int64("id", "tradeId"),
+		OrderID:         row.Int64("orderId"),
+		Pair:            row.String("pair"),
+		Side:            SideType(strings.ToUpper(row.String("side"))),
+		Price:           row.String("price"),
+		Quantity:        row.String("qty", "quantity"),
+		RealizedPnl:     row.String("realizedPnl", "realizedProfit"),
+		MarginAsset:     row.String("marginAsset"),
+		BaseQuantity:    row.String("baseQty"),
+		Commission:      row.String("commission", "fee"),
+		CommissionAsset: row.String("commissionAsset", "feeCoin", "feeAsset"),
+		Time:            row.Time("time", "date(UTC)"),
+		PositionSide:    PositionSideType(strings.ToUpper(row.String("positionSide"))),
+	}
+	if row.Has("buyer") {
+		trade.Buyer = row.Bool("buyer")
+	} else {
+		trade.Buyer = trade.Side == SideTypeBuy
+	}
+	if row.Has("maker") {
+		trade.Maker = row.Bool("maker")
+	} else {
+		trade.Maker = strings.EqualFold(row.String("role", "liquidity"), "maker")
+	}
+	return trade
+}
@@ -0,0 +1,90 @@
+package delivery
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BobHye/binance-go/common"
+)
+
+// parseCSV 按下载文件的方式解析 data, 返回每行 parse 的结果
+func parseCSV[T any](t *testing.T, data string, parse func(row *common.CSVRow) T) []T {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, data)
+	}))
+	defer srv.Close()
+	res := make([]T, 0)
+	_, err := common.StreamCSV(context.Background(), srv.Client(), "", srv.URL, func(row *common.CSVRow) error {
+		res = append(res, parse(row))
+		return nil
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestIncomeFromCSV(t *testing.T) {
+	// /dapi/v1/income/asyn 导出文件
+	incomes := parseCSV(t, "\ufeffDate(UTC),Asset,Type,Amount,Symbol,Transaction ID\n"+
+		"2024-03-01 08:00:00,BTC,FUNDING_FEE,-0.00000524,BTCUSD_PERP,9689322392\n", incomeFromCSV)
+	if len(incomes) != 1 {
+		t.Fatalf("got %d incomes", len(incomes))
+	}
+	i := incomes[0]
+	if i.Asset != "BTC" || i.Income != "-0.00000524" || i.IncomeType != "FUNDING_FEE" || i.Symbol != "BTCUSD_PERP" ||
+		i.Time != 1709280000000 || i.TranID != 9689322392 {
+		t.Errorf("unexpected income %+v", i)
+	}
+}
+
+func TestOrderFromCSV(t *testing.T) {
+	// /dapi/v1/order/asyn 导出文件, 数量为张数, Total 为基础资产数量
+	orders := parseCSV(t, "Date(UTC),Order No,Symbol,Type,Side,Order Price,Order Amount,AvgTrading Price,Filled,Total,Trigger Conditions,Reduce Only,Status\n"+
+		"2024-03-01 08:00:01,83897656394,BTCUSD_PERP,LIMIT,BUY,60000,10,60000,10,0.01666666,,false,FILLED\n", orderFromCSV)
+	if len(orders) != 1 {
+		t.Fatalf("got %d orders", len(orders))
+	}
+	o := orders[0]
+	if o.OrderID != 83897656394 || o.Symbol != "BTCUSD_PERP" || o.Type != OrderTypeLimit || o.Side != SideTypeBuy ||
+		o.Price != "60000" || o.OrigQuantity != "10" || o.AvgPrice != "60000" || o.ExecutedQuantity != "10" ||
+		o.CumBase != "0.01666666" || o.Status != OrderStatusTypeFilled || o.Time != 1709280001000 {
+		t.Errorf("unexpected order %+v", o)
+	}
+
+	// 含义不明确的列不作为订单号和数量
+	orders = parseCSV(t, "id,amount,symbol\n1,5,BTCUSD_PERP\n", orderFromCSV)
+	if o = orders[0]; o.OrderID != 0 || o.OrigQuantity != "" {
+		t.Errorf("ambiguous columns parsed into order %+v", o)
+	}
+}
+
+func TestAccountTradeFromCSV(t *testing.T) {
+	// /dapi/v1/trade/asyn 导出文件, Amount 为基础资产数量
+	trades := parseCSV(t, "Date(UTC),Symbol,Side,Price,Quantity,Amount,Fee,Fee Coin,Realized Profit\n"+
+		"2024-03-01 08:00:01,BTCUSD_PERP,SELL,61000,10,0.01639344,0.00000819,BTC,0.00027322\n", accountTradeFromCSV)
+	if len(trades) != 1 {
+		t.Fatalf("got %d trades", len(trades))
+	}
+	tr := trades[0]
+	if tr.Symbol != "BTCUSD_PERP" || tr.Side != SideTypeSell || tr.Buyer || tr.Price != "61000" || tr.Quantity != "10" ||
+		tr.Commission != "0.00000819" || tr.CommissionAsset != "BTC" || tr.RealizedPnl != "0.00027322" || tr.Time != 1709280001000 {
+		t.Errorf("unexpected trade %+v", tr)
+	}
+	if tr.BaseQuantity != "" || tr.ID != 0 {
+		t.Errorf("ambiguous columns parsed into trade %+v", tr)
+	}
+
+	// 接口字段名
+	trades = parseCSV(t, "symbol,id,orderId,pair,side,price,qty,realizedPnl,marginAsset,baseQty,commission,commissionAsset,time,positionSide,buyer,maker\n"+
+		"BTCUSD_PERP,6,28,BTCUSD,BUY,60000,10,0,BTC,0.01666666,0.00000833,BTC,1709280001000,LONG,true,false\n", accountTradeFromCSV)
+	tr = trades[0]
+	if tr.ID != 6 || tr.OrderID != 28 || tr.Pair != "BTCUSD" || tr.BaseQuantity != "0.01666666" || tr.MarginAsset != "BTC" ||
+		!tr.Buyer || tr.Maker || tr.PositionSide != PositionSideTypeLong {
+		t.Errorf("unexpected trade %+v", tr)
+	}
+}
@@ -0,0 +1,92 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+)
+
+// GetIncomeHistoryService get income history service | 获取账户损益资金流水
+type GetIncomeHistoryService struct {
+	c          *Client
+	symbol     string
+	incomeType string // 收益类型: TRANSFER, REALIZED_PNL, FUNDING_FEE, COMMISSION, INSURANCE_CLEAR 等
+	startTime  *int64
+	endTime    *int64
+	limit      *int64 // 默认值:100 最大值:1000
+}
+
+// SetSymbol set symbol
+func (s *GetIncomeHistoryService) SetSymbol(symbol string) *GetIncomeHistoryService {
+	s.symbol = symbol
+	return s
+}
+
+// SetIncomeType set income type
+func (s *GetIncomeHistoryService) SetIncomeType(incomeType string) *GetIncomeHistoryService {
+	s.incomeType = incomeType
+	return s
+}
+
+// SetStartTime set startTime
+func (s *GetIncomeHistoryService) SetStartTime(startTime int64) *GetIncomeHistoryService {
+	s.startTime = &startTime
+	return s
+}
+
+// SetEndTime set endTime
+func (s *GetIncomeHistoryService) SetEndTime(endTime int64) *GetIncomeHistoryService {
+	s.endTime = &endTime
+	return s
+}
+
+// SetLimit set limit
+func (s *GetIncomeHistoryService) SetLimit(limit int64) *GetIncomeHistoryService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *GetIncomeHistoryService) Do(ctx context.Context, opts ...RequestOption) (res []*IncomeHistory, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/dapi/v1/income",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
+	}
+	if s.incomeType != "" {
+		r.setParam("incomeType", s.incomeType)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*IncomeHistory{}, err
+	}
+	res = make([]*IncomeHistory, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*IncomeHistory{}, err
+	}
+	return res, nil
+}
+
+// IncomeHistory define income history info
+type IncomeHistory struct {
+	Asset      string `json:"asset"`
+	Income     string `json:"income"` // 正数代表流入，负数代表流出
+	IncomeType string `json:"incomeType"`
+	Info       string `json:"info"`
+	Symbol     string `json:"symbol"`
+	Time       int64  `json:"time"`
+	TranID     int64  `json:"tranId"`
+	TradeID    string `json:"tradeId"`
+}
@@ -0,0 +1,58 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+)
+
+// GetLeverageBracketService get notional tiers of symbols | 查询杠杆分层标准
+type GetLeverageBracketService struct {
+	c      *Client
+	symbol *string
+}
+
+// SetSymbol set symbol
+func (s *GetLeverageBracketService) SetSymbol(symbol string) *GetLeverageBracketService {
+	s.symbol = &symbol
+	return s
+}
+
+// Do send request
+func (s *GetLeverageBracketService) Do(ctx context.Context, opts ...RequestOption) (res []*LeverageBracket, err error) {
+	// GET /dapi/v2/leverageBracket | 查询交易对的杠杆分层标准, 分层以基础资产数量划分
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/dapi/v2/leverageBracket",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*LeverageBracket{}, err
+	}
+	res = make([]*LeverageBracket, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*LeverageBracket{}, err
+	}
+	return res, nil
+}
+
+// LeverageBracket define the leverage brackets of a symbol
+type LeverageBracket struct {
+	Symbol       string    `json:"symbol"`
+	NotionalCoef float64   `json:"notionalCoef"` // 用户bracket相对默认bracket的倍数，仅在和交易对默认不一样时显示
+	Brackets     []Bracket `json:"brackets"`
+}
+
+// Bracket define one bracket, caps are base asset quantities
+type Bracket struct {
+	Bracket          int     `json:"bracket"`          // 层级
+	InitialLeverage  int     `json:"initialLeverage"`  // 该层允许的最高初始杠杆倍数
+	QtyCap           float64 `json:"qtyCap"`           // 该层对应的基础资产数量上限
+	QtyFloor         float64 `json:"qtyFloor"`         // 该层对应的基础资产数量下限
+	MaintMarginRatio float64 `json:"maintMarginRatio"` // 该层对应的维持保证金率
+	Cum              float64 `json:"cum"`              // 速算数, 基础资产
+}
@@ -0,0 +1,381 @@
+package delivery
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// 本地保证金计算(币本位, 以保证金资产计价), 公式与币安文档一致:
+//   名义价值 = 张数 * 合约面值 / 标记价格
+//   维持保证金 = 名义价值 * 维持保证金率 - 维持保证金速算数
+//   起始保证金 = 名义价值 / 杠杆倍数
+//   强平价格 LP = (Position1BOTH*MMR_B + Position1LONG*MMR_L + Position1SHORT*MMR_S + Side1BOTH*Position1BOTH + Position1LONG - Position1SHORT)
+//            / ((WB - TMM1 + UPNL1 + cumB + cumL + cumS) / ContractSize + Side1BOTH*Position1BOTH/EP1BOTH + Position1LONG/EP1LONG - Position1SHORT/EP1SHORT)
+//   逐仓时 WB 为逐仓钱包余额, TMM1 与 UPNL1 为 0; 全仓时 WB 为该保证金资产的全仓钱包余额, TMM1 与 UPNL1 为其他全仓持仓的维持保证金和未实现盈亏
+
+// MarginPosition define a real or hypothetical position evaluated by MarginCalculator, amounts are contracts | 用于保证金计算的持仓
+type MarginPosition struct {
+	Symbol         string           // 交易对
+	PositionSide   PositionSideType // 持仓方向, 单向持仓为 BOTH 或空
+	Amount         float64          // 持仓张数, 符号代表多空方向; 双向持仓时 LONG 取正, SHORT 取负
+	EntryPrice     float64          // 开仓均价
+	MarkPrice      float64          // 标记价格, 为 0 时使用开仓均价
+	Leverage       int              // 杠杆倍数
+	MarginType     MarginType       // 保证金模式, 为空时按全仓计算
+	IsolatedMargin float64          // 逐仓钱包余额, 为 0 时按起始保证金计算
+}
+
+// MarginAccount define the cross wallet of one margin asset and its positions | 保证金计算使用的账户, 对应一种保证金资产
+type MarginAccount struct {
+	CrossWalletBalance float64           // 全仓钱包余额
+	Positions          []*MarginPosition // 该保证金资产的全部持仓
+}
+
+// MarginResult define the margin figures of one position, values are in the margin asset | 保证金计算结果
+type MarginResult struct {
+	Notional         float64 // 名义价值, 按标记价格计算
+	InitialMargin    float64 // 起始保证金
+	MaintMarginRatio float64 // 维持保证金率
+	MaintAmount      float64 // 维持保证金速算数
+	MaintMargin      float64 // 维持保证金
+	UnrealizedPnL    float64 // 未实现盈亏
+	LiquidationPrice float64 // 强平价格, 0 表示不会被强平
+}
+
+// MarginCalculator calculate margins, liquidation prices and maximum open quantities of COIN-M positions offline
+// from the leverage brackets of GetLeverageBracketService and the contract sizes of ExchangeInfoService.
+// Symbols without a contract size evaluate to zero | 本地保证金与强平价格计算器
+type MarginCalculator struct {
+	mu            sync.RWMutex
+	brackets      map[string]*LeverageBracket
+	contractSizes map[string]float64
+}
+
+// NewMarginCalculator init a calculator with the leverage brackets of symbols | 创建保证金计算器
+func NewMarginCalculator(brackets ...*LeverageBracket) *MarginCalculator {
+	m := &MarginCalculator{brackets: make(map[string]*LeverageBracket), contractSizes: make(map[string]float64)}
+	return m.SetBrackets(brackets...)
+}
+
+// SetBrackets set or replace the leverage brackets of symbols | 设置杠杆分层
+func (m *MarginCalculator) SetBrackets(brackets ...*LeverageBracket) *MarginCalculator {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, b := range brackets {
+		m.brackets[b.Symbol] = b
+	}
+	return m
+}
+
+// SetContractSizes set the contract sizes of symbols, e.g. ExchangeInfo.Symbols | 设置合约面值
+func (m *MarginCalculator) SetContractSizes(symbols ...Symbol) *MarginCalculator {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, s := range symbols {
+		m.contractSizes[s.Symbol] = float64(s.ContractSize)
+	}
+	return m
+}
+
+// SetContractSize set the contract size of symbol in USD | 设置合约面值
+func (m *MarginCalculator) SetContractSize(symbol string, size float64) *MarginCalculator {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.contractSizes[symbol] = size
+	return m
+}
+
+// ContractSize return the contract size of symbol, 0 if unknown | 合约面值
+func (m *MarginCalculator) ContractSize(symbol string) float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.contractSizes[symbol]
+}
+
+// Bracket return the bracket of the notional value, nil if the symbol has no brackets | 名义价值所在的杠杆分层
+func (m *MarginCalculator) Bracket(symbol string, notional float64) *Bracket {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	lb := m.brackets[symbol]
+	if lb == nil || len(lb.Brackets) == 0 {
+		return nil
+	}
+	for i := range lb.Brackets {
+		if notional < lb.Brackets[i].QtyCap {
+			return &lb.Brackets[i]
+		}
+	}
+	return &lb.Brackets[len(lb.Brackets)-1]
+}
+
+// MaintMargin return the maintenance margin of the notional value with its ratio and maintenance amount | 维持保证金
+func (m *MarginCalculator) MaintMargin(symbol string, notional float64) (margin, ratio, cum float64) {
+	b := m.Bracket(symbol, notional)
+	if b == nil {
+		return 0, 0, 0
+	}
+	return notional*b.MaintMarginRatio - b.Cum, b.MaintMarginRatio, b.Cum
+}
+
+// MaxNotional return the maximum notional value in the margin asset allowed at the leverage, 0 if the symbol has no brackets | 杠杆倍数允许的名义价值上限
+func (m *MarginCalculator) MaxNotional(symbol string, leverage int) float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	lb := m.brackets[symbol]
+	if lb == nil {
+		return 0
+	}
+	res := 0.0
+	for _, b := range lb.Brackets {
+		if b.InitialLeverage >= leverage && b.QtyCap > res {
+			res = b.QtyCap
+		}
+	}
+	return res
+}
+
+// Calculate return the margin figures and the liquidation price of pos within account. pos replaces the position of
+// account with the same symbol and position side, so hypothetical positions can be evaluated directly | 计算持仓的保证金与强平价格
+func (m *MarginCalculator) Calculate(account *MarginAccount, pos *MarginPosition) *MarginResult {
+	res := m.position(pos)
+	res.LiquidationPrice = m.LiquidationPrice(account, pos)
+	return res
+}
+
+// LiquidationPrice return the liquidation price of pos within account, 0 if it can not be liquidated | 强平价格
+func (m *MarginCalculator) LiquidationPrice(account *MarginAccount, pos *MarginPosition) float64 {
+	size := m.ContractSize(pos.Symbol)
+	if pos.Amount == 0 || size == 0 {
+		return 0
+	}
+	group := []*MarginPosition{pos}
+	var wallet, otherMaint, otherPnL float64
+	if pos.MarginType == MarginTypeIsolated {
+		wallet = m.isolatedMargin(pos)
+	} else {
+		wallet = account.CrossWalletBalance
+		for _, p := range account.Positions {
+			if p.Amount == 0 || p.MarginType == MarginTypeIsolated || samePosition(p, pos) {
+				continue
+			}
+			if p.Symbol == pos.Symbol {
+				// 双向持仓的另一方向与本持仓一起计算
+				group = append(group, p)
+				continue
+			}
+			r := m.position(p)
+			otherMaint += r.MaintMargin
+			otherPnL += r.UnrealizedPnL
+		}
+	}
+	numerator := 0.0
+	denominator := (wallet - otherMaint + otherPnL) / size
+	for _, p := range group {
+		amount := p.signedAmount()
+		r := m.position(p)
+		numerator += math.Abs(amount)*r.MaintMarginRatio + amount
+		denominator += r.MaintAmount / size
+		if p.EntryPrice > 0 {
+			denominator += amount / p.EntryPrice
+		}
+	}
+	// 空仓保证金不足时分子分母同为负数
+	if denominator == 0 {
+		return 0
+	}
+	price := numerator / denominator
+	if price <= 0 {
+		return 0
+	}
+	return price
+}
+
+// AvailableBalance return the cross wallet balance plus cross unrealized profit minus the initial margin of
+// cross positions, which is what new orders can use | 可用余额
+func (m *MarginCalculator) AvailableBalance(account *MarginAccount) float64 {
+	res := account.CrossWalletBalance
+	for _, p := range account.Positions {
+		if p.Amount == 0 || p.MarginType == MarginTypeIsolated {
+			continue
+		}
+		r := m.position(p)
+		res += r.UnrealizedPnL - r.InitialMargin
+	}
+	return math.Max(res, 0)
+}
+
+// MaxOpenQuantity return the maximum contracts that can be added to the position of symbol and positionSide at
+// price and leverage, limited by the available balance and by the notional cap of the leverage | 最大可开张数
+func (m *MarginCalculator) MaxOpenQuantity(account *MarginAccount, symbol string, positionSide PositionSideType, leverage int, price float64) float64 {
+	size := m.ContractSize(symbol)
+	if price <= 0 || leverage <= 0 || size == 0 {
+		return 0
+	}
+	notional := m.AvailableBalance(account) * float64(leverage)
+	if maxNotional := m.MaxNotional(symbol, leverage); maxNotional > 0 {
+		current := 0.0
+		for _, p := range account.Positions {
+			if p.Symbol == symbol && normalizePositionSide(p.PositionSide) == normalizePositionSide(positionSide) {
+				current += m.position(p).Notional
+			}
+		}
+		notional = math.Min(notional, math.Max(maxNotional-current, 0))
+	}
+	return math.Floor(notional * price / size)
+}
+
+// position return the margin figures of p without the liquidation price
+func (m *MarginCalculator) position(p *MarginPosition) *MarginResult {
+	size := m.ContractSize(p.Symbol)
+	mark := p.markPrice()
+	res := &MarginResult{}
+	if size == 0 || mark <= 0 {
+		return res
+	}
+	res.Notional = math.Abs(p.Amount) * size / mark
+	if p.EntryPrice > 0 {
+		res.UnrealizedPnL = p.signedAmount() * size * (1/p.EntryPrice - 1/mark)
+	}
+	res.MaintMargin, res.MaintMarginRatio, res.MaintAmount = m.MaintMargin(p.Symbol, res.Notional)
+	if p.Leverage > 0 {
+		res.InitialMargin = res.Notional / float64(p.Leverage)
+	}
+	return res
+}
+
+// isolatedMargin return the isolated wallet of p, the initial margin when unset
+func (m *MarginCalculator) isolatedMargin(p *MarginPosition) float64 {
+	if p.IsolatedMargin != 0 || p.Leverage <= 0 || p.EntryPrice <= 0 {
+		return p.IsolatedMargin
+	}
+	return math.Abs(p.Amount) * m.ContractSize(p.Symbol) / p.EntryPrice / float64(p.Leverage)
+}
+
+// Add return a copy of p with quantity contracts added at price, quantity signed as Amount. The entry price is
+// averaged when the position grows and kept when it shrinks. Isolated margin changes are left to the caller since
+// they depend on the contract size, see MarginCalculator.Add | 加仓或减仓后的持仓
+func (p *MarginPosition) Add(quantity, price float64) *MarginPosition {
+	res := *p
+	amount := p.signedAmount()
+	next := amount + quantity
+	switch {
+	case next == 0:
+		res.EntryPrice = 0
+	case amount == 0 || amount*next < 0:
+		// 新开仓或反向开仓, 原持仓全部平掉
+		res.EntryPrice = price
+	case amount*quantity > 0:
+		// 币本位按张数对开仓价做调和平均
+		res.EntryPrice = next / (amount/p.EntryPrice + quantity/price)
+	}
+	res.Amount = next
+	return &res
+}
+
+// Add return a copy of p with quantity contracts added at price, isolated margin adjusted with the initial margin
+// of the added part | 加仓或减仓后的持仓, 逐仓保证金随之调整
+func (m *MarginCalculator) Add(p *MarginPosition, quantity, price float64) *MarginPosition {
+	res := p.Add(quantity, price)
+	if res.MarginType != MarginTypeIsolated || res.Leverage <= 0 || price <= 0 {
+		return res
+	}
+	size := m.ContractSize(p.Symbol)
+	amount, next := p.signedAmount(), res.Amount
+	switch {
+	case next == 0:
+		res.IsolatedMargin = 0
+	case amount*next < 0:
+		res.IsolatedMargin = math.Abs(next) * size / price / float64(res.Leverage)
+	case amount == 0 || amount*quantity > 0:
+		res.IsolatedMargin += math.Abs(quantity) * size / price / float64(res.Leverage)
+	default:
+		res.IsolatedMargin *= math.Abs(next) / math.Abs(amount)
+	}
+	return res
+}
+
+// With return a copy of a with pos in place of the position of the same symbol and position side | 替换持仓后的账户
+func (a *MarginAccount) With(pos *MarginPosition) *MarginAccount {
+	res := &MarginAccount{CrossWalletBalance: a.CrossWalletBalance, Positions: make([]*MarginPosition, 0, len(a.Positions)+1)}
+	for _, p := range a.Positions {
+		if !samePosition(p, pos) {
+			res.Positions = append(res.Positions, p)
+		}
+	}
+	res.Positions = append(res.Positions, pos)
+	return res
+}
+
+// Position return the position of symbol and positionSide, nil if absent | 查询持仓
+func (a *MarginAccount) Position(symbol string, positionSide PositionSideType) *MarginPosition {
+	for _, p := range a.Positions {
+		if p.Symbol == symbol && normalizePositionSide(p.PositionSide) == normalizePositionSide(positionSide) {
+			return p
+		}
+	}
+	return nil
+}
+
+// NewMarginAccount build the margin account of asset from GetAccountService and the GetPositionRiskService
+// result of the same margin asset | 由账户信息和持仓风险创建保证金计算账户
+func NewMarginAccount(account *Account, asset string, risks []*PositionRisk) *MarginAccount {
+	res := &MarginAccount{}
+	for _, a := range account.Assets {
+		if a.Asset == asset {
+			res.CrossWalletBalance = parseFloat(a.CrossWalletBalance)
+		}
+	}
+	for _, r := range risks {
+		amount := parseFloat(r.PositionAmt)
+		if amount == 0 {
+			continue
+		}
+		leverage, _ := strconv.Atoi(r.Leverage)
+		pos := &MarginPosition{
+			Symbol:       r.Symbol,
+			PositionSide: r.PositionSide,
+			Amount:       amount,
+			EntryPrice:   parseFloat(r.EntryPrice),
+			MarkPrice:    parseFloat(r.MarkPrice),
+			Leverage:     leverage,
+			MarginType:   MarginTypeCrossed,
+		}
+		if strings.EqualFold(r.MarginType, string(MarginTypeIsolated)) {
+			pos.MarginType = MarginTypeIsolated
+			pos.IsolatedMargin = parseFloat(r.IsolatedMargin) - parseFloat(r.UnRealizedProfit)
+		}
+		res.Positions = append(res.Positions, pos)
+	}
+	return res
+}
+
+func (p *MarginPosition) markPrice() float64 {
+	if p.MarkPrice > 0 {
+		return p.MarkPrice
+	}
+	return p.EntryPrice
+}
+
+// signedAmount 双向持仓时按持仓方向确定数量的符号
+func (p *MarginPosition) signedAmount() float64 {
+	switch p.PositionSide {
+	case PositionSideTypeLong:
+		return math.Abs(p.Amount)
+	case PositionSideTypeShort:
+		return -math.Abs(p.Amount)
+	}
+	return p.Amount
+}
+
+func samePosition(a, b *MarginPosition) bool {
+	return a.Symbol == b.Symbol && normalizePositionSide(a.PositionSide) == normalizePositionSide(b.PositionSide)
+}
+
+func normalizePositionSide(s PositionSideType) PositionSideType {
+	if s == "" {
+		return PositionSideTypeBoth
+	}
+	return s
+}
@@ -0,0 +1,108 @@
+package delivery
+
+import (
+	"math"
+	"testing"
+)
+
+// btcusdBrackets BTCUSD_PERP 前四层杠杆分层, 名义价值以 BTC 计, 速算数 cum_n = cum_n-1 + floor_n * (mmr_n - mmr_n-1)
+func btcusdBrackets() *LeverageBracket {
+	return &LeverageBracket{
+		Symbol: "BTCUSD_PERP",
+		Brackets: []Bracket{
+			{Bracket: 1, InitialLeverage: 125, QtyCap: 5, QtyFloor: 0, MaintMarginRatio: 0.004, Cum: 0},
+			{Bracket: 2, InitialLeverage: 100, QtyCap: 10, QtyFloor: 5, MaintMarginRatio: 0.005, Cum: 0.005},
+			{Bracket: 3, InitialLeverage: 50, QtyCap: 20, QtyFloor: 10, MaintMarginRatio: 0.01, Cum: 0.055},
+			{Bracket: 4, InitialLeverage: 20, QtyCap: 50, QtyFloor: 20, MaintMarginRatio: 0.025, Cum: 0.355},
+		},
+	}
+}
+
+func newBTCUSDCalculator() *MarginCalculator {
+	return NewMarginCalculator(btcusdBrackets()).SetContractSize("BTCUSD_PERP", 100)
+}
+
+func assertFloat(t *testing.T, name string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-6*math.Max(1, math.Abs(want)) {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+func TestMarginCalculatorMaintMarginBracketBoundary(t *testing.T) {
+	m := newBTCUSDCalculator()
+	// 分层边界处维持保证金连续: 5 * 0.4% = 5 * 0.5% - 0.005
+	margin, ratio, cum := m.MaintMargin("BTCUSD_PERP", 5)
+	assertFloat(t, "margin", margin, 0.02)
+	assertFloat(t, "ratio", ratio, 0.005)
+	assertFloat(t, "cum", cum, 0.005)
+	_, ratio, _ = m.MaintMargin("BTCUSD_PERP", 4.99)
+	assertFloat(t, "ratio", ratio, 0.004)
+}
+
+func TestMarginCalculatorOneWayCross(t *testing.T) {
+	m := newBTCUSDCalculator()
+	pos := &MarginPosition{Symbol: "BTCUSD_PERP", Amount: 100, EntryPrice: 50000, Leverage: 20, MarginType: MarginTypeCrossed}
+	account := &MarginAccount{CrossWalletBalance: 1, Positions: []*MarginPosition{pos}}
+
+	res := m.Calculate(account, pos)
+	// 100 张 * 100 USD / 50000 = 0.2 BTC
+	assertFloat(t, "Notional", res.Notional, 0.2)
+	assertFloat(t, "InitialMargin", res.InitialMargin, 0.01)
+	assertFloat(t, "MaintMargin", res.MaintMargin, 0.0008)
+	// LP = (100 * 0.004 + 100) / (1 / 100 + 100 / 50000)
+	assertFloat(t, "LiquidationPrice", res.LiquidationPrice, 8366.666666666666)
+}
+
+func TestMarginCalculatorIsolated(t *testing.T) {
+	m := newBTCUSDCalculator()
+	pos := &MarginPosition{Symbol: "BTCUSD_PERP", Amount: -50, EntryPrice: 40000, Leverage: 10, MarginType: MarginTypeIsolated}
+	// 全仓余额不影响逐仓强平价格
+	account := &MarginAccount{CrossWalletBalance: 100, Positions: []*MarginPosition{pos}}
+
+	// WB = 50 * 100 / 40000 / 10 = 0.0125, LP = (50 * 0.004 - 50) / (0.0125 / 100 - 50 / 40000)
+	assertFloat(t, "LiquidationPrice", m.LiquidationPrice(account, pos), 44266.66666666666)
+	pos.IsolatedMargin = 0.0125
+	assertFloat(t, "LiquidationPrice", m.LiquidationPrice(account, pos), 44266.66666666666)
+}
+
+func TestMarginCalculatorHedgeMode(t *testing.T) {
+	m := newBTCUSDCalculator()
+	long := &MarginPosition{Symbol: "BTCUSD_PERP", PositionSide: PositionSideTypeLong, Amount: 100, EntryPrice: 50000, MarkPrice: 51000, Leverage: 20}
+	short := &MarginPosition{Symbol: "BTCUSD_PERP", PositionSide: PositionSideTypeShort, Amount: 60, EntryPrice: 52000, MarkPrice: 51000, Leverage: 20}
+	account := &MarginAccount{CrossWalletBalance: 1, Positions: []*MarginPosition{long, short}}
+
+	// LP = (100 * 0.004 + 100 + 60 * 0.004 - 60) / (1 / 100 + 100 / 50000 - 60 / 52000)
+	want := 3746.9503546099295
+	assertFloat(t, "LONG", m.LiquidationPrice(account, long), want)
+	assertFloat(t, "SHORT", m.LiquidationPrice(account, short), want)
+}
+
+func TestMarginCalculatorUnknownContractSize(t *testing.T) {
+	m := NewMarginCalculator(btcusdBrackets())
+	pos := &MarginPosition{Symbol: "BTCUSD_PERP", Amount: 100, EntryPrice: 50000, Leverage: 20}
+	account := &MarginAccount{CrossWalletBalance: 1, Positions: []*MarginPosition{pos}}
+	assertFloat(t, "LiquidationPrice", m.LiquidationPrice(account, pos), 0)
+	assertFloat(t, "MaxOpenQuantity", m.MaxOpenQuantity(account, "BTCUSD_PERP", PositionSideTypeBoth, 20, 50000), 0)
+}
+
+func TestMarginCalculatorMaxOpenQuantity(t *testing.T) {
+	m := newBTCUSDCalculator()
+	empty := &MarginAccount{CrossWalletBalance: 1}
+
+	assertFloat(t, "MaxNotional(100)", m.MaxNotional("BTCUSD_PERP", 100), 10)
+	assertFloat(t, "MaxNotional(125)", m.MaxNotional("BTCUSD_PERP", 125), 5)
+	// 125 倍只能开到第一层上限 5 BTC, 即 5 * 50000 / 100 张
+	assertFloat(t, "125x", m.MaxOpenQuantity(empty, "BTCUSD_PERP", PositionSideTypeBoth, 125, 50000), 2500)
+	// 100 倍受第二层上限 10 BTC 限制, 而非余额 1 * 100
+	assertFloat(t, "100x", m.MaxOpenQuantity(empty, "BTCUSD_PERP", PositionSideTypeBoth, 100, 50000), 5000)
+	// 余额不足时受可用余额限制, 结果向下取整为张数
+	assertFloat(t, "3x", m.MaxOpenQuantity(empty, "BTCUSD_PERP", PositionSideTypeBoth, 3, 33333), 999)
+
+	// 已有 5 BTC 名义价值的持仓, 只能再开到 10 BTC
+	pos := &MarginPosition{Symbol: "BTCUSD_PERP", PositionSide: PositionSideTypeLong, Amount: 2500, EntryPrice: 50000, Leverage: 100}
+	account := empty.With(pos)
+	assertFloat(t, "with position", m.MaxOpenQuantity(account, "BTCUSD_PERP", PositionSideTypeLong, 100, 50000), 2500)
+	// 双向持仓另一方向不占用本方向的上限
+	assertFloat(t, "other side", m.MaxOpenQuantity(account, "BTCUSD_PERP", PositionSideTypeShort, 100, 50000), 5000)
+}
@@ -27,7 +27,7 @@ type CreateOrderService struct {
 }
 
 // SetSymbol set symbol
-func (s *CreateOrderService) setSymbol(symbol string) *CreateOrderService {
+func (s *CreateOrderService) SetSymbol(symbol string) *CreateOrderService {
 	s.symbol = symbol
 	return s
 }
@@ -150,6 +150,9 @@ func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, o
 	}
 	if s.newClientOrderID != nil {
 		m["newClientOrderId"] = *s.newClientOrderID
+	} else if s.c.OrderIDs != nil {
+		// 生成的订单号只用于本次请求, 不写回 service
+		m["newClientOrderId"] = s.c.OrderIDs.Next()
 	}
 	if s.stopPrice != nil {
 		m["stopPrice"] = *s.stopPrice
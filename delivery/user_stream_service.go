@@ -20,7 +20,7 @@ func (s *StartUserStreamService) Do(ctx context.Context, opts ...RequestOption)
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: "/dapi/v1/listenKey",
-		secType:  SecTypeSigned,
+		secType:  secTypeSigned,
 	}
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -48,7 +48,7 @@ func (s *KeepaliveUserStreamService) Do(ctx context.Context, opts ...RequestOpti
 	r := &request{
 		method:   http.MethodPut,
 		endpoint: "dapi/v1/listenKey",
-		secType:  SecTypeSigned,
+		secType:  secTypeSigned,
 	}
 	r.setFormParam("listenKey", s.listenKey)
 	_, err = s.c.callAPI(ctx, r, opts...)
@@ -73,7 +73,7 @@ func (s *CloseUserStreamService) Do(ctx context.Context, opts ...RequestOption)
 	r := &request{
 		method:   http.MethodDelete,
 		endpoint: "/dapi/v1/listenKey",
-		secType:  SecTypeSigned,
+		secType:  secTypeSigned,
 	}
 	r.setFormParam("listenKey", s.listenKey)
 	_, err = s.c.callAPI(ctx, r, opts...)
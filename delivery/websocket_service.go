@@ -0,0 +1,75 @@
+package delivery
+
+import (
+	"fmt"
+)
+
+// Endpoints
+const (
+	baseWsMainUrl    = "wss://dstream.binance.com/ws"
+	baseWsTestnetUrl = "wss://dstream.binancefuture.com/ws"
+)
+
+// getWsEndpoint return the base endpoint of the WS according the UseTestnet flag | 根据 UseTestnet 标志返回 WS 的基本端点
+func getWsEndpoint() string {
+	if UseTestnet {
+		return baseWsTestnetUrl
+	}
+	return baseWsMainUrl
+}
+
+// WsUserDataEvent define user data event. Only ORDER_TRADE_UPDATE is decoded | 用户数据推送, 仅解析订单推送
+type WsUserDataEvent struct {
+	Event            UserDataEventType  `json:"e"`
+	Time             int64              `json:"E"`
+	TransactionTime  int64              `json:"T"`
+	OrderTradeUpdate WsOrderTradeUpdate `json:"o"`
+}
+
+// WsOrderTradeUpdate define order trade update | 订单/交易 更新推送
+type WsOrderTradeUpdate struct {
+	Symbol               string             `json:"s"`         // 交易对
+	ClientOrderID        string             `json:"c"`         // 客户端自定订单ID
+	Side                 SideType           `json:"S"`         // 订单方向
+	Type                 OrderType          `json:"o"`         // 订单类型
+	TimeInForce          TimeInForceType    `json:"f"`         // 有效方式
+	OriginalQty          float64            `json:"q,string"`  // 订单原始数量(张)
+	OriginalPrice        float64            `json:"p,string"`  // 订单原始价格
+	AveragePrice         float64            `json:"ap,string"` // 订单平均价格
+	StopPrice            float64            `json:"sp,string"` // 条件订单触发价格
+	ExecutionType        OrderExecutionType `json:"x"`         // 本次事件的具体执行类型
+	Status               OrderStatusType    `json:"X"`         // 订单的当前状态
+	ID                   int64              `json:"i"`         // 订单ID
+	LastFilledQty        float64            `json:"l,string"`  // 订单末次成交量(张)
+	AccumulatedFilledQty float64            `json:"z,string"`  // 订单累计已成交量(张)
+	LastFilledPrice      float64            `json:"L,string"`  // 订单末次成交价格
+	CommissionAsset      string             `json:"N"`         // 手续费资产类型
+	Commission           float64            `json:"n,string"`  // 手续费数量
+	TradeTime            int64              `json:"T"`         // 成交时间
+	TradeID              int64              `json:"t"`         // 成交ID
+	IsMaker              bool               `json:"m"`         // 该成交是作为挂单成交吗？
+	IsReduceOnly         bool               `json:"R"`         // 是否是只减仓单
+	WorkingType          WorkingType        `json:"wt"`        // 触发价类型
+	OriginalType         OrderType          `json:"ot"`        // 原始订单类型
+	PositionSide         PositionSideType   `json:"ps"`        // 持仓方向
+	IsClosingPosition    bool               `json:"cp"`        // 是否为触发平仓单
+	RealizedPnL          float64            `json:"rp,string"` // 该交易实现盈亏
+}
+
+// WsUserDataHandler handle WsUserDataEvent
+type WsUserDataHandler func(event *WsUserDataEvent)
+
+// WsUserDataServe serve user data handler with listen key | 订阅用户数据推送
+func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (done chan struct{}, err error) {
+	cfg := NewWsConfig(fmt.Sprintf("%s/%s", getWsEndpoint(), listenKey))
+	wsHandler := func(message []byte) {
+		event := new(WsUserDataEvent)
+		err := json.Unmarshal(message, event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}
+	return WsServe(cfg, wsHandler, errHandler)
+}
@@ -0,0 +1,159 @@
+package futures
+
+import (
+	"context"
+	"sort"
+	"strconv"
+)
+
+// 账户配置项名称
+const (
+	AccountSettingPositionMode      = "dualSidePosition"  // 持仓模式
+	AccountSettingMultiAssetsMargin = "multiAssetsMargin" // 联合保证金模式
+	AccountSettingFeeBurn           = "feeBurn"           // BNB抵扣
+	AccountSettingMarginType        = "marginType"        // 交易对保证金模式
+	AccountSettingLeverage          = "leverage"          // 交易对杠杆
+)
+
+// DesiredAccountConfig define the account settings a bot expects, nil or zero fields are left as they are | 期望的账户配置
+type DesiredAccountConfig struct {
+	DualSidePosition  *bool                           // 双向持仓模式
+	MultiAssetsMargin *bool                           // 联合保证金模式
+	FeeBurn           *bool                           // BNB抵扣
+	Symbols           map[string]*DesiredSymbolConfig // 交易对配置
+}
+
+// DesiredSymbolConfig define the settings of a symbol, zero fields are left as they are | 期望的交易对配置
+type DesiredSymbolConfig struct {
+	MarginType MarginType // 保证金模式
+	Leverage   int        // 杠杆倍数
+}
+
+// AccountConfigChange define one setting changed by EnsureAccountConfig | 账户配置变更
+type AccountConfigChange struct {
+	Setting string // 配置项, AccountSetting*
+	Symbol  string // 交易对, 账户级配置为空
+	From    string // 变更前的值
+	To      string // 变更后的值
+}
+
+// EnsureAccountConfig read the current account settings and apply only the ones differing from want, e.g. at bot startup.
+// Multi-assets mode only allows cross margin, so it is turned off before and turned on after the symbol settings.
+// On error the changes applied so far are returned with it | 按期望配置调整账户, 只修改不一致的配置
+func (c *Client) EnsureAccountConfig(ctx context.Context, want *DesiredAccountConfig, opts ...RequestOption) (changes []*AccountConfigChange, err error) {
+	changes = make([]*AccountConfigChange, 0)
+	if want == nil {
+		return changes, nil
+	}
+
+	if want.DualSidePosition != nil {
+		mode, err := c.NewGetPositionModeService().Do(ctx, opts...)
+		if err != nil {
+			return changes, err
+		}
+		if mode.DualSidePosition != *want.DualSidePosition {
+			err = c.NewChangePositionModeService().SetDualSide(*want.DualSidePosition).Do(ctx, opts...)
+			if err != nil {
+				return changes, err
+			}
+			changes = append(changes, boolSettingChange(AccountSettingPositionMode, *want.DualSidePosition))
+		}
+	}
+
+	multiAssets := false
+	if want.MultiAssetsMargin != nil {
+		mode, err := c.NewGetMultiAssetsModeService().Do(ctx, opts...)
+		if err != nil {
+			return changes, err
+		}
+		multiAssets = mode.MultiAssetsMargin != *want.MultiAssetsMargin
+		// 关闭联合保证金模式后才能切换逐仓
+		if multiAssets && !*want.MultiAssetsMargin {
+			err = c.NewChangeMultiAssetsModeService().SetMultiAssetsMargin(false).Do(ctx, opts...)
+			if err != nil {
+				return changes, err
+			}
+			changes = append(changes, boolSettingChange(AccountSettingMultiAssetsMargin, false))
+			multiAssets = false
+		}
+	}
+
+	if len(want.Symbols) > 0 {
+		configs, err := c.NewGetSymbolConfigService().Do(ctx, opts...)
+		if err != nil {
+			return changes, err
+		}
+		current := make(map[string]*SymbolConfig, len(configs))
+		for _, config := range configs {
+			current[config.Symbol] = config
+		}
+		symbols := make([]string, 0, len(want.Symbols))
+		for symbol := range want.Symbols {
+			symbols = append(symbols, symbol)
+		}
+		sort.Strings(symbols)
+		for _, symbol := range symbols {
+			desired := want.Symbols[symbol]
+			if desired == nil {
+				continue
+			}
+			config := current[symbol]
+			if config == nil {
+				config = &SymbolConfig{Symbol: symbol}
+			}
+			if desired.MarginType != "" && config.MarginType != desired.MarginType {
+				err = c.NewChangeMarginTypeService().SetSymbol(symbol).SetMarginType(desired.MarginType).Do(ctx, opts...)
+				if err != nil {
+					return changes, err
+				}
+				changes = append(changes, &AccountConfigChange{
+					Setting: AccountSettingMarginType,
+					Symbol:  symbol,
+					From:    string(config.MarginType),
+					To:      string(desired.MarginType),
+				})
+			}
+			if desired.Leverage > 0 && config.Leverage != desired.Leverage {
+				_, err = c.NewChangeLeverageService().SetSymbol(symbol).SetLeverage(desired.Leverage).Do(ctx, opts...)
+				if err != nil {
+					return changes, err
+				}
+				changes = append(changes, &AccountConfigChange{
+					Setting: AccountSettingLeverage,
+					Symbol:  symbol,
+					From:    strconv.Itoa(config.Leverage),
+					To:      strconv.Itoa(desired.Leverage),
+				})
+			}
+		}
+	}
+
+	// 交易对均切换为全仓后再开启联合保证金模式
+	if multiAssets {
+		err = c.NewChangeMultiAssetsModeService().SetMultiAssetsMargin(true).Do(ctx, opts...)
+		if err != nil {
+			return changes, err
+		}
+		changes = append(changes, boolSettingChange(AccountSettingMultiAssetsMargin, true))
+	}
+
+	if want.FeeBurn != nil {
+		status, err := c.NewGetFeeBurnService().Do(ctx, opts...)
+		if err != nil {
+			return changes, err
+		}
+		if status.FeeBurn != *want.FeeBurn {
+			err = c.NewChangeFeeBurnService().SetFeeBurn(*want.FeeBurn).Do(ctx, opts...)
+			if err != nil {
+				return changes, err
+			}
+			changes = append(changes, boolSettingChange(AccountSettingFeeBurn, *want.FeeBurn))
+		}
+	}
+	return changes, nil
+}
+
+// boolSettingChange 开关类配置的变更记录
+func boolSettingChange(setting string, to bool) *AccountConfigChange {
+	return &AccountConfigChange{Setting: setting, From: strconv.FormatBool(!to), To: strconv.FormatBool(to)}
+}
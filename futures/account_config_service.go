@@ -0,0 +1,305 @@
+package futures
+
+import (
+	"context"
+	"net/http"
+)
+
+// ChangeMultiAssetsModeService change user's multi-assets mode | 更改联合保证金模式
+type ChangeMultiAssetsModeService struct {
+	c                 *Client
+	multiAssetsMargin string // "true": 联合保证金模式开启；"false": 联合保证金模式关闭
+}
+
+// SetMultiAssetsMargin Change user's multi-assets mode: true - Multi-Assets Mode, false - Single-Asset Mode
+func (s *ChangeMultiAssetsModeService) SetMultiAssetsMargin(multiAssetsMargin bool) *ChangeMultiAssetsModeService {
+	if multiAssetsMargin {
+		s.multiAssetsMargin = "true"
+	} else {
+		s.multiAssetsMargin = "false"
+	}
+	return s
+}
+
+// Do send request
+func (s *ChangeMultiAssetsModeService) Do(ctx context.Context, opts ...RequestOption) (err error) {
+	// POST /fapi/v1/multiAssetsMargin | 变换用户在 所有symbol 合约上的联合保证金模式：开启或关闭联合保证金模式。
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/fapi/v1/multiAssetsMargin",
+		secType:  secTypeSigned,
+	}
+	r.setFormParams(params{
+		"multiAssetsMargin": s.multiAssetsMargin,
+	})
+	_, _, err = s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetMultiAssetsModeService get user's multi-assets mode | 查询联合保证金模式
+type GetMultiAssetsModeService struct {
+	c *Client
+}
+
+// Do send request
+func (s *GetMultiAssetsModeService) Do(ctx context.Context, opts ...RequestOption) (res *MultiAssetsMode, err error) {
+	// GET /fapi/v1/multiAssetsMargin | 查询用户目前在 所有symbol 合约上的联合保证金模式。
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/fapi/v1/multiAssetsMargin",
+		secType:  secTypeSigned,
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(MultiAssetsMode)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// MultiAssetsMode Response of user's multi-assets mode
+type MultiAssetsMode struct {
+	MultiAssetsMargin bool `json:"multiAssetsMargin"` // "true": 联合保证金模式开启；"false": 单币种保证金模式
+}
+
+// ChangeFeeBurnService change user's BNB fee burn status | 更改BNB抵扣开关
+type ChangeFeeBurnService struct {
+	c       *Client
+	feeBurn string // "true": 开启BNB抵扣；"false": 关闭BNB抵扣
+}
+
+// SetFeeBurn Change user's BNB fee burn status: true - Fee Discount On, false - Fee Discount Off
+func (s *ChangeFeeBurnService) SetFeeBurn(feeBurn bool) *ChangeFeeBurnService {
+	if feeBurn {
+		s.feeBurn = "true"
+	} else {
+		s.feeBurn = "false"
+	}
+	return s
+}
+
+// Do send request
+func (s *ChangeFeeBurnService) Do(ctx context.Context, opts ...RequestOption) (err error) {
+	// POST /fapi/v1/feeBurn | 变换用户在 所有symbol 合约上的BNB抵扣开关。
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/fapi/v1/feeBurn",
+		secType:  secTypeSigned,
+	}
+	r.setFormParams(params{
+		"feeBurn": s.feeBurn,
+	})
+	_, _, err = s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetFeeBurnService get user's BNB fee burn status | 查询BNB抵扣开关
+type GetFeeBurnService struct {
+	c *Client
+}
+
+// Do send request
+func (s *GetFeeBurnService) Do(ctx context.Context, opts ...RequestOption) (res *FeeBurnStatus, err error) {
+	// GET /fapi/v1/feeBurn | 查询用户的BNB抵扣开关状态。
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/fapi/v1/feeBurn",
+		secType:  secTypeSigned,
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(FeeBurnStatus)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// FeeBurnStatus Response of user's BNB fee burn status
+type FeeBurnStatus struct {
+	FeeBurn bool `json:"feeBurn"` // "true": 开启BNB抵扣；"false": 关闭BNB抵扣
+}
+
+// GetApiTradingStatusService get user's quantitative rules indicators | 账户交易量化规则指标
+type GetApiTradingStatusService struct {
+	c      *Client
+	symbol *string
+}
+
+// SetSymbol set symbol
+func (s *GetApiTradingStatusService) SetSymbol(symbol string) *GetApiTradingStatusService {
+	s.symbol = &symbol
+	return s
+}
+
+// Do send request
+func (s *GetApiTradingStatusService) Do(ctx context.Context, opts ...RequestOption) (res *ApiTradingStatus, err error) {
+	// GET /fapi/v1/apiTradingStatus | 查询账户交易量化规则指标, 不发送交易对时返回所有触发了规则的交易对
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/fapi/v1/apiTradingStatus",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(ApiTradingStatus)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ApiTradingStatus define quantitative rules indicators of the account | 账户交易量化规则指标
+type ApiTradingStatus struct {
+	Indicators map[string][]*ApiTradingIndicator `json:"indicators"` // 按交易对分组的指标, 账户级指标的键为 ACCOUNT
+	UpdateTime int64                             `json:"updateTime"` // 更新时间
+}
+
+// ApiTradingIndicator define one quantitative rules indicator | 量化规则指标
+type ApiTradingIndicator struct {
+	IsLocked           bool    `json:"isLocked"`           // 是否被禁止交易
+	PlannedRecoverTime int64   `json:"plannedRecoverTime"` // 禁止交易的恢复时间
+	Indicator          string  `json:"indicator"`          // 指标名称, 如 UFR, IFER, GCR, DR, TMV
+	Value              float64 `json:"value"`              // 当前值
+	TriggerValue       float64 `json:"triggerValue"`       // 触发值
+}
+
+// Locked report whether any indicator of symbol, or of the account when symbol is "ACCOUNT", is locked | 是否被禁止交易
+func (s *ApiTradingStatus) Locked(symbol string) bool {
+	for _, indicator := range s.Indicators[symbol] {
+		if indicator.IsLocked {
+			return true
+		}
+	}
+	return false
+}
+
+// GetADLQuantileService get position ADL quantile estimation | 持仓ADL队列估算
+type GetADLQuantileService struct {
+	c      *Client
+	symbol *string
+}
+
+// SetSymbol set symbol
+func (s *GetADLQuantileService) SetSymbol(symbol string) *GetADLQuantileService {
+	s.symbol = &symbol
+	return s
+}
+
+// Do send request
+func (s *GetADLQuantileService) Do(ctx context.Context, opts ...RequestOption) (res []*ADLQuantile, err error) {
+	// GET /fapi/v1/adlQuantile | 持仓ADL队列估算, 每30秒更新
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/fapi/v1/adlQuantile",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*ADLQuantile{}, err
+	}
+	res = make([]*ADLQuantile, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*ADLQuantile{}, err
+	}
+	return res, nil
+}
+
+// ADLQuantile define ADL quantile of a symbol | 持仓ADL队列
+type ADLQuantile struct {
+	Symbol      string             `json:"symbol"`      // 交易对
+	ADLQuantile ADLQuantilesBySide `json:"adlQuantile"` // 各持仓方向的队列分数 0-4, 分数越高越先被减仓
+}
+
+// ADLQuantilesBySide define ADL quantile of each position side | 各持仓方向的ADL队列
+type ADLQuantilesBySide struct {
+	Long  int `json:"LONG"`  // 双向持仓模式下的多头
+	Short int `json:"SHORT"` // 双向持仓模式下的空头
+	Hedge int `json:"HEDGE"` // 双向持仓模式下多空同时持仓时为 0, 忽略
+	Both  int `json:"BOTH"`  // 单向持仓模式
+}
+
+// GetAccountConfigService get futures account configuration | 查询账户配置
+type GetAccountConfigService struct {
+	c *Client
+}
+
+// Do send request
+func (s *GetAccountConfigService) Do(ctx context.Context, opts ...RequestOption) (res *AccountConfig, err error) {
+	// GET /fapi/v1/accountConfig | 查询账户配置
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/fapi/v1/accountConfig",
+		secType:  secTypeSigned,
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(AccountConfig)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AccountConfig define futures account configuration | 账户配置
+type AccountConfig struct {
+	FeeTier           int   `json:"feeTier"`           // 手续费等级
+	CanTrade          bool  `json:"canTrade"`          // 是否可以交易
+	CanDeposit        bool  `json:"canDeposit"`        // 是否可以入金
+	CanWithdraw       bool  `json:"canWithdraw"`       // 是否可以出金
+	DualSidePosition  bool  `json:"dualSidePosition"`  // 是否双向持仓模式
+	UpdateTime        int64 `json:"updateTime"`        // 保留字段，请忽略
+	MultiAssetsMargin bool  `json:"multiAssetsMargin"` // 是否联合保证金模式
+	TradeGroupId      int64 `json:"tradeGroupId"`      // 交易组
+}
+
+// GetOrderRateLimitService get user's order rate limit | 查询用户下单限频
+type GetOrderRateLimitService struct {
+	c *Client
+}
+
+// Do send request
+func (s *GetOrderRateLimitService) Do(ctx context.Context, opts ...RequestOption) (res []*RateLimit, err error) {
+	// GET /fapi/v1/rateLimit/order | 查询用户下单限频
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/fapi/v1/rateLimit/order",
+		secType:  secTypeSigned,
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*RateLimit{}, err
+	}
+	res = make([]*RateLimit, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*RateLimit{}, err
+	}
+	return res, nil
+}
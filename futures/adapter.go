@@ -0,0 +1,382 @@
+package futures
+
+import (
+	"context"
+	"github.com/BobHye/binance-go/common"
+	"math"
+	"strconv"
+)
+
+// Adapter implement common.Exchange over a USDⓈ-M futures client | U本位合约的统一接口实现
+type Adapter struct {
+	c *Client
+}
+
+var _ common.Exchange = (*Adapter)(nil)
+
+// NewAdapter create an adapter over client | 创建统一接口适配器
+func NewAdapter(c *Client) *Adapter {
+	return &Adapter{c: c}
+}
+
+// Klines return the latest klines of symbol | 查询K线
+func (a *Adapter) Klines(ctx context.Context, symbol string, interval string, limit int) ([]*common.Kline, error) {
+	klines, err := a.c.NewKlinesService().SetSymbol(symbol).SetInterval(interval).SetLimit(limit).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*common.Kline, 0, len(klines))
+	for _, k := range klines {
+		res = append(res, ToCommonKline(k))
+	}
+	return res, nil
+}
+
+// BookTicker return the best bid and ask of symbol | 查询最优挂单
+func (a *Adapter) BookTicker(ctx context.Context, symbol string) (*common.BookTicker, error) {
+	tickers, err := a.c.NewListBookTickersService().SetSymbol(symbol).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(tickers) == 0 {
+		return nil, &common.APIError{Code: -1121, Message: "Invalid symbol."}
+	}
+	return ToCommonBookTicker(tickers[0]), nil
+}
+
+// CreateOrder place a new order. LIMIT_MAKER is sent as a GTX limit order | 下单
+func (a *Adapter) CreateOrder(ctx context.Context, req *common.OrderRequest) (*common.Order, error) {
+	s := a.c.NewCreateOrderService().
+		SetSymbol(req.Symbol).
+		SetSide(SideType(req.Side)).
+		SetType(OrderType(req.Type)).
+		SetQuantity(formatFloat(req.Quantity))
+	if req.PositionSide != "" {
+		s.SetPositionSide(PositionSideType(req.PositionSide))
+	}
+	tif := req.TimeInForce
+	switch req.Type {
+	case common.OrderTypeLimitMaker:
+		s.SetType(OrderTypeLimit)
+		tif = common.TimeInForceGTX
+	case common.OrderTypeLimit, common.OrderTypeStop, common.OrderTypeTakeProfit:
+		if tif == "" {
+			tif = common.TimeInForceGTC
+		}
+	}
+	if tif != "" {
+		s.SetTimeInForce(TimeInForceType(tif))
+	}
+	if req.Price > 0 {
+		s.SetPrice(formatFloat(req.Price))
+	}
+	if req.StopPrice > 0 {
+		s.SetStopPrice(formatFloat(req.StopPrice))
+	}
+	if req.ClientOrderID != "" {
+		s.SetNewClientOrderID(req.ClientOrderID)
+	}
+	if req.ReduceOnly {
+		s.SetReduceOnly(true)
+	}
+	res, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ToCommonOrder(&Order{
+		Symbol:           res.Symbol,
+		OrderID:          res.OrderID,
+		ClientOrderID:    res.ClientOrderID,
+		Side:             res.Side,
+		PositionSide:     res.PositionSide,
+		Type:             res.Type,
+		TimeInForce:      res.TimeInForce,
+		Status:           res.Status,
+		Price:            res.Price,
+		StopPrice:        res.StopPrice,
+		OrigQuantity:     res.OrigQuantity,
+		ExecutedQuantity: res.ExecutedQuantity,
+		CumQuote:         res.CumQuote,
+		AvgPrice:         res.AvgPrice,
+		ReduceOnly:       res.ReduceOnly,
+		Time:             res.UpdateTime,
+		UpdateTime:       res.UpdateTime,
+	}), nil
+}
+
+// CancelOrder cancel an open order | 撤单
+func (a *Adapter) CancelOrder(ctx context.Context, symbol string, orderID int64, clientOrderID string) (*common.Order, error) {
+	s := a.c.NewCancelOrderService().SetSymbol(symbol)
+	if orderID != 0 {
+		s.SetOrderID(orderID)
+	} else {
+		s.SetOrigClientOrderID(clientOrderID)
+	}
+	res, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ToCommonOrder(&Order{
+		Symbol:           res.Symbol,
+		OrderID:          res.OrderID,
+		ClientOrderID:    res.ClientOrderID,
+		Side:             res.Side,
+		PositionSide:     res.PositionSide,
+		Type:             res.Type,
+		TimeInForce:      res.TimeInForce,
+		Status:           res.Status,
+		Price:            res.Price,
+		StopPrice:        res.StopPrice,
+		OrigQuantity:     res.OrigQuantity,
+		ExecutedQuantity: res.ExecutedQuantity,
+		CumQuote:         res.CumQuote,
+		ReduceOnly:       res.ReduceOnly,
+		UpdateTime:       res.UpdateTime,
+	}), nil
+}
+
+// GetOrder query an order | 查询订单
+func (a *Adapter) GetOrder(ctx context.Context, symbol string, orderID int64, clientOrderID string) (*common.Order, error) {
+	s := a.c.NewGetOrderService().SetSymbol(symbol)
+	if orderID != 0 {
+		s.SetOrderID(orderID)
+	} else {
+		s.SetOrigClientOrderID(clientOrderID)
+	}
+	o, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ToCommonOrder(o), nil
+}
+
+// ListOpenOrders query open orders | 查询当前挂单
+func (a *Adapter) ListOpenOrders(ctx context.Context, symbol string) ([]*common.Order, error) {
+	s := a.c.NewListOpenOrdersService()
+	if symbol != "" {
+		s.Symbol(symbol)
+	}
+	orders, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*common.Order, 0, len(orders))
+	for _, o := range orders {
+		res = append(res, ToCommonOrder(o))
+	}
+	return res, nil
+}
+
+// ListFills query trades of symbol | 查询成交历史
+func (a *Adapter) ListFills(ctx context.Context, symbol string, startTime, endTime int64) ([]*common.Fill, error) {
+	s := a.c.NewListAccountTradeService().SetSymbol(symbol)
+	if startTime > 0 {
+		s.SetStartTime(startTime)
+	}
+	if endTime > 0 {
+		s.SetEndTime(endTime)
+	}
+	trades, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*common.Fill, 0, len(trades))
+	for _, t := range trades {
+		res = append(res, ToCommonFill(t))
+	}
+	return res, nil
+}
+
+// Balances return balances of all assets | 查询资产余额
+func (a *Adapter) Balances(ctx context.Context) ([]*common.Balance, error) {
+	balances, err := a.c.NewGetBalanceService().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*common.Balance, 0, len(balances))
+	for _, b := range balances {
+		res = append(res, ToCommonBalance(b))
+	}
+	return res, nil
+}
+
+// Positions return positions with a non-zero amount | 查询持仓
+func (a *Adapter) Positions(ctx context.Context) ([]*common.Position, error) {
+	risks, err := a.c.NewGetPositionRiskService().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*common.Position, 0)
+	for _, p := range risks {
+		if p.PositionAmt != 0 {
+			res = append(res, ToCommonPosition(p))
+		}
+	}
+	return res, nil
+}
+
+// ToCommonKline convert kline to the normalized model | 转换K线
+func ToCommonKline(k *Kline) *common.Kline {
+	return &common.Kline{
+		OpenTime:    k.OpenTime,
+		Open:        k.Open,
+		High:        k.High,
+		Low:         k.Low,
+		Close:       k.Close,
+		Volume:      k.Volume,
+		CloseTime:   k.CloseTime,
+		QuoteVolume: k.QuoteAssetVolume,
+		TradeNum:    k.TradeNum,
+	}
+}
+
+// ToCommonBookTicker convert book ticker to the normalized model | 转换最优挂单
+func ToCommonBookTicker(t *BookTicker) *common.BookTicker {
+	return &common.BookTicker{
+		Symbol:      t.Symbol,
+		BidPrice:    t.BidPrice,
+		BidQuantity: t.BidQuantity,
+		AskPrice:    t.AskPrice,
+		AskQuantity: t.AskQuantity,
+		Time:        t.Time,
+	}
+}
+
+// ToCommonOrder convert order to the normalized model | 转换订单
+func ToCommonOrder(o *Order) *common.Order {
+	return &common.Order{
+		Symbol:           o.Symbol,
+		OrderID:          o.OrderID,
+		ClientOrderID:    o.ClientOrderID,
+		Side:             common.Side(o.Side),
+		PositionSide:     common.PositionSide(o.PositionSide),
+		Type:             common.OrderType(o.Type),
+		TimeInForce:      common.TimeInForce(o.TimeInForce),
+		Status:           common.OrderStatus(o.Status),
+		Price:            o.Price,
+		StopPrice:        o.StopPrice,
+		Quantity:         o.OrigQuantity,
+		ExecutedQuantity: o.ExecutedQuantity,
+		QuoteQuantity:    o.CumQuote,
+		AvgPrice:         o.AvgPrice,
+		ReduceOnly:       o.ReduceOnly,
+		Time:             o.Time,
+		UpdateTime:       o.UpdateTime,
+	}
+}
+
+// ToCommonFill convert account trade to the normalized model | 转换成交
+func ToCommonFill(t *AccountTrade) *common.Fill {
+	price, _ := strconv.ParseFloat(t.Price, 64)
+	quantity, _ := strconv.ParseFloat(t.Quantity, 64)
+	quoteQuantity, _ := strconv.ParseFloat(t.QuoteQuantity, 64)
+	commission, _ := strconv.ParseFloat(t.Commission, 64)
+	realizedPnl, _ := strconv.ParseFloat(t.RealizedPnl, 64)
+	return &common.Fill{
+		Symbol:          t.Symbol,
+		TradeID:         t.ID,
+		OrderID:         t.OrderID,
+		Side:            common.Side(t.Side),
+		PositionSide:    common.PositionSide(t.PositionSide),
+		Price:           price,
+		Quantity:        quantity,
+		QuoteQuantity:   quoteQuantity,
+		Commission:      commission,
+		CommissionAsset: t.CommissionAsset,
+		RealizedPnl:     realizedPnl,
+		Maker:           t.Maker,
+		Time:            t.Time,
+	}
+}
+
+// ToCommonFlow convert income history to the normalized ledger flow | 转换资金流水
+func ToCommonFlow(i *IncomeHistory) *common.Flow {
+	amount, _ := strconv.ParseFloat(i.Income, 64)
+	tradeID, _ := strconv.ParseInt(i.TradeID, 10, 64)
+	return &common.Flow{
+		Venue:   common.VenueFutures,
+		Type:    common.IncomeFlowType(i.IncomeType),
+		ID:      i.IncomeType + "-" + strconv.FormatInt(i.TranID, 10),
+		Symbol:  i.Symbol,
+		Asset:   i.Asset,
+		Amount:  amount,
+		TradeID: tradeID,
+		Info:    i.Info,
+		Time:    i.Time,
+	}
+}
+
+// ToCommonOrderUpdate convert ORDER_TRADE_UPDATE to the normalized model. The order creation time is not pushed and left 0 | 转换订单推送
+func ToCommonOrderUpdate(o *WsOrderTradeUpdate) *common.OrderUpdate {
+	// 条件单触发后 o 变为 LIMIT/MARKET, 与 REST 一致使用原始订单类型
+	orderType := o.OriginalType
+	if orderType == "" {
+		orderType = o.Type
+	}
+	res := &common.OrderUpdate{
+		Order: &common.Order{
+			Symbol:           o.Symbol,
+			OrderID:          o.ID,
+			ClientOrderID:    o.ClientOrderID,
+			Side:             common.Side(o.Side),
+			PositionSide:     common.PositionSide(o.PositionSide),
+			Type:             common.OrderType(orderType),
+			TimeInForce:      common.TimeInForce(o.TimeInForce),
+			Status:           common.OrderStatus(o.Status),
+			Price:            o.OriginalPrice,
+			StopPrice:        o.StopPrice,
+			Quantity:         o.OriginalQty,
+			ExecutedQuantity: o.AccumulatedFilledQty,
+			QuoteQuantity:    o.AccumulatedFilledQty * o.AveragePrice,
+			AvgPrice:         o.AveragePrice,
+			ReduceOnly:       o.IsReduceOnly,
+			UpdateTime:       o.TradeTime,
+		},
+		Execution: string(o.ExecutionType),
+	}
+	if o.ExecutionType == OrderExecutionTypeTrade {
+		res.Fill = &common.Fill{
+			Symbol:          o.Symbol,
+			TradeID:         o.TradeID,
+			OrderID:         o.ID,
+			Side:            common.Side(o.Side),
+			PositionSide:    common.PositionSide(o.PositionSide),
+			Price:           o.LastFilledPrice,
+			Quantity:        o.LastFilledQty,
+			QuoteQuantity:   o.LastFilledQty * o.LastFilledPrice,
+			Commission:      o.Commission,
+			CommissionAsset: o.CommissionAsset,
+			RealizedPnl:     o.RealizedPnL,
+			Maker:           o.IsMaker,
+			Time:            o.TradeTime,
+		}
+	}
+	return res
+}
+
+// ToCommonBalance convert balance to the normalized model | 转换资产余额
+func ToCommonBalance(b *Balance) *common.Balance {
+	return &common.Balance{
+		Asset:  b.Asset,
+		Total:  b.Balance,
+		Free:   b.AvailableBalance,
+		Locked: math.Max(b.Balance-b.AvailableBalance, 0),
+	}
+}
+
+// ToCommonPosition convert position risk to the normalized model | 转换持仓
+func ToCommonPosition(p *PositionRisk) *common.Position {
+	return &common.Position{
+		Symbol:           p.Symbol,
+		PositionSide:     common.PositionSide(p.PositionSide),
+		Amount:           p.PositionAmt,
+		EntryPrice:       p.EntryPrice,
+		MarkPrice:        p.MarkPrice,
+		UnrealizedProfit: p.UnRealizedProfit,
+		LiquidationPrice: p.LiquidationPrice,
+		Leverage:         p.Leverage,
+		Isolated:         p.MarginType == "isolated",
+		Notional:         p.Notional,
+		UpdateTime:       p.UpdateTime,
+	}
+}
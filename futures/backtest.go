@@ -0,0 +1,226 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 回测: 同一套策略代码既可以运行在实盘, 也可以运行在历史K线上
+
+// Venue define where a strategy trades: the live exchange or a backtest | 策略运行的交易场所
+type Venue interface {
+	// Client return the client used for REST requests | 返回 REST 客户端
+	Client() *Client
+	// WsUserDataServe subscribe account and order updates | 订阅账户信息推送
+	WsUserDataServe(handler WsUserDataHandler, errHandler ErrHandler) (done chan struct{}, err error)
+	// WsKlineServe subscribe klines of symbol | 订阅K线推送
+	WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHandler ErrHandler) (done chan struct{}, err error)
+}
+
+// LiveVenue trade on the exchange through a client | 实盘交易场所
+type LiveVenue struct {
+	c *Client
+}
+
+// NewLiveVenue create a live venue over client | 创建实盘交易场所
+func NewLiveVenue(c *Client) *LiveVenue {
+	return &LiveVenue{c: c}
+}
+
+// Client return the client | 返回客户端
+func (v *LiveVenue) Client() *Client {
+	return v.c
+}
+
+// WsUserDataServe start a listen key, subscribe it and keep it alive until the stream is done | 订阅账户信息推送, 并定时延长 listenKey
+func (v *LiveVenue) WsUserDataServe(handler WsUserDataHandler, errHandler ErrHandler) (done chan struct{}, err error) {
+	listenKey, err := v.c.NewStartUserStreamService().Do(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	_, done, err = WsUserDataServe(listenKey, handler, errHandler)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		ticker := time.NewTicker(30 * time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := v.c.NewKeepaliveUserStreamService().SetListenKey(listenKey).Do(context.Background()); err != nil {
+					errHandler(err)
+				}
+			}
+		}
+	}()
+	return done, nil
+}
+
+// WsKlineServe subscribe klines of symbol | 订阅K线推送
+func (v *LiveVenue) WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHandler ErrHandler) (done chan struct{}, err error) {
+	_, done, err = WsKlineServe(symbol, interval, handler, errHandler)
+	return done, err
+}
+
+// backtestSeries 一个交易对一个周期的历史K线
+type backtestSeries struct {
+	symbol   string
+	interval string
+	klines   []*Kline
+}
+
+// backtestSubscription K线订阅
+type backtestSubscription struct {
+	symbol   string
+	interval string
+	handler  WsKlineHandler
+	done     chan struct{}
+}
+
+// Backtest replay historical klines through a SimExchange, delivering them as kline events | 回测
+type Backtest struct {
+	exchange      *SimExchange
+	mu            sync.Mutex
+	series        []*backtestSeries
+	subscriptions []*backtestSubscription
+}
+
+// NewBacktest create a backtest on exchange | 创建回测
+func NewBacktest(exchange *SimExchange) *Backtest {
+	return &Backtest{exchange: exchange}
+}
+
+// Exchange return the simulated exchange | 返回模拟交易所
+func (b *Backtest) Exchange() *SimExchange {
+	return b.exchange
+}
+
+// AddKlines add historical klines of symbol, e.g. from KlinesService.
+// When several intervals of a symbol are added, the finest one drives the price | 添加历史K线, 同一交易对以最小周期推进行情
+func (b *Backtest) AddKlines(symbol string, interval string, klines []*Kline) *Backtest {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.series = append(b.series, &backtestSeries{symbol: symbol, interval: interval, klines: klines})
+	return b
+}
+
+// Client return the client served by the simulated exchange | 返回由模拟交易所响应请求的客户端
+func (b *Backtest) Client() *Client {
+	return b.exchange.Client()
+}
+
+// WsUserDataServe subscribe the simulated account and order updates | 订阅模拟账户信息推送
+func (b *Backtest) WsUserDataServe(handler WsUserDataHandler, errHandler ErrHandler) (done chan struct{}, err error) {
+	return b.exchange.WsUserDataServe(handler, errHandler)
+}
+
+// WsKlineServe subscribe historical klines of symbol and interval added by AddKlines | 订阅历史K线
+func (b *Backtest) WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHandler ErrHandler) (done chan struct{}, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	sub := &backtestSubscription{symbol: strings.ToUpper(symbol), interval: interval, handler: handler, done: make(chan struct{})}
+	b.subscriptions = append(b.subscriptions, sub)
+	return sub.done, nil
+}
+
+// backtestStep 回测中的一根K线
+type backtestStep struct {
+	series *backtestSeries
+	kline  *Kline
+	drive  bool // 是否用于推进行情
+}
+
+// Run replay all klines ordered by close time: the price moves through each bar of the finest interval,
+// then the closed klines are delivered to the subscribers. Subscriptions' done channels are closed at the end | 运行回测
+func (b *Backtest) Run(ctx context.Context) error {
+	b.mu.Lock()
+	steps := b.steps()
+	subscriptions := append([]*backtestSubscription(nil), b.subscriptions...)
+	b.mu.Unlock()
+	if len(steps) == 0 {
+		return errors.New("backtest: no klines added")
+	}
+	defer func() {
+		for _, sub := range subscriptions {
+			close(sub.done)
+		}
+	}()
+
+	for _, step := range steps {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		symbol := strings.ToUpper(step.series.symbol)
+		if step.drive {
+			b.exchange.ProcessKline(symbol, step.kline)
+		}
+		k := step.kline
+		event := &WsKlineEvent{
+			Event:  "kline",
+			Time:   k.CloseTime,
+			Symbol: symbol,
+			Kline: WsKline{
+				StartTime:            k.OpenTime,
+				EndTime:              k.CloseTime,
+				Symbol:               symbol,
+				Interval:             step.series.interval,
+				Open:                 k.Open,
+				Close:                k.Close,
+				High:                 k.High,
+				Low:                  k.Low,
+				Volume:               k.Volume,
+				TradeNum:             k.TradeNum,
+				IsFinal:              true,
+				QuoteVolume:          k.QuoteAssetVolume,
+				ActiveBuyVolume:      k.TakerBuyBaseAssetVolume,
+				ActiveBuyQuoteVolume: k.TakerBuyQuoteAssetVolume,
+			},
+		}
+		for _, sub := range subscriptions {
+			if sub.symbol == symbol && sub.interval == step.series.interval {
+				sub.handler(event)
+			}
+		}
+	}
+	return nil
+}
+
+// steps 合并所有K线, 按收盘时间排序, 同一时刻周期小的在前; 需持有锁
+func (b *Backtest) steps() []*backtestStep {
+	finest := make(map[string]*backtestSeries)
+	for _, s := range b.series {
+		symbol := strings.ToUpper(s.symbol)
+		if f, ok := finest[symbol]; !ok || seriesSpan(s) < seriesSpan(f) {
+			finest[symbol] = s
+		}
+	}
+	var steps []*backtestStep
+	for _, s := range b.series {
+		drive := finest[strings.ToUpper(s.symbol)] == s
+		for _, k := range s.klines {
+			steps = append(steps, &backtestStep{series: s, kline: k, drive: drive})
+		}
+	}
+	sort.SliceStable(steps, func(i, j int) bool {
+		if steps[i].kline.CloseTime != steps[j].kline.CloseTime {
+			return steps[i].kline.CloseTime < steps[j].kline.CloseTime
+		}
+		return steps[i].kline.CloseTime-steps[i].kline.OpenTime < steps[j].kline.CloseTime-steps[j].kline.OpenTime
+	})
+	return steps
+}
+
+// seriesSpan K线周期(毫秒)
+func seriesSpan(s *backtestSeries) int64 {
+	if len(s.klines) == 0 {
+		return 1<<63 - 1
+	}
+	return s.klines[0].CloseTime - s.klines[0].OpenTime
+}
@@ -70,6 +70,12 @@ type UserDataEventReasonType string
 // ForceOrderCloseType define reason type for force order | 强平订单类型
 type ForceOrderCloseType string
 
+// PriceMatchType define BBO price match mode | 盘口价格下单模式
+type PriceMatchType string
+
+// SelfTradePreventionModeType define self-trade prevention mode | 自成交保护模式
+type SelfTradePreventionModeType string
+
 // Redefining the standard package
 var json = jsoniter.ConfigCompatibleWithStandardLibrary
 
@@ -100,6 +106,22 @@ const (
 	TimeInForceTypeIOC TimeInForceType = "IOC" // Immediate or Cancel 无法立即成交(吃单)的部分就撤销
 	TimeInForceTypeFOK TimeInForceType = "FOK" // Fill or Kill 无法全部立即成交就撤销
 	TimeInForceTypeGTX TimeInForceType = "GTX" // Good Till Crossing 无法成为挂单方就撤销
+	TimeInForceTypeGTD TimeInForceType = "GTD" // Good Till Date 在 goodTillDate 时自动撤销
+
+	PriceMatchTypeNone       PriceMatchType = "NONE"        // 不使用盘口价格
+	PriceMatchTypeOpponent   PriceMatchType = "OPPONENT"    // 对手价(买单为卖一价, 卖单为买一价)
+	PriceMatchTypeOpponent5  PriceMatchType = "OPPONENT_5"  // 对手5档价
+	PriceMatchTypeOpponent10 PriceMatchType = "OPPONENT_10" // 对手10档价
+	PriceMatchTypeOpponent20 PriceMatchType = "OPPONENT_20" // 对手20档价
+	PriceMatchTypeQueue      PriceMatchType = "QUEUE"       // 同向价(买单为买一价, 卖单为卖一价)
+	PriceMatchTypeQueue5     PriceMatchType = "QUEUE_5"     // 同向5档价
+	PriceMatchTypeQueue10    PriceMatchType = "QUEUE_10"    // 同向10档价
+	PriceMatchTypeQueue20    PriceMatchType = "QUEUE_20"    // 同向20档价
+
+	SelfTradePreventionModeTypeNone        SelfTradePreventionModeType = "NONE"         // 不防止自成交
+	SelfTradePreventionModeTypeExpireTaker SelfTradePreventionModeType = "EXPIRE_TAKER" // 过期吃单方订单
+	SelfTradePreventionModeTypeExpireMaker SelfTradePreventionModeType = "EXPIRE_MAKER" // 过期挂单方订单
+	SelfTradePreventionModeTypeExpireBoth  SelfTradePreventionModeType = "EXPIRE_BOTH"  // 过期双方订单
 
 	NewOrderRespTypeACK    NewOrderRespType = "ACK"
 	NewOrderRespTypeRESULT NewOrderRespType = "RESULT"
@@ -145,7 +167,9 @@ const (
 	MarginTypeIsolated MarginType = "ISOLATED" // 逐仓
 	MarginTypeCrossed  MarginType = "CROSSED"  // 全仓
 
-	ContractTypePerpetual ContractType = "PERPETUAL" // 永续合约
+	ContractTypePerpetual      ContractType = "PERPETUAL"       // 永续合约
+	ContractTypeCurrentQuarter ContractType = "CURRENT_QUARTER" // 当季交割合约
+	ContractTypeNextQuarter    ContractType = "NEXT_QUARTER"    // 次季交割合约
 
 	UserDataEventTypeListenKeyExpired    UserDataEventType = "listenKeyExpired"      // listenKey 过期推送
 	UserDataEventTypeMarginCall          UserDataEventType = "MARGIN_CALL"           // 追加保证金通知
@@ -217,6 +241,7 @@ type Client struct {
 	Debug      bool
 	Logger     *log.Logger
 	TimeOffset int64
+	OrderIDs   *common.ClientOrderIDGenerator // 下单未指定 newClientOrderId 时用于生成, 为空则由交易所分配
 	do         doFunc
 }
 
@@ -344,7 +369,7 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 	req.Header = r.header
 	c.debug("request: %#v", req)
 	fun := c.do
-	if fun != nil {
+	if fun == nil {
 		fun = c.HTTPClient.Do
 	}
 	res, err := fun(req)
@@ -556,3 +581,128 @@ func (c *Client) NewGetPositionModeService() *GetPositionModeService {
 func (c *Client) NewCommissionRateService() *CommissionRateService {
 	return &CommissionRateService{c: c}
 }
+
+// NewPremiumIndexService init premium index service
+func (c *Client) NewPremiumIndexService() *PremiumIndexService {
+	return &PremiumIndexService{c: c}
+}
+
+// NewFundingRateService init funding rate history service
+func (c *Client) NewFundingRateService() *FundingRateService {
+	return &FundingRateService{c: c}
+}
+
+// NewFundingInfoService init funding info service
+func (c *Client) NewFundingInfoService() *FundingInfoService {
+	return &FundingInfoService{c: c}
+}
+
+// NewGetLeverageBracketService init leverage bracket service
+func (c *Client) NewGetLeverageBracketService() *GetLeverageBracketService {
+	return &GetLeverageBracketService{c: c}
+}
+
+// NewTakerLongShortRatioService init taker buy/sell volume service
+func (c *Client) NewTakerLongShortRatioService() *TakerLongShortRatioService {
+	return &TakerLongShortRatioService{c: c}
+}
+
+// NewBasisService init basis service
+func (c *Client) NewBasisService() *BasisService {
+	return &BasisService{c: c}
+}
+
+// NewContinuousKlinesService init continuous contract klines service
+func (c *Client) NewContinuousKlinesService() *ContinuousKlinesService {
+	return &ContinuousKlinesService{c: c}
+}
+
+// NewPremiumIndexKlinesService init premium index klines service
+func (c *Client) NewPremiumIndexKlinesService() *PremiumIndexKlinesService {
+	return &PremiumIndexKlinesService{c: c}
+}
+
+// NewIndexInfoService init composite index info service
+func (c *Client) NewIndexInfoService() *IndexInfoService {
+	return &IndexInfoService{c: c}
+}
+
+// NewAssetIndexService init multi-assets mode asset index service
+func (c *Client) NewAssetIndexService() *AssetIndexService {
+	return &AssetIndexService{c: c}
+}
+
+// NewChangeMultiAssetsModeService init change multi-assets mode service
+func (c *Client) NewChangeMultiAssetsModeService() *ChangeMultiAssetsModeService {
+	return &ChangeMultiAssetsModeService{c: c}
+}
+
+// NewGetMultiAssetsModeService init get multi-assets mode service
+func (c *Client) NewGetMultiAssetsModeService() *GetMultiAssetsModeService {
+	return &GetMultiAssetsModeService{c: c}
+}
+
+// NewChangeFeeBurnService init change BNB fee burn service
+func (c *Client) NewChangeFeeBurnService() *ChangeFeeBurnService {
+	return &ChangeFeeBurnService{c: c}
+}
+
+// NewGetFeeBurnService init get BNB fee burn service
+func (c *Client) NewGetFeeBurnService() *GetFeeBurnService {
+	return &GetFeeBurnService{c: c}
+}
+
+// NewGetApiTradingStatusService init get quantitative rules indicators service
+func (c *Client) NewGetApiTradingStatusService() *GetApiTradingStatusService {
+	return &GetApiTradingStatusService{c: c}
+}
+
+// NewGetADLQuantileService init get ADL quantile service
+func (c *Client) NewGetADLQuantileService() *GetADLQuantileService {
+	return &GetADLQuantileService{c: c}
+}
+
+// NewGetAccountConfigService init get account config service
+func (c *Client) NewGetAccountConfigService() *GetAccountConfigService {
+	return &GetAccountConfigService{c: c}
+}
+
+// NewGetOrderRateLimitService init get order rate limit service
+func (c *Client) NewGetOrderRateLimitService() *GetOrderRateLimitService {
+	return &GetOrderRateLimitService{c: c}
+}
+
+// NewGetIncomeDownloadIDService init get income history download id service
+func (c *Client) NewGetIncomeDownloadIDService() *GetDownloadIDService {
+	return &GetDownloadIDService{c: c, downloadType: HistoryDownloadTypeIncome}
+}
+
+// NewGetIncomeDownloadLinkService init get income history download link service
+func (c *Client) NewGetIncomeDownloadLinkService() *GetDownloadLinkService {
+	return &GetDownloadLinkService{c: c, downloadType: HistoryDownloadTypeIncome}
+}
+
+// NewGetOrderDownloadIDService init get order history download id service
+func (c *Client) NewGetOrderDownloadIDService() *GetDownloadIDService {
+	return &GetDownloadIDService{c: c, downloadType: HistoryDownloadTypeOrder}
+}
+
+// NewGetOrderDownloadLinkService init get order history download link service
+func (c *Client) NewGetOrderDownloadLinkService() *GetDownloadLinkService {
+	return &GetDownloadLinkService{c: c, downloadType: HistoryDownloadTypeOrder}
+}
+
+// NewGetTradeDownloadIDService init get trade history download id service
+func (c *Client) NewGetTradeDownloadIDService() *GetDownloadIDService {
+	return &GetDownloadIDService{c: c, downloadType: HistoryDownloadTypeTrade}
+}
+
+// NewGetTradeDownloadLinkService init get trade history download link service
+func (c *Client) NewGetTradeDownloadLinkService() *GetDownloadLinkService {
+	return &GetDownloadLinkService{c: c, downloadType: HistoryDownloadTypeTrade}
+}
+
+// NewHistoryDownloadService init asynchronous history download service
+func (c *Client) NewHistoryDownloadService() *HistoryDownloadService {
+	return &HistoryDownloadService{c: c}
+}
@@ -57,6 +57,7 @@ func (s *DepthService) Do(ctx context.Context, opts ...RequestOption) (res *Dept
 	if err != nil {
 		return nil, err
 	}
+	recordSnapshot(r, data)
 
 	res = new(DepthResponse)
 	if err := json.Unmarshal(data, &res); err != nil {
@@ -0,0 +1,334 @@
+package futures
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+)
+
+const (
+	// defaultFundingIntervalHours 未在 fundingInfo 中列出的交易对按 8 小时收取资金费
+	defaultFundingIntervalHours = 8
+	// fundingMatchWindow 资金费流水时间与资金费时间的最大偏差(毫秒)
+	fundingMatchWindow = 60 * 1000
+	// incomeTypeFundingFee 资金费流水类型
+	incomeTypeFundingFee = "FUNDING_FEE"
+	incomePageLimit      = 1000
+	hoursPerYear         = 365 * 24
+)
+
+// FundingPayment define the estimated funding payment of an open position at the next funding time | 预估资金费
+type FundingPayment struct {
+	Symbol       string           // 交易对
+	PositionSide PositionSideType // 持仓方向
+	PositionAmt  float64          // 持仓数量, 符号代表多空方向
+	MarkPrice    float64          // 标记价格
+	FundingRate  float64          // 预测资金费率, 已按上下限截断
+	Payment      float64          // 预估资金费, 正数为收取, 负数为支付
+	FundingTime  int64            // 资金费时间
+}
+
+// FundingSummary define realized funding of a symbol | 已实现资金费汇总
+type FundingSummary struct {
+	Symbol    string  // 交易对
+	Asset     string  // 资产
+	Total     float64 // 资金费合计, 正数为收取
+	Count     int     // 收取次数
+	FirstTime int64   // 第一笔时间
+	LastTime  int64   // 最后一笔时间
+}
+
+// FundingReconciliation define estimated against realized funding of a symbol at one funding time | 资金费对账
+type FundingReconciliation struct {
+	Symbol      string  // 交易对
+	FundingTime int64   // 资金费时间
+	Estimated   float64 // 预估资金费
+	Realized    float64 // 实际资金费
+	Diff        float64 // 实际 - 预估
+	Missing     bool    // 没有找到实际流水
+}
+
+// FundingCarry define the funding carry and basis of a perpetual symbol | 资金费率套利指标
+type FundingCarry struct {
+	Symbol               string  // 交易对
+	MarkPrice            float64 // 标记价格
+	IndexPrice           float64 // 指数价格
+	FundingRate          float64 // 预测资金费率
+	AvgFundingRate       float64 // 历史平均资金费率, 未请求历史时为 0
+	FundingIntervalHours int     // 资金费收取间隔(小时)
+	AnnualizedRate       float64 // 年化预测资金费率
+	AnnualizedAvgRate    float64 // 年化历史平均资金费率
+	Basis                float64 // 基差率 = (标记价格 - 指数价格) / 指数价格
+	NextFundingTime      int64   // 下次资金费时间
+}
+
+// FundingAnalyzer estimate funding payments of open positions, reconcile them with the FUNDING_FEE income history
+// and rank symbols by annualized carry. Funding caps and intervals come from FundingInfoService | 资金费率分析
+type FundingAnalyzer struct {
+	c    *Client
+	mu   sync.RWMutex
+	info map[string]*FundingInfo
+}
+
+// NewFundingAnalyzer init a funding analyzer, funding info is loaded on first use | 创建资金费率分析器
+func (c *Client) NewFundingAnalyzer() *FundingAnalyzer {
+	return &FundingAnalyzer{c: c}
+}
+
+// Refresh reload the funding caps and intervals | 重新加载资金费率上下限和收取间隔
+func (a *FundingAnalyzer) Refresh(ctx context.Context) error {
+	list, err := a.c.NewFundingInfoService().Do(ctx)
+	if err != nil {
+		return err
+	}
+	info := make(map[string]*FundingInfo, len(list))
+	for _, i := range list {
+		info[i.Symbol] = i
+	}
+	a.mu.Lock()
+	a.info = info
+	a.mu.Unlock()
+	return nil
+}
+
+// IntervalHours return the funding interval of symbol, 8 hours unless adjusted | 资金费收取间隔
+func (a *FundingAnalyzer) IntervalHours(symbol string) int {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if i := a.info[symbol]; i != nil && i.FundingIntervalHours > 0 {
+		return i.FundingIntervalHours
+	}
+	return defaultFundingIntervalHours
+}
+
+// ClampRate cut rate to the adjusted funding cap and floor of symbol, if any | 按资金费率上下限截断
+func (a *FundingAnalyzer) ClampRate(symbol string, rate float64) float64 {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	i := a.info[symbol]
+	if i == nil || i.AdjustedFundingRateCap == 0 && i.AdjustedFundingRateFloor == 0 {
+		return rate
+	}
+	return math.Min(math.Max(rate, i.AdjustedFundingRateFloor), i.AdjustedFundingRateCap)
+}
+
+// EstimatePayments estimate the payment of every open position at its next funding time | 预估持仓的下一笔资金费
+func (a *FundingAnalyzer) EstimatePayments(ctx context.Context) (res []*FundingPayment, err error) {
+	if err = a.ensureInfo(ctx); err != nil {
+		return []*FundingPayment{}, err
+	}
+	positions, err := a.c.NewGetPositionRiskService().Do(ctx)
+	if err != nil {
+		return []*FundingPayment{}, err
+	}
+	premiums, err := a.premiums(ctx)
+	if err != nil {
+		return []*FundingPayment{}, err
+	}
+	res = make([]*FundingPayment, 0)
+	for _, p := range positions {
+		premium := premiums[p.Symbol]
+		if p.PositionAmt == 0 || premium == nil || premium.NextFundingTime == 0 {
+			continue
+		}
+		mark := premium.MarkPrice
+		if mark == 0 {
+			mark = p.MarkPrice
+		}
+		rate := a.ClampRate(p.Symbol, premium.LastFundingRate)
+		res = append(res, &FundingPayment{
+			Symbol:       p.Symbol,
+			PositionSide: p.PositionSide,
+			PositionAmt:  p.PositionAmt,
+			MarkPrice:    mark,
+			FundingRate:  rate,
+			Payment:      -p.PositionAmt * mark * rate,
+			FundingTime:  premium.NextFundingTime,
+		})
+	}
+	return res, nil
+}
+
+// RealizedFunding return the FUNDING_FEE income of symbol, or of all symbols when symbol is empty,
+// between startTime and endTime, following pages until done. Binance keeps 3 months | 查询已实现资金费流水
+func (a *FundingAnalyzer) RealizedFunding(ctx context.Context, symbol string, startTime, endTime int64) (res []*IncomeHistory, err error) {
+	res = make([]*IncomeHistory, 0)
+	for {
+		page, err := a.c.NewGetIncomeHistoryService().SetSymbol(symbol).SetIncomeType(incomeTypeFundingFee).
+			SetStartTime(startTime).SetEndTime(endTime).SetLimit(incomePageLimit).Do(ctx)
+		if err != nil {
+			return []*IncomeHistory{}, err
+		}
+		res = append(res, page...)
+		if len(page) < incomePageLimit {
+			return res, nil
+		}
+		startTime = page[len(page)-1].Time + 1
+	}
+}
+
+// Carry return the funding carry and basis of symbols, or of all perpetual symbols when none is given, ordered by
+// annualized funding rate from high to low. history > 0 also averages the last history funding rates of each symbol | 资金费率套利指标
+func (a *FundingAnalyzer) Carry(ctx context.Context, history int, symbols ...string) (res []*FundingCarry, err error) {
+	if err = a.ensureInfo(ctx); err != nil {
+		return []*FundingCarry{}, err
+	}
+	premiums, err := a.premiums(ctx)
+	if err != nil {
+		return []*FundingCarry{}, err
+	}
+	if len(symbols) == 0 {
+		for symbol, p := range premiums {
+			if p.NextFundingTime > 0 {
+				symbols = append(symbols, symbol)
+			}
+		}
+	}
+	res = make([]*FundingCarry, 0, len(symbols))
+	for _, symbol := range symbols {
+		p := premiums[symbol]
+		if p == nil {
+			continue
+		}
+		carry := a.carry(p.Symbol, p.MarkPrice, p.IndexPrice, p.LastFundingRate, p.NextFundingTime)
+		if history > 0 {
+			rates, err := a.c.NewFundingRateService().SetSymbol(symbol).SetLimit(history).Do(ctx)
+			if err != nil {
+				return []*FundingCarry{}, err
+			}
+			if len(rates) > 0 {
+				sum := 0.0
+				for _, r := range rates {
+					sum += r.FundingRate
+				}
+				carry.AvgFundingRate = sum / float64(len(rates))
+				carry.AnnualizedAvgRate = carry.AvgFundingRate * hoursPerYear / float64(carry.FundingIntervalHours)
+			}
+		}
+		res = append(res, carry)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].AnnualizedRate > res[j].AnnualizedRate })
+	return res, nil
+}
+
+// CarryFromMarkPrice return the funding carry and basis carried by a mark price stream event, nil for
+// symbols without funding | 由标记价格推送计算资金费率套利指标
+func (a *FundingAnalyzer) CarryFromMarkPrice(event *WsMarkPriceEvent) *FundingCarry {
+	if event.FundingRate == "" || event.NextFundingTime == 0 {
+		return nil
+	}
+	return a.carry(event.Symbol, MustFloat64(event.MarkPrice), MustFloat64(event.IndexPrice), MustFloat64(event.FundingRate), event.NextFundingTime)
+}
+
+func (a *FundingAnalyzer) carry(symbol string, mark, index, rate float64, nextFundingTime int64) *FundingCarry {
+	interval := a.IntervalHours(symbol)
+	res := &FundingCarry{
+		Symbol:               symbol,
+		MarkPrice:            mark,
+		IndexPrice:           index,
+		FundingRate:          rate,
+		FundingIntervalHours: interval,
+		AnnualizedRate:       rate * hoursPerYear / float64(interval),
+		NextFundingTime:      nextFundingTime,
+	}
+	if index > 0 {
+		res.Basis = (mark - index) / index
+	}
+	return res
+}
+
+// ensureInfo 首次使用时加载资金费率信息
+func (a *FundingAnalyzer) ensureInfo(ctx context.Context) error {
+	a.mu.RLock()
+	loaded := a.info != nil
+	a.mu.RUnlock()
+	if loaded {
+		return nil
+	}
+	return a.Refresh(ctx)
+}
+
+func (a *FundingAnalyzer) premiums(ctx context.Context) (map[string]*PremiumIndex, error) {
+	list, err := a.c.NewPremiumIndexService().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]*PremiumIndex, len(list))
+	for _, p := range list {
+		res[p.Symbol] = p
+	}
+	return res, nil
+}
+
+// SummarizeFunding sum FUNDING_FEE incomes per symbol and asset, ordered by symbol | 汇总已实现资金费
+func SummarizeFunding(incomes []*IncomeHistory) []*FundingSummary {
+	type key struct{ symbol, asset string }
+	summaries := make(map[key]*FundingSummary)
+	for _, i := range incomes {
+		if i.IncomeType != incomeTypeFundingFee {
+			continue
+		}
+		k := key{i.Symbol, i.Asset}
+		s := summaries[k]
+		if s == nil {
+			s = &FundingSummary{Symbol: i.Symbol, Asset: i.Asset, FirstTime: i.Time}
+			summaries[k] = s
+		}
+		s.Total += MustFloat64(i.Income)
+		s.Count++
+		s.FirstTime = min(s.FirstTime, i.Time)
+		s.LastTime = max(s.LastTime, i.Time)
+	}
+	res := make([]*FundingSummary, 0, len(summaries))
+	for _, s := range summaries {
+		res = append(res, s)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Symbol != res[j].Symbol {
+			return res[i].Symbol < res[j].Symbol
+		}
+		return res[i].Asset < res[j].Asset
+	})
+	return res
+}
+
+// ReconcileFunding match estimated payments with FUNDING_FEE incomes booked within a minute of the funding time.
+// Both position sides of a symbol are summed | 资金费预估与实际流水对账
+func ReconcileFunding(estimates []*FundingPayment, incomes []*IncomeHistory) []*FundingReconciliation {
+	type key struct {
+		symbol string
+		time   int64
+	}
+	var order []key
+	rows := make(map[key]*FundingReconciliation)
+	for _, e := range estimates {
+		k := key{e.Symbol, e.FundingTime}
+		r := rows[k]
+		if r == nil {
+			r = &FundingReconciliation{Symbol: e.Symbol, FundingTime: e.FundingTime, Missing: true}
+			rows[k] = r
+			order = append(order, k)
+		}
+		r.Estimated += e.Payment
+	}
+	for _, i := range incomes {
+		if i.IncomeType != incomeTypeFundingFee {
+			continue
+		}
+		for _, k := range order {
+			if k.symbol == i.Symbol && i.Time >= k.time-fundingMatchWindow && i.Time <= k.time+fundingMatchWindow {
+				rows[k].Realized += MustFloat64(i.Income)
+				rows[k].Missing = false
+				break
+			}
+		}
+	}
+	res := make([]*FundingReconciliation, 0, len(order))
+	for _, k := range order {
+		r := rows[k]
+		r.Diff = r.Realized - r.Estimated
+		res = append(res, r)
+	}
+	return res
+}
@@ -0,0 +1,106 @@
+package futures
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/BobHye/binance-go/common"
+)
+
+// parseCSV 按下载文件的方式解析 data, 返回每行 parse 的结果
+func parseCSV[T any](t *testing.T, data string, parse func(row *common.CSVRow) T) []T {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, data)
+	}))
+	defer srv.Close()
+	res := make([]T, 0)
+	_, err := common.StreamCSV(context.Background(), srv.Client(), "", srv.URL, func(row *common.CSVRow) error {
+		res = append(res, parse(row))
+		return nil
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestIncomeFromCSV(t *testing.T) {
+	// /fapi/v1/income/asyn 导出文件
+	incomes := parseCSV(t, "\ufeffDate(UTC),Asset,Type,Amount,Symbol,Transaction ID\n"+
+		"2024-03-01 08:00:00,USDT,FUNDING_FEE,-0.52310000,BTCUSDT,9689322392\n", incomeFromCSV)
+	if len(incomes) != 1 {
+		t.Fatalf("got %d incomes", len(incomes))
+	}
+	i := incomes[0]
+	if i.Asset != "USDT" || i.Income != "-0.52310000" || i.IncomeType != "FUNDING_FEE" || i.Symbol != "BTCUSDT" ||
+		i.Time != 1709280000000 || i.TranID != 9689322392 {
+		t.Errorf("unexpected income %+v", i)
+	}
+
+	// 接口字段名
+	incomes = parseCSV(t, "symbol,incomeType,income,asset,info,time,tranId,tradeId\n"+
+		"BTCUSDT,COMMISSION,-0.01000000,USDT,COMMISSION,1709280001000,9689322393,2107512392\n", incomeFromCSV)
+	i = incomes[0]
+	if i.IncomeType != "COMMISSION" || i.Income != "-0.01000000" || i.TranID != 9689322393 || i.TradeID != "2107512392" {
+		t.Errorf("unexpected income %+v", i)
+	}
+}
+
+func TestOrderFromCSV(t *testing.T) {
+	// /fapi/v1/order/asyn 导出文件
+	orders := parseCSV(t, "Date(UTC),Order No,Symbol,Type,Side,Order Price,Order Amount,AvgTrading Price,Filled,Total,Trigger Conditions,Reduce Only,Status\n"+
+		"2024-03-01 08:00:01,8389765639459283456,BTCUSDT,LIMIT,BUY,60000,0.010,60000,0.010,600,,false,FILLED\n", orderFromCSV)
+	if len(orders) != 1 {
+		t.Fatalf("got %d orders", len(orders))
+	}
+	o := orders[0]
+	if o.OrderID != 8389765639459283456 || o.Symbol != "BTCUSDT" || o.Type != OrderTypeLimit || o.Side != SideTypeBuy ||
+		o.Price != 60000 || o.OrigQuantity != 0.01 || o.AvgPrice != 60000 || o.ExecutedQuantity != 0.01 ||
+		o.CumQuote != 600 || o.ReduceOnly || o.Status != OrderStatusTypeFilled || o.Time != 1709280001000 {
+		t.Errorf("unexpected order %+v", o)
+	}
+
+	// 接口字段名
+	orders = parseCSV(t, "orderId,symbol,status,clientOrderId,price,avgPrice,origQty,executedQty,cumQuote,timeInForce,type,side,positionSide,time,updateTime\n"+
+		"22542179,BTCUSDT,CANCELED,myOrder1,61000,0,0.020,0,0,GTC,LIMIT,SELL,SHORT,1709280001000,1709280002000\n", orderFromCSV)
+	o = orders[0]
+	if o.OrderID != 22542179 || o.ClientOrderID != "myOrder1" || o.OrigQuantity != 0.02 || o.PositionSide != PositionSideTypeShort ||
+		o.TimeInForce != TimeInForceTypeGTC || o.UpdateTime != 1709280002000 {
+		t.Errorf("unexpected order %+v", o)
+	}
+
+	// 含义不明确的列不作为订单号和数量
+	orders = parseCSV(t, "id,amount,symbol\n1,0.5,BTCUSDT\n", orderFromCSV)
+	if o = orders[0]; o.OrderID != 0 || o.OrigQuantity != 0 {
+		t.Errorf("ambiguous columns parsed into order %+v", o)
+	}
+}
+
+func TestAccountTradeFromCSV(t *testing.T) {
+	// /fapi/v1/trade/asyn 导出文件, Amount 为成交额
+	trades := parseCSV(t, "Date(UTC),Symbol,Side,Price,Quantity,Amount,Fee,Fee Coin,Realized Profit,Quote Asset\n"+
+		"2024-03-01 08:00:01,BTCUSDT,SELL,61000,0.010,610,0.24400000,USDT,10.00000000,USDT\n", accountTradeFromCSV)
+	if len(trades) != 1 {
+		t.Fatalf("got %d trades", len(trades))
+	}
+	tr := trades[0]
+	if tr.Symbol != "BTCUSDT" || tr.Side != SideTypeSell || tr.Buyer || tr.Price != "61000" || tr.Quantity != "0.010" ||
+		tr.Commission != "0.24400000" || tr.CommissionAsset != "USDT" || tr.RealizedPnl != "10.00000000" || tr.Time != 1709280001000 {
+		t.Errorf("unexpected trade %+v", tr)
+	}
+	if tr.QuoteQuantity != "" || tr.ID != 0 {
+		t.Errorf("ambiguous columns parsed into trade %+v", tr)
+	}
+
+	// 接口字段名
+	trades = parseCSV(t, "symbol,id,orderId,side,price,qty,realizedPnl,quoteQty,commission,commissionAsset,time,positionSide,buyer,maker\n"+
+		"BTCUSDT,698759,25851813,BUY,60000,0.010,0,600,0.12,USDT,1709280001000,LONG,true,true\n", accountTradeFromCSV)
+	tr = trades[0]
+	if tr.ID != 698759 || tr.OrderID != 25851813 || tr.QuoteQuantity != "600" || !tr.Buyer || !tr.Maker || tr.PositionSide != PositionSideTypeLong {
+		t.Errorf("unexpected trade %+v", tr)
+	}
+}
@@ -56,8 +56,11 @@ func (s *GetIncomeHistoryService) Do(ctx context.Context, opts ...RequestOption)
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/fapi/v1/income",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
 	}
-	r.setParam("symbol", s.symbol)
 	if s.incomeType != "" {
 		r.setParam("incomeType", s.incomeType)
 	}
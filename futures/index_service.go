@@ -0,0 +1,106 @@
+package futures
+
+import (
+	"context"
+	"github.com/BobHye/binance-go/common"
+	"net/http"
+)
+
+// IndexInfoService get the components of composite index symbols
+type IndexInfoService struct {
+	c      *Client
+	symbol *string
+}
+
+// SetSymbol set symbol
+func (s *IndexInfoService) SetSymbol(symbol string) *IndexInfoService {
+	s.symbol = &symbol
+	return s
+}
+
+// Do send request
+func (s *IndexInfoService) Do(ctx context.Context, opts ...RequestOption) (res []*IndexInfo, err error) {
+	// GET /fapi/v1/indexInfo | 综合指数交易对信息, 仅适用于综合指数交易对
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/fapi/v1/indexInfo",
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*IndexInfo{}, err
+	}
+	res = make([]*IndexInfo, 0)
+	err = json.Unmarshal(common.ToJSONList(data), &res)
+	if err != nil {
+		return []*IndexInfo{}, err
+	}
+	return res, nil
+}
+
+// IndexInfo define a composite index and its components
+type IndexInfo struct {
+	Symbol        string            `json:"symbol"`        // 交易对
+	Time          int64             `json:"time"`          // 请求时间
+	Component     string            `json:"component"`     // 成分资产
+	BaseAssetList []*IndexBaseAsset `json:"baseAssetList"` // 成分资产列表
+}
+
+// IndexBaseAsset define a component of a composite index
+type IndexBaseAsset struct {
+	BaseAsset          string  `json:"baseAsset"`                 // 基础资产
+	QuoteAsset         string  `json:"quoteAsset"`                // 报价资产
+	WeightInQuantity   float64 `json:"weightInQuantity,string"`   // 权重(数量)
+	WeightInPercentage float64 `json:"weightInPercentage,string"` // 权重(比例)
+}
+
+// AssetIndexService get the asset indexes of the multi-assets mode
+type AssetIndexService struct {
+	c      *Client
+	symbol *string
+}
+
+// SetSymbol set asset symbol, e.g. ADAUSD
+func (s *AssetIndexService) SetSymbol(symbol string) *AssetIndexService {
+	s.symbol = &symbol
+	return s
+}
+
+// Do send request
+func (s *AssetIndexService) Do(ctx context.Context, opts ...RequestOption) (res []*AssetIndex, err error) {
+	// GET /fapi/v1/assetIndex | 多资产模式资产汇率指数
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/fapi/v1/assetIndex",
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*AssetIndex{}, err
+	}
+	res = make([]*AssetIndex, 0)
+	err = json.Unmarshal(common.ToJSONList(data), &res)
+	if err != nil {
+		return []*AssetIndex{}, err
+	}
+	return res, nil
+}
+
+// AssetIndex define the exchange rate index of an asset in the multi-assets mode
+type AssetIndex struct {
+	Symbol                string  `json:"symbol"`                       // 资产交易对, 如 ADAUSD
+	Time                  int64   `json:"time"`                         // 时间
+	Index                 float64 `json:"index,string"`                 // 指数价格
+	BidBuffer             float64 `json:"bidBuffer,string"`             // 买价缓冲比例
+	AskBuffer             float64 `json:"askBuffer,string"`             // 卖价缓冲比例
+	BidRate               float64 `json:"bidRate,string"`               // 买价汇率
+	AskRate               float64 `json:"askRate,string"`               // 卖价汇率
+	AutoExchangeBidBuffer float64 `json:"autoExchangeBidBuffer,string"` // 自动兑换买价缓冲比例
+	AutoExchangeAskBuffer float64 `json:"autoExchangeAskBuffer,string"` // 自动兑换卖价缓冲比例
+	AutoExchangeBidRate   float64 `json:"autoExchangeBidRate,string"`   // 自动兑换买价汇率
+	AutoExchangeAskRate   float64 `json:"autoExchangeAskRate,string"`   // 自动兑换卖价汇率
+}
@@ -75,6 +75,146 @@ func (s *KlinesService) Do(ctx context.Context, opts ...RequestOption) (res []*K
 	return res, err
 }
 
+// ContinuousKlinesService list klines of the continuous contract of a pair
+type ContinuousKlinesService struct {
+	c            *Client
+	pair         string       // 标的交易对
+	contractType ContractType // 合约类型
+	interval     string       // 时间间隔
+	startTime    *int64       // 起始时间
+	endTime      *int64       // 结束时间
+	limit        *int         // 默认值:500 最大值:1500
+}
+
+// SetPair set pair
+func (s *ContinuousKlinesService) SetPair(pair string) *ContinuousKlinesService {
+	s.pair = pair
+	return s
+}
+
+// SetContractType set contract type: PERPETUAL, CURRENT_QUARTER or NEXT_QUARTER
+func (s *ContinuousKlinesService) SetContractType(contractType ContractType) *ContinuousKlinesService {
+	s.contractType = contractType
+	return s
+}
+
+// SetInterval set interval
+func (s *ContinuousKlinesService) SetInterval(interval string) *ContinuousKlinesService {
+	s.interval = interval
+	return s
+}
+
+// SetLimit set limit
+func (s *ContinuousKlinesService) SetLimit(limit int) *ContinuousKlinesService {
+	s.limit = &limit
+	return s
+}
+
+// SetStartTime set startTime
+func (s *ContinuousKlinesService) SetStartTime(startTime int64) *ContinuousKlinesService {
+	s.startTime = &startTime
+	return s
+}
+
+// SetEndTime set endTime
+func (s *ContinuousKlinesService) SetEndTime(endTime int64) *ContinuousKlinesService {
+	s.endTime = &endTime
+	return s
+}
+
+// Do send request
+func (s *ContinuousKlinesService) Do(ctx context.Context, opts ...RequestOption) (res []*Kline, err error) {
+	// GET /fapi/v1/continuousKlines | 连续合约K线数据 (每根K线的开盘时间可视为唯一ID)
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/fapi/v1/continuousKlines",
+	}
+	r.setParam("pair", s.pair)
+	r.setParam("contractType", s.contractType)
+	r.setParam("interval", s.interval)
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return res, err
+	}
+	err = json.Unmarshal(data, &res)
+	return res, err
+}
+
+// PremiumIndexKlinesService list premium index klines of a symbol. Volume fields are always 0
+type PremiumIndexKlinesService struct {
+	c         *Client
+	symbol    string // 交易对
+	interval  string // 时间间隔
+	startTime *int64 // 起始时间
+	endTime   *int64 // 结束时间
+	limit     *int   // 默认值:500 最大值:1500
+}
+
+// SetSymbol set symbol
+func (s *PremiumIndexKlinesService) SetSymbol(symbol string) *PremiumIndexKlinesService {
+	s.symbol = symbol
+	return s
+}
+
+// SetInterval set interval
+func (s *PremiumIndexKlinesService) SetInterval(interval string) *PremiumIndexKlinesService {
+	s.interval = interval
+	return s
+}
+
+// SetLimit set limit
+func (s *PremiumIndexKlinesService) SetLimit(limit int) *PremiumIndexKlinesService {
+	s.limit = &limit
+	return s
+}
+
+// SetStartTime set startTime
+func (s *PremiumIndexKlinesService) SetStartTime(startTime int64) *PremiumIndexKlinesService {
+	s.startTime = &startTime
+	return s
+}
+
+// SetEndTime set endTime
+func (s *PremiumIndexKlinesService) SetEndTime(endTime int64) *PremiumIndexKlinesService {
+	s.endTime = &endTime
+	return s
+}
+
+// Do send request
+func (s *PremiumIndexKlinesService) Do(ctx context.Context, opts ...RequestOption) (res []*Kline, err error) {
+	// GET /fapi/v1/premiumIndexKlines | 溢价指数K线数据
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/fapi/v1/premiumIndexKlines",
+	}
+	r.setParam("symbol", s.symbol)
+	r.setParam("interval", s.interval)
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return res, err
+	}
+	err = json.Unmarshal(data, &res)
+	return res, err
+}
+
 // Kline define kline info
 type Kline struct {
 	OpenTime                 int64   `json:"openTime"`                        // 开盘时间
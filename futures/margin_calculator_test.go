@@ -0,0 +1,131 @@
+package futures
+
+import (
+	"math"
+	"testing"
+)
+
+// btcusdtBrackets BTCUSDT 前四层杠杆分层, 速算数 cum_n = cum_n-1 + floor_n * (mmr_n - mmr_n-1)
+func btcusdtBrackets() *LeverageBracket {
+	return &LeverageBracket{
+		Symbol: "BTCUSDT",
+		Brackets: []Bracket{
+			{Bracket: 1, InitialLeverage: 125, NotionalCap: 50000, NotionalFloor: 0, MaintMarginRatio: 0.004, Cum: 0},
+			{Bracket: 2, InitialLeverage: 100, NotionalCap: 250000, NotionalFloor: 50000, MaintMarginRatio: 0.005, Cum: 50},
+			{Bracket: 3, InitialLeverage: 50, NotionalCap: 3000000, NotionalFloor: 250000, MaintMarginRatio: 0.01, Cum: 1300},
+			{Bracket: 4, InitialLeverage: 20, NotionalCap: 15000000, NotionalFloor: 3000000, MaintMarginRatio: 0.025, Cum: 46300},
+		},
+	}
+}
+
+func ethusdtBrackets() *LeverageBracket {
+	return &LeverageBracket{
+		Symbol:   "ETHUSDT",
+		Brackets: []Bracket{{Bracket: 1, InitialLeverage: 100, NotionalCap: 10000000, MaintMarginRatio: 0.005}},
+	}
+}
+
+func assertFloat(t *testing.T, name string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-6*math.Max(1, math.Abs(want)) {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+func TestLeverageBracketUnmarshal(t *testing.T) {
+	data := []byte(`[{"symbol":"BTCUSDT","brackets":[{"bracket":1,"initialLeverage":125,"notionalCap":50000,"notionalFloor":0,"maintMarginRatio":0.004,"cum":0.0}]}]`)
+	var res []*LeverageBracket
+	if err := json.Unmarshal(data, &res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 1 || len(res[0].Brackets) != 1 {
+		t.Fatalf("unexpected brackets %+v", res)
+	}
+	assertFloat(t, "MaintMarginRatio", res[0].Brackets[0].MaintMarginRatio, 0.004)
+}
+
+func TestMarginCalculatorMaintMarginBracketBoundary(t *testing.T) {
+	m := NewMarginCalculator(btcusdtBrackets())
+	// 分层边界处维持保证金连续: 50000 * 0.4% = 50000 * 0.5% - 50
+	margin, ratio, cum := m.MaintMargin("BTCUSDT", 50000)
+	assertFloat(t, "margin", margin, 200)
+	assertFloat(t, "ratio", ratio, 0.005)
+	assertFloat(t, "cum", cum, 50)
+	margin, ratio, _ = m.MaintMargin("BTCUSDT", 49999.99)
+	assertFloat(t, "margin", margin, 199.99996)
+	assertFloat(t, "ratio", ratio, 0.004)
+	margin, _, _ = m.MaintMargin("BTCUSDT", 3000000)
+	assertFloat(t, "margin", margin, 3000000*0.01-1300)
+}
+
+func TestMarginCalculatorOneWayCross(t *testing.T) {
+	m := NewMarginCalculator(btcusdtBrackets())
+	pos := &MarginPosition{Symbol: "BTCUSDT", Amount: 1, EntryPrice: 50000, Leverage: 10, MarginType: MarginTypeCrossed}
+	account := &MarginAccount{CrossWalletBalance: 10000, Positions: []*MarginPosition{pos}}
+
+	res := m.Calculate(account, pos)
+	assertFloat(t, "Notional", res.Notional, 50000)
+	assertFloat(t, "InitialMargin", res.InitialMargin, 5000)
+	assertFloat(t, "MaintMargin", res.MaintMargin, 200)
+	// LP = (10000 + 50 - 1 * 50000) / (1 * 0.005 - 1)
+	assertFloat(t, "LiquidationPrice", res.LiquidationPrice, 40150.753768844224)
+}
+
+func TestMarginCalculatorCrossWithOtherPositions(t *testing.T) {
+	m := NewMarginCalculator(btcusdtBrackets(), ethusdtBrackets())
+	pos := &MarginPosition{Symbol: "BTCUSDT", Amount: 1, EntryPrice: 50000, Leverage: 10}
+	eth := &MarginPosition{Symbol: "ETHUSDT", Amount: 10, EntryPrice: 3000, MarkPrice: 3100, Leverage: 10}
+	account := &MarginAccount{CrossWalletBalance: 10000, Positions: []*MarginPosition{pos, eth}}
+
+	// TMM1 = 31000 * 0.5% = 155, UPNL1 = 10 * (3100 - 3000) = 1000
+	// LP = (10000 - 155 + 1000 + 50 - 1 * 50000) / (1 * 0.005 - 1)
+	assertFloat(t, "LiquidationPrice", m.LiquidationPrice(account, pos), 39301.50753768844)
+	// 逐仓持仓不计入全仓
+	eth.MarginType = MarginTypeIsolated
+	assertFloat(t, "LiquidationPrice", m.LiquidationPrice(account, pos), 40150.753768844224)
+}
+
+func TestMarginCalculatorIsolated(t *testing.T) {
+	m := NewMarginCalculator(btcusdtBrackets())
+	pos := &MarginPosition{Symbol: "BTCUSDT", Amount: -2, EntryPrice: 40000, Leverage: 20, MarginType: MarginTypeIsolated}
+	// 全仓余额不影响逐仓强平价格
+	account := &MarginAccount{CrossWalletBalance: 1000000, Positions: []*MarginPosition{pos}}
+
+	// WB = 80000 / 20 = 4000, LP = (4000 + 50 - (-2) * 40000) / (2 * 0.005 - (-2))
+	assertFloat(t, "LiquidationPrice", m.LiquidationPrice(account, pos), 41815.920398009956)
+	pos.IsolatedMargin = 4000
+	assertFloat(t, "LiquidationPrice", m.LiquidationPrice(account, pos), 41815.920398009956)
+}
+
+func TestMarginCalculatorHedgeMode(t *testing.T) {
+	m := NewMarginCalculator(btcusdtBrackets())
+	long := &MarginPosition{Symbol: "BTCUSDT", PositionSide: PositionSideTypeLong, Amount: 2, EntryPrice: 50000, MarkPrice: 51000, Leverage: 10}
+	short := &MarginPosition{Symbol: "BTCUSDT", PositionSide: PositionSideTypeShort, Amount: 1, EntryPrice: 52000, MarkPrice: 51000, Leverage: 10}
+	account := &MarginAccount{CrossWalletBalance: 10000, Positions: []*MarginPosition{long, short}}
+
+	// LP = (10000 + 50 + 50 - 2 * 50000 + 1 * 52000) / (2 * 0.005 + 1 * 0.005 - 2 + 1)
+	want := 38477.15736040609
+	assertFloat(t, "LONG", m.LiquidationPrice(account, long), want)
+	assertFloat(t, "SHORT", m.LiquidationPrice(account, short), want)
+}
+
+func TestMarginCalculatorMaxOpenQuantity(t *testing.T) {
+	m := NewMarginCalculator(btcusdtBrackets())
+	empty := &MarginAccount{CrossWalletBalance: 10000}
+
+	assertFloat(t, "MaxNotional(100)", m.MaxNotional("BTCUSDT", 100), 250000)
+	assertFloat(t, "MaxNotional(125)", m.MaxNotional("BTCUSDT", 125), 50000)
+	// 125 倍只能开到第一层上限 50000
+	assertFloat(t, "125x", m.MaxOpenQuantity(empty, "BTCUSDT", PositionSideTypeBoth, 125, 50000), 1)
+	// 100 倍受第二层上限 250000 限制, 而非余额 10000 * 100
+	assertFloat(t, "100x", m.MaxOpenQuantity(empty, "BTCUSDT", PositionSideTypeBoth, 100, 50000), 5)
+	// 余额不足时受可用余额限制
+	assertFloat(t, "10x", m.MaxOpenQuantity(empty, "BTCUSDT", PositionSideTypeBoth, 10, 50000), 2)
+
+	// 已有 50000 名义价值的持仓, 只能再开到 250000
+	pos := &MarginPosition{Symbol: "BTCUSDT", PositionSide: PositionSideTypeLong, Amount: 1, EntryPrice: 50000, Leverage: 100}
+	account := empty.With(pos)
+	assertFloat(t, "with position", m.MaxOpenQuantity(account, "BTCUSDT", PositionSideTypeLong, 100, 50000), 4)
+	// 双向持仓另一方向不占用本方向的上限
+	assertFloat(t, "other side", m.MaxOpenQuantity(account, "BTCUSDT", PositionSideTypeShort, 100, 50000), 5)
+}
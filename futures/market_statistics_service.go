@@ -0,0 +1,178 @@
+package futures
+
+import (
+	"context"
+	"net/http"
+)
+
+// TakerLongShortRatioService list taker buy/sell volume of a symbol
+type TakerLongShortRatioService struct {
+	c         *Client
+	symbol    string
+	period    string // "5m","15m","30m","1h","2h","4h","6h","12h","1d"
+	limit     *int   // default 30, max 500
+	startTime *int64
+	endTime   *int64
+}
+
+// TakerLongShortRatio 合约主动买卖量
+type TakerLongShortRatio struct {
+	BuySellRatio float64 `json:"buySellRatio,string"` // 主动买卖量比值
+	BuyVol       float64 `json:"buyVol,string"`       // 主动买入量
+	SellVol      float64 `json:"sellVol,string"`      // 主动卖出量
+	Timestamp    int64   `json:"timestamp"`
+}
+
+// SetSymbol set symbol
+func (s *TakerLongShortRatioService) SetSymbol(symbol string) *TakerLongShortRatioService {
+	s.symbol = symbol
+	return s
+}
+
+// SetPeriod set period interval
+func (s *TakerLongShortRatioService) SetPeriod(period string) *TakerLongShortRatioService {
+	s.period = period
+	return s
+}
+
+// SetLimit set limit
+func (s *TakerLongShortRatioService) SetLimit(limit int) *TakerLongShortRatioService {
+	s.limit = &limit
+	return s
+}
+
+// SetStartTime set startTime
+func (s *TakerLongShortRatioService) SetStartTime(startTime int64) *TakerLongShortRatioService {
+	s.startTime = &startTime
+	return s
+}
+
+// SetEndTime set endTime
+func (s *TakerLongShortRatioService) SetEndTime(endTime int64) *TakerLongShortRatioService {
+	s.endTime = &endTime
+	return s
+}
+
+// Do send request
+func (s *TakerLongShortRatioService) Do(ctx context.Context, opts ...RequestOption) (res []*TakerLongShortRatio, err error) {
+	// GET /futures/data/takerlongshortRatio | 合约主动买卖量
+	// 若无 startime 和 endtime 限制， 则默认返回当前时间往前的limit值
+	// 仅支持最近30天的数据
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/futures/data/takerlongshortRatio",
+	}
+	r.setParam("symbol", s.symbol)
+	r.setParam("period", s.period)
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*TakerLongShortRatio{}, err
+	}
+	res = make([]*TakerLongShortRatio, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*TakerLongShortRatio{}, err
+	}
+	return res, nil
+}
+
+// BasisService list basis of a pair and contract type
+type BasisService struct {
+	c            *Client
+	pair         string
+	contractType ContractType
+	period       string // "5m","15m","30m","1h","2h","4h","6h","12h","1d"
+	limit        *int   // default 30, max 500
+	startTime    *int64
+	endTime      *int64
+}
+
+// Basis 基差
+type Basis struct {
+	Pair                string       `json:"pair"`                // 标的交易对
+	ContractType        ContractType `json:"contractType"`        // 合约类型
+	FuturesPrice        float64      `json:"futuresPrice,string"` // 合约价格
+	IndexPrice          float64      `json:"indexPrice,string"`   // 指数价格
+	Basis               float64      `json:"basis,string"`        // 基差 = 合约价格 - 指数价格
+	BasisRate           float64      `json:"basisRate,string"`    // 基差率
+	AnnualizedBasisRate string       `json:"annualizedBasisRate"` // 年化基差率, 永续合约为空
+	Timestamp           int64        `json:"timestamp"`
+}
+
+// SetPair set pair
+func (s *BasisService) SetPair(pair string) *BasisService {
+	s.pair = pair
+	return s
+}
+
+// SetContractType set contract type: PERPETUAL, CURRENT_QUARTER or NEXT_QUARTER
+func (s *BasisService) SetContractType(contractType ContractType) *BasisService {
+	s.contractType = contractType
+	return s
+}
+
+// SetPeriod set period interval
+func (s *BasisService) SetPeriod(period string) *BasisService {
+	s.period = period
+	return s
+}
+
+// SetLimit set limit
+func (s *BasisService) SetLimit(limit int) *BasisService {
+	s.limit = &limit
+	return s
+}
+
+// SetStartTime set startTime
+func (s *BasisService) SetStartTime(startTime int64) *BasisService {
+	s.startTime = &startTime
+	return s
+}
+
+// SetEndTime set endTime
+func (s *BasisService) SetEndTime(endTime int64) *BasisService {
+	s.endTime = &endTime
+	return s
+}
+
+// Do send request
+func (s *BasisService) Do(ctx context.Context, opts ...RequestOption) (res []*Basis, err error) {
+	// GET /futures/data/basis | 基差
+	// 若无 startime 和 endtime 限制， 则默认返回当前时间往前的limit值
+	// 仅支持最近30天的数据
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/futures/data/basis",
+	}
+	r.setParam("pair", s.pair)
+	r.setParam("contractType", s.contractType)
+	r.setParam("period", s.period)
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*Basis{}, err
+	}
+	res = make([]*Basis, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*Basis{}, err
+	}
+	return res, nil
+}
@@ -11,23 +11,26 @@ import (
 
 // CreateOrderService create order
 type CreateOrderService struct {
-	c                *Client
-	symbol           string            // 交易对
-	side             SideType          // 买卖方向 SELL, BUY
-	positionSide     *PositionSideType // 持仓方向，单向持仓模式下非必填，默认且仅可填BOTH;在双向持仓模式下必填,且仅可选择 LONG 或 SHORT
-	orderType        OrderType         // type 订单类型 LIMIT, MARKET, STOP, TAKE_PROFIT, STOP_MARKET, TAKE_PROFIT_MARKET, TRAILING_STOP_MARKET
-	reduceOnly       *bool             // true, false; 非双开模式下默认false；双开模式下不接受此参数； 使用closePosition不支持此参数。
-	quantity         string            // 下单数量,使用closePosition不支持此参数。
-	price            *string           // 委托价格
-	newClientOrderID *string           // 用户自定义的订单号，不可以重复出现在挂单中。如空缺系统会自动赋值。必须满足正则规则 ^[\.A-Z\:/a-z0-9_-]{1,36}$
-	stopPrice        *string           // 触发价, 仅 STOP, STOP_MARKET, TAKE_PROFIT, TAKE_PROFIT_MARKET 需要此参数
-	closePosition    *bool             // true, false；触发后全部平仓，仅支持STOP_MARKET和TAKE_PROFIT_MARKET；不与quantity合用；自带只平仓效果，不与reduceOnly 合用
-	activationPrice  *string           // 追踪止损激活价格，仅TRAILING_STOP_MARKET 需要此参数, 默认为下单当前市场价格(支持不同workingType)
-	callbackRate     *string           // 追踪止损回调比例，可取值范围[0.1, 5],其中 1代表1% ,仅TRAILING_STOP_MARKET 需要此参数
-	timeInForce      *TimeInForceType  // 有效方法
-	workingType      *WorkingType      // stopPrice 触发类型: MARK_PRICE(标记价格), CONTRACT_PRICE(合约最新价). 默认 CONTRACT_PRICE
-	priceProtect     *bool             // 条件单触发保护："TRUE","FALSE", 默认"FALSE". 仅 STOP, STOP_MARKET, TAKE_PROFIT, TAKE_PROFIT_MARKET 需要此参数
-	newOrderRespType NewOrderRespType  // "ACK", "RESULT", 默认 "ACK"
+	c                       *Client
+	symbol                  string                       // 交易对
+	side                    SideType                     // 买卖方向 SELL, BUY
+	positionSide            *PositionSideType            // 持仓方向，单向持仓模式下非必填，默认且仅可填BOTH;在双向持仓模式下必填,且仅可选择 LONG 或 SHORT
+	orderType               OrderType                    // type 订单类型 LIMIT, MARKET, STOP, TAKE_PROFIT, STOP_MARKET, TAKE_PROFIT_MARKET, TRAILING_STOP_MARKET
+	reduceOnly              *bool                        // true, false; 非双开模式下默认false；双开模式下不接受此参数； 使用closePosition不支持此参数。
+	quantity                string                       // 下单数量,使用closePosition不支持此参数。
+	price                   *string                      // 委托价格
+	newClientOrderID        *string                      // 用户自定义的订单号，不可以重复出现在挂单中。如空缺系统会自动赋值。必须满足正则规则 ^[\.A-Z\:/a-z0-9_-]{1,36}$
+	stopPrice               *string                      // 触发价, 仅 STOP, STOP_MARKET, TAKE_PROFIT, TAKE_PROFIT_MARKET 需要此参数
+	closePosition           *bool                        // true, false；触发后全部平仓，仅支持STOP_MARKET和TAKE_PROFIT_MARKET；不与quantity合用；自带只平仓效果，不与reduceOnly 合用
+	activationPrice         *string                      // 追踪止损激活价格，仅TRAILING_STOP_MARKET 需要此参数, 默认为下单当前市场价格(支持不同workingType)
+	callbackRate            *string                      // 追踪止损回调比例，可取值范围[0.1, 5],其中 1代表1% ,仅TRAILING_STOP_MARKET 需要此参数
+	timeInForce             *TimeInForceType             // 有效方法
+	workingType             *WorkingType                 // stopPrice 触发类型: MARK_PRICE(标记价格), CONTRACT_PRICE(合约最新价). 默认 CONTRACT_PRICE
+	priceProtect            *bool                        // 条件单触发保护："TRUE","FALSE", 默认"FALSE". 仅 STOP, STOP_MARKET, TAKE_PROFIT, TAKE_PROFIT_MARKET 需要此参数
+	newOrderRespType        NewOrderRespType             // "ACK", "RESULT", 默认 "ACK"
+	priceMatch              *PriceMatchType              // 盘口价格下单模式, 仅 LIMIT, STOP, TAKE_PROFIT; 不能与 price 同时使用
+	selfTradePreventionMode *SelfTradePreventionModeType // 自成交保护模式, 默认 NONE
+	goodTillDate            *int64                       // TIF为GTD时的自动取消时间, 毫秒时间戳
 }
 
 // SetSymbol set symbol
@@ -126,13 +129,26 @@ func (s *CreateOrderService) SetClosePosition(closePosition bool) *CreateOrderSe
 	return s
 }
 
-// createOrder 新建订单
-func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, header *http.Header, err error) {
-	r := &request{
-		method:   http.MethodPost,
-		endpoint: endpoint,
-		secType:  secTypeSigned,
-	}
+// SetPriceMatch set priceMatch
+func (s *CreateOrderService) SetPriceMatch(priceMatch PriceMatchType) *CreateOrderService {
+	s.priceMatch = &priceMatch
+	return s
+}
+
+// SetSelfTradePreventionMode set selfTradePreventionMode
+func (s *CreateOrderService) SetSelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionModeType) *CreateOrderService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
+// SetGoodTillDate set goodTillDate, required when timeInForce is GTD
+func (s *CreateOrderService) SetGoodTillDate(goodTillDate int64) *CreateOrderService {
+	s.goodTillDate = &goodTillDate
+	return s
+}
+
+// params 下单参数, 单个下单和批量下单共用
+func (s *CreateOrderService) params() params {
 	m := params{
 		"symbol":           s.symbol,
 		"side":             s.side,
@@ -173,6 +189,30 @@ func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, o
 	if s.closePosition != nil {
 		m["closePosition"] = *s.closePosition
 	}
+	if s.priceMatch != nil {
+		m["priceMatch"] = *s.priceMatch
+	}
+	if s.selfTradePreventionMode != nil {
+		m["selfTradePreventionMode"] = *s.selfTradePreventionMode
+	}
+	if s.goodTillDate != nil {
+		m["goodTillDate"] = *s.goodTillDate
+	}
+	return m
+}
+
+// createOrder 新建订单
+func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, header *http.Header, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: endpoint,
+		secType:  secTypeSigned,
+	}
+	m := s.params()
+	if s.newClientOrderID == nil && s.c.OrderIDs != nil {
+		// 生成的订单号只用于本次请求, 不写回 service
+		m["newClientOrderId"] = s.c.OrderIDs.Next()
+	}
 	r.setFormParams(m)
 	data, header, err = s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -834,46 +874,9 @@ func (s *CreateBatchOrdersService) Do(ctx context.Context, opts ...RequestOption
 
 	var orders []params
 	for _, order := range s.orders {
-		m := params{
-			"symbol":           order.symbol,
-			"side":             order.side,
-			"type":             order.orderType,
-			"quantity":         order.quantity,
-			"newOrderRespType": order.newOrderRespType,
-		}
-
-		if order.positionSide != nil {
-			m["positionSide"] = *order.positionSide
-		}
-		if order.timeInForce != nil {
-			m["timeInForce"] = *order.timeInForce
-		}
-		if order.reduceOnly != nil {
-			m["reduceOnly"] = *order.reduceOnly
-		}
-		if order.price != nil {
-			m["price"] = *order.price
-		}
-		if order.newClientOrderID != nil {
-			m["newClientOrderId"] = *order.newClientOrderID
-		}
-		if order.stopPrice != nil {
-			m["stopPrice"] = *order.stopPrice
-		}
-		if order.workingType != nil {
-			m["workingType"] = *order.workingType
-		}
-		if order.priceProtect != nil {
-			m["priceProtect"] = *order.priceProtect
-		}
-		if order.activationPrice != nil {
-			m["activationPrice"] = *order.activationPrice
-		}
-		if order.callbackRate != nil {
-			m["callbackRate"] = *order.callbackRate
-		}
-		if order.closePosition != nil {
-			m["closePosition"] = *order.closePosition
+		m := order.params()
+		if order.newClientOrderID == nil && s.c.OrderIDs != nil {
+			m["newClientOrderId"] = s.c.OrderIDs.Next()
 		}
 		orders = append(orders, m)
 	}
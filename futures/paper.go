@@ -0,0 +1,209 @@
+package futures
+
+import (
+	"bytes"
+	"context"
+	"github.com/BobHye/wsc"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// 模拟盘: 订单和账户接口由本地模拟账本响应, 行情来自实盘推送
+
+// paperEndpoints 模拟盘在本地响应的接口, 其余接口(行情等)仍然请求交易所
+var paperEndpoints = map[string]bool{
+	"/fapi/v1/order":             true,
+	"/fapi/v1/batchOrders":       true,
+	"/fapi/v1/openOrder":         true,
+	"/fapi/v1/openOrders":        true,
+	"/fapi/v1/allOrders":         true,
+	"/fapi/v1/allOpenOrders":     true,
+	"/fapi/v1/userTrades":        true,
+	"/fapi/v1/commissionRate":    true,
+	"/fapi/v1/leverage":          true,
+	"/fapi/v1/marginType":        true,
+	"/fapi/v1/positionSide/dual": true,
+	"/fapi/v1/listenKey":         true,
+	"/fapi/v2/account":           true,
+	"/fapi/v2/balance":           true,
+	"/fapi/v2/positionRisk":      true,
+}
+
+// PaperExchange a SimExchange whose prices follow the live book ticker streams | 模拟盘交易所
+type PaperExchange struct {
+	*SimExchange
+	mu         sync.Mutex
+	live       *Client
+	feeds      map[string]*wsc.Wsc
+	errHandler ErrHandler
+}
+
+// NewPaperExchange create a paper exchange whose wallet holds balance of the margin asset, e.g. USDT.
+// Funding is not charged unless SetFundingRates is called | 创建模拟盘交易所
+func NewPaperExchange(asset string, balance float64) *PaperExchange {
+	return &PaperExchange{
+		SimExchange: NewSimExchange(asset, balance),
+		feeds:       make(map[string]*wsc.Wsc),
+		errHandler:  func(err error) {},
+	}
+}
+
+// SetErrHandler set the handler of errors from the live streams | 设置行情推送的错误处理器
+func (p *PaperExchange) SetErrHandler(errHandler ErrHandler) *PaperExchange {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.errHandler = errHandler
+	return p
+}
+
+// UsePaperTrading serve the order, account and user data endpoints of the client from p, while market data is still
+// requested from the exchange. Strategies keep calling the same services | 将客户端切换到模拟盘
+func (c *Client) UsePaperTrading(p *PaperExchange) *Client {
+	live := *c
+	p.mu.Lock()
+	if p.live == nil {
+		p.live = &live
+	}
+	p.mu.Unlock()
+	c.do = p.do
+	return c
+}
+
+// Subscribe start following the live book ticker of symbols. Symbols are also subscribed on their first order | 订阅交易对实时最优挂单
+func (p *PaperExchange) Subscribe(symbols ...string) error {
+	for _, symbol := range symbols {
+		if err := p.subscribe(symbol); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// subscribe 以 REST 最优挂单初始化价格, 然后跟随实时推送. 先在锁内占位, 请求和连接不持有锁
+func (p *PaperExchange) subscribe(symbol string) error {
+	p.mu.Lock()
+	if _, ok := p.feeds[symbol]; ok {
+		p.mu.Unlock()
+		return nil
+	}
+	p.feeds[symbol] = nil
+	if p.live == nil {
+		p.live = NewClient("", "")
+	}
+	live := p.live
+	p.mu.Unlock()
+
+	ws, err := p.follow(live, symbol)
+
+	p.mu.Lock()
+	feed, ok := p.feeds[symbol]
+	reserved := ok && feed == nil // 订阅期间 Close 会删除占位
+	if reserved && err != nil {
+		delete(p.feeds, symbol)
+	} else if reserved {
+		p.feeds[symbol] = ws
+	}
+	p.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if !reserved {
+		ws.Close()
+	}
+	return nil
+}
+
+// follow 以 REST 最优挂单初始化价格并连接实时推送
+func (p *PaperExchange) follow(live *Client, symbol string) (*wsc.Wsc, error) {
+	tickers, err := live.NewListBookTickersService().SetSymbol(symbol).Do(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tickers {
+		p.ProcessBookTicker(t.Symbol, t.BidPrice, t.AskPrice, t.Time)
+	}
+	ws, _, err := WsBookTickerServe(symbol, func(event *WsBookTickerEvent) {
+		p.ProcessBookTicker(event.Symbol, event.BestBidPrice, event.BestAskPrice, event.TransactionTime)
+	}, p.handleErr)
+	return ws, err
+}
+
+// handleErr 每次读取当前的错误处理器, 使 SetErrHandler 对已订阅的推送生效
+func (p *PaperExchange) handleErr(err error) {
+	p.mu.Lock()
+	errHandler := p.errHandler
+	p.mu.Unlock()
+	errHandler(err)
+}
+
+// Close stop following the live streams | 停止实时推送
+func (p *PaperExchange) Close() {
+	p.mu.Lock()
+	feeds := make([]*wsc.Wsc, 0, len(p.feeds))
+	for symbol, ws := range p.feeds {
+		if ws != nil {
+			feeds = append(feeds, ws)
+		}
+		delete(p.feeds, symbol)
+	}
+	p.mu.Unlock()
+	for _, ws := range feeds {
+		ws.Close()
+	}
+}
+
+// do 模拟盘接口在本地响应, 下单前先订阅交易对行情; 其余请求发往交易所
+func (p *PaperExchange) do(req *http.Request) (*http.Response, error) {
+	if !paperEndpoints[req.URL.Path] {
+		p.mu.Lock()
+		live := p.live
+		p.mu.Unlock()
+		return live.HTTPClient.Do(req)
+	}
+	if req.Method == http.MethodPost && (req.URL.Path == "/fapi/v1/order" || req.URL.Path == "/fapi/v1/batchOrders") {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, err
+		}
+		for _, symbol := range paperSymbols(form) {
+			if err := p.subscribe(symbol); err != nil {
+				return nil, err
+			}
+		}
+	}
+	p.SimExchange.mu.Lock()
+	if now := time.Now().UnixMilli(); now > p.now {
+		p.now = now
+	}
+	p.SimExchange.mu.Unlock()
+	return p.SimExchange.do(req)
+}
+
+// paperSymbols 返回下单请求涉及的交易对
+func paperSymbols(form url.Values) []string {
+	if batch := form.Get("batchOrders"); batch != "" {
+		var orders []struct {
+			Symbol string `json:"symbol"`
+		}
+		if err := json.Unmarshal([]byte(batch), &orders); err != nil {
+			return nil
+		}
+		symbols := make([]string, 0, len(orders))
+		for _, o := range orders {
+			symbols = append(symbols, o.Symbol)
+		}
+		return symbols
+	}
+	if symbol := form.Get("symbol"); symbol != "" {
+		return []string{symbol}
+	}
+	return nil
+}
@@ -0,0 +1,439 @@
+package futures
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// 持仓与余额跟踪: 以 REST 初始化, 由用户数据推送和标记价格推送保持最新, 并定期与 REST 对账
+
+// TrackedPosition define a position kept by PositionTracker. Amount is negative for short positions | 跟踪的持仓
+type TrackedPosition struct {
+	Symbol              string           // 交易对
+	Side                PositionSideType // 持仓方向, 单向持仓为 BOTH
+	Amount              float64          // 持仓数量, 空头为负
+	EntryPrice          float64          // 开仓均价
+	BreakEvenPrice      float64          // 盈亏平衡价
+	MarkPrice           float64          // 标记价格
+	UnrealizedPnL       float64          // 按标记价格计算的未实现盈亏
+	AccumulatedRealized float64          // 累计实现盈亏, 初始化后的成交累加, 每笔成交只计一次
+	MarginType          MarginType       // 保证金模式
+	IsolatedWallet      float64          // 逐仓仓位保证金
+	UpdateTime          int64            // 最后更新的交易时间
+}
+
+// updatePnL 按标记价格重算未实现盈亏, 尚无标记价格时为 0
+func (p *TrackedPosition) updatePnL() {
+	p.UnrealizedPnL = 0
+	if p.MarkPrice > 0 {
+		p.UnrealizedPnL = p.Amount * (p.MarkPrice - p.EntryPrice)
+	}
+}
+
+// TrackedBalance define a wallet balance kept by PositionTracker | 跟踪的余额
+type TrackedBalance struct {
+	Asset              string  // 资产
+	Balance            float64 // 钱包余额
+	CrossWalletBalance float64 // 全仓钱包余额
+	UpdateTime         int64   // 最后更新的交易时间
+}
+
+// PositionDrift define a position whose local state differs from REST | 与 REST 不一致的持仓
+type PositionDrift struct {
+	Symbol           string
+	Side             PositionSideType
+	LocalAmount      float64
+	RemoteAmount     float64
+	LocalEntryPrice  float64
+	RemoteEntryPrice float64
+}
+
+// BalanceDrift define a balance whose local state differs from REST | 与 REST 不一致的余额
+type BalanceDrift struct {
+	Asset  string
+	Local  float64
+	Remote float64
+}
+
+// TrackerDrift define the differences found by PositionTracker.Reconcile | 对账差异
+type TrackerDrift struct {
+	Positions []*PositionDrift
+	Balances  []*BalanceDrift
+}
+
+// Empty return whether no drift was found
+func (d *TrackerDrift) Empty() bool {
+	return len(d.Positions) == 0 && len(d.Balances) == 0
+}
+
+// TrackerDriftHandler handle drifts found by the periodic reconciliation | 处理对账差异
+type TrackerDriftHandler func(drift *TrackerDrift)
+
+// positionKey 持仓按交易对和持仓方向区分, 同时支持单向与双向持仓
+type positionKey struct {
+	symbol string
+	side   PositionSideType
+}
+
+// fillKey 成交按持仓和成交 ID 去重, 双向持仓自成交时两个方向各有一笔
+type fillKey struct {
+	positionKey
+	tradeID int64
+}
+
+// fillRetention 已处理成交的保留时长, 对账时清理更早的记录
+const fillRetention = time.Hour
+
+// PositionTracker keep an in-memory book of positions and balances. Seed it with Sync, feed it with HandleUserData
+// and HandleMarkPrice, and call Reconcile (or Run) to re-check against REST.
+// Fills move positions right away, ACCOUNT_UPDATE events then overwrite them with the exchange's values | 持仓与余额跟踪器
+type PositionTracker struct {
+	c *Client
+
+	mu        sync.RWMutex
+	positions map[positionKey]*TrackedPosition
+	balances  map[string]*TrackedBalance
+	fills     map[fillKey]int64 // 已处理的成交及其交易时间
+}
+
+// NewPositionTracker init a position tracker, call Sync before use | 创建持仓跟踪器
+func (c *Client) NewPositionTracker() *PositionTracker {
+	return &PositionTracker{
+		c:         c,
+		positions: make(map[positionKey]*TrackedPosition),
+		balances:  make(map[string]*TrackedBalance),
+		fills:     make(map[fillKey]int64),
+	}
+}
+
+// fetch 查询 REST 持仓与余额
+func (t *PositionTracker) fetch(ctx context.Context) (positions []*PositionRisk, balances []*Balance, err error) {
+	positions, err = t.c.NewGetPositionRiskService().Do(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	balances, err = t.c.NewGetBalanceService().Do(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return positions, balances, nil
+}
+
+// Sync replace the local state with REST positions and balances | 以 REST 数据初始化
+func (t *PositionTracker) Sync(ctx context.Context) error {
+	positions, balances, err := t.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.positions = make(map[positionKey]*TrackedPosition)
+	t.balances = make(map[string]*TrackedBalance)
+	t.apply(positions, balances, 0)
+	return nil
+}
+
+// apply 写入 REST 数据, 跳过 since 之后已被推送更新的持仓和余额, 需持有锁
+func (t *PositionTracker) apply(positions []*PositionRisk, balances []*Balance, since int64) {
+	for _, p := range positions {
+		key := positionKey{p.Symbol, p.PositionSide}
+		if old, ok := t.positions[key]; ok && since > 0 && old.UpdateTime > since {
+			continue
+		}
+		pos := &TrackedPosition{
+			Symbol:         p.Symbol,
+			Side:           p.PositionSide,
+			Amount:         p.PositionAmt,
+			EntryPrice:     p.EntryPrice,
+			BreakEvenPrice: p.BreakEvenPrice,
+			MarkPrice:      p.MarkPrice,
+			MarginType:     MarginType(p.MarginType),
+			IsolatedWallet: p.IsolatedWallet,
+			UpdateTime:     p.UpdateTime,
+		}
+		if old, ok := t.positions[key]; ok {
+			pos.AccumulatedRealized = old.AccumulatedRealized
+		}
+		pos.updatePnL()
+		t.positions[key] = pos
+	}
+	for _, b := range balances {
+		if old, ok := t.balances[b.Asset]; ok && since > 0 && old.UpdateTime > since {
+			continue
+		}
+		t.balances[b.Asset] = &TrackedBalance{
+			Asset:              b.Asset,
+			Balance:            b.Balance,
+			CrossWalletBalance: b.CrossWalletBalance,
+		}
+	}
+}
+
+// HandleUserData apply ACCOUNT_UPDATE and the fills of ORDER_TRADE_UPDATE, other events are ignored.
+// It can be used as, or called from, the handler of WsUserDataServe | 处理用户数据推送
+func (t *PositionTracker) HandleUserData(event *WsUserDataEvent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	switch event.Event {
+	case UserDataEventTypeAccountUpdate:
+		for _, b := range event.AccountUpdate.Balances {
+			if old, ok := t.balances[b.Asset]; ok && old.UpdateTime > event.TransactionTime {
+				continue
+			}
+			t.balances[b.Asset] = &TrackedBalance{
+				Asset:              b.Asset,
+				Balance:            b.Balance,
+				CrossWalletBalance: b.CrossWalletBalance,
+				UpdateTime:         event.TransactionTime,
+			}
+		}
+		for _, p := range event.AccountUpdate.Positions {
+			pos := t.position(p.Symbol, p.Side)
+			if pos.UpdateTime > event.TransactionTime {
+				continue
+			}
+			pos.Amount = p.Amount
+			pos.EntryPrice = p.EntryPrice
+			pos.BreakEvenPrice = p.BreakEvenPrice
+			pos.MarginType = p.MarginType
+			pos.IsolatedWallet = p.IsolatedWallet
+			if p.MarkPrice > 0 {
+				pos.MarkPrice = p.MarkPrice
+			}
+			pos.updatePnL()
+			pos.UpdateTime = event.TransactionTime
+		}
+	case UserDataEventTypeOrderTradeUpdate:
+		o := event.OrderTradeUpdate
+		if o.ExecutionType != OrderExecutionTypeTrade || o.LastFilledQty == 0 {
+			return
+		}
+		pos := t.position(o.Symbol, o.PositionSide)
+		fill := fillKey{positionKey{pos.Symbol, pos.Side}, o.TradeID}
+		if _, ok := t.fills[fill]; ok {
+			return
+		}
+		t.fills[fill] = o.TradeTime
+		pos.AccumulatedRealized += o.RealizedPnL
+		// 同一交易时间的 ACCOUNT_UPDATE 已包含这笔成交的持仓变化
+		if pos.UpdateTime >= o.TradeTime {
+			return
+		}
+		delta := o.LastFilledQty
+		if o.Side == SideTypeSell {
+			delta = -delta
+		}
+		amount := pos.Amount + delta
+		switch {
+		case amount == 0:
+			pos.EntryPrice = 0
+		case pos.Amount == 0 || (pos.Amount > 0) == (delta > 0):
+			// 加仓, 按成交价加权计算均价
+			pos.EntryPrice = (pos.Amount*pos.EntryPrice + delta*o.LastFilledPrice) / amount
+		case (pos.Amount > 0) != (amount > 0):
+			// 反手, 剩余仓位以成交价开仓
+			pos.EntryPrice = o.LastFilledPrice
+		}
+		pos.Amount = amount
+		pos.updatePnL()
+		pos.UpdateTime = o.TradeTime
+	}
+}
+
+// position 返回持仓, 不存在时创建, 需持有锁
+func (t *PositionTracker) position(symbol string, side PositionSideType) *TrackedPosition {
+	if side == "" {
+		side = PositionSideTypeBoth
+	}
+	key := positionKey{symbol, side}
+	pos, ok := t.positions[key]
+	if !ok {
+		pos = &TrackedPosition{Symbol: symbol, Side: side}
+		t.positions[key] = pos
+	}
+	return pos
+}
+
+// HandleMarkPrice update the mark price and unrealized PnL of the positions of the symbol.
+// It can be used as, or called from, the handler of WsMarkPriceServe | 以标记价格更新未实现盈亏
+func (t *PositionTracker) HandleMarkPrice(event *WsMarkPriceEvent) {
+	price := MustFloat64(event.MarkPrice)
+	if price <= 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key, pos := range t.positions {
+		if key.symbol == event.Symbol {
+			pos.MarkPrice = price
+			pos.updatePnL()
+		}
+	}
+}
+
+// HandleAllMarkPrice update the mark prices of all symbols, for WsAllMarkPriceServe | 以全市场标记价格更新未实现盈亏
+func (t *PositionTracker) HandleAllMarkPrice(event WsAllMarkPriceEvent) {
+	for _, e := range event {
+		t.HandleMarkPrice(e)
+	}
+}
+
+// Position return a copy of the position, side is BOTH in one-way mode | 查询持仓
+func (t *PositionTracker) Position(symbol string, side PositionSideType) (pos TrackedPosition, ok bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	p, ok := t.positions[positionKey{symbol, side}]
+	if !ok {
+		return pos, false
+	}
+	return *p, true
+}
+
+// Positions return copies of the open positions, sorted by symbol and side | 查询所有持仓
+func (t *PositionTracker) Positions() []TrackedPosition {
+	t.mu.RLock()
+	res := make([]TrackedPosition, 0, len(t.positions))
+	for _, p := range t.positions {
+		if p.Amount != 0 {
+			res = append(res, *p)
+		}
+	}
+	t.mu.RUnlock()
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Symbol != res[j].Symbol {
+			return res[i].Symbol < res[j].Symbol
+		}
+		return res[i].Side < res[j].Side
+	})
+	return res
+}
+
+// Balance return a copy of the balance of asset | 查询余额
+func (t *PositionTracker) Balance(asset string) (b TrackedBalance, ok bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	p, ok := t.balances[asset]
+	if !ok {
+		return b, false
+	}
+	return *p, true
+}
+
+// Balances return copies of the balances, sorted by asset | 查询所有余额
+func (t *PositionTracker) Balances() []TrackedBalance {
+	t.mu.RLock()
+	res := make([]TrackedBalance, 0, len(t.balances))
+	for _, b := range t.balances {
+		res = append(res, *b)
+	}
+	t.mu.RUnlock()
+	sort.Slice(res, func(i, j int) bool { return res[i].Asset < res[j].Asset })
+	return res
+}
+
+// UnrealizedPnL return the unrealized PnL of all positions | 所有持仓的未实现盈亏
+func (t *PositionTracker) UnrealizedPnL() float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	var pnl float64
+	for _, p := range t.positions {
+		pnl += p.UnrealizedPnL
+	}
+	return pnl
+}
+
+// Reconcile compare the local state with REST, then take the REST values for positions and balances
+// not updated by events since the query | 与 REST 对账并以 REST 数据修正
+func (t *PositionTracker) Reconcile(ctx context.Context) (drift *TrackerDrift, err error) {
+	since := currentTimestamp() - t.c.TimeOffset
+	positions, balances, err := t.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	drift = new(TrackerDrift)
+	seen := make(map[positionKey]bool)
+	for _, p := range positions {
+		key := positionKey{p.Symbol, p.PositionSide}
+		seen[key] = true
+		local, ok := t.positions[key]
+		if ok && local.UpdateTime > since {
+			continue
+		}
+		var amount, entry float64
+		if ok {
+			amount, entry = local.Amount, local.EntryPrice
+		}
+		if !floatEqual(amount, p.PositionAmt) || !floatEqual(entry, p.EntryPrice) {
+			drift.Positions = append(drift.Positions, &PositionDrift{
+				Symbol:           p.Symbol,
+				Side:             p.PositionSide,
+				LocalAmount:      amount,
+				RemoteAmount:     p.PositionAmt,
+				LocalEntryPrice:  entry,
+				RemoteEntryPrice: p.EntryPrice,
+			})
+		}
+	}
+	for key, local := range t.positions {
+		if !seen[key] && local.Amount != 0 && local.UpdateTime <= since {
+			drift.Positions = append(drift.Positions, &PositionDrift{
+				Symbol:          key.symbol,
+				Side:            key.side,
+				LocalAmount:     local.Amount,
+				LocalEntryPrice: local.EntryPrice,
+			})
+			delete(t.positions, key)
+		}
+	}
+	for _, b := range balances {
+		local, ok := t.balances[b.Asset]
+		if ok && local.UpdateTime > since {
+			continue
+		}
+		var balance float64
+		if ok {
+			balance = local.Balance
+		}
+		if !floatEqual(balance, b.Balance) {
+			drift.Balances = append(drift.Balances, &BalanceDrift{Asset: b.Asset, Local: balance, Remote: b.Balance})
+		}
+	}
+	t.apply(positions, balances, since)
+	for fill, tradeTime := range t.fills {
+		if tradeTime < since-fillRetention.Milliseconds() {
+			delete(t.fills, fill)
+		}
+	}
+	return drift, nil
+}
+
+// Run reconcile every interval until ctx is done, passing non-empty drifts to handler | 定期对账
+func (t *PositionTracker) Run(ctx context.Context, interval time.Duration, handler TrackerDriftHandler, errHandler ErrHandler) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		drift, err := t.Reconcile(ctx)
+		if err != nil {
+			errHandler(err)
+			continue
+		}
+		if !drift.Empty() {
+			handler(drift)
+		}
+	}
+}
+
+// floatEqual 按相对误差比较, 避免浮点累加误差被当作差异
+func floatEqual(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-8*math.Max(1, math.Max(math.Abs(a), math.Abs(b)))
+}
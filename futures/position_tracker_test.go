@@ -0,0 +1,75 @@
+package futures
+
+import "testing"
+
+func accountUpdate(time int64, amount, entryPrice float64) *WsUserDataEvent {
+	return &WsUserDataEvent{
+		Event:           UserDataEventTypeAccountUpdate,
+		TransactionTime: time,
+		AccountUpdate: WsAccountUpdate{
+			Positions: []WsPosition{{Symbol: "BTCUSDT", Side: PositionSideTypeBoth, Amount: amount, EntryPrice: entryPrice}},
+		},
+	}
+}
+
+func tradeUpdate(time, tradeID int64, side SideType, qty, price, realized float64) *WsUserDataEvent {
+	return &WsUserDataEvent{
+		Event:           UserDataEventTypeOrderTradeUpdate,
+		TransactionTime: time,
+		OrderTradeUpdate: WsOrderTradeUpdate{
+			Symbol:          "BTCUSDT",
+			Side:            side,
+			ExecutionType:   OrderExecutionTypeTrade,
+			LastFilledQty:   qty,
+			LastFilledPrice: price,
+			TradeTime:       time,
+			TradeID:         tradeID,
+			PositionSide:    PositionSideTypeBoth,
+			RealizedPnL:     realized,
+		},
+	}
+}
+
+func TestPositionTrackerAccountUpdateBeforeFill(t *testing.T) {
+	tracker := NewClient("", "").NewPositionTracker()
+	tracker.HandleUserData(accountUpdate(1000, 2, 50000))
+
+	// 交易所先推送 ACCOUNT_UPDATE, 再推送同一笔成交的 ORDER_TRADE_UPDATE
+	tracker.HandleUserData(accountUpdate(2000, 1, 50000))
+	tracker.HandleUserData(tradeUpdate(2000, 11, SideTypeSell, 1, 51000, 1000))
+	pos, ok := tracker.Position("BTCUSDT", PositionSideTypeBoth)
+	if !ok {
+		t.Fatal("position not found")
+	}
+	if pos.Amount != 1 || pos.EntryPrice != 50000 {
+		t.Errorf("fill applied twice to position %+v", pos)
+	}
+	if pos.AccumulatedRealized != 1000 {
+		t.Errorf("AccumulatedRealized = %v, want 1000", pos.AccumulatedRealized)
+	}
+
+	// 重复推送的成交不重复累加
+	tracker.HandleUserData(tradeUpdate(2000, 11, SideTypeSell, 1, 51000, 1000))
+	pos, _ = tracker.Position("BTCUSDT", PositionSideTypeBoth)
+	if pos.AccumulatedRealized != 1000 {
+		t.Errorf("AccumulatedRealized = %v after duplicate fill, want 1000", pos.AccumulatedRealized)
+	}
+}
+
+func TestPositionTrackerFillBeforeAccountUpdate(t *testing.T) {
+	tracker := NewClient("", "").NewPositionTracker()
+	tracker.HandleUserData(accountUpdate(1000, 2, 50000))
+
+	tracker.HandleUserData(tradeUpdate(2000, 12, SideTypeSell, 0.5, 49000, -500))
+	pos, _ := tracker.Position("BTCUSDT", PositionSideTypeBoth)
+	if pos.Amount != 1.5 || pos.EntryPrice != 50000 || pos.AccumulatedRealized != -500 {
+		t.Errorf("unexpected position %+v", pos)
+	}
+	tracker.HandleUserData(accountUpdate(2000, 1.5, 50000))
+	tracker.HandleUserData(tradeUpdate(3000, 13, SideTypeBuy, 0.5, 52000, 0))
+	pos, _ = tracker.Position("BTCUSDT", PositionSideTypeBoth)
+	// 加仓按成交价加权: (1.5 * 50000 + 0.5 * 52000) / 2
+	if pos.Amount != 2 || pos.EntryPrice != 50500 || pos.AccumulatedRealized != -500 {
+		t.Errorf("unexpected position %+v", pos)
+	}
+}
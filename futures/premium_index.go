@@ -165,6 +165,40 @@ type Bracket struct {
 	InitialLeverage  int     `json:"initialLeverage"`  // 该层允许的最高初始杠杆倍数
 	NotionalCap      float64 `json:"notionalCap"`      // 该层对应的名义价值上限
 	NotionalFloor    float64 `json:"notionalFloor"`    // 该层对应的名义价值下限
-	MaintMarginRatio float64 `json:"mainMarginRation"` // 该层对应的维持保证金率
+	MaintMarginRatio float64 `json:"maintMarginRatio"` // 该层对应的维持保证金率
 	Cum              float64 `json:"cum"`              // 速算数
 }
+
+// FundingInfoService get funding rate caps and intervals of symbols with adjusted funding settings
+type FundingInfoService struct {
+	c *Client
+}
+
+// Do send request
+func (s *FundingInfoService) Do(ctx context.Context, opts ...RequestOption) (res []*FundingInfo, err error) {
+	// GET /fapi/v1/fundingInfo | 查询资金费率信息, 仅返回资金费率上下限或收取间隔经过调整的交易对
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/fapi/v1/fundingInfo",
+		secType:  secTypeNone,
+	}
+	data, _, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*FundingInfo{}, err
+	}
+	res = make([]*FundingInfo, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*FundingInfo{}, err
+	}
+	return res, nil
+}
+
+// FundingInfo define funding rate cap, floor and interval of a symbol
+type FundingInfo struct {
+	Symbol                   string  `json:"symbol"`                          // 交易对
+	AdjustedFundingRateCap   float64 `json:"adjustedFundingRateCap,string"`   // 资金费率上限
+	AdjustedFundingRateFloor float64 `json:"adjustedFundingRateFloor,string"` // 资金费率下限
+	FundingIntervalHours     int     `json:"fundingIntervalHours"`            // 资金费收取间隔(小时)
+	Disclaimer               bool    `json:"disclaimer"`                      // 忽略
+}
@@ -0,0 +1,205 @@
+package futures
+
+import (
+	"context"
+	"github.com/BobHye/binance-go/common"
+	"github.com/BobHye/wsc"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 原始消息记录与回放, 仅支持 USDⓈ-M 合约的 websocket 推送和深度快照
+
+// wsServeFunc serve a websocket | websocket 实现
+type wsServeFunc func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (*wsc.Wsc, chan struct{}, error)
+
+var (
+	// recorderMu guard recorder and wsServeImpl | 保护 recorder 和 wsServeImpl
+	recorderMu sync.RWMutex
+	recorder   *common.Recorder
+	// wsServeImpl the websocket implementation used by the Ws*Serve functions, swapped by Replay | 当前的 websocket 实现, 回放时被替换
+	wsServeImpl wsServeFunc = connectWsServe
+)
+
+// wsServe serve cfg with the current implementation, recording raw messages while a recorder is enabled | 使用当前实现连接 websocket, 启用记录时记录原始消息
+func wsServe(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (*wsc.Wsc, chan struct{}, error) {
+	recorderMu.RLock()
+	serve, rec := wsServeImpl, recorder
+	recorderMu.RUnlock()
+	if rec != nil {
+		stream := streamPath(cfg.Endpoint)
+		next := handler
+		handler = func(message []byte) {
+			if err := rec.Record(common.RecordSourceWs, stream, message); err != nil {
+				errHandler(err)
+			}
+			next(message)
+		}
+	}
+	return serve(cfg, handler, errHandler)
+}
+
+// streamPath return the stream part of a websocket endpoint, e.g. /ws/btcusdt@depth.
+// Combined streams are sorted since some are built from maps | 返回 websocket 端点中的流路径, 组合流按名称排序
+func streamPath(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return endpoint
+	}
+	streams := u.Query().Get("streams")
+	if streams == "" {
+		return u.Path
+	}
+	names := strings.Split(streams, "/")
+	sort.Strings(names)
+	return u.Path + "?streams=" + strings.Join(names, "/")
+}
+
+// EnableRecorder record every raw websocket message of this package served from now on, and the REST depth snapshots.
+// Spot and COIN-M streams are not recorded | 开始记录本包所有原始 websocket 消息及深度快照
+func EnableRecorder(rec *common.Recorder) {
+	recorderMu.Lock()
+	defer recorderMu.Unlock()
+	recorder = rec
+}
+
+// DisableRecorder stop recording for websockets served from now on | 停止记录
+func DisableRecorder() {
+	recorderMu.Lock()
+	defer recorderMu.Unlock()
+	recorder = nil
+}
+
+// recordSnapshot 记录用于初始化订单簿的 REST 快照
+func recordSnapshot(r *request, data []byte) {
+	recorderMu.RLock()
+	rec := recorder
+	recorderMu.RUnlock()
+	if rec == nil {
+		return
+	}
+	stream := r.endpoint
+	if len(r.query) > 0 {
+		stream += "?" + r.query.Encode()
+	}
+	_ = rec.Record(common.RecordSourceRest, stream, data)
+}
+
+// DepthSnapshotHandler handle a replayed depth snapshot, stream is the REST path with query | 处理回放的深度快照
+type DepthSnapshotHandler func(stream string, snapshot *DepthResponse)
+
+// Replay feed recorded messages back through the typed handlers of the Ws*Serve functions.
+// Call Install, then the usual Ws*Serve functions, then Run. The returned *wsc.Wsc is never connected, so Close
+// does nothing; send to done to unsubscribe. Every done channel is closed when Run returns | 通过 Ws*Serve 的类型化处理器回放记录
+type Replay struct {
+	player          *common.Replayer
+	mu              sync.Mutex
+	handlers        map[string][]*replaySubscription
+	snapshotHandler DepthSnapshotHandler
+	installed       bool
+	previous        wsServeFunc // 安装前的实现, 卸载时恢复
+}
+
+// NewReplay create a replay over the given replayer | 创建回放
+func NewReplay(player *common.Replayer) *Replay {
+	return &Replay{
+		player:   player,
+		handlers: make(map[string][]*replaySubscription),
+	}
+}
+
+// OnDepthSnapshot set the handler of recorded REST depth snapshots | 设置深度快照处理器
+func (p *Replay) OnDepthSnapshot(handler DepthSnapshotHandler) *Replay {
+	p.snapshotHandler = handler
+	return p
+}
+
+// Install route the Ws*Serve functions to this replay instead of the current implementation | 将 Ws*Serve 切换到回放
+func (p *Replay) Install() {
+	recorderMu.Lock()
+	defer recorderMu.Unlock()
+	if p.installed {
+		return
+	}
+	p.previous, p.installed = wsServeImpl, true
+	wsServeImpl = p.serve
+}
+
+// Uninstall route the Ws*Serve functions back to the implementation replaced by Install | 恢复安装前的实现
+func (p *Replay) Uninstall() {
+	recorderMu.Lock()
+	defer recorderMu.Unlock()
+	if !p.installed {
+		return
+	}
+	wsServeImpl, p.previous, p.installed = p.previous, nil, false
+}
+
+// replaySubscription 回放订阅
+type replaySubscription struct {
+	handler WsHandler
+	done    chan struct{}
+	stopped bool
+}
+
+// serve subscribe handler to the records of the stream of cfg, until done receives or Run returns
+func (p *Replay) serve(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (*wsc.Wsc, chan struct{}, error) {
+	stream := streamPath(cfg.Endpoint)
+	sub := &replaySubscription{handler: handler, done: make(chan struct{})}
+	p.mu.Lock()
+	p.handlers[stream] = append(p.handlers[stream], sub)
+	p.mu.Unlock()
+	go func() {
+		<-sub.done
+		p.mu.Lock()
+		sub.stopped = true
+		p.mu.Unlock()
+	}()
+	return wsc.New(cfg.Endpoint), sub.done, nil
+}
+
+// Run replay the records to the subscribed handlers; records of streams nobody subscribed are skipped.
+// The subscriptions end with the replay, their done channels are closed | 开始回放, 结束后关闭所有订阅的 done
+func (p *Replay) Run(ctx context.Context) error {
+	defer p.closeSubscriptions()
+	return p.player.Run(ctx, func(record *common.Record) error {
+		if record.Source == common.RecordSourceRest {
+			if p.snapshotHandler == nil {
+				return nil
+			}
+			snapshot := new(DepthResponse)
+			if err := json.Unmarshal(record.Data, snapshot); err != nil {
+				return err
+			}
+			p.snapshotHandler(record.Stream, snapshot)
+			return nil
+		}
+		p.mu.Lock()
+		handlers := make([]WsHandler, 0, len(p.handlers[record.Stream]))
+		for _, sub := range p.handlers[record.Stream] {
+			if !sub.stopped {
+				handlers = append(handlers, sub.handler)
+			}
+		}
+		p.mu.Unlock()
+		for _, handler := range handlers {
+			handler(record.Data)
+		}
+		return nil
+	})
+}
+
+// closeSubscriptions 结束所有订阅并关闭其 done
+func (p *Replay) closeSubscriptions() {
+	p.mu.Lock()
+	handlers := p.handlers
+	p.handlers = make(map[string][]*replaySubscription)
+	p.mu.Unlock()
+	for _, subs := range handlers {
+		for _, sub := range subs {
+			close(sub.done)
+		}
+	}
+}
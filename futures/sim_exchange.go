@@ -0,0 +1,907 @@
+package futures
+
+import (
+	"fmt"
+	"github.com/BobHye/binance-go/common"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// 模拟撮合与账户, 用于回测和模拟盘
+
+const (
+	simDefaultLeverage        = 20
+	simDefaultMakerCommission = 0.0002
+	simDefaultTakerCommission = 0.0005
+	simDefaultMaintMargin     = 0.004
+)
+
+var (
+	simListenKeysMu sync.Mutex
+	simListenKeys   = make(map[string]*SimExchange)
+)
+
+// simExchangeByListenKey 返回 listenKey 对应的模拟交易所, WsUserDataServe 据此将订阅转到本地
+func simExchangeByListenKey(listenKey string) *SimExchange {
+	simListenKeysMu.Lock()
+	defer simListenKeysMu.Unlock()
+	return simListenKeys[listenKey]
+}
+
+// simOrder 模拟盘中的订单
+type simOrder struct {
+	order     *Order
+	triggered bool // 条件单是否已触发
+}
+
+// simPosition 模拟盘中的持仓
+type simPosition struct {
+	symbol         string
+	side           PositionSideType
+	amount         float64 // 持仓数量, 正数为多, 负数为空
+	entryPrice     float64
+	isolatedMargin float64 // 逐仓保证金(包含在钱包余额内)
+	realized       float64 // 累计实现盈亏
+	updateTime     int64
+}
+
+// simSymbol 模拟盘中交易对的配置与行情
+type simSymbol struct {
+	leverage     int
+	marginType   MarginType
+	markPrice    float64 // 以买卖价中间价作为标记价格
+	bid          float64
+	ask          float64
+	commission   *CommissionRate
+	bracket      *LeverageBracket
+	fundingRates []*FundingRate
+	nextFunding  int
+}
+
+// SimExchange simulate the USDⓈ-M order, account and user data endpoints against a local ledger.
+// Prices come from ProcessKline/ProcessPrice, and the REST services of Client() are served locally | 模拟交易所
+type SimExchange struct {
+	mu                sync.Mutex
+	asset             string
+	walletBalance     float64
+	dualSidePosition  bool
+	now               int64
+	defaultCommission CommissionRate
+	symbols           map[string]*simSymbol
+	positions         map[string]*simPosition
+	orders            map[int64]*simOrder
+	clientOrders      map[string]int64
+	openOrders        []int64
+	trades            []*AccountTrade
+	nextOrderID       int64
+	nextTradeID       int64
+	handlers          []WsUserDataHandler
+	events            []*WsUserDataEvent
+	listenKey         string
+}
+
+// NewSimExchange create a simulated exchange whose wallet holds balance of the margin asset, e.g. USDT | 创建模拟交易所
+func NewSimExchange(asset string, balance float64) *SimExchange {
+	return &SimExchange{
+		asset:         asset,
+		walletBalance: balance,
+		defaultCommission: CommissionRate{
+			MakerCommissionRate: simDefaultMakerCommission,
+			TakerCommissionRate: simDefaultTakerCommission,
+		},
+		symbols:      make(map[string]*simSymbol),
+		positions:    make(map[string]*simPosition),
+		orders:       make(map[int64]*simOrder),
+		clientOrders: make(map[string]int64),
+		nextOrderID:  1,
+		nextTradeID:  1,
+	}
+}
+
+// SetCommissionRate set maker/taker fees of a symbol, e.g. from CommissionRateService | 设置交易对手续费率
+func (s *SimExchange) SetCommissionRate(rate *CommissionRate) *SimExchange {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.symbol(rate.Symbol).commission = rate
+	return s
+}
+
+// SetDefaultCommissionRate set maker/taker fees of symbols without their own rate | 设置默认手续费率
+func (s *SimExchange) SetDefaultCommissionRate(maker, taker float64) *SimExchange {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.defaultCommission.MakerCommissionRate = maker
+	s.defaultCommission.TakerCommissionRate = taker
+	return s
+}
+
+// SetLeverageBracket set notional tiers of symbols, e.g. from GetLeverageBracketService | 设置杠杆分层
+func (s *SimExchange) SetLeverageBracket(brackets ...*LeverageBracket) *SimExchange {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, b := range brackets {
+		s.symbol(b.Symbol).bracket = b
+	}
+	return s
+}
+
+// SetFundingRates set funding history of symbols, e.g. from FundingRateService | 设置资金费率历史
+func (s *SimExchange) SetFundingRates(rates []*FundingRate) *SimExchange {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range rates {
+		sym := s.symbol(r.Symbol)
+		sym.fundingRates = append(sym.fundingRates, r)
+	}
+	for _, sym := range s.symbols {
+		sort.SliceStable(sym.fundingRates, func(i, j int) bool {
+			return sym.fundingRates[i].FundingTime < sym.fundingRates[j].FundingTime
+		})
+		for sym.nextFunding < len(sym.fundingRates) && sym.fundingRates[sym.nextFunding].FundingTime <= s.now {
+			sym.nextFunding++
+		}
+	}
+	return s
+}
+
+// SetTime set the simulated clock in milliseconds | 设置模拟时钟(毫秒)
+func (s *SimExchange) SetTime(now int64) *SimExchange {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+	return s
+}
+
+// Time return the simulated clock in milliseconds | 返回模拟时钟
+func (s *SimExchange) Time() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.now
+}
+
+// Client return a client whose REST requests are served by the simulated exchange | 返回由模拟交易所响应请求的客户端
+func (s *SimExchange) Client() *Client {
+	c := NewClient("", "")
+	c.BaseURL = "http://sim.local"
+	c.do = s.do
+	return c
+}
+
+// userStream 返回本交易所的 listenKey, 首次调用时注册
+func (s *SimExchange) userStream() string {
+	if s.listenKey != "" {
+		return s.listenKey
+	}
+	simListenKeysMu.Lock()
+	defer simListenKeysMu.Unlock()
+	s.listenKey = fmt.Sprintf("sim%d", len(simListenKeys)+1)
+	simListenKeys[s.listenKey] = s
+	return s.listenKey
+}
+
+// WsUserDataServe deliver the simulated user data events to handler, in the goroutine that produced them | 订阅模拟账户信息推送
+func (s *SimExchange) WsUserDataServe(handler WsUserDataHandler, errHandler ErrHandler) (done chan struct{}, err error) {
+	s.mu.Lock()
+	s.handlers = append(s.handlers, handler)
+	s.mu.Unlock()
+	return make(chan struct{}), nil
+}
+
+// ProcessKline move the price of symbol through a closed kline, filling orders, paying funding and liquidating.
+// The path within the bar is open, low, high, close for up bars and open, high, low, close for down bars | 以K线推进行情
+func (s *SimExchange) ProcessKline(symbol string, k *Kline) {
+	s.mu.Lock()
+	path := []float64{k.Open, k.Low, k.High, k.Close}
+	if k.Close < k.Open {
+		path = []float64{k.Open, k.High, k.Low, k.Close}
+	}
+	times := []int64{k.OpenTime, k.OpenTime, k.CloseTime, k.CloseTime}
+	for i, price := range path {
+		s.moveTo(symbol, price, times[i])
+	}
+	events := s.flush()
+	s.mu.Unlock()
+	s.dispatch(events)
+}
+
+// ProcessPrice move the price of symbol to price at time, e.g. from a trade or mark price event | 以成交价推进行情
+func (s *SimExchange) ProcessPrice(symbol string, price float64, time int64) {
+	s.mu.Lock()
+	s.moveTo(symbol, price, time)
+	events := s.flush()
+	s.mu.Unlock()
+	s.dispatch(events)
+}
+
+// ProcessBookTicker move the best bid and ask of symbol, e.g. from a live book ticker stream.
+// Buy orders match against the ask and sell orders against the bid | 以最优挂单推进行情
+func (s *SimExchange) ProcessBookTicker(symbol string, bid, ask float64, time int64) {
+	s.mu.Lock()
+	s.moveBook(symbol, bid, ask, time)
+	events := s.flush()
+	s.mu.Unlock()
+	s.dispatch(events)
+}
+
+// symbol 返回交易对配置, 不存在时按默认值创建
+func (s *SimExchange) symbol(symbol string) *simSymbol {
+	sym, ok := s.symbols[symbol]
+	if !ok {
+		sym = &simSymbol{leverage: simDefaultLeverage, marginType: MarginTypeCrossed}
+		s.symbols[symbol] = sym
+	}
+	return sym
+}
+
+// position 返回持仓, 不存在时创建
+func (s *SimExchange) position(symbol string, side PositionSideType) *simPosition {
+	key := symbol + "|" + string(side)
+	pos, ok := s.positions[key]
+	if !ok {
+		pos = &simPosition{symbol: symbol, side: side}
+		s.positions[key] = pos
+	}
+	return pos
+}
+
+// sortedPositions 按交易对和方向排序的持仓列表
+func (s *SimExchange) sortedPositions() []*simPosition {
+	res := make([]*simPosition, 0, len(s.positions))
+	for _, pos := range s.positions {
+		res = append(res, pos)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].symbol != res[j].symbol {
+			return res[i].symbol < res[j].symbol
+		}
+		return res[i].side < res[j].side
+	})
+	return res
+}
+
+func (s *SimExchange) commission(symbol string) *CommissionRate {
+	if rate := s.symbol(symbol).commission; rate != nil {
+		return rate
+	}
+	return &s.defaultCommission
+}
+
+// bracket 返回名义价值所在的杠杆分层
+func (s *SimExchange) bracket(symbol string, notional float64) *Bracket {
+	lb := s.symbol(symbol).bracket
+	if lb == nil || len(lb.Brackets) == 0 {
+		return nil
+	}
+	for i := range lb.Brackets {
+		if notional < lb.Brackets[i].NotionalCap {
+			return &lb.Brackets[i]
+		}
+	}
+	return &lb.Brackets[len(lb.Brackets)-1]
+}
+
+// maintMargin 维持保证金 = 名义价值 * 维持保证金率 - 速算数
+func (s *SimExchange) maintMargin(symbol string, notional float64) float64 {
+	b := s.bracket(symbol, notional)
+	if b == nil {
+		return notional * simDefaultMaintMargin
+	}
+	return notional*b.MaintMarginRatio - b.Cum
+}
+
+// maxNotional 当前杠杆倍数下允许的最大名义价值, 0 表示不限制
+func (s *SimExchange) maxNotional(symbol string, leverage int) float64 {
+	lb := s.symbol(symbol).bracket
+	if lb == nil {
+		return 0
+	}
+	res := 0.0
+	for _, b := range lb.Brackets {
+		if b.InitialLeverage >= leverage && b.NotionalCap > res {
+			res = b.NotionalCap
+		}
+	}
+	return res
+}
+
+func (s *SimExchange) unrealizedPnL(pos *simPosition) float64 {
+	return (s.symbol(pos.symbol).markPrice - pos.entryPrice) * pos.amount
+}
+
+func (s *SimExchange) notional(pos *simPosition) float64 {
+	return math.Abs(pos.amount) * s.symbol(pos.symbol).markPrice
+}
+
+// crossWalletBalance 除去逐仓保证金的钱包余额
+func (s *SimExchange) crossWalletBalance() float64 {
+	res := s.walletBalance
+	for _, pos := range s.positions {
+		res -= pos.isolatedMargin
+	}
+	return res
+}
+
+// openOrderMargin 挂单所需起始保证金
+func (s *SimExchange) openOrderMargin() float64 {
+	res := 0.0
+	for _, id := range s.openOrders {
+		o := s.orders[id].order
+		if o.ReduceOnly || o.ClosePosition {
+			continue
+		}
+		price := o.Price
+		if price == 0 {
+			price = o.StopPrice
+		}
+		if price == 0 {
+			price = s.symbol(o.Symbol).markPrice
+		}
+		res += (o.OrigQuantity - o.ExecutedQuantity) * price / float64(s.symbol(o.Symbol).leverage)
+	}
+	return res
+}
+
+// availableBalance 下单可用余额
+func (s *SimExchange) availableBalance() float64 {
+	res := s.crossWalletBalance() - s.openOrderMargin()
+	for _, pos := range s.positions {
+		if pos.amount == 0 || s.symbol(pos.symbol).marginType == MarginTypeIsolated {
+			continue
+		}
+		res += s.unrealizedPnL(pos) - s.notional(pos)/float64(s.symbol(pos.symbol).leverage)
+	}
+	return res
+}
+
+// orderPosition 返回订单作用的持仓
+func (s *SimExchange) orderPosition(o *Order) *simPosition {
+	return s.position(o.Symbol, o.PositionSide)
+}
+
+// openingQuantity 订单中开仓(增加持仓)的数量
+func (s *SimExchange) openingQuantity(o *Order, qty float64) float64 {
+	pos := s.orderPosition(o)
+	switch o.PositionSide {
+	case PositionSideTypeLong:
+		if o.Side == SideTypeBuy {
+			return qty
+		}
+		return 0
+	case PositionSideTypeShort:
+		if o.Side == SideTypeSell {
+			return qty
+		}
+		return 0
+	}
+	if (o.Side == SideTypeBuy) == (pos.amount >= 0) {
+		return qty
+	}
+	return math.Max(0, qty-math.Abs(pos.amount))
+}
+
+// closeableQuantity 订单可以平仓的数量
+func (s *SimExchange) closeableQuantity(o *Order) float64 {
+	pos := s.orderPosition(o)
+	if pos.amount > 0 && o.Side == SideTypeSell || pos.amount < 0 && o.Side == SideTypeBuy {
+		return math.Abs(pos.amount)
+	}
+	return 0
+}
+
+// checkOrder 检查新订单的保证金和持仓上限
+func (s *SimExchange) checkOrder(o *Order, price float64) error {
+	if o.ReduceOnly || o.ClosePosition {
+		return nil
+	}
+	qty := s.openingQuantity(o, o.OrigQuantity)
+	if qty == 0 {
+		return nil
+	}
+	sym := s.symbol(o.Symbol)
+	pos := s.orderPosition(o)
+	if limit := s.maxNotional(o.Symbol, sym.leverage); limit > 0 && (math.Abs(pos.amount)+qty)*price > limit {
+		return &common.APIError{Code: -2027, Message: "Exceeded the maximum allowable position at current leverage."}
+	}
+	required := qty * price * (1/float64(sym.leverage) + s.commission(o.Symbol).TakerCommissionRate)
+	if required > s.availableBalance() {
+		return &common.APIError{Code: -2019, Message: "Margin is insufficient."}
+	}
+	return nil
+}
+
+// placeOrder 接受新订单, 可立即成交的部分按吃单成交
+func (s *SimExchange) placeOrder(o *Order) error {
+	sym := s.symbol(o.Symbol)
+	if sym.markPrice == 0 {
+		return &common.APIError{Code: -1, Message: "No market price for symbol."}
+	}
+	if s.dualSidePosition != (o.PositionSide != PositionSideTypeBoth) {
+		return &common.APIError{Code: -4061, Message: "Order's position side does not match user's setting."}
+	}
+	if o.ReduceOnly && s.dualSidePosition {
+		return &common.APIError{Code: -1106, Message: "Parameter 'reduceonly' sent when not required."}
+	}
+	if o.ClientOrderID == "" {
+		o.ClientOrderID = fmt.Sprintf("sim%d", s.nextOrderID)
+	}
+	if id, ok := s.clientOrders[o.ClientOrderID]; ok && s.isOpen(id) {
+		return &common.APIError{Code: -4015, Message: "Client order id is not valid."}
+	}
+	switch o.Type {
+	case OrderTypeLimit, OrderTypeMarket, OrderTypeStop, OrderTypeStopMarket, OrderTypeTakeProfit, OrderTypeTakeProfitMarket:
+	default:
+		return &common.APIError{Code: -1116, Message: "Invalid orderType."}
+	}
+	if o.ReduceOnly && s.closeableQuantity(o) == 0 {
+		return &common.APIError{Code: -2022, Message: "ReduceOnly Order is rejected."}
+	}
+	switch PriceMatchType(o.PriceMatch) {
+	case PriceMatchTypeNone:
+	case PriceMatchTypeOpponent:
+		o.Price = s.takerPrice(o.Symbol, o.Side)
+	case PriceMatchTypeQueue:
+		o.Price = s.makerPrice(o.Symbol, o.Side)
+	default:
+		// 只有最优挂单, 无法计算多档价格
+		return &common.APIError{Code: -1000, Message: fmt.Sprintf("priceMatch %s is not supported in simulation.", o.PriceMatch)}
+	}
+	price := o.Price
+	if price == 0 {
+		price = s.takerPrice(o.Symbol, o.Side)
+	}
+	if err := s.checkOrder(o, price); err != nil {
+		return err
+	}
+
+	o.OrderID = s.nextOrderID
+	s.nextOrderID++
+	o.Status = OrderStatusTypeNew
+	o.OrigType = string(o.Type)
+	o.Time = s.now
+	o.UpdateTime = s.now
+	so := &simOrder{order: o}
+	s.orders[o.OrderID] = so
+	s.clientOrders[o.ClientOrderID] = o.OrderID
+	s.orderEvent(o, OrderExecutionTypeNew, 0, 0, 0, false, 0)
+
+	taker := s.takerPrice(o.Symbol, o.Side)
+	switch o.Type {
+	case OrderTypeMarket:
+		s.fill(so, taker, false)
+		return nil
+	case OrderTypeLimit:
+		marketable := o.Side == SideTypeBuy && o.Price >= taker || o.Side == SideTypeSell && o.Price <= taker
+		switch {
+		case marketable && o.TimeInForce == TimeInForceTypeGTX:
+			s.finish(so, OrderStatusTypeExpired, OrderExecutionTypeExpired)
+		case marketable:
+			s.fill(so, taker, false)
+		case o.TimeInForce == TimeInForceTypeIOC || o.TimeInForce == TimeInForceTypeFOK:
+			s.finish(so, OrderStatusTypeExpired, OrderExecutionTypeExpired)
+		default:
+			s.openOrders = append(s.openOrders, o.OrderID)
+		}
+		return nil
+	}
+	if s.triggered(o, taker, taker) {
+		return &common.APIError{Code: -2021, Message: "Order would immediately trigger."}
+	}
+	s.openOrders = append(s.openOrders, o.OrderID)
+	return nil
+}
+
+func (s *SimExchange) isOpen(orderID int64) bool {
+	for _, id := range s.openOrders {
+		if id == orderID {
+			return true
+		}
+	}
+	return false
+}
+
+// removeOpen 从挂单列表中移除订单
+func (s *SimExchange) removeOpen(orderID int64) {
+	for i, id := range s.openOrders {
+		if id == orderID {
+			s.openOrders = append(s.openOrders[:i], s.openOrders[i+1:]...)
+			return
+		}
+	}
+}
+
+// cancelOrder 撤销挂单
+func (s *SimExchange) cancelOrder(so *simOrder) error {
+	if !s.isOpen(so.order.OrderID) {
+		return &common.APIError{Code: -2011, Message: "Unknown order sent."}
+	}
+	s.finish(so, OrderStatusTypeCanceled, OrderExecutionTypeCanceled)
+	return nil
+}
+
+// finish 订单以撤销或过期结束
+func (s *SimExchange) finish(so *simOrder, status OrderStatusType, execution OrderExecutionType) {
+	s.removeOpen(so.order.OrderID)
+	so.order.Status = status
+	so.order.UpdateTime = s.now
+	s.orderEvent(so.order, execution, 0, 0, 0, false, 0)
+}
+
+// triggered 判断条件单在价格区间 [from, to] 内是否触发
+func (s *SimExchange) triggered(o *Order, from, to float64) bool {
+	lo, hi := math.Min(from, to), math.Max(from, to)
+	switch o.Type {
+	case OrderTypeStop, OrderTypeStopMarket:
+		if o.Side == SideTypeBuy {
+			return hi >= o.StopPrice
+		}
+		return lo <= o.StopPrice
+	case OrderTypeTakeProfit, OrderTypeTakeProfitMarket:
+		if o.Side == SideTypeBuy {
+			return lo <= o.StopPrice
+		}
+		return hi >= o.StopPrice
+	}
+	return false
+}
+
+// moveTo 将交易对价格推进到 price
+func (s *SimExchange) moveTo(symbol string, price float64, time int64) {
+	s.moveBook(symbol, price, price, time)
+}
+
+// moveBook 将最优买卖价推进到 bid/ask, 买单按卖一价撮合, 卖单按买一价撮合, 然后处理资金费和强平
+func (s *SimExchange) moveBook(symbol string, bid, ask float64, time int64) {
+	if time > s.now {
+		s.now = time
+	}
+	sym := s.symbol(symbol)
+	fromBid, fromAsk := sym.bid, sym.ask
+	if sym.markPrice == 0 {
+		fromBid, fromAsk = bid, ask
+	}
+	for _, id := range append([]int64(nil), s.openOrders...) {
+		so := s.orders[id]
+		o := so.order
+		if o.Symbol != symbol {
+			continue
+		}
+		if o.TimeInForce == TimeInForceTypeGTD && s.now >= o.GoodTillDate {
+			s.finish(so, OrderStatusTypeExpired, OrderExecutionTypeExpired)
+			continue
+		}
+		from, to := fromBid, bid
+		if o.Side == SideTypeBuy {
+			from, to = fromAsk, ask
+		}
+		lo, hi := math.Min(from, to), math.Max(from, to)
+		if o.Type != OrderTypeLimit && !so.triggered {
+			if !s.triggered(o, from, to) {
+				continue
+			}
+			so.triggered = true
+			if o.Type == OrderTypeStopMarket || o.Type == OrderTypeTakeProfitMarket {
+				// 跳空越过触发价时按区间起点成交
+				fill := o.StopPrice
+				if o.StopPrice < lo || o.StopPrice > hi {
+					fill = from
+				}
+				s.removeOpen(id)
+				sym.markPrice = fill
+				s.fill(so, fill, false)
+				continue
+			}
+		}
+		if o.Side == SideTypeBuy && lo <= o.Price || o.Side == SideTypeSell && hi >= o.Price {
+			s.removeOpen(id)
+			sym.markPrice = o.Price
+			s.fill(so, o.Price, true)
+		}
+	}
+	sym.bid, sym.ask = bid, ask
+	sym.markPrice = (bid + ask) / 2
+	s.payFunding(symbol, time)
+	s.liquidate()
+}
+
+// makerPrice 同向最优价, 买单为买一价, 卖单为卖一价
+func (s *SimExchange) makerPrice(symbol string, side SideType) float64 {
+	if side == SideTypeBuy {
+		return s.symbol(symbol).bid
+	}
+	return s.symbol(symbol).ask
+}
+
+// takerPrice 吃单成交价, 买单为卖一价, 卖单为买一价
+func (s *SimExchange) takerPrice(symbol string, side SideType) float64 {
+	if side == SideTypeBuy {
+		return s.symbol(symbol).ask
+	}
+	return s.symbol(symbol).bid
+}
+
+// fill 订单全部成交
+func (s *SimExchange) fill(so *simOrder, price float64, maker bool) {
+	o := so.order
+	qty := o.OrigQuantity - o.ExecutedQuantity
+	if o.ClosePosition || o.ReduceOnly {
+		qty = math.Min(qty, s.closeableQuantity(o))
+		if o.ClosePosition {
+			qty = s.closeableQuantity(o)
+		}
+	}
+	if qty <= 0 {
+		s.finish(so, OrderStatusTypeExpired, OrderExecutionTypeExpired)
+		return
+	}
+	rate := s.commission(o.Symbol).TakerCommissionRate
+	if maker {
+		rate = s.commission(o.Symbol).MakerCommissionRate
+	}
+	fee := price * qty * rate
+	realized := s.applyFill(s.orderPosition(o), o.Side, qty, price)
+	s.walletBalance += realized - fee
+
+	o.AvgPrice = (o.AvgPrice*o.ExecutedQuantity + price*qty) / (o.ExecutedQuantity + qty)
+	o.ExecutedQuantity += qty
+	o.CumQuote += price * qty
+	o.Status = OrderStatusTypeFilled
+	o.UpdateTime = s.now
+
+	tradeID := s.nextTradeID
+	s.nextTradeID++
+	s.trades = append(s.trades, &AccountTrade{
+		Buyer:           o.Side == SideTypeBuy,
+		Commission:      formatFloat(fee),
+		CommissionAsset: s.asset,
+		ID:              tradeID,
+		Maker:           maker,
+		OrderID:         o.OrderID,
+		Price:           formatFloat(price),
+		Quantity:        formatFloat(qty),
+		QuoteQuantity:   formatFloat(price * qty),
+		RealizedPnl:     formatFloat(realized),
+		Side:            o.Side,
+		PositionSide:    o.PositionSide,
+		Symbol:          o.Symbol,
+		Time:            s.now,
+	})
+	s.orderEvent(o, OrderExecutionTypeTrade, qty, price, fee, maker, realized)
+	s.accountEvent(UserDataEventReasonTypeOrder, -fee+realized, s.orderPosition(o))
+}
+
+// applyFill 按成交更新持仓, 返回实现盈亏
+func (s *SimExchange) applyFill(pos *simPosition, side SideType, qty, price float64) (realized float64) {
+	delta := qty
+	if side == SideTypeSell {
+		delta = -qty
+	}
+	leverage := float64(s.symbol(pos.symbol).leverage)
+	isolated := s.symbol(pos.symbol).marginType == MarginTypeIsolated
+	if pos.amount == 0 || (pos.amount > 0) == (delta > 0) {
+		pos.entryPrice = (pos.entryPrice*math.Abs(pos.amount) + price*qty) / (math.Abs(pos.amount) + qty)
+		pos.amount += delta
+		if isolated {
+			pos.isolatedMargin += price * qty / leverage
+		}
+	} else {
+		closed := math.Min(qty, math.Abs(pos.amount))
+		sign := math.Copysign(1, pos.amount)
+		realized = (price - pos.entryPrice) * closed * sign
+		if isolated {
+			pos.isolatedMargin -= pos.isolatedMargin * closed / math.Abs(pos.amount)
+		}
+		pos.amount -= sign * closed
+		if rest := qty - closed; rest > 0 {
+			pos.amount = math.Copysign(rest, delta)
+			pos.entryPrice = price
+			if isolated {
+				pos.isolatedMargin = price * rest / leverage
+			}
+		}
+	}
+	if pos.amount == 0 {
+		pos.entryPrice = 0
+		pos.isolatedMargin = 0
+	}
+	pos.realized += realized
+	pos.updateTime = s.now
+	return realized
+}
+
+// payFunding 结算到期的资金费
+func (s *SimExchange) payFunding(symbol string, time int64) {
+	sym := s.symbol(symbol)
+	for sym.nextFunding < len(sym.fundingRates) && sym.fundingRates[sym.nextFunding].FundingTime <= time {
+		rate := sym.fundingRates[sym.nextFunding].FundingRate
+		sym.nextFunding++
+		for _, pos := range s.sortedPositions() {
+			if pos.symbol != symbol || pos.amount == 0 {
+				continue
+			}
+			payment := -pos.amount * sym.markPrice * rate
+			s.walletBalance += payment
+			if sym.marginType == MarginTypeIsolated {
+				pos.isolatedMargin += payment
+			}
+			s.accountEvent(UserDataEventReasonTypeFundingFee, payment, pos)
+		}
+	}
+}
+
+// liquidate 检查保证金, 不足维持保证金时按标记价格强平
+func (s *SimExchange) liquidate() {
+	crossBalance := s.crossWalletBalance()
+	crossMaint := 0.0
+	var cross []*simPosition
+	for _, pos := range s.sortedPositions() {
+		if pos.amount == 0 {
+			continue
+		}
+		maint := s.maintMargin(pos.symbol, s.notional(pos))
+		if s.symbol(pos.symbol).marginType == MarginTypeIsolated {
+			if pos.isolatedMargin+s.unrealizedPnL(pos) <= maint {
+				margin := pos.isolatedMargin
+				realized := s.closePosition(pos)
+				if remain := margin + realized; remain < 0 {
+					s.clearInsurance(remain)
+				} else {
+					s.clearInsurance(math.Min(remain, maint))
+				}
+			}
+			continue
+		}
+		crossBalance += s.unrealizedPnL(pos)
+		crossMaint += maint
+		cross = append(cross, pos)
+	}
+	if crossMaint == 0 || crossBalance > crossMaint {
+		return
+	}
+	for _, pos := range cross {
+		s.closePosition(pos)
+	}
+	if remain := s.crossWalletBalance(); remain < 0 {
+		s.clearInsurance(remain)
+	} else {
+		s.clearInsurance(math.Min(remain, crossMaint))
+	}
+}
+
+// closePosition 以强平单按标记价格平掉持仓
+func (s *SimExchange) closePosition(pos *simPosition) (realized float64) {
+	side := SideTypeSell
+	if pos.amount < 0 {
+		side = SideTypeBuy
+	}
+	qty := math.Abs(pos.amount)
+	o := &Order{
+		ClientOrderID: fmt.Sprintf("autoclose-%d", s.now),
+		OrderID:       s.nextOrderID,
+		OrigQuantity:  qty,
+		Side:          side,
+		PositionSide:  pos.side,
+		Symbol:        pos.symbol,
+		Time:          s.now,
+		TimeInForce:   TimeInForceTypeIOC,
+		Type:          OrderTypeMarket,
+		OrigType:      string(OrderTypeMarket),
+		ReduceOnly:    true,
+		Status:        OrderStatusTypeNew,
+	}
+	s.nextOrderID++
+	so := &simOrder{order: o}
+	s.orders[o.OrderID] = so
+	s.clientOrders[o.ClientOrderID] = o.OrderID
+	before := pos.realized
+	s.fill(so, s.takerPrice(pos.symbol, side), false)
+	return pos.realized - before
+}
+
+// clearInsurance 强平后剩余保证金归入保险基金, 穿仓亏损(amount 为负)由保险基金承担
+func (s *SimExchange) clearInsurance(amount float64) {
+	if amount == 0 {
+		return
+	}
+	s.walletBalance -= amount
+	s.accountEvent(UserDataEventReasonTypeInsuranceClear, -amount)
+}
+
+// orderEvent 生成 ORDER_TRADE_UPDATE 推送
+func (s *SimExchange) orderEvent(o *Order, execution OrderExecutionType, qty, price, fee float64, maker bool, realized float64) {
+	update := WsOrderTradeUpdate{
+		Symbol:                  o.Symbol,
+		ClientOrderID:           o.ClientOrderID,
+		Side:                    o.Side,
+		Type:                    o.Type,
+		TimeInForce:             o.TimeInForce,
+		OriginalQty:             o.OrigQuantity,
+		OriginalPrice:           o.Price,
+		AveragePrice:            o.AvgPrice,
+		StopPrice:               o.StopPrice,
+		ExecutionType:           execution,
+		Status:                  o.Status,
+		ID:                      o.OrderID,
+		LastFilledQty:           qty,
+		AccumulatedFilledQty:    o.ExecutedQuantity,
+		LastFilledPrice:         price,
+		TradeTime:               s.now,
+		IsMaker:                 maker,
+		IsReduceOnly:            o.ReduceOnly,
+		WorkingType:             o.WorkingType,
+		OriginalType:            OrderType(o.OrigType),
+		PositionSide:            o.PositionSide,
+		IsClosingPosition:       o.ClosePosition,
+		RealizedPnL:             realized,
+		SelfTradePreventionMode: o.SelfTradePreventionMode,
+		PriceMatch:              o.PriceMatch,
+		GoodTillDate:            o.GoodTillDate,
+	}
+	if execution == OrderExecutionTypeTrade {
+		update.CommissionAsset = s.asset
+		update.Commission = fee
+		update.TradeID = s.nextTradeID - 1
+	}
+	s.events = append(s.events, &WsUserDataEvent{
+		Event:            UserDataEventTypeOrderTradeUpdate,
+		Time:             s.now,
+		TransactionTime:  s.now,
+		OrderTradeUpdate: update,
+	})
+}
+
+// accountEvent 生成 ACCOUNT_UPDATE 推送
+func (s *SimExchange) accountEvent(reason UserDataEventReasonType, change float64, positions ...*simPosition) {
+	update := WsAccountUpdate{
+		Reason: reason,
+		Balances: []WsBalance{{
+			Asset:              s.asset,
+			Balance:            s.walletBalance,
+			CrossWalletBalance: s.crossWalletBalance(),
+			ChangeBalance:      change,
+		}},
+	}
+	for _, pos := range positions {
+		update.Positions = append(update.Positions, WsPosition{
+			Symbol:              pos.symbol,
+			Side:                pos.side,
+			Amount:              pos.amount,
+			MarginType:          s.symbol(pos.symbol).marginType,
+			IsolatedWallet:      pos.isolatedMargin,
+			MarkPrice:           s.symbol(pos.symbol).markPrice,
+			UnrealizedPnL:       s.unrealizedPnL(pos),
+			EntryPrice:          pos.entryPrice,
+			AccumulatedRealized: pos.realized,
+			BreakEvenPrice:      pos.entryPrice,
+		})
+	}
+	s.events = append(s.events, &WsUserDataEvent{
+		Event:           UserDataEventTypeAccountUpdate,
+		Time:            s.now,
+		TransactionTime: s.now,
+		AccountUpdate:   update,
+	})
+}
+
+// flush 取出待推送事件, 需持有锁
+func (s *SimExchange) flush() (events []*WsUserDataEvent) {
+	events, s.events = s.events, nil
+	return events
+}
+
+// dispatch 在锁外推送事件, 处理器可以继续调用 REST 接口
+func (s *SimExchange) dispatch(events []*WsUserDataEvent) {
+	s.mu.Lock()
+	handlers := append([]WsUserDataHandler(nil), s.handlers...)
+	s.mu.Unlock()
+	for _, event := range events {
+		for _, handler := range handlers {
+			handler(event)
+		}
+	}
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
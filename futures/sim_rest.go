@@ -0,0 +1,538 @@
+package futures
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/BobHye/binance-go/common"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 模拟交易所的 REST 接口
+
+// do 在本地响应 Client 发出的请求
+func (s *SimExchange) do(req *http.Request) (*http.Response, error) {
+	values := req.URL.Query()
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range form {
+			values[k] = append(values[k], v...)
+		}
+	}
+
+	s.mu.Lock()
+	res, err := s.route(req.Method, req.URL.Path, values)
+	events := s.flush()
+	s.mu.Unlock()
+	s.dispatch(events)
+
+	status := http.StatusOK
+	if err != nil {
+		status = http.StatusBadRequest
+		apiErr, ok := err.(*common.APIError)
+		if !ok {
+			apiErr = &common.APIError{Code: -1000, Message: err.Error()}
+		}
+		res = apiErr
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:     http.StatusText(status),
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(data)),
+		Request:    req,
+	}, nil
+}
+
+// route 按请求方法和路径分发, 需持有锁
+func (s *SimExchange) route(method, path string, values url.Values) (interface{}, error) {
+	switch method + " " + path {
+	case "GET /fapi/v1/ping":
+		return struct{}{}, nil
+	case "GET /fapi/v1/time":
+		return map[string]int64{"serverTime": s.now}, nil
+	case "POST /fapi/v1/listenKey", "PUT /fapi/v1/listenKey", "DELETE /fapi/v1/listenKey":
+		return map[string]string{"listenKey": s.userStream()}, nil
+	case "POST /fapi/v1/order":
+		o, err := s.newOrder(values)
+		if err != nil {
+			return nil, err
+		}
+		return o, s.placeOrder(o)
+	case "POST /fapi/v1/batchOrders":
+		return s.batchOrders(values.Get("batchOrders"))
+	case "GET /fapi/v1/order", "GET /fapi/v1/openOrder":
+		so, err := s.findOrder(values)
+		if err != nil {
+			return nil, err
+		}
+		if path == "/fapi/v1/openOrder" && !s.isOpen(so.order.OrderID) {
+			return nil, &common.APIError{Code: -2013, Message: "Order does not exist."}
+		}
+		return so.order, nil
+	case "DELETE /fapi/v1/order":
+		so, err := s.findOrder(values)
+		if err != nil {
+			return nil, err
+		}
+		return so.order, s.cancelOrder(so)
+	case "DELETE /fapi/v1/batchOrders":
+		return s.cancelOrders(values)
+	case "DELETE /fapi/v1/allOpenOrders":
+		for _, id := range append([]int64(nil), s.openOrders...) {
+			if so := s.orders[id]; so.order.Symbol == values.Get("symbol") {
+				_ = s.cancelOrder(so)
+			}
+		}
+		return map[string]interface{}{"code": 200, "msg": "The operation of cancel all open order is done."}, nil
+	case "GET /fapi/v1/openOrders":
+		res := make([]*Order, 0)
+		for _, id := range s.openOrders {
+			if o := s.orders[id].order; values.Get("symbol") == "" || o.Symbol == values.Get("symbol") {
+				res = append(res, o)
+			}
+		}
+		return res, nil
+	case "GET /fapi/v1/allOrders":
+		return s.allOrders(values), nil
+	case "GET /fapi/v1/userTrades":
+		return s.userTrades(values), nil
+	case "GET /fapi/v2/account":
+		return s.account(), nil
+	case "GET /fapi/v2/balance":
+		return s.balance(), nil
+	case "GET /fapi/v2/positionRisk":
+		return s.positionRisk(values.Get("symbol")), nil
+	case "GET /fapi/v1/commissionRate":
+		rate := *s.commission(values.Get("symbol"))
+		rate.Symbol = values.Get("symbol")
+		return &rate, nil
+	case "GET /fapi/v1/leverageBracket":
+		res := make([]*LeverageBracket, 0)
+		for symbol, sym := range s.symbols {
+			if sym.bracket != nil && (values.Get("symbol") == "" || symbol == values.Get("symbol")) {
+				res = append(res, sym.bracket)
+			}
+		}
+		sort.Slice(res, func(i, j int) bool { return res[i].Symbol < res[j].Symbol })
+		return res, nil
+	case "POST /fapi/v1/leverage":
+		return s.changeLeverage(values.Get("symbol"), values.Get("leverage"))
+	case "POST /fapi/v1/marginType":
+		return s.changeMarginType(values.Get("symbol"), MarginType(values.Get("marginType")))
+	case "GET /fapi/v1/positionSide/dual":
+		return &PositionMode{DualSidePosition: s.dualSidePosition}, nil
+	case "POST /fapi/v1/positionSide/dual":
+		return s.changePositionMode(values.Get("dualSidePosition") == "true")
+	}
+	return nil, &common.APIError{Code: -1000, Message: fmt.Sprintf("%s %s is not supported by the simulated exchange.", method, path)}
+}
+
+// newOrder 根据下单参数构造订单
+func (s *SimExchange) newOrder(values url.Values) (*Order, error) {
+	o := &Order{
+		Symbol:                  values.Get("symbol"),
+		Side:                    SideType(values.Get("side")),
+		Type:                    OrderType(values.Get("type")),
+		PositionSide:            PositionSideType(values.Get("positionSide")),
+		TimeInForce:             TimeInForceType(values.Get("timeInForce")),
+		ClientOrderID:           values.Get("newClientOrderId"),
+		WorkingType:             WorkingType(values.Get("workingType")),
+		ReduceOnly:              values.Get("reduceOnly") == "true",
+		ClosePosition:           values.Get("closePosition") == "true",
+		PriceProtect:            values.Get("priceProtect") == "true",
+		PriceMatch:              values.Get("priceMatch"),
+		SelfTradePreventionMode: values.Get("selfTradePreventionMode"),
+	}
+	if o.Symbol == "" || o.Side == "" || o.Type == "" {
+		return nil, &common.APIError{Code: -1102, Message: "Mandatory parameter 'symbol', 'side' or 'type' was not sent."}
+	}
+	if o.PositionSide == "" {
+		o.PositionSide = PositionSideTypeBoth
+	}
+	if o.TimeInForce == "" && (o.Type == OrderTypeLimit || o.Type == OrderTypeStop || o.Type == OrderTypeTakeProfit) {
+		o.TimeInForce = TimeInForceTypeGTC
+	}
+	if o.WorkingType == "" {
+		o.WorkingType = WorkingTypeContractPrice
+	}
+	if o.PriceMatch == "" {
+		o.PriceMatch = string(PriceMatchTypeNone)
+	}
+	if o.SelfTradePreventionMode == "" {
+		o.SelfTradePreventionMode = string(SelfTradePreventionModeTypeNone)
+	}
+	priceMatched := o.PriceMatch != string(PriceMatchTypeNone)
+	if priceMatched && values.Get("price") != "" {
+		return nil, &common.APIError{Code: -4457, Message: "Price and priceMatch cannot be sent together."}
+	}
+	var err error
+	if o.TimeInForce == TimeInForceTypeGTD {
+		if o.GoodTillDate, err = strconv.ParseInt(values.Get("goodTillDate"), 10, 64); err != nil || o.GoodTillDate <= 0 {
+			return nil, &common.APIError{Code: -1102, Message: "Mandatory parameter 'goodTillDate' was not sent, was empty/null, or malformed."}
+		}
+	}
+	for _, f := range []struct {
+		key      string
+		dest     *float64
+		required bool
+	}{
+		{"quantity", &o.OrigQuantity, !o.ClosePosition},
+		{"price", &o.Price, !priceMatched && (o.Type == OrderTypeLimit || o.Type == OrderTypeStop || o.Type == OrderTypeTakeProfit)},
+		{"stopPrice", &o.StopPrice, o.Type != OrderTypeLimit && o.Type != OrderTypeMarket},
+	} {
+		v := values.Get(f.key)
+		if v == "" {
+			if f.required {
+				return nil, &common.APIError{Code: -1102, Message: fmt.Sprintf("Mandatory parameter '%s' was not sent, was empty/null, or malformed.", f.key)}
+			}
+			continue
+		}
+		if *f.dest, err = strconv.ParseFloat(v, 64); err != nil || *f.dest <= 0 {
+			return nil, &common.APIError{Code: -1111, Message: fmt.Sprintf("Invalid %s.", f.key)}
+		}
+	}
+	if o.ClosePosition && o.Type != OrderTypeStopMarket && o.Type != OrderTypeTakeProfitMarket {
+		return nil, &common.APIError{Code: -4136, Message: "Target strategy invalid for orderType."}
+	}
+	return o, nil
+}
+
+// batchOrders 批量下单, 每个订单单独返回结果或错误
+func (s *SimExchange) batchOrders(batch string) ([]interface{}, error) {
+	var list []map[string]interface{}
+	if err := json.Unmarshal([]byte(batch), &list); err != nil {
+		return nil, &common.APIError{Code: -1130, Message: "Data sent for parameter 'batchOrders' is not valid."}
+	}
+	res := make([]interface{}, 0, len(list))
+	for _, m := range list {
+		values := url.Values{}
+		for k, v := range m {
+			values.Set(k, fmt.Sprintf("%v", v))
+		}
+		o, err := s.newOrder(values)
+		if err == nil {
+			err = s.placeOrder(o)
+		}
+		if err != nil {
+			res = append(res, err)
+			continue
+		}
+		res = append(res, o)
+	}
+	return res, nil
+}
+
+// findOrder 按 orderId 或 origClientOrderId 查找订单
+func (s *SimExchange) findOrder(values url.Values) (*simOrder, error) {
+	id, _ := strconv.ParseInt(values.Get("orderId"), 10, 64)
+	if id == 0 {
+		id = s.clientOrders[values.Get("origClientOrderId")]
+	}
+	so, ok := s.orders[id]
+	if !ok || so.order.Symbol != values.Get("symbol") {
+		return nil, &common.APIError{Code: -2013, Message: "Order does not exist."}
+	}
+	return so, nil
+}
+
+// cancelOrders 批量撤单
+func (s *SimExchange) cancelOrders(values url.Values) ([]interface{}, error) {
+	lookups := make([]url.Values, 0)
+	if list := values.Get("orderIdList"); list != "" {
+		for _, id := range strings.Split(strings.Trim(list, "[]"), ",") {
+			lookups = append(lookups, url.Values{"symbol": {values.Get("symbol")}, "orderId": {strings.TrimSpace(id)}})
+		}
+	}
+	if list := values.Get("origClientOrderIdList"); list != "" {
+		for _, id := range strings.Fields(strings.Trim(list, "[]")) {
+			lookups = append(lookups, url.Values{"symbol": {values.Get("symbol")}, "origClientOrderId": {strings.Trim(id, `",`)}})
+		}
+	}
+	res := make([]interface{}, 0, len(lookups))
+	for _, lookup := range lookups {
+		so, err := s.findOrder(lookup)
+		if err == nil {
+			err = s.cancelOrder(so)
+		}
+		if err != nil {
+			res = append(res, err)
+			continue
+		}
+		res = append(res, so.order)
+	}
+	return res, nil
+}
+
+// timeRange 解析 startTime/endTime/limit 查询参数
+func timeRange(values url.Values, defaultLimit int) (start, end int64, limit int) {
+	start, _ = strconv.ParseInt(values.Get("startTime"), 10, 64)
+	end, _ = strconv.ParseInt(values.Get("endTime"), 10, 64)
+	if end == 0 {
+		end = math.MaxInt64
+	}
+	limit, _ = strconv.Atoi(values.Get("limit"))
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	return start, end, limit
+}
+
+// allOrders 查询所有订单
+func (s *SimExchange) allOrders(values url.Values) []*Order {
+	start, end, limit := timeRange(values, 500)
+	fromID, _ := strconv.ParseInt(values.Get("orderId"), 10, 64)
+	res := make([]*Order, 0)
+	for id := int64(1); id < s.nextOrderID && len(res) < limit; id++ {
+		so, ok := s.orders[id]
+		if !ok || id < fromID || so.order.Symbol != values.Get("symbol") || so.order.Time < start || so.order.Time > end {
+			continue
+		}
+		res = append(res, so.order)
+	}
+	return res
+}
+
+// userTrades 查询成交历史
+func (s *SimExchange) userTrades(values url.Values) []*AccountTrade {
+	start, end, limit := timeRange(values, 500)
+	fromID, _ := strconv.ParseInt(values.Get("fromId"), 10, 64)
+	res := make([]*AccountTrade, 0)
+	for _, t := range s.trades {
+		if len(res) >= limit {
+			break
+		}
+		if t.ID < fromID || t.Symbol != values.Get("symbol") || t.Time < start || t.Time > end {
+			continue
+		}
+		res = append(res, t)
+	}
+	return res
+}
+
+// changeLeverage 调整杠杆倍数
+func (s *SimExchange) changeLeverage(symbol, leverage string) (*SymbolLeverage, error) {
+	l, err := strconv.Atoi(leverage)
+	if err != nil || l < 1 || l > 125 {
+		return nil, &common.APIError{Code: -4028, Message: "Leverage is not valid."}
+	}
+	sym := s.symbol(symbol)
+	if limit := s.maxNotional(symbol, l); limit > 0 {
+		for _, pos := range s.positions {
+			if pos.symbol == symbol && s.notional(pos) > limit {
+				return nil, &common.APIError{Code: -2027, Message: "Exceeded the maximum allowable position at current leverage."}
+			}
+		}
+	}
+	sym.leverage = l
+	res := &SymbolLeverage{Leverage: l, Symbol: symbol, MaxNotionalValue: "INF"}
+	if limit := s.maxNotional(symbol, l); limit > 0 {
+		res.MaxNotionalValue = formatFloat(limit)
+	}
+	return res, nil
+}
+
+// changeMarginType 变换逐全仓模式, 有持仓或挂单时不允许
+func (s *SimExchange) changeMarginType(symbol string, marginType MarginType) (interface{}, error) {
+	if marginType != MarginTypeIsolated && marginType != MarginTypeCrossed {
+		return nil, &common.APIError{Code: -4046, Message: "Invalid margin type."}
+	}
+	sym := s.symbol(symbol)
+	if sym.marginType == marginType {
+		return nil, &common.APIError{Code: -4046, Message: "No need to change margin type."}
+	}
+	for _, pos := range s.positions {
+		if pos.symbol == symbol && pos.amount != 0 {
+			return nil, &common.APIError{Code: -4048, Message: "Margin type cannot be changed if there exists position."}
+		}
+	}
+	for _, id := range s.openOrders {
+		if s.orders[id].order.Symbol == symbol {
+			return nil, &common.APIError{Code: -4047, Message: "Margin type cannot be changed if there exists open orders."}
+		}
+	}
+	sym.marginType = marginType
+	return map[string]interface{}{"code": 200, "msg": "success"}, nil
+}
+
+// changePositionMode 变换持仓模式, 有持仓或挂单时不允许
+func (s *SimExchange) changePositionMode(dualSidePosition bool) (interface{}, error) {
+	if s.dualSidePosition == dualSidePosition {
+		return nil, &common.APIError{Code: -4059, Message: "No need to change position side."}
+	}
+	for _, pos := range s.positions {
+		if pos.amount != 0 {
+			return nil, &common.APIError{Code: -4068, Message: "Position side cannot be changed if there exists position."}
+		}
+	}
+	if len(s.openOrders) > 0 {
+		return nil, &common.APIError{Code: -4067, Message: "Position side cannot be changed if there exists open orders."}
+	}
+	s.dualSidePosition = dualSidePosition
+	return map[string]interface{}{"code": 200, "msg": "success"}, nil
+}
+
+// positionRisk 用户持仓风险
+func (s *SimExchange) positionRisk(symbol string) []*PositionRisk {
+	res := make([]*PositionRisk, 0)
+	for _, pos := range s.sortedPositions() {
+		if symbol != "" && pos.symbol != symbol {
+			continue
+		}
+		sym := s.symbol(pos.symbol)
+		risk := &PositionRisk{
+			Symbol:           pos.symbol,
+			PositionAmt:      pos.amount,
+			EntryPrice:       pos.entryPrice,
+			BreakEvenPrice:   pos.entryPrice,
+			MarkPrice:        sym.markPrice,
+			UnRealizedProfit: s.unrealizedPnL(pos),
+			Leverage:         sym.leverage,
+			MaxNotionalValue: s.maxNotional(pos.symbol, sym.leverage),
+			MarginType:       strings.ToLower(string(sym.marginType)),
+			IsolatedMargin:   pos.isolatedMargin + s.unrealizedPnL(pos),
+			PositionSide:     pos.side,
+			Notional:         pos.amount * sym.markPrice,
+			IsolatedWallet:   pos.isolatedMargin,
+			UpdateTime:       pos.updateTime,
+		}
+		if sym.marginType != MarginTypeIsolated {
+			risk.IsolatedMargin = 0
+		}
+		risk.LiquidationPrice = s.liquidationPrice(pos)
+		res = append(res, risk)
+	}
+	return res
+}
+
+// liquidationPrice 参考强平价格, 只考虑本持仓
+func (s *SimExchange) liquidationPrice(pos *simPosition) float64 {
+	if pos.amount == 0 {
+		return 0
+	}
+	margin := pos.isolatedMargin
+	if s.symbol(pos.symbol).marginType != MarginTypeIsolated {
+		margin = s.crossWalletBalance()
+		for _, other := range s.positions {
+			if other != pos && other.amount != 0 && s.symbol(other.symbol).marginType != MarginTypeIsolated {
+				margin += s.unrealizedPnL(other) - s.maintMargin(other.symbol, s.notional(other))
+			}
+		}
+	}
+	// margin + (p - entry) * amt = |amt| * p * mmr - cum
+	mmr, cum := simDefaultMaintMargin, 0.0
+	if b := s.bracket(pos.symbol, s.notional(pos)); b != nil {
+		mmr, cum = b.MaintMarginRatio, b.Cum
+	}
+	abs := math.Abs(pos.amount)
+	price := (margin + cum - pos.entryPrice*pos.amount) / (abs*mmr - pos.amount)
+	if price < 0 {
+		return 0
+	}
+	return price
+}
+
+// account 账户信息
+func (s *SimExchange) account() *Account {
+	var unrealized, crossUnrealized, maint, positionMargin float64
+	positions := make([]*AccountPosition, 0)
+	for _, pos := range s.sortedPositions() {
+		sym := s.symbol(pos.symbol)
+		pnl := s.unrealizedPnL(pos)
+		notional := s.notional(pos)
+		mm := 0.0
+		if pos.amount != 0 {
+			mm = s.maintMargin(pos.symbol, notional)
+		}
+		im := notional / float64(sym.leverage)
+		unrealized += pnl
+		maint += mm
+		positionMargin += im
+		if sym.marginType != MarginTypeIsolated {
+			crossUnrealized += pnl
+		}
+		positions = append(positions, &AccountPosition{
+			Isolated:              sym.marginType == MarginTypeIsolated,
+			Leverage:              strconv.Itoa(sym.leverage),
+			InitialMargin:         im,
+			MaintMargin:           mm,
+			PositionInitialMargin: im,
+			Symbol:                pos.symbol,
+			UnrealizedProfit:      pnl,
+			EntryPrice:            pos.entryPrice,
+			MaxNotional:           s.maxNotional(pos.symbol, sym.leverage),
+			PositionSide:          pos.side,
+			PositionAmt:           pos.amount,
+			Notional:              pos.amount * sym.markPrice,
+			IsolatedWallet:        formatFloat(pos.isolatedMargin),
+			UpdateTime:            pos.updateTime,
+		})
+	}
+	orderMargin := s.openOrderMargin()
+	available := s.availableBalance()
+	return &Account{
+		Assets: []*AccountAsset{{
+			Asset:                  s.asset,
+			InitialMargin:          positionMargin + orderMargin,
+			MaintMargin:            maint,
+			MarginBalance:          s.walletBalance + unrealized,
+			MaxWithdrawAmount:      math.Max(0, available),
+			OpenOrderInitialMargin: orderMargin,
+			PositionInitialMargin:  positionMargin,
+			UnrealizedProfit:       unrealized,
+			WalletBalance:          s.walletBalance,
+		}},
+		CanTrade:                    true,
+		CanDeposit:                  true,
+		CanWithdraw:                 true,
+		UpdateTime:                  s.now,
+		TotalInitialMargin:          positionMargin + orderMargin,
+		TotalMaintMargin:            maint,
+		TotalWalletBalance:          s.walletBalance,
+		TotalUnrealizedProfit:       unrealized,
+		TotalMarginBalance:          s.walletBalance + unrealized,
+		TotalPositionInitialMargin:  positionMargin,
+		TotalOpenOrderInitialMargin: orderMargin,
+		TotalCrossWalletBalance:     s.crossWalletBalance(),
+		TotalCrossUnPnl:             crossUnrealized,
+		AvailableBalance:            available,
+		MaxWithdrawAmount:           math.Max(0, available),
+		Positions:                   positions,
+	}
+}
+
+// balance 账户余额
+func (s *SimExchange) balance() []*Balance {
+	crossUnrealized := 0.0
+	for _, pos := range s.positions {
+		if s.symbol(pos.symbol).marginType != MarginTypeIsolated {
+			crossUnrealized += s.unrealizedPnL(pos)
+		}
+	}
+	available := s.availableBalance()
+	return []*Balance{{
+		AccountAlias:       "sim",
+		Asset:              s.asset,
+		Balance:            s.walletBalance,
+		CrossWalletBalance: s.crossWalletBalance(),
+		CrossUnPnl:         crossUnrealized,
+		AvailableBalance:   available,
+		MaxWithdrawAmount:  math.Max(0, available),
+	}}
+}
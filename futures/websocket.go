@@ -22,7 +22,8 @@ func newWsConfig(endpoint string) *WsConfig {
 	}
 }
 
-var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (ws *wsc.Wsc, done chan struct{}, err error) {
+// connectWsServe serve cfg over the network | 通过网络连接 websocket
+func connectWsServe(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (ws *wsc.Wsc, done chan struct{}, err error) {
 	done = make(chan struct{})
 
 	ws = wsc.New(cfg.Endpoint)
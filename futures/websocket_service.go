@@ -305,6 +305,88 @@ func WsCombinedKlineServe(symbolIntervalPair map[string]string, handler WsKlineH
 	return wsServe(cfg, wsHandler, errHandler)
 }
 
+// WsContinuousKlineEvent 定义连续合约K线事件
+type WsContinuousKlineEvent struct {
+	Event        string       `json:"e"`  // 事件类型
+	Time         int64        `json:"E"`  // 事件时间
+	Pair         string       `json:"ps"` // 标的交易对
+	ContractType ContractType `json:"ct"` // 合约类型
+	Kline        WsKline      `json:"k"`  // k线数据, 不含交易对和成交ID
+}
+
+// WsContinuousKlineHandler 处理 websocket 连续合约K线事件
+type WsContinuousKlineHandler func(event *WsContinuousKlineEvent)
+
+// WsContinuousKlineServe serve websocket continuous contract kline handler with pair, contract type and interval
+func WsContinuousKlineServe(pair string, contractType ContractType, interval string, handler WsContinuousKlineHandler, errHandler ErrHandler) (ws *wsc.Wsc, done chan struct{}, err error) {
+	// <pair>_<contractType>@continuousKline_<interval> | 连续合约K线, 每250毫秒推送
+	endpoint := fmt.Sprintf("%s/%s_%s@continuousKline_%s", getWsEndpoint(), strings.ToLower(pair), strings.ToLower(string(contractType)), interval)
+	cfg := newWsConfig(endpoint)
+	wsHandler := func(data []byte) {
+		event := new(WsContinuousKlineEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
+
+// WsAssetIndexEvent 定义多资产模式资产汇率指数事件
+type WsAssetIndexEvent struct {
+	Event                 string  `json:"e"`        // 事件类型
+	Time                  int64   `json:"E"`        // 事件时间
+	Symbol                string  `json:"s"`        // 资产交易对, 如 ADAUSD
+	Index                 float64 `json:"i,string"` // 指数价格
+	BidBuffer             float64 `json:"b,string"` // 买价缓冲比例
+	AskBuffer             float64 `json:"a,string"` // 卖价缓冲比例
+	BidRate               float64 `json:"B,string"` // 买价汇率
+	AskRate               float64 `json:"A,string"` // 卖价汇率
+	AutoExchangeBidBuffer float64 `json:"q,string"` // 自动兑换买价缓冲比例
+	AutoExchangeAskBuffer float64 `json:"g,string"` // 自动兑换卖价缓冲比例
+	AutoExchangeBidRate   float64 `json:"Q,string"` // 自动兑换买价汇率
+	AutoExchangeAskRate   float64 `json:"G,string"` // 自动兑换卖价汇率
+}
+
+// WsAssetIndexHandler 处理 websocket 资产汇率指数事件
+type WsAssetIndexHandler func(event *WsAssetIndexEvent)
+
+// WsAllAssetIndexHandler 处理 websocket 全部资产汇率指数事件
+type WsAllAssetIndexHandler func(event []*WsAssetIndexEvent)
+
+// WsAssetIndexServe serve websocket asset index handler of an asset symbol, e.g. ADAUSD
+func WsAssetIndexServe(symbol string, handler WsAssetIndexHandler, errHandler ErrHandler) (ws *wsc.Wsc, done chan struct{}, err error) {
+	// <assetSymbol>@assetIndex | 多资产模式资产汇率指数, 每秒推送
+	endpoint := fmt.Sprintf("%s/%s@assetIndex", getWsEndpoint(), strings.ToLower(symbol))
+	cfg := newWsConfig(endpoint)
+	wsHandler := func(data []byte) {
+		event := new(WsAssetIndexEvent)
+		if err := json.Unmarshal(data, event); err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
+
+// WsAllAssetIndexServe serve websocket asset index handler of all assets
+func WsAllAssetIndexServe(handler WsAllAssetIndexHandler, errHandler ErrHandler) (ws *wsc.Wsc, done chan struct{}, err error) {
+	// !assetIndex@arr | 全部资产汇率指数, 每秒推送
+	endpoint := fmt.Sprintf("%s/!assetIndex@arr", getWsEndpoint())
+	cfg := newWsConfig(endpoint)
+	wsHandler := func(data []byte) {
+		var event []*WsAssetIndexEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
+
 // WsMiniMarketTickerEvent 定义 精简Ticker websocket事件
 type WsMiniMarketTickerEvent struct {
 	Event       string `json:"e"` // 事件类型
@@ -791,36 +873,39 @@ type WsPosition struct {
 }
 
 type WsOrderTradeUpdate struct {
-	Symbol               string             `json:"s"`         // 交易对
-	ClientOrderID        string             `json:"c"`         // 客户端自定订单ID
-	Side                 SideType           `json:"S"`         // 订单方向
-	Type                 OrderType          `json:"o"`         // 订单类型
-	TimeInForce          TimeInForceType    `json:"f"`         // 有效方式
-	OriginalQty          float64            `json:"q,string"`  // 订单原始数量
-	OriginalPrice        float64            `json:"p,string"`  // 订单原始价格
-	AveragePrice         float64            `json:"ap,string"` // 订单平均价格
-	StopPrice            float64            `json:"sp,string"` // 条件订单触发价格，对追踪止损单无效
-	ExecutionType        OrderExecutionType `json:"x"`         // 本次事件的具体执行类型
-	Status               OrderStatusType    `json:"X"`         // 订单的当前状态
-	ID                   int64              `json:"i"`         // 订单ID
-	LastFilledQty        float64            `json:"l,string"`  // 订单末次成交量
-	AccumulatedFilledQty float64            `json:"z,string"`  // 订单累计已成交量
-	LastFilledPrice      float64            `json:"L,string"`  // 订单末次成交价格
-	CommissionAsset      string             `json:"N"`         // 手续费资产类型
-	Commission           float64            `json:"n,string"`  // 手续费数量
-	TradeTime            int64              `json:"T"`         // 成交时间
-	TradeID              int64              `json:"t"`         // 成交ID
-	BidsNotional         float64            `json:"b,string"`  // 买单净值
-	AsksNotional         float64            `json:"a,string"`  // 卖单净值
-	IsMaker              bool               `json:"m"`         // 该成交是作为挂单成交吗？
-	IsReduceOnly         bool               `json:"R"`         // 是否是只减仓单
-	WorkingType          WorkingType        `json:"wt"`        // 触发价类型
-	OriginalType         OrderType          `json:"ot"`        // 原始订单类型
-	PositionSide         PositionSideType   `json:"ps"`        // 持仓方向
-	IsClosingPosition    bool               `json:"cp"`        // 是否为触发平仓单; 仅在条件订单情况下会推送此字段
-	ActivationPrice      float64            `json:"AP,string"` // 追踪止损激活价格, 仅在追踪止损单时会推送此字段
-	CallbackRate         float64            `json:"cr,string"` // 追踪止损回调比例, 仅在追踪止损单时会推送此字段
-	RealizedPnL          float64            `json:"rp,string"` // 该交易实现盈亏
+	Symbol                  string             `json:"s"`         // 交易对
+	ClientOrderID           string             `json:"c"`         // 客户端自定订单ID
+	Side                    SideType           `json:"S"`         // 订单方向
+	Type                    OrderType          `json:"o"`         // 订单类型
+	TimeInForce             TimeInForceType    `json:"f"`         // 有效方式
+	OriginalQty             float64            `json:"q,string"`  // 订单原始数量
+	OriginalPrice           float64            `json:"p,string"`  // 订单原始价格
+	AveragePrice            float64            `json:"ap,string"` // 订单平均价格
+	StopPrice               float64            `json:"sp,string"` // 条件订单触发价格，对追踪止损单无效
+	ExecutionType           OrderExecutionType `json:"x"`         // 本次事件的具体执行类型
+	Status                  OrderStatusType    `json:"X"`         // 订单的当前状态
+	ID                      int64              `json:"i"`         // 订单ID
+	LastFilledQty           float64            `json:"l,string"`  // 订单末次成交量
+	AccumulatedFilledQty    float64            `json:"z,string"`  // 订单累计已成交量
+	LastFilledPrice         float64            `json:"L,string"`  // 订单末次成交价格
+	CommissionAsset         string             `json:"N"`         // 手续费资产类型
+	Commission              float64            `json:"n,string"`  // 手续费数量
+	TradeTime               int64              `json:"T"`         // 成交时间
+	TradeID                 int64              `json:"t"`         // 成交ID
+	BidsNotional            float64            `json:"b,string"`  // 买单净值
+	AsksNotional            float64            `json:"a,string"`  // 卖单净值
+	IsMaker                 bool               `json:"m"`         // 该成交是作为挂单成交吗？
+	IsReduceOnly            bool               `json:"R"`         // 是否是只减仓单
+	WorkingType             WorkingType        `json:"wt"`        // 触发价类型
+	OriginalType            OrderType          `json:"ot"`        // 原始订单类型
+	PositionSide            PositionSideType   `json:"ps"`        // 持仓方向
+	IsClosingPosition       bool               `json:"cp"`        // 是否为触发平仓单; 仅在条件订单情况下会推送此字段
+	ActivationPrice         float64            `json:"AP,string"` // 追踪止损激活价格, 仅在追踪止损单时会推送此字段
+	CallbackRate            float64            `json:"cr,string"` // 追踪止损回调比例, 仅在追踪止损单时会推送此字段
+	RealizedPnL             float64            `json:"rp,string"` // 该交易实现盈亏
+	SelfTradePreventionMode string             `json:"V"`         // 自成交保护模式
+	PriceMatch              string             `json:"pm"`        // 盘口价格下单模式
+	GoodTillDate            int64              `json:"gtd"`       // TIF为GTD时的自动取消时间
 }
 
 // WsAccountConfigUpdate define account config update
@@ -833,6 +918,11 @@ type WsAccountConfigUpdate struct {
 type WsUserDataHandler func(event *WsUserDataEvent)
 
 func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (ws *wsc.Wsc, done chan struct{}, err error) {
+	if sim := simExchangeByListenKey(listenKey); sim != nil {
+		// listenKey 来自模拟交易所(回测或模拟盘), 推送在本地产生
+		done, err = sim.WsUserDataServe(handler, errHandler)
+		return nil, done, err
+	}
 	endpoint := fmt.Sprintf("%s/%s", getWsEndpoint(), listenKey)
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
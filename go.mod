@@ -3,12 +3,15 @@ module github.com/BobHye/binance-go
 go 1.23.1
 
 require (
-	github.com/BobHye/wsc v0.0.0-20240402021910-aac57f9b942e // indirect
-	github.com/bitly/go-simplejson v0.5.1 // indirect
-	github.com/gorilla/websocket v1.5.1 // indirect
+	github.com/BobHye/wsc v0.0.0-20240402021910-aac57f9b942e
+	github.com/bitly/go-simplejson v0.5.1
+	github.com/gorilla/websocket v1.5.1
+	github.com/json-iterator/go v1.1.12
+)
+
+require (
 	github.com/jpillora/backoff v1.0.0 // indirect
-	github.com/json-iterator/go v1.1.12 // indirect
 	github.com/modern-go/concurrent v0.0.0-20180228061459-e0a39a4cb421 // indirect
 	github.com/modern-go/reflect2 v1.0.2 // indirect
 	golang.org/x/net v0.19.0 // indirect
-)
\ No newline at end of file
+)
@@ -0,0 +1,496 @@
+package margin
+
+import (
+	"context"
+	"github.com/BobHye/binance-go/common"
+	"sort"
+	"strconv"
+)
+
+// Adapter implement common.OrderGateway and common.Account over a margin client. Market data comes from the spot package
+type Adapter struct {
+	c          *Client
+	isolated   bool
+	sideEffect SideEffectType
+}
+
+var (
+	_ common.OrderGateway = (*Adapter)(nil)
+	_ common.Account      = (*Adapter)(nil)
+)
+
+// NewAdapter create an adapter over client, trading the cross margin account by default
+func NewAdapter(c *Client) *Adapter {
+	return &Adapter{c: c}
+}
+
+// IsIsolated trade the isolated margin accounts instead of the cross one
+func (a *Adapter) IsIsolated(isolated bool) *Adapter {
+	a.isolated = isolated
+	return a
+}
+
+// SideEffectType set the side effect of new orders, e.g. MARGIN_BUY to borrow automatically
+func (a *Adapter) SideEffectType(sideEffect SideEffectType) *Adapter {
+	a.sideEffect = sideEffect
+	return a
+}
+
+// CreateOrder place a new order. PositionSide and ReduceOnly are ignored
+func (a *Adapter) CreateOrder(ctx context.Context, req *common.OrderRequest) (*common.Order, error) {
+	orderType, err := FromCommonOrderType(req.Type)
+	if err != nil {
+		return nil, err
+	}
+	s := a.c.NewCreateMarginOrderService().
+		Symbol(req.Symbol).
+		IsIsolated(a.isolated).
+		Side(SideType(req.Side)).
+		Type(orderType).
+		Quantity(formatFloat(req.Quantity)).
+		NewOrderRespType(NewOrderRespTypeRESULT)
+	switch orderType {
+	case OrderTypeLimit, OrderTypeStopLossLimit, OrderTypeTakeProfitLimit:
+		tif := TimeInForceType(req.TimeInForce)
+		if tif == "" {
+			tif = TimeInForceTypeGTC
+		}
+		s.TimeInForce(tif)
+	}
+	if req.Price > 0 {
+		s.Price(formatFloat(req.Price))
+	}
+	if req.StopPrice > 0 {
+		s.StopPrice(formatFloat(req.StopPrice))
+	}
+	if req.ClientOrderID != "" {
+		s.NewClientOrderID(req.ClientOrderID)
+	}
+	if a.sideEffect != "" {
+		s.SideEffectType(a.sideEffect)
+	}
+	res, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ToCommonOrder(&Order{
+		Symbol:                   res.Symbol,
+		OrderID:                  res.OrderID,
+		ClientOrderID:            res.ClientOrderID,
+		Price:                    res.Price,
+		OrigQuantity:             res.OrigQuantity,
+		ExecutedQuantity:         res.ExecutedQuantity,
+		CummulativeQuoteQuantity: res.CummulativeQuoteQuantity,
+		Status:                   res.Status,
+		TimeInForce:              res.TimeInForce,
+		Type:                     res.Type,
+		Side:                     res.Side,
+		StopPrice:                formatFloat(req.StopPrice),
+		Time:                     res.TransactTime,
+		UpdateTime:               res.TransactTime,
+	}), nil
+}
+
+// CancelOrder cancel an open order
+func (a *Adapter) CancelOrder(ctx context.Context, symbol string, orderID int64, clientOrderID string) (*common.Order, error) {
+	s := a.c.NewCancelMarginOrderService().Symbol(symbol).IsIsolated(a.isolated)
+	if orderID != 0 {
+		s.OrderID(orderID)
+	} else {
+		s.OrigClientOrderID(clientOrderID)
+	}
+	res, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	id, _ := strconv.ParseInt(res.OrderID, 10, 64)
+	return ToCommonOrder(&Order{
+		Symbol:                   res.Symbol,
+		OrderID:                  id,
+		ClientOrderID:            res.OrigClientOrderID,
+		Price:                    res.Price,
+		OrigQuantity:             res.OrigQuantity,
+		ExecutedQuantity:         res.ExecutedQuantity,
+		CummulativeQuoteQuantity: res.CummulativeQuoteQuantity,
+		Status:                   res.Status,
+		TimeInForce:              res.TimeInForce,
+		Type:                     res.Type,
+		Side:                     res.Side,
+		UpdateTime:               res.TransactTime,
+	}), nil
+}
+
+// GetOrder query an order
+func (a *Adapter) GetOrder(ctx context.Context, symbol string, orderID int64, clientOrderID string) (*common.Order, error) {
+	s := a.c.NewGetMarginOrderService().Symbol(symbol).IsIsolated(a.isolated)
+	if orderID != 0 {
+		s.OrderID(orderID)
+	} else {
+		s.OrigClientOrderID(clientOrderID)
+	}
+	o, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ToCommonOrder(o), nil
+}
+
+// ListOpenOrders query open orders, symbol is required for isolated margin
+func (a *Adapter) ListOpenOrders(ctx context.Context, symbol string) ([]*common.Order, error) {
+	s := a.c.NewListMarginOpenOrdersService().IsIsolated(a.isolated)
+	if symbol != "" {
+		s.Symbol(symbol)
+	}
+	orders, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*common.Order, 0, len(orders))
+	for _, o := range orders {
+		res = append(res, ToCommonOrder(o))
+	}
+	return res, nil
+}
+
+// ListFills query trades of symbol
+func (a *Adapter) ListFills(ctx context.Context, symbol string, startTime, endTime int64) ([]*common.Fill, error) {
+	s := a.c.NewListMarginTradesService().Symbol(symbol).IsIsolated(a.isolated)
+	if startTime > 0 {
+		s.StartTime(startTime)
+	}
+	if endTime > 0 {
+		s.EndTime(endTime)
+	}
+	trades, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*common.Fill, 0, len(trades))
+	for _, t := range trades {
+		res = append(res, ToCommonFill(t))
+	}
+	return res, nil
+}
+
+// Balances return non-zero balances. For isolated margin, the assets of all pairs are summed up
+func (a *Adapter) Balances(ctx context.Context) ([]*common.Balance, error) {
+	if !a.isolated {
+		account, err := a.c.NewGetMarginAccountService().Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		res := make([]*common.Balance, 0)
+		for _, asset := range account.UserAssets {
+			if b := ToCommonBalance(asset); b.Free != 0 || b.Locked != 0 || b.Borrowed != 0 {
+				res = append(res, b)
+			}
+		}
+		return res, nil
+	}
+
+	account, err := a.c.NewGetIsolatedMarginAccountService().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sums := make(map[string]*common.Balance)
+	for _, pair := range account.Assets {
+		for _, asset := range []IsolatedUserAsset{pair.BaseAsset, pair.QuoteAsset} {
+			b := ToCommonBalance(UserAsset{
+				Asset:    asset.Asset,
+				Borrowed: asset.Borrowed,
+				Free:     asset.Free,
+				Interest: asset.Interest,
+				Locked:   asset.Locked,
+				NetAsset: asset.NetAsset,
+			})
+			if sum, ok := sums[b.Asset]; ok {
+				sum.Total += b.Total
+				sum.Free += b.Free
+				sum.Locked += b.Locked
+				sum.Borrowed += b.Borrowed
+				sum.Interest += b.Interest
+			} else {
+				sums[b.Asset] = b
+			}
+		}
+	}
+	res := make([]*common.Balance, 0, len(sums))
+	for _, b := range sums {
+		if b.Free != 0 || b.Locked != 0 || b.Borrowed != 0 {
+			res = append(res, b)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Asset < res[j].Asset })
+	return res, nil
+}
+
+// Positions return no positions, borrowed amounts are reported in Balances
+func (a *Adapter) Positions(ctx context.Context) ([]*common.Position, error) {
+	return []*common.Position{}, nil
+}
+
+// FromCommonOrderType convert the normalized order type to the margin one
+func FromCommonOrderType(t common.OrderType) (OrderType, error) {
+	switch t {
+	case common.OrderTypeLimit:
+		return OrderTypeLimit, nil
+	case common.OrderTypeMarket:
+		return OrderTypeMarket, nil
+	case common.OrderTypeLimitMaker:
+		return OrderTypeLimitMaker, nil
+	case common.OrderTypeStop:
+		return OrderTypeStopLossLimit, nil
+	case common.OrderTypeStopMarket:
+		return OrderTypeStopLoss, nil
+	case common.OrderTypeTakeProfit:
+		return OrderTypeTakeProfitLimit, nil
+	case common.OrderTypeTakeProfitMarket:
+		return OrderTypeTakeProfit, nil
+	}
+	return "", common.ErrNotSupported
+}
+
+// ToCommonOrderType convert the margin order type to the normalized one
+func ToCommonOrderType(t OrderType) common.OrderType {
+	switch t {
+	case OrderTypeStopLossLimit:
+		return common.OrderTypeStop
+	case OrderTypeStopLoss:
+		return common.OrderTypeStopMarket
+	case OrderTypeTakeProfitLimit:
+		return common.OrderTypeTakeProfit
+	case OrderTypeTakeProfit:
+		return common.OrderTypeTakeProfitMarket
+	}
+	return common.OrderType(t)
+}
+
+// ToCommonOrder convert order to the normalized model
+func ToCommonOrder(o *Order) *common.Order {
+	res := &common.Order{
+		Symbol:           o.Symbol,
+		OrderID:          o.OrderID,
+		ClientOrderID:    o.ClientOrderID,
+		Side:             common.Side(o.Side),
+		Type:             ToCommonOrderType(o.Type),
+		TimeInForce:      common.TimeInForce(o.TimeInForce),
+		Status:           common.OrderStatus(o.Status),
+		Price:            parseFloat(o.Price),
+		StopPrice:        parseFloat(o.StopPrice),
+		Quantity:         parseFloat(o.OrigQuantity),
+		ExecutedQuantity: parseFloat(o.ExecutedQuantity),
+		QuoteQuantity:    parseFloat(o.CummulativeQuoteQuantity),
+		Time:             o.Time,
+		UpdateTime:       o.UpdateTime,
+	}
+	if res.ExecutedQuantity > 0 {
+		res.AvgPrice = res.QuoteQuantity / res.ExecutedQuantity
+	}
+	return res
+}
+
+// ToCommonFill convert trade to the normalized model
+func ToCommonFill(t *TradeV3) *common.Fill {
+	side := common.SideSell
+	if t.IsBuyer {
+		side = common.SideBuy
+	}
+	return &common.Fill{
+		Symbol:          t.Symbol,
+		TradeID:         t.ID,
+		OrderID:         t.OrderID,
+		Side:            side,
+		Price:           parseFloat(t.Price),
+		Quantity:        parseFloat(t.Quantity),
+		QuoteQuantity:   parseFloat(t.QuoteQuantity),
+		Commission:      parseFloat(t.Commission),
+		CommissionAsset: t.CommissionAsset,
+		Maker:           t.IsMaker,
+		Time:            t.Time,
+	}
+}
+
+// ToCommonOrderUpdate convert executionReport to the normalized model
+func ToCommonOrderUpdate(e *WsOrderUpdate) *common.OrderUpdate {
+	// 撤单推送中 c 为撤单请求的ID, 原始的客户自定义订单ID在 C 中
+	clientOrderID := e.ClientOrderID
+	if e.OrigClientOrderID != "" {
+		clientOrderID = e.OrigClientOrderID
+	}
+	order := &common.Order{
+		Symbol:           e.Symbol,
+		OrderID:          e.ID,
+		ClientOrderID:    clientOrderID,
+		Side:             common.Side(e.Side),
+		Type:             ToCommonOrderType(e.Type),
+		TimeInForce:      common.TimeInForce(e.TimeInForce),
+		Status:           common.OrderStatus(e.Status),
+		Price:            parseFloat(e.Price),
+		StopPrice:        parseFloat(e.StopPrice),
+		Quantity:         parseFloat(e.Volume),
+		ExecutedQuantity: parseFloat(e.FilledVolume),
+		QuoteQuantity:    parseFloat(e.FilledQuoteVolume),
+		Time:             e.CreateTime,
+		UpdateTime:       e.TransactionTime,
+	}
+	if order.ExecutedQuantity > 0 {
+		order.AvgPrice = order.QuoteQuantity / order.ExecutedQuantity
+	}
+	res := &common.OrderUpdate{Order: order, Execution: string(e.ExecutionType)}
+	if e.ExecutionType == OrderExecutionTypeTrade {
+		res.Fill = &common.Fill{
+			Symbol:          e.Symbol,
+			TradeID:         e.TradeID,
+			OrderID:         e.ID,
+			Side:            common.Side(e.Side),
+			Price:           parseFloat(e.LatestPrice),
+			Quantity:        parseFloat(e.LatestVolume),
+			QuoteQuantity:   parseFloat(e.LatestQuoteVolume),
+			Commission:      parseFloat(e.FeeCost),
+			CommissionAsset: e.FeeAsset,
+			Maker:           e.IsMaker,
+			Time:            e.TransactionTime,
+		}
+	}
+	return res
+}
+
+// ToCommonDepositFlow convert a credited deposit to the normalized ledger flow, nil while it is not credited
+func ToCommonDepositFlow(d *Deposit) *common.Flow {
+	// 0: 处理中, 6: 已上账但暂不能提现, 1: 成功
+	if d.Status != 1 && d.Status != 6 {
+		return nil
+	}
+	return &common.Flow{
+		Venue:  common.VenueSpot,
+		Type:   common.FlowDeposit,
+		ID:     d.Coin + "-" + d.TxID,
+		Asset:  d.Coin,
+		Amount: parseFloat(d.Amount),
+		TxID:   d.TxID,
+		Info:   d.Network,
+		Time:   d.InsertTime,
+	}
+}
+
+// ToCommonWithdrawFlows convert a completed withdrawal to the normalized ledger flows of the amount and the fee,
+// empty while it is not completed
+func ToCommonWithdrawFlows(w *Withdraw) []*common.Flow {
+	// 6: 提现完成
+	if w.Status != 6 {
+		return []*common.Flow{}
+	}
+	t := common.ParseCSVTime(w.ApplyTime)
+	res := []*common.Flow{{
+		Venue:  common.VenueSpot,
+		Type:   common.FlowWithdraw,
+		ID:     w.ID,
+		Asset:  w.Coin,
+		Amount: -parseFloat(w.Amount),
+		TxID:   w.TxID,
+		Info:   w.Network,
+		Time:   t,
+	}}
+	if fee := parseFloat(w.TransactionFee); fee != 0 {
+		res = append(res, &common.Flow{
+			Venue:  common.VenueSpot,
+			Type:   common.FlowWithdrawFee,
+			ID:     w.ID,
+			Asset:  w.Coin,
+			Amount: -fee,
+			TxID:   w.TxID,
+			Info:   w.Network,
+			Time:   t,
+		})
+	}
+	return res
+}
+
+// ToCommonDividendFlows convert asset dividend records to the normalized ledger flows
+func ToCommonDividendFlows(w *DividendResponseWrapper) []*common.Flow {
+	res := make([]*common.Flow, 0)
+	if w == nil || w.Rows == nil {
+		return res
+	}
+	for _, d := range *w.Rows {
+		res = append(res, &common.Flow{
+			Venue:  common.VenueSpot,
+			Type:   common.FlowDividend,
+			ID:     strconv.FormatInt(d.TranID, 10),
+			Asset:  d.Asset,
+			Amount: parseFloat(d.Amount),
+			Info:   d.Info,
+			Time:   d.Time,
+		})
+	}
+	return res
+}
+
+// ToCommonConversions convert successful convert trades to the normalized ledger conversions
+func ToCommonConversions(h *ConvertTradeHistory) []*common.Conversion {
+	res := make([]*common.Conversion, 0)
+	if h == nil {
+		return res
+	}
+	for _, item := range h.List {
+		if item.OrderStatus != "SUCCESS" {
+			continue
+		}
+		res = append(res, &common.Conversion{
+			Venue:      common.VenueSpot,
+			ID:         strconv.FormatInt(item.OrderId, 10),
+			FromAsset:  item.FromAsset,
+			FromAmount: parseFloat(item.FromAmount),
+			ToAsset:    item.ToAsset,
+			ToAmount:   parseFloat(item.ToAmount),
+			Time:       item.CreateTime,
+		})
+	}
+	return res
+}
+
+// ToCommonDustConversions convert dust log to the normalized ledger conversions into BNB, one for each converted asset
+func ToCommonDustConversions(r *DustResult) []*common.Conversion {
+	res := make([]*common.Conversion, 0)
+	if r == nil {
+		return res
+	}
+	for _, dribblet := range r.UserAssetDribblets {
+		for _, d := range dribblet.UserAssetDribbletDetails {
+			res = append(res, &common.Conversion{
+				Venue:      common.VenueSpot,
+				ID:         strconv.Itoa(d.TransID) + "-" + d.FromAsset,
+				FromAsset:  d.FromAsset,
+				FromAmount: parseFloat(d.Amount),
+				ToAsset:    "BNB",
+				ToAmount:   parseFloat(d.TransferedAmount),
+				Fee:        parseFloat(d.ServiceChargeAmount),
+				FeeAsset:   "BNB",
+				Time:       d.OperateTime,
+			})
+		}
+	}
+	return res
+}
+
+// ToCommonBalance convert user asset to the normalized model, Total is the net asset
+func ToCommonBalance(a UserAsset) *common.Balance {
+	return &common.Balance{
+		Asset:    a.Asset,
+		Total:    parseFloat(a.NetAsset),
+		Free:     parseFloat(a.Free),
+		Locked:   parseFloat(a.Locked),
+		Borrowed: parseFloat(a.Borrowed),
+		Interest: parseFloat(a.Interest),
+	}
+}
+
+// parseFloat 解析数值字符串, 空字符串或非法值返回 0
+func parseFloat(s string) float64 {
+	f, _ := strconv.ParseFloat(s, 64)
+	return f
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
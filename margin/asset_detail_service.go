@@ -46,13 +46,13 @@ type GetAllCoinsInfoService struct {
 }
 
 // Do send request
-func (s *GetAllCoinsInfoService) Do(ctx context.Context) (res []*CoinInfo, err error) {
+func (s *GetAllCoinsInfoService) Do(ctx context.Context, opts ...RequestOption) (res []*CoinInfo, err error) {
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/sapi/v1/capital/config/getall",
 		secType:  secTypeSigned,
 	}
-	data, err := s.c.callAPI(ctx, r)
+	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return []*CoinInfo{}, err
 	}
@@ -140,7 +140,7 @@ type UserAssetRecord struct {
 	BtcValuation string `json:"btcValuation"`
 }
 
-func (s *GetUserAssetService) Do(ctx context.Context) (res []UserAssetRecord, err error) {
+func (s *GetUserAssetService) Do(ctx context.Context, opts ...RequestOption) (res []UserAssetRecord, err error) {
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: "/sapi/v3/asset/getUserAsset",
@@ -152,7 +152,7 @@ func (s *GetUserAssetService) Do(ctx context.Context) (res []UserAssetRecord, er
 	if s.needBtcValuation {
 		r.setParam("needBtcValuation", s.needBtcValuation)
 	}
-	data, err := s.c.callAPI(ctx, r)
+	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return
 	}
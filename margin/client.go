@@ -47,6 +47,12 @@ type SymbolFilterType string
 // UserDataEventType define spot user data event type
 type UserDataEventType string
 
+// OrderExecutionType define order execution type of executionReport
+type OrderExecutionType string
+
+// MarginLevelStatusType define margin level status of the account
+type MarginLevelStatusType string
+
 // MarginTransferType define margin transfer type
 type MarginTransferType int
 
@@ -96,6 +102,9 @@ type RateLimitType string
 // RateLimitInterval define the rate limitation intervals
 type RateLimitInterval string
 
+// SelfTradePreventionModeType define self-trade prevention mode
+type SelfTradePreventionModeType string
+
 // Endpoints
 const (
 	baseAPIMainURL    = "https://api.binance.com"
@@ -122,7 +131,7 @@ const (
 	OrderTypeTakeProfitLimit OrderType = "TAKE_PROFIT_LIMIT"
 
 	TimeInForceTypeGTC TimeInForceType = "GTC"
-	TimeInForceTypeIOC TimeInForceType = "GTC"
+	TimeInForceTypeIOC TimeInForceType = "IOC"
 	TimeInForceTypeFOK TimeInForceType = "FOK"
 
 	NewOrderRespTypeACK    NewOrderRespType = "ACK"
@@ -159,6 +168,23 @@ const (
 	UserDataEventTypeBalanceUpdate           UserDataEventType = "balanceUpdate"
 	UserDataEventTypeExecutionReport         UserDataEventType = "executionReport"
 	UserDataEventTypeListStatus              UserDataEventType = "ListStatus"
+	UserDataEventTypeLiabilityChange         UserDataEventType = "USER_LIABILITY_CHANGE"
+	UserDataEventTypeMarginLevelStatusChange UserDataEventType = "MARGIN_LEVEL_STATUS_CHANGE"
+	UserDataEventTypeListenKeyExpired        UserDataEventType = "listenKeyExpired"
+
+	OrderExecutionTypeNew             OrderExecutionType = "NEW"
+	OrderExecutionTypeCanceled        OrderExecutionType = "CANCELED"
+	OrderExecutionTypeReplaced        OrderExecutionType = "REPLACED"
+	OrderExecutionTypeRejected        OrderExecutionType = "REJECTED"
+	OrderExecutionTypeTrade           OrderExecutionType = "TRADE"
+	OrderExecutionTypeExpired         OrderExecutionType = "EXPIRED"
+	OrderExecutionTypeTradePrevention OrderExecutionType = "TRADE_PREVENTION"
+
+	MarginLevelStatusTypeExcessive        MarginLevelStatusType = "EXCESSIVE"
+	MarginLevelStatusTypeNormal           MarginLevelStatusType = "NORMAL"
+	MarginLevelStatusTypeMarginCall       MarginLevelStatusType = "MARGIN_CALL"
+	MarginLevelStatusTypePreLiquidation   MarginLevelStatusType = "PRE_LIQUIDATION"
+	MarginLevelStatusTypeForceLiquidation MarginLevelStatusType = "FORCE_LIQUIDATION"
 
 	MarginTransferTypeToMargin MarginTransferType = 1
 	MarginTransferTypeToMain   MarginTransferType = 2
@@ -174,9 +200,10 @@ const (
 	MarginRepayStatusTypeConfirmed MarginRepayStatusType = "CONFIRMED"
 	MarginRepayStatusTypeFailed    MarginRepayStatusType = "FAILED"
 
-	SideEffectTypeNoSideEffect SideEffectType = "NO_SIDE_EFFECT"
-	SideEffectTypeMarginBuy    SideEffectType = "MARGIN_BUY"
-	SideEffectTypeAutoRepay    SideEffectType = "AUTO_REPAY"
+	SideEffectTypeNoSideEffect    SideEffectType = "NO_SIDE_EFFECT"
+	SideEffectTypeMarginBuy       SideEffectType = "MARGIN_BUY"
+	SideEffectTypeAutoRepay       SideEffectType = "AUTO_REPAY"
+	SideEffectTypeAutoBorrowRepay SideEffectType = "AUTO_BORROW_REPAY"
 
 	TransactionTypeDeposit  TransactionType = "0"
 	TransactionTypeWithdraw TransactionType = "1"
@@ -219,6 +246,11 @@ const (
 	RateLimitIntervalSecond RateLimitInterval = "SECOND"
 	RateLimitIntervalMinute RateLimitInterval = "MINUTE"
 	RateLimitIntervalDay    RateLimitInterval = "DAY"
+
+	SelfTradePreventionModeTypeNone        SelfTradePreventionModeType = "NONE"
+	SelfTradePreventionModeTypeExpireTaker SelfTradePreventionModeType = "EXPIRE_TAKER"
+	SelfTradePreventionModeTypeExpireMaker SelfTradePreventionModeType = "EXPIRE_MAKER"
+	SelfTradePreventionModeTypeExpireBoth  SelfTradePreventionModeType = "EXPIRE_BOTH"
 )
 
 func currentTimestamp() int64 {
@@ -250,6 +282,7 @@ type Client struct {
 	Debug      bool
 	Logger     *log.Logger
 	TimeOffset int64
+	OrderIDs   *common.ClientOrderIDGenerator // 下单未指定 newClientOrderId 时用于生成, 为空则由交易所分配
 	do         doFunc
 }
 
@@ -352,15 +385,15 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 			return err
 		}
 		v := url.Values{}
-		v.Set(signatureKey, fmt.Sprintf("x", (mac.Sum(nil))))
+		v.Set(signatureKey, fmt.Sprintf("%x", (mac.Sum(nil))))
 		if queryString == "" {
 			queryString = v.Encode()
 		} else {
-			queryString = fmt.Sprintf("%s%s", queryString, v.Encode())
+			queryString = fmt.Sprintf("%s&%s", queryString, v.Encode())
 		}
 	}
 	if queryString != "" {
-		fullURL = fmt.Sprintf("%s%s", fullURL, queryString)
+		fullURL = fmt.Sprintf("%s?%s", fullURL, queryString)
 	}
 	c.debug("full url: %s, body: %s", fullURL, bodyString)
 
@@ -372,6 +405,9 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 
 func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption) (data []byte, err error) {
 	err = c.parseRequest(r, opts...)
+	if err != nil {
+		return []byte{}, err
+	}
 	req, err := http.NewRequest(r.method, r.fullURL, r.body)
 	if err != nil {
 		return []byte{}, err
@@ -416,31 +452,43 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 }
 
 // NewSavingFlexibleProductPositionsService get flexible products positions (Savings)
+//
+// Deprecated: the lending endpoints are retired, use NewSimpleEarnFlexiblePositionsService.
 func (c *Client) NewSavingFlexibleProductPositionsService() *SavingFlexibleProductPositionsService {
 	return &SavingFlexibleProductPositionsService{c: c}
 }
 
 // NewSavingFixedProjectPositionsService get fixed project positions (Savings)
+//
+// Deprecated: the lending endpoints are retired, use NewSimpleEarnLockedPositionsService.
 func (c *Client) NewSavingFixedProjectPositionsService() *SavingFixedProjectPositionsService {
 	return &SavingFixedProjectPositionsService{c: c}
 }
 
 // NewListSavingsFlexibleProductsService get flexible products list (Savings)
+//
+// Deprecated: the lending endpoints are retired, use NewListSimpleEarnFlexibleProductsService.
 func (c *Client) NewListSavingsFlexibleProductsService() *ListSavingsFlexibleProductsService {
 	return &ListSavingsFlexibleProductsService{c: c}
 }
 
 // NewPurchaseSavingsFlexibleProductService purchase a flexible product (Savings)
+//
+// Deprecated: the lending endpoints are retired, use NewSubscribeSimpleEarnFlexibleProductService.
 func (c *Client) NewPurchaseSavingsFlexibleProductService() *PurchaseSavingsFlexibleProductService {
 	return &PurchaseSavingsFlexibleProductService{c: c}
 }
 
 // NewRedeemSavingsFlexibleProductService redeem a flexible product (Savings)
+//
+// Deprecated: the lending endpoints are retired, use NewRedeemSimpleEarnFlexibleProductService.
 func (c *Client) NewRedeemSavingsFlexibleProductService() *RedeemSavingsFlexibleProductService {
 	return &RedeemSavingsFlexibleProductService{c: c}
 }
 
 // NewListSavingsFixedAndActivityProductsService get fixed and activity product list (Savings)
+//
+// Deprecated: the lending endpoints are retired, use NewListSimpleEarnLockedProductsService.
 func (c *Client) NewListSavingsFixedAndActivityProductsService() *ListSavingsFixedAndActivityProductsService {
 	return &ListSavingsFixedAndActivityProductsService{c: c}
 }
@@ -500,6 +548,31 @@ func (c *Client) NewCancelMarginOCOService() *CancelMarginOCOService {
 	return &CancelMarginOCOService{c: c}
 }
 
+// NewCancelMarginOpenOrdersService init cancel all margin open orders service
+func (c *Client) NewCancelMarginOpenOrdersService() *CancelMarginOpenOrdersService {
+	return &CancelMarginOpenOrdersService{c: c}
+}
+
+// NewCreateMarginOTOService init creating margin OTO service
+func (c *Client) NewCreateMarginOTOService() *CreateMarginOTOService {
+	return &CreateMarginOTOService{c: c}
+}
+
+// NewCreateMarginOTOCOService init creating margin OTOCO service
+func (c *Client) NewCreateMarginOTOCOService() *CreateMarginOTOCOService {
+	return &CreateMarginOTOCOService{c: c}
+}
+
+// NewGetMarginOrderRateLimitService init getting margin order rate limit service
+func (c *Client) NewGetMarginOrderRateLimitService() *GetMarginOrderRateLimitService {
+	return &GetMarginOrderRateLimitService{c: c}
+}
+
+// NewListMarginPreventedMatchesService init listing margin prevented matches service
+func (c *Client) NewListMarginPreventedMatchesService() *ListMarginPreventedMatchesService {
+	return &ListMarginPreventedMatchesService{c: c}
+}
+
 // NewGetMarginOrderService init get order service
 func (c *Client) NewGetMarginOrderService() *GetMarginOrderService {
 	return &GetMarginOrderService{c: c}
@@ -804,3 +877,208 @@ func (c *Client) NewSubAccountListService() *SubAccountListService {
 func (c *Client) NewGetUserAsset() *GetUserAssetService {
 	return &GetUserAssetService{c: c}
 }
+
+// NewGetDepositAddressService init getting deposit address service
+func (c *Client) NewGetDepositAddressService() *GetDepositsAddressService {
+	return &GetDepositsAddressService{c: c}
+}
+
+// NewGetWalletBalanceService init getting wallet balance service
+func (c *Client) NewGetWalletBalanceService() *GetWalletBalanceService {
+	return &GetWalletBalanceService{c: c}
+}
+
+// NewGetFundingAssetService init getting funding wallet service
+func (c *Client) NewGetFundingAssetService() *GetFundingAssetService {
+	return &GetFundingAssetService{c: c}
+}
+
+// NewListDustAssetsService init listing dust assets service
+func (c *Client) NewListDustAssetsService() *ListDustAssetsService {
+	return &ListDustAssetsService{c: c}
+}
+
+// NewGetSystemStatusService init getting system status service
+func (c *Client) NewGetSystemStatusService() *GetSystemStatusService {
+	return &GetSystemStatusService{c: c}
+}
+
+// NewGetAccountStatusService init getting account status service
+func (c *Client) NewGetAccountStatusService() *GetAccountStatusService {
+	return &GetAccountStatusService{c: c}
+}
+
+// NewGetApiTradingStatusService init getting API trading status service
+func (c *Client) NewGetApiTradingStatusService() *GetApiTradingStatusService {
+	return &GetApiTradingStatusService{c: c}
+}
+
+// NewCreateSubAccountService init creating virtual sub-account service
+func (c *Client) NewCreateSubAccountService() *CreateSubAccountService {
+	return &CreateSubAccountService{c: c}
+}
+
+// NewEnableSubAccountFuturesService init enabling sub-account futures service
+func (c *Client) NewEnableSubAccountFuturesService() *EnableSubAccountFuturesService {
+	return &EnableSubAccountFuturesService{c: c}
+}
+
+// NewEnableSubAccountMarginService init enabling sub-account margin service
+func (c *Client) NewEnableSubAccountMarginService() *EnableSubAccountMarginService {
+	return &EnableSubAccountMarginService{c: c}
+}
+
+// NewSubAccountFuturesSummaryService init sub-account futures account summary service
+func (c *Client) NewSubAccountFuturesSummaryService() *SubAccountFuturesSummaryService {
+	return &SubAccountFuturesSummaryService{c: c, futuresType: SubAccountFuturesTypeUSDT}
+}
+
+// NewSubAccountMarginSummaryService init sub-account margin account summary service
+func (c *Client) NewSubAccountMarginSummaryService() *SubAccountMarginSummaryService {
+	return &SubAccountMarginSummaryService{c: c}
+}
+
+// NewSubAccountFuturesPositionRiskService init sub-account futures position risk service
+func (c *Client) NewSubAccountFuturesPositionRiskService() *SubAccountFuturesPositionRiskService {
+	return &SubAccountFuturesPositionRiskService{c: c, futuresType: SubAccountFuturesTypeUSDT}
+}
+
+// NewSubAccountFuturesTransferService init sub-account futures transfer service
+func (c *Client) NewSubAccountFuturesTransferService() *SubAccountFuturesTransferService {
+	return &SubAccountFuturesTransferService{c: c}
+}
+
+// NewSubAccountMarginTransferService init sub-account margin transfer service
+func (c *Client) NewSubAccountMarginTransferService() *SubAccountMarginTransferService {
+	return &SubAccountMarginTransferService{c: c}
+}
+
+// NewSubAccountUniversalTransferHistoryService init sub-account universal transfer history service
+func (c *Client) NewSubAccountUniversalTransferHistoryService() *SubAccountUniversalTransferHistoryService {
+	return &SubAccountUniversalTransferHistoryService{c: c}
+}
+
+// NewGetSubAccountIPRestrictionService init getting sub-account API key IP restriction service
+func (c *Client) NewGetSubAccountIPRestrictionService() *GetSubAccountIPRestrictionService {
+	return &GetSubAccountIPRestrictionService{c: c}
+}
+
+// NewAddSubAccountIPRestrictionService init adding sub-account API key IP restriction service
+func (c *Client) NewAddSubAccountIPRestrictionService() *AddSubAccountIPRestrictionService {
+	return &AddSubAccountIPRestrictionService{c: c}
+}
+
+// NewDeleteSubAccountIPRestrictionService init deleting sub-account API key IP restriction service
+func (c *Client) NewDeleteSubAccountIPRestrictionService() *DeleteSubAccountIPRestrictionService {
+	return &DeleteSubAccountIPRestrictionService{c: c}
+}
+
+// NewListManagedSubAccountsService init listing managed sub-accounts service
+func (c *Client) NewListManagedSubAccountsService() *ListManagedSubAccountsService {
+	return &ListManagedSubAccountsService{c: c}
+}
+
+// NewGetManagedSubAccountAssetService init getting managed sub-account asset service
+func (c *Client) NewGetManagedSubAccountAssetService() *GetManagedSubAccountAssetService {
+	return &GetManagedSubAccountAssetService{c: c}
+}
+
+// NewListSimpleEarnFlexibleProductsService get flexible products list (Simple Earn)
+func (c *Client) NewListSimpleEarnFlexibleProductsService() *ListSimpleEarnFlexibleProductsService {
+	return &ListSimpleEarnFlexibleProductsService{c: c}
+}
+
+// NewListSimpleEarnLockedProductsService get locked products list (Simple Earn)
+func (c *Client) NewListSimpleEarnLockedProductsService() *ListSimpleEarnLockedProductsService {
+	return &ListSimpleEarnLockedProductsService{c: c}
+}
+
+// NewSubscribeSimpleEarnFlexibleProductService subscribe a flexible product (Simple Earn)
+func (c *Client) NewSubscribeSimpleEarnFlexibleProductService() *SubscribeSimpleEarnFlexibleProductService {
+	return &SubscribeSimpleEarnFlexibleProductService{c: c}
+}
+
+// NewSubscribeSimpleEarnLockedProductService subscribe a locked product (Simple Earn)
+func (c *Client) NewSubscribeSimpleEarnLockedProductService() *SubscribeSimpleEarnLockedProductService {
+	return &SubscribeSimpleEarnLockedProductService{c: c}
+}
+
+// NewRedeemSimpleEarnFlexibleProductService redeem a flexible product (Simple Earn)
+func (c *Client) NewRedeemSimpleEarnFlexibleProductService() *RedeemSimpleEarnFlexibleProductService {
+	return &RedeemSimpleEarnFlexibleProductService{c: c}
+}
+
+// NewRedeemSimpleEarnLockedProductService redeem a locked position (Simple Earn)
+func (c *Client) NewRedeemSimpleEarnLockedProductService() *RedeemSimpleEarnLockedProductService {
+	return &RedeemSimpleEarnLockedProductService{c: c}
+}
+
+// NewSimpleEarnFlexiblePositionsService get flexible product positions (Simple Earn)
+func (c *Client) NewSimpleEarnFlexiblePositionsService() *SimpleEarnFlexiblePositionsService {
+	return &SimpleEarnFlexiblePositionsService{c: c}
+}
+
+// NewSimpleEarnLockedPositionsService get locked product positions (Simple Earn)
+func (c *Client) NewSimpleEarnLockedPositionsService() *SimpleEarnLockedPositionsService {
+	return &SimpleEarnLockedPositionsService{c: c}
+}
+
+// NewGetSimpleEarnAccountService get the Simple Earn account
+func (c *Client) NewGetSimpleEarnAccountService() *GetSimpleEarnAccountService {
+	return &GetSimpleEarnAccountService{c: c}
+}
+
+// NewGetSimpleEarnFlexibleQuotaService get the personal left quota of a flexible product (Simple Earn)
+func (c *Client) NewGetSimpleEarnFlexibleQuotaService() *GetSimpleEarnFlexibleQuotaService {
+	return &GetSimpleEarnFlexibleQuotaService{c: c}
+}
+
+// NewGetSimpleEarnLockedQuotaService get the personal left quota of a locked product (Simple Earn)
+func (c *Client) NewGetSimpleEarnLockedQuotaService() *GetSimpleEarnLockedQuotaService {
+	return &GetSimpleEarnLockedQuotaService{c: c}
+}
+
+// NewSetSimpleEarnFlexibleAutoSubscribeService set auto subscribe of a flexible product (Simple Earn)
+func (c *Client) NewSetSimpleEarnFlexibleAutoSubscribeService() *SetSimpleEarnFlexibleAutoSubscribeService {
+	return &SetSimpleEarnFlexibleAutoSubscribeService{c: c}
+}
+
+// NewSetSimpleEarnLockedAutoSubscribeService set auto subscribe of a locked position (Simple Earn)
+func (c *Client) NewSetSimpleEarnLockedAutoSubscribeService() *SetSimpleEarnLockedAutoSubscribeService {
+	return &SetSimpleEarnLockedAutoSubscribeService{c: c}
+}
+
+// NewListSimpleEarnFlexibleSubscriptionsService get flexible subscription history (Simple Earn)
+func (c *Client) NewListSimpleEarnFlexibleSubscriptionsService() *ListSimpleEarnFlexibleSubscriptionsService {
+	return &ListSimpleEarnFlexibleSubscriptionsService{c: c}
+}
+
+// NewListSimpleEarnLockedSubscriptionsService get locked subscription history (Simple Earn)
+func (c *Client) NewListSimpleEarnLockedSubscriptionsService() *ListSimpleEarnLockedSubscriptionsService {
+	return &ListSimpleEarnLockedSubscriptionsService{c: c}
+}
+
+// NewListSimpleEarnFlexibleRedemptionsService get flexible redemption history (Simple Earn)
+func (c *Client) NewListSimpleEarnFlexibleRedemptionsService() *ListSimpleEarnFlexibleRedemptionsService {
+	return &ListSimpleEarnFlexibleRedemptionsService{c: c}
+}
+
+// NewListSimpleEarnLockedRedemptionsService get locked redemption history (Simple Earn)
+func (c *Client) NewListSimpleEarnLockedRedemptionsService() *ListSimpleEarnLockedRedemptionsService {
+	return &ListSimpleEarnLockedRedemptionsService{c: c}
+}
+
+// NewListSimpleEarnFlexibleRewardsService get flexible rewards history (Simple Earn)
+func (c *Client) NewListSimpleEarnFlexibleRewardsService() *ListSimpleEarnFlexibleRewardsService {
+	return &ListSimpleEarnFlexibleRewardsService{c: c}
+}
+
+// NewListSimpleEarnLockedRewardsService get locked rewards history (Simple Earn)
+func (c *Client) NewListSimpleEarnLockedRewardsService() *ListSimpleEarnLockedRewardsService {
+	return &ListSimpleEarnLockedRewardsService{c: c}
+}
+
+// NewListSimpleEarnFlexibleRateHistoryService get flexible product rate history (Simple Earn)
+func (c *Client) NewListSimpleEarnFlexibleRateHistoryService() *ListSimpleEarnFlexibleRateHistoryService {
+	return &ListSimpleEarnFlexibleRateHistoryService{c: c}
+}
@@ -140,7 +140,7 @@ func (s *GetDepositsAddressService) Network(network string) *GetDepositsAddressS
 }
 
 // Do sends the request.
-func (s *GetDepositsAddressService) Do(ctx context.Context) (*GetDepositAddressResponse, error) {
+func (s *GetDepositsAddressService) Do(ctx context.Context, opts ...RequestOption) (*GetDepositAddressResponse, error) {
 	r := &request{
 		method:   http.MethodGet,
 		endpoint: "/sapi/v1/capital/deposit/address",
@@ -151,7 +151,7 @@ func (s *GetDepositsAddressService) Do(ctx context.Context) (*GetDepositAddressR
 		r.setParam("network", *s.network)
 	}
 
-	data, err := s.c.callAPI(ctx, r)
+	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,332 @@
+package margin
+
+import (
+	"context"
+	"net/http"
+)
+
+// MarginOrderListLeg define one order of a margin order list, built with NewMarginOrderListLeg
+type MarginOrderListLeg struct {
+	orderType       OrderType
+	side            *SideType
+	quantity        *string
+	price           *string
+	stopPrice       *string
+	trailingDelta   *string
+	timeInForce     *TimeInForceType
+	clientOrderID   *string
+	icebergQuantity *string
+}
+
+// NewMarginOrderListLeg init an order list leg of orderType
+func NewMarginOrderListLeg(orderType OrderType) *MarginOrderListLeg {
+	return &MarginOrderListLeg{orderType: orderType}
+}
+
+// Side set side. Ignored for OTOCO pending legs, whose side is set on the list
+func (l *MarginOrderListLeg) Side(side SideType) *MarginOrderListLeg {
+	l.side = &side
+	return l
+}
+
+// Quantity set quantity. Ignored for OTOCO pending legs, whose quantity is set on the list
+func (l *MarginOrderListLeg) Quantity(quantity string) *MarginOrderListLeg {
+	l.quantity = &quantity
+	return l
+}
+
+// Price set price
+func (l *MarginOrderListLeg) Price(price string) *MarginOrderListLeg {
+	l.price = &price
+	return l
+}
+
+// StopPrice set stopPrice
+func (l *MarginOrderListLeg) StopPrice(stopPrice string) *MarginOrderListLeg {
+	l.stopPrice = &stopPrice
+	return l
+}
+
+// TrailingDelta set trailingDelta
+func (l *MarginOrderListLeg) TrailingDelta(trailingDelta string) *MarginOrderListLeg {
+	l.trailingDelta = &trailingDelta
+	return l
+}
+
+// TimeInForce set timeInForce
+func (l *MarginOrderListLeg) TimeInForce(timeInForce TimeInForceType) *MarginOrderListLeg {
+	l.timeInForce = &timeInForce
+	return l
+}
+
+// ClientOrderID set clientOrderId of the leg
+func (l *MarginOrderListLeg) ClientOrderID(clientOrderID string) *MarginOrderListLeg {
+	l.clientOrderID = &clientOrderID
+	return l
+}
+
+// IcebergQuantity set icebergQty
+func (l *MarginOrderListLeg) IcebergQuantity(icebergQuantity string) *MarginOrderListLeg {
+	l.icebergQuantity = &icebergQuantity
+	return l
+}
+
+// setParams write the leg params with prefix, e.g. workingType, workingPrice for prefix working.
+// Side and quantity are left to the list when withSideQuantity is false
+func (l *MarginOrderListLeg) setParams(m params, prefix string, withSideQuantity bool) {
+	if l == nil {
+		return
+	}
+	m[prefix+"Type"] = l.orderType
+	if withSideQuantity && l.side != nil {
+		m[prefix+"Side"] = *l.side
+	}
+	if withSideQuantity && l.quantity != nil {
+		m[prefix+"Quantity"] = *l.quantity
+	}
+	if l.price != nil {
+		m[prefix+"Price"] = *l.price
+	}
+	if l.stopPrice != nil {
+		m[prefix+"StopPrice"] = *l.stopPrice
+	}
+	if l.trailingDelta != nil {
+		m[prefix+"TrailingDelta"] = *l.trailingDelta
+	}
+	if l.timeInForce != nil {
+		m[prefix+"TimeInForce"] = *l.timeInForce
+	}
+	if l.clientOrderID != nil {
+		m[prefix+"ClientOrderId"] = *l.clientOrderID
+	}
+	if l.icebergQuantity != nil {
+		m[prefix+"IcebergQty"] = *l.icebergQuantity
+	}
+}
+
+// marginOrderListParams define the params shared by margin order lists
+type marginOrderListParams struct {
+	isIsolated              *bool
+	listClientOrderID       *string
+	newOrderRespType        *NewOrderRespType
+	sideEffectType          *SideEffectType
+	selfTradePreventionMode *SelfTradePreventionModeType
+	autoRepayAtCancel       *bool
+}
+
+func (p *marginOrderListParams) setParams(m params) {
+	if p.isIsolated != nil {
+		if *p.isIsolated {
+			m["isIsolated"] = "TRUE"
+		} else {
+			m["isIsolated"] = "FALSE"
+		}
+	}
+	if p.listClientOrderID != nil {
+		m["listClientOrderId"] = *p.listClientOrderID
+	}
+	if p.newOrderRespType != nil {
+		m["newOrderRespType"] = *p.newOrderRespType
+	}
+	if p.sideEffectType != nil {
+		m["sideEffectType"] = *p.sideEffectType
+	}
+	if p.selfTradePreventionMode != nil {
+		m["selfTradePreventionMode"] = *p.selfTradePreventionMode
+	}
+	if p.autoRepayAtCancel != nil {
+		m["autoRepayAtCancel"] = *p.autoRepayAtCancel
+	}
+}
+
+// createMarginOrderList send the order list request. OTO and OTOCO responses share the layout of OCO
+func createMarginOrderList(ctx context.Context, c *Client, endpoint string, m params, opts ...RequestOption) (res *CreateMarginOCOResponse, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: endpoint,
+		secType:  secTypeSigned,
+	}
+	r.setFormParams(m)
+	data, err := c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CreateMarginOCOResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateMarginOTOService create a margin OTO order list: the pending order is placed once the working order is filled
+type CreateMarginOTOService struct {
+	c       *Client
+	symbol  string
+	working *MarginOrderListLeg
+	pending *MarginOrderListLeg
+	marginOrderListParams
+}
+
+// Symbol set symbol
+func (s *CreateMarginOTOService) Symbol(symbol string) *CreateMarginOTOService {
+	s.symbol = symbol
+	return s
+}
+
+// IsIsolated set isIsolated
+func (s *CreateMarginOTOService) IsIsolated(isIsolated bool) *CreateMarginOTOService {
+	s.isIsolated = &isIsolated
+	return s
+}
+
+// Working set the working leg: LIMIT or LIMIT_MAKER
+func (s *CreateMarginOTOService) Working(working *MarginOrderListLeg) *CreateMarginOTOService {
+	s.working = working
+	return s
+}
+
+// Pending set the pending leg, any order type except MARKET with quoteOrderQty
+func (s *CreateMarginOTOService) Pending(pending *MarginOrderListLeg) *CreateMarginOTOService {
+	s.pending = pending
+	return s
+}
+
+// ListClientOrderID set listClientOrderID
+func (s *CreateMarginOTOService) ListClientOrderID(listClientOrderID string) *CreateMarginOTOService {
+	s.listClientOrderID = &listClientOrderID
+	return s
+}
+
+// NewOrderRespType set newOrderRespType
+func (s *CreateMarginOTOService) NewOrderRespType(newOrderRespType NewOrderRespType) *CreateMarginOTOService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+// SideEffectType set sideEffectType
+func (s *CreateMarginOTOService) SideEffectType(sideEffectType SideEffectType) *CreateMarginOTOService {
+	s.sideEffectType = &sideEffectType
+	return s
+}
+
+// SelfTradePreventionMode set selfTradePreventionMode
+func (s *CreateMarginOTOService) SelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionModeType) *CreateMarginOTOService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
+// AutoRepayAtCancel set autoRepayAtCancel, only used with AUTO_REPAY or AUTO_BORROW_REPAY side effect
+func (s *CreateMarginOTOService) AutoRepayAtCancel(autoRepayAtCancel bool) *CreateMarginOTOService {
+	s.autoRepayAtCancel = &autoRepayAtCancel
+	return s
+}
+
+// Do send request
+func (s *CreateMarginOTOService) Do(ctx context.Context, opts ...RequestOption) (res *CreateMarginOCOResponse, err error) {
+	m := params{
+		"symbol": s.symbol,
+	}
+	s.setParams(m)
+	s.working.setParams(m, "working", true)
+	s.pending.setParams(m, "pending", true)
+	return createMarginOrderList(ctx, s.c, "/sapi/v1/margin/order/oto", m, opts...)
+}
+
+// CreateMarginOTOCOService create a margin OTOCO order list: an OCO pair is placed once the working order is filled
+type CreateMarginOTOCOService struct {
+	c               *Client
+	symbol          string
+	working         *MarginOrderListLeg
+	pendingSide     SideType
+	pendingQuantity string
+	pendingAbove    *MarginOrderListLeg
+	pendingBelow    *MarginOrderListLeg
+	marginOrderListParams
+}
+
+// Symbol set symbol
+func (s *CreateMarginOTOCOService) Symbol(symbol string) *CreateMarginOTOCOService {
+	s.symbol = symbol
+	return s
+}
+
+// IsIsolated set isIsolated
+func (s *CreateMarginOTOCOService) IsIsolated(isIsolated bool) *CreateMarginOTOCOService {
+	s.isIsolated = &isIsolated
+	return s
+}
+
+// Working set the working leg: LIMIT or LIMIT_MAKER
+func (s *CreateMarginOTOCOService) Working(working *MarginOrderListLeg) *CreateMarginOTOCOService {
+	s.working = working
+	return s
+}
+
+// PendingSide set side of both pending legs
+func (s *CreateMarginOTOCOService) PendingSide(pendingSide SideType) *CreateMarginOTOCOService {
+	s.pendingSide = pendingSide
+	return s
+}
+
+// PendingQuantity set quantity of both pending legs
+func (s *CreateMarginOTOCOService) PendingQuantity(pendingQuantity string) *CreateMarginOTOCOService {
+	s.pendingQuantity = pendingQuantity
+	return s
+}
+
+// PendingAbove set the pending above leg: LIMIT_MAKER, STOP_LOSS or STOP_LOSS_LIMIT
+func (s *CreateMarginOTOCOService) PendingAbove(pendingAbove *MarginOrderListLeg) *CreateMarginOTOCOService {
+	s.pendingAbove = pendingAbove
+	return s
+}
+
+// PendingBelow set the pending below leg: LIMIT_MAKER, STOP_LOSS or STOP_LOSS_LIMIT
+func (s *CreateMarginOTOCOService) PendingBelow(pendingBelow *MarginOrderListLeg) *CreateMarginOTOCOService {
+	s.pendingBelow = pendingBelow
+	return s
+}
+
+// ListClientOrderID set listClientOrderID
+func (s *CreateMarginOTOCOService) ListClientOrderID(listClientOrderID string) *CreateMarginOTOCOService {
+	s.listClientOrderID = &listClientOrderID
+	return s
+}
+
+// NewOrderRespType set newOrderRespType
+func (s *CreateMarginOTOCOService) NewOrderRespType(newOrderRespType NewOrderRespType) *CreateMarginOTOCOService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+// SideEffectType set sideEffectType
+func (s *CreateMarginOTOCOService) SideEffectType(sideEffectType SideEffectType) *CreateMarginOTOCOService {
+	s.sideEffectType = &sideEffectType
+	return s
+}
+
+// SelfTradePreventionMode set selfTradePreventionMode
+func (s *CreateMarginOTOCOService) SelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionModeType) *CreateMarginOTOCOService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
+// AutoRepayAtCancel set autoRepayAtCancel, only used with AUTO_REPAY or AUTO_BORROW_REPAY side effect
+func (s *CreateMarginOTOCOService) AutoRepayAtCancel(autoRepayAtCancel bool) *CreateMarginOTOCOService {
+	s.autoRepayAtCancel = &autoRepayAtCancel
+	return s
+}
+
+// Do send request
+func (s *CreateMarginOTOCOService) Do(ctx context.Context, opts ...RequestOption) (res *CreateMarginOCOResponse, err error) {
+	m := params{
+		"symbol":          s.symbol,
+		"pendingSide":     s.pendingSide,
+		"pendingQuantity": s.pendingQuantity,
+	}
+	s.setParams(m)
+	s.working.setParams(m, "working", true)
+	s.pendingAbove.setParams(m, "pendingAbove", false)
+	s.pendingBelow.setParams(m, "pendingBelow", false)
+	return createMarginOrderList(ctx, s.c, "/sapi/v1/margin/order/otoco", m, opts...)
+}
@@ -2,25 +2,28 @@ package margin
 
 import (
 	"context"
+	stdjson "encoding/json"
 	"net/http"
 )
 
 // CreateMarginOrderService create order
 type CreateMarginOrderService struct {
-	c                *Client
-	symbol           string
-	side             SideType
-	orderType        OrderType
-	quantity         *string
-	quoteOrderQty    *string
-	price            *string
-	stopPrice        *string
-	newClientOrderID *string
-	icebergQuantity  *string
-	newOrderRespType *NewOrderRespType
-	sideEffectType   *SideEffectType
-	timeInForce      *TimeInForceType
-	isIsolated       *bool
+	c                       *Client
+	symbol                  string
+	side                    SideType
+	orderType               OrderType
+	quantity                *string
+	quoteOrderQty           *string
+	price                   *string
+	stopPrice               *string
+	newClientOrderID        *string
+	icebergQuantity         *string
+	newOrderRespType        *NewOrderRespType
+	sideEffectType          *SideEffectType
+	timeInForce             *TimeInForceType
+	isIsolated              *bool
+	selfTradePreventionMode *SelfTradePreventionModeType
+	autoRepayAtCancel       *bool
 }
 
 // Symbol set symbol
@@ -101,6 +104,19 @@ func (s *CreateMarginOrderService) SideEffectType(sideEffectType SideEffectType)
 	return s
 }
 
+// SelfTradePreventionMode set selfTradePreventionMode
+func (s *CreateMarginOrderService) SelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionModeType) *CreateMarginOrderService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
+// AutoRepayAtCancel set autoRepayAtCancel, whether the debt borrowed by the order is repaid when it is canceled.
+// Only used with AUTO_REPAY or AUTO_BORROW_REPAY side effect, true by default
+func (s *CreateMarginOrderService) AutoRepayAtCancel(autoRepayAtCancel bool) *CreateMarginOrderService {
+	s.autoRepayAtCancel = &autoRepayAtCancel
+	return s
+}
+
 // Do send request
 func (s *CreateMarginOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CreateOrderResponse, err error) {
 	r := &request{
@@ -134,6 +150,9 @@ func (s *CreateMarginOrderService) Do(ctx context.Context, opts ...RequestOption
 	}
 	if s.newClientOrderID != nil {
 		m["newClientOrderId"] = *s.newClientOrderID
+	} else if s.c.OrderIDs != nil {
+		// 生成的订单号只用于本次请求, 不写回 service
+		m["newClientOrderId"] = s.c.OrderIDs.Next()
 	}
 	if s.stopPrice != nil {
 		m["stopPrice"] = *s.stopPrice
@@ -147,6 +166,12 @@ func (s *CreateMarginOrderService) Do(ctx context.Context, opts ...RequestOption
 	if s.sideEffectType != nil {
 		m["sideEffectType"] = *s.sideEffectType
 	}
+	if s.selfTradePreventionMode != nil {
+		m["selfTradePreventionMode"] = *s.selfTradePreventionMode
+	}
+	if s.autoRepayAtCancel != nil {
+		m["autoRepayAtCancel"] = *s.autoRepayAtCancel
+	}
 	r.setFormParams(m)
 	res = new(CreateOrderResponse)
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -504,22 +529,24 @@ type CancelMarginOrderResponse struct {
 
 // CreateMarginOCOService create a new OCO for a margin account
 type CreateMarginOCOService struct {
-	c                    *Client
-	symbol               string
-	isIsolated           *bool
-	listClientOrderID    *string
-	side                 SideType
-	quantity             *string
-	limitClientOrderID   *string
-	price                *string
-	limitIcebergQty      *string
-	stopClientOrderID    *string
-	stopPrice            *string
-	stopLimitPrice       *string
-	stopIcebergQty       *string
-	stopLimitTimeInForce *TimeInForceType
-	newOrderRespType     *NewOrderRespType
-	sideEffectType       *SideEffectType
+	c                       *Client
+	symbol                  string
+	isIsolated              *bool
+	listClientOrderID       *string
+	side                    SideType
+	quantity                *string
+	limitClientOrderID      *string
+	price                   *string
+	limitIcebergQty         *string
+	stopClientOrderID       *string
+	stopPrice               *string
+	stopLimitPrice          *string
+	stopIcebergQty          *string
+	stopLimitTimeInForce    *TimeInForceType
+	newOrderRespType        *NewOrderRespType
+	sideEffectType          *SideEffectType
+	selfTradePreventionMode *SelfTradePreventionModeType
+	autoRepayAtCancel       *bool
 }
 
 // Symbol set symbol
@@ -612,6 +639,18 @@ func (s *CreateMarginOCOService) SideEffectType(sideEffectType SideEffectType) *
 	return s
 }
 
+// SelfTradePreventionMode set selfTradePreventionMode
+func (s *CreateMarginOCOService) SelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionModeType) *CreateMarginOCOService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
+// AutoRepayAtCancel set autoRepayAtCancel, only used with AUTO_REPAY or AUTO_BORROW_REPAY side effect
+func (s *CreateMarginOCOService) AutoRepayAtCancel(autoRepayAtCancel bool) *CreateMarginOCOService {
+	s.autoRepayAtCancel = &autoRepayAtCancel
+	return s
+}
+
 func (s *CreateMarginOCOService) createOrder(ctx context.Context, opts ...RequestOption) (data []byte, err error) {
 	r := &request{
 		method:   http.MethodPost,
@@ -660,6 +699,12 @@ func (s *CreateMarginOCOService) createOrder(ctx context.Context, opts ...Reques
 	if s.sideEffectType != nil {
 		m["sideEffectType"] = *s.sideEffectType
 	}
+	if s.selfTradePreventionMode != nil {
+		m["selfTradePreventionMode"] = *s.selfTradePreventionMode
+	}
+	if s.autoRepayAtCancel != nil {
+		m["autoRepayAtCancel"] = *s.autoRepayAtCancel
+	}
 	r.setFormParams(m)
 	data, err = s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -808,3 +853,236 @@ type CancelMarginOCOResponse struct {
 	Orders            []*MarginOCOOrder       `json:"orders"`
 	OrderReports      []*MarginOCOOrderReport `json:"orderReports"`
 }
+
+// CancelMarginOpenOrdersService cancel all open orders on a symbol, including the orders of order lists
+type CancelMarginOpenOrdersService struct {
+	c          *Client
+	symbol     string
+	isIsolated *bool
+}
+
+// Symbol set symbol
+func (s *CancelMarginOpenOrdersService) Symbol(symbol string) *CancelMarginOpenOrdersService {
+	s.symbol = symbol
+	return s
+}
+
+// IsIsolated set isIsolated
+func (s *CancelMarginOpenOrdersService) IsIsolated(isIsolated bool) *CancelMarginOpenOrdersService {
+	s.isIsolated = &isIsolated
+	return s
+}
+
+// Do send request
+func (s *CancelMarginOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (res *CancelMarginOpenOrdersResponse, err error) {
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: "/sapi/v1/margin/openOrders",
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("symbol", s.symbol)
+	if s.isIsolated != nil {
+		if *s.isIsolated {
+			r.setFormParam("isIsolated", "TRUE")
+		} else {
+			r.setFormParam("isIsolated", "FALSE")
+		}
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return &CancelMarginOpenOrdersResponse{}, err
+	}
+	rawMessages := make([]*stdjson.RawMessage, 0)
+	err = json.Unmarshal(data, &rawMessages)
+	if err != nil {
+		return &CancelMarginOpenOrdersResponse{}, err
+	}
+	res = new(CancelMarginOpenOrdersResponse)
+	for _, j := range rawMessages {
+		var head struct {
+			OrderListID     int64  `json:"orderListId"`
+			ContingencyType string `json:"contingencyType"`
+		}
+		if err := json.Unmarshal(*j, &head); err != nil {
+			return &CancelMarginOpenOrdersResponse{}, err
+		}
+		// order lists carry a contingencyType, single orders don't
+		if head.ContingencyType == "" {
+			o := new(CanceledMarginOrder)
+			if err := json.Unmarshal(*j, o); err != nil {
+				return &CancelMarginOpenOrdersResponse{}, err
+			}
+			res.Orders = append(res.Orders, o)
+			continue
+		}
+		l := new(CancelMarginOCOResponse)
+		if err := json.Unmarshal(*j, l); err != nil {
+			return &CancelMarginOpenOrdersResponse{}, err
+		}
+		res.OrderLists = append(res.OrderLists, l)
+	}
+	return res, nil
+}
+
+// CancelMarginOpenOrdersResponse define response of canceling all open orders
+type CancelMarginOpenOrdersResponse struct {
+	Orders     []*CanceledMarginOrder
+	OrderLists []*CancelMarginOCOResponse
+}
+
+// CanceledMarginOrder define a single order canceled by CancelMarginOpenOrdersService
+type CanceledMarginOrder struct {
+	Symbol                   string          `json:"symbol"`
+	IsIsolated               bool            `json:"isIsolated"`
+	OrigClientOrderID        string          `json:"origClientOrderId"`
+	OrderID                  int64           `json:"orderId"`
+	OrderListID              int64           `json:"orderListId"`
+	ClientOrderID            string          `json:"clientOrderId"`
+	Price                    string          `json:"price"`
+	OrigQuantity             string          `json:"origQty"`
+	ExecutedQuantity         string          `json:"executedQty"`
+	CummulativeQuoteQuantity string          `json:"cummulativeQuoteQty"`
+	Status                   OrderStatusType `json:"status"`
+	TimeInForce              TimeInForceType `json:"timeInForce"`
+	Type                     OrderType       `json:"type"`
+	Side                     SideType        `json:"side"`
+	SelfTradePreventionMode  string          `json:"selfTradePreventionMode"`
+}
+
+// GetMarginOrderRateLimitService get the unfilled order count of all intervals
+type GetMarginOrderRateLimitService struct {
+	c          *Client
+	symbol     *string
+	isIsolated bool
+}
+
+// Symbol set symbol, required for isolated margin
+func (s *GetMarginOrderRateLimitService) Symbol(symbol string) *GetMarginOrderRateLimitService {
+	s.symbol = &symbol
+	return s
+}
+
+// IsIsolated set isIsolated
+func (s *GetMarginOrderRateLimitService) IsIsolated(isIsolated bool) *GetMarginOrderRateLimitService {
+	s.isIsolated = isIsolated
+	return s
+}
+
+// Do send request
+func (s *GetMarginOrderRateLimitService) Do(ctx context.Context, opts ...RequestOption) (res []*RateLimitFull, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/margin/rateLimit/order",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	if s.isIsolated {
+		r.setParam("isIsolated", "TRUE")
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*RateLimitFull{}, err
+	}
+	res = make([]*RateLimitFull, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*RateLimitFull{}, err
+	}
+	return res, nil
+}
+
+// RateLimitFull define a rate limit with the current count
+type RateLimitFull struct {
+	RateLimitType RateLimitType     `json:"rateLimitType"`
+	Interval      RateLimitInterval `json:"interval"`
+	IntervalNum   int               `json:"intervalNum"`
+	Limit         int               `json:"limit"`
+	Count         int               `json:"count"`
+}
+
+// ListMarginPreventedMatchesService list orders expired because of self-trade prevention
+type ListMarginPreventedMatchesService struct {
+	c                    *Client
+	symbol               string
+	preventedMatchID     *int64
+	orderID              *int64
+	fromPreventedMatchID *int64
+	isIsolated           bool
+}
+
+// Symbol set symbol
+func (s *ListMarginPreventedMatchesService) Symbol(symbol string) *ListMarginPreventedMatchesService {
+	s.symbol = symbol
+	return s
+}
+
+// PreventedMatchID set preventedMatchID
+func (s *ListMarginPreventedMatchesService) PreventedMatchID(preventedMatchID int64) *ListMarginPreventedMatchesService {
+	s.preventedMatchID = &preventedMatchID
+	return s
+}
+
+// OrderID set orderID
+func (s *ListMarginPreventedMatchesService) OrderID(orderID int64) *ListMarginPreventedMatchesService {
+	s.orderID = &orderID
+	return s
+}
+
+// FromPreventedMatchID set fromPreventedMatchID, used with orderID
+func (s *ListMarginPreventedMatchesService) FromPreventedMatchID(fromPreventedMatchID int64) *ListMarginPreventedMatchesService {
+	s.fromPreventedMatchID = &fromPreventedMatchID
+	return s
+}
+
+// IsIsolated set isIsolated
+func (s *ListMarginPreventedMatchesService) IsIsolated(isIsolated bool) *ListMarginPreventedMatchesService {
+	s.isIsolated = isIsolated
+	return s
+}
+
+// Do send request
+func (s *ListMarginPreventedMatchesService) Do(ctx context.Context, opts ...RequestOption) (res []*PreventedMatch, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/margin/myPreventedMatches",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.preventedMatchID != nil {
+		r.setParam("preventedMatchId", *s.preventedMatchID)
+	}
+	if s.orderID != nil {
+		r.setParam("orderId", *s.orderID)
+	}
+	if s.fromPreventedMatchID != nil {
+		r.setParam("fromPreventedMatchId", *s.fromPreventedMatchID)
+	}
+	if s.isIsolated {
+		r.setParam("isIsolated", "TRUE")
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*PreventedMatch{}, err
+	}
+	res = make([]*PreventedMatch, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*PreventedMatch{}, err
+	}
+	return res, nil
+}
+
+// PreventedMatch define an order expired because of self-trade prevention
+type PreventedMatch struct {
+	Symbol                  string `json:"symbol"`
+	PreventedMatchID        int64  `json:"preventedMatchId"`
+	TakerOrderID            int64  `json:"takerOrderId"`
+	MakerOrderID            int64  `json:"makerOrderId"`
+	TradeGroupID            int64  `json:"tradeGroupId"`
+	SelfTradePreventionMode string `json:"selfTradePreventionMode"`
+	Price                   string `json:"price"`
+	MakerPreventedQuantity  string `json:"makerPreventedQuantity"`
+	TransactTime            int64  `json:"transactTime"`
+}
@@ -0,0 +1,175 @@
+package margin
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultPortfolioQuote quote asset used by Portfolio when none is given
+const DefaultPortfolioQuote = "USDT"
+
+// portfolioBridges assets tried, in order, to value an asset without a direct pair against the quote
+var portfolioBridges = []string{"BTC", "USDT", "BNB", "ETH"}
+
+// PortfolioAsset define the holdings of an asset in the spot and funding wallets valued in the quote asset
+type PortfolioAsset struct {
+	Asset   string
+	Spot    float64 // free, locked, frozen and withdrawing in the spot wallet
+	Funding float64 // free, locked, frozen and withdrawing in the funding wallet
+	Total   float64
+	Price   float64 // price in the quote asset, 0 when it can't be priced
+	Value   float64
+}
+
+// Portfolio define the value of the account in a quote asset
+type Portfolio struct {
+	Quote        string
+	Assets       []*PortfolioAsset // spot and funding assets, by value descending
+	SpotValue    float64
+	FundingValue float64
+	Wallets      map[string]float64 // balance of every wallet valued by the exchange, keyed by wallet name
+	WalletsValue float64            // total of Wallets, including margin, futures and earn wallets
+	Unpriced     []string           // assets held without a price path to the quote asset
+	Time         int64
+}
+
+// Portfolio value the account in quote, USDT by default. Spot and funding assets are valued with spot prices,
+// directly or through BTC, USDT, BNB or ETH; Simple Earn flexible assets (LDxxx) are valued as the underlying asset.
+// The wallet overview gives the exchange's own valuation of every wallet, including margin, futures and earn
+func (c *Client) Portfolio(ctx context.Context, quote string, opts ...RequestOption) (*Portfolio, error) {
+	if quote == "" {
+		quote = DefaultPortfolioQuote
+	}
+	spot, err := c.NewGetUserAsset().Do(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	funding, err := c.NewGetFundingAssetService().Do(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	wallets, err := c.NewGetWalletBalanceService().QuoteAsset(quote).Do(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	prices, err := c.spotPrices(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Portfolio{
+		Quote:    quote,
+		Assets:   make([]*PortfolioAsset, 0),
+		Wallets:  make(map[string]float64, len(wallets)),
+		Unpriced: make([]string, 0),
+		Time:     time.Now().UnixMilli(),
+	}
+	assets := make(map[string]*PortfolioAsset)
+	get := func(asset string) *PortfolioAsset {
+		a := assets[asset]
+		if a == nil {
+			a = &PortfolioAsset{Asset: asset}
+			assets[asset] = a
+			res.Assets = append(res.Assets, a)
+		}
+		return a
+	}
+	for _, r := range spot {
+		get(r.Asset).Spot += parseFloat(r.Free) + parseFloat(r.Locked) + parseFloat(r.Freeze) + parseFloat(r.Withdrawing)
+	}
+	for _, r := range funding {
+		get(r.Asset).Funding += parseFloat(r.Free) + parseFloat(r.Locked) + parseFloat(r.Freeze) + parseFloat(r.Withdrawing)
+	}
+	for _, a := range res.Assets {
+		a.Total = a.Spot + a.Funding
+		price, ok := portfolioPrice(prices, a.Asset, quote)
+		if !ok && strings.HasPrefix(a.Asset, "LD") {
+			price, ok = portfolioPrice(prices, strings.TrimPrefix(a.Asset, "LD"), quote)
+		}
+		if !ok {
+			if a.Total != 0 {
+				res.Unpriced = append(res.Unpriced, a.Asset)
+			}
+			continue
+		}
+		a.Price = price
+		a.Value = a.Total * price
+		res.SpotValue += a.Spot * price
+		res.FundingValue += a.Funding * price
+	}
+	sort.Slice(res.Assets, func(i, j int) bool { return res.Assets[i].Value > res.Assets[j].Value })
+	for _, w := range wallets {
+		v := parseFloat(w.Balance)
+		res.Wallets[w.WalletName] = v
+		res.WalletsValue += v
+	}
+	return res, nil
+}
+
+// Value return the total value of the account: the wallet overview when available, else the spot and funding assets
+func (p *Portfolio) Value() float64 {
+	if len(p.Wallets) > 0 {
+		return p.WalletsValue
+	}
+	return p.SpotValue + p.FundingValue
+}
+
+// portfolioPrice price asset in quote with the spot prices, directly, inversely or through a bridge asset
+func portfolioPrice(prices map[string]float64, asset, quote string) (float64, bool) {
+	if asset == quote {
+		return 1, true
+	}
+	if p, ok := pairPrice(prices, asset, quote); ok {
+		return p, true
+	}
+	for _, bridge := range portfolioBridges {
+		if bridge == asset || bridge == quote {
+			continue
+		}
+		p1, ok1 := pairPrice(prices, asset, bridge)
+		p2, ok2 := pairPrice(prices, bridge, quote)
+		if ok1 && ok2 {
+			return p1 * p2, true
+		}
+	}
+	return 0, false
+}
+
+// pairPrice price of base in quote from the BASEQUOTE or QUOTEBASE symbol
+func pairPrice(prices map[string]float64, base, quote string) (float64, bool) {
+	if p, ok := prices[base+quote]; ok && p > 0 {
+		return p, true
+	}
+	if p, ok := prices[quote+base]; ok && p > 0 {
+		return 1 / p, true
+	}
+	return 0, false
+}
+
+// spotPrices latest spot price of every symbol
+func (c *Client) spotPrices(ctx context.Context, opts ...RequestOption) (map[string]float64, error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/api/v3/ticker/price",
+		secType:  setTypeNone,
+	}
+	data, err := c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var tickers []struct {
+		Symbol string `json:"symbol"`
+		Price  string `json:"price"`
+	}
+	if err = json.Unmarshal(data, &tickers); err != nil {
+		return nil, err
+	}
+	res := make(map[string]float64, len(tickers))
+	for _, t := range tickers {
+		res[t.Symbol] = parseFloat(t.Price)
+	}
+	return res, nil
+}
@@ -0,0 +1,411 @@
+package margin
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Default margin levels of the margin call and liquidation, see RiskMonitor.Thresholds
+const (
+	DefaultMarginCallLevel  = 1.1
+	DefaultLiquidationLevel = 1.05
+)
+
+// RiskLevel define a margin level at which the monitor alerts, and the playbook run when the level is crossed
+type RiskLevel struct {
+	Name        string
+	MarginLevel float64
+	Actions     []RiskAction
+}
+
+// RiskAsset define the balance and debt of an asset
+type RiskAsset struct {
+	Asset    string
+	Free     float64
+	Locked   float64
+	Borrowed float64
+	Interest float64
+}
+
+// Debt return borrowed plus interest
+func (a RiskAsset) Debt() float64 {
+	return a.Borrowed + a.Interest
+}
+
+// RiskStatus define the margin risk of the cross margin account or of an isolated pair.
+// For the cross account values are in BTC and distances are the fraction of the asset value that can be lost
+// against the liabilities; for isolated pairs values are in the quote asset and distances are the relative
+// move of the index price, negative when the price has to fall
+type RiskStatus struct {
+	Symbol              string  // isolated pair, empty for the cross margin account
+	BaseAsset           string  // isolated only
+	QuoteAsset          string  // isolated only
+	MarginLevel         float64 // margin level of the exchange
+	ComputedMarginLevel float64 // isolated only, margin level computed at IndexPrice, 0 without liabilities
+	TotalAsset          float64
+	TotalLiability      float64
+	IndexPrice          float64 // isolated only
+	MarginCallPrice     float64 // isolated only, 0 when the level can't be reached by the price
+	LiquidationPrice    float64 // isolated only, 0 when the level can't be reached by the price
+	MarginCallDistance  float64
+	LiquidationDistance float64
+	Assets              []RiskAsset
+	Time                int64
+}
+
+// IsIsolated return whether the status is of an isolated pair
+func (s *RiskStatus) IsIsolated() bool {
+	return s.Symbol != ""
+}
+
+// RiskAlert define an alert fired when the margin level falls to a RiskLevel
+type RiskAlert struct {
+	Level  RiskLevel
+	Status *RiskStatus
+}
+
+// RiskAlertHandler handle RiskAlert
+type RiskAlertHandler func(alert *RiskAlert)
+
+// RiskAction define an action of a RiskLevel playbook
+type RiskAction func(ctx context.Context, m *RiskMonitor, status *RiskStatus) error
+
+// RiskMonitor poll the margin level of the cross margin account and the isolated pairs, fire alerts when
+// configured levels are crossed and run their playbooks. Feed it user data events with HandleUserData to
+// check right away on margin level changes instead of waiting for the next poll
+type RiskMonitor struct {
+	c                *Client
+	handler          RiskAlertHandler
+	errHandler       ErrHandler
+	interval         time.Duration
+	marginCallLevel  float64
+	liquidationLevel float64
+	levels           []RiskLevel
+	cross            bool
+	isolated         bool
+	symbols          []string
+	formatQuantity   func(symbol string, quantity float64) string
+
+	mu       sync.Mutex
+	breached map[string]map[int]bool // symbol -> index of level
+	trigger  chan struct{}
+}
+
+// NewRiskMonitor init a risk monitor of the cross margin account and all isolated pairs, handler and errHandler can be nil
+func (c *Client) NewRiskMonitor(handler RiskAlertHandler, errHandler ErrHandler) *RiskMonitor {
+	return &RiskMonitor{
+		c:                c,
+		handler:          handler,
+		errHandler:       errHandler,
+		interval:         time.Minute,
+		marginCallLevel:  DefaultMarginCallLevel,
+		liquidationLevel: DefaultLiquidationLevel,
+		cross:            true,
+		isolated:         true,
+		formatQuantity: func(symbol string, quantity float64) string {
+			return formatFloat(quantity)
+		},
+		breached: make(map[string]map[int]bool),
+		trigger:  make(chan struct{}, 1),
+	}
+}
+
+// Interval set the poll interval, 1 minute by default
+func (m *RiskMonitor) Interval(interval time.Duration) *RiskMonitor {
+	m.interval = interval
+	return m
+}
+
+// Thresholds set the margin levels of the margin call and liquidation used to compute distances
+func (m *RiskMonitor) Thresholds(marginCall, liquidation float64) *RiskMonitor {
+	m.marginCallLevel = marginCall
+	m.liquidationLevel = liquidation
+	return m
+}
+
+// Level add an alert level
+func (m *RiskMonitor) Level(level RiskLevel) *RiskMonitor {
+	m.levels = append(m.levels, level)
+	return m
+}
+
+// Cross set whether to monitor the cross margin account, true by default
+func (m *RiskMonitor) Cross(cross bool) *RiskMonitor {
+	m.cross = cross
+	return m
+}
+
+// Isolated set whether to monitor isolated pairs, true by default. With symbols only these pairs are monitored
+func (m *RiskMonitor) Isolated(isolated bool, symbols ...string) *RiskMonitor {
+	m.isolated = isolated
+	m.symbols = symbols
+	return m
+}
+
+// QuantityFormatter set how order quantities of ReducePositions are formatted, e.g. to round to the lot size
+func (m *RiskMonitor) QuantityFormatter(format func(symbol string, quantity float64) string) *RiskMonitor {
+	m.formatQuantity = format
+	return m
+}
+
+// HandleUserData check right away on margin level, liability and balance events, other events are ignored.
+// It can be used as, or called from, the handler of a UserDataSubscriber
+func (m *RiskMonitor) HandleUserData(event *WsUserDataEvent) {
+	switch event.Event {
+	case UserDataEventTypeMarginLevelStatusChange, UserDataEventTypeLiabilityChange, UserDataEventTypeOutboundAccountPosition:
+		select {
+		case m.trigger <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Run check every interval, and on user data events, until ctx is done
+func (m *RiskMonitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		if _, err := m.Check(ctx); err != nil {
+			m.handleErr(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-m.trigger:
+		}
+	}
+}
+
+// Check query the monitored accounts once, fire alerts and run playbooks of newly crossed levels
+func (m *RiskMonitor) Check(ctx context.Context) (res []*RiskStatus, err error) {
+	if m.cross {
+		account, err := m.c.NewGetMarginAccountService().Do(ctx)
+		if err != nil {
+			return res, err
+		}
+		res = append(res, m.crossStatus(account))
+	}
+	if m.isolated {
+		account, err := m.c.NewGetIsolatedMarginAccountService().Symbols(m.symbols...).Do(ctx)
+		if err != nil {
+			return res, err
+		}
+		for _, a := range account.Assets {
+			if !a.IsolatedCreated {
+				continue
+			}
+			price := parseFloat(a.IndexPrice)
+			index, err := m.c.NewGetMarginPriceIndexService().Symbol(a.Symbol).Do(ctx)
+			if err != nil {
+				// 指数价格查询失败时使用账户接口返回的价格
+				m.handleErr(err)
+			} else {
+				price = parseFloat(index.Price)
+			}
+			res = append(res, m.isolatedStatus(a, price))
+		}
+	}
+	for _, status := range res {
+		m.evaluate(ctx, status)
+	}
+	return res, nil
+}
+
+func (m *RiskMonitor) crossStatus(account *MarginAccount) *RiskStatus {
+	status := &RiskStatus{
+		MarginLevel:    parseFloat(account.MarginLevel),
+		TotalAsset:     parseFloat(account.TotalAssetOfBTC),
+		TotalLiability: parseFloat(account.TotalLiabilityOfBTC),
+		Time:           currentTimestamp(),
+	}
+	for _, a := range account.UserAssets {
+		status.Assets = append(status.Assets, RiskAsset{
+			Asset:    a.Asset,
+			Free:     parseFloat(a.Free),
+			Locked:   parseFloat(a.Locked),
+			Borrowed: parseFloat(a.Borrowed),
+			Interest: parseFloat(a.Interest),
+		})
+	}
+	// 资产价值相对负债下跌 x 时风险率降至 k: A(1-x) = kL
+	status.MarginCallDistance, status.LiquidationDistance = 1, 1
+	if status.TotalAsset > 0 && status.TotalLiability > 0 {
+		status.MarginCallDistance = 1 - m.marginCallLevel*status.TotalLiability/status.TotalAsset
+		status.LiquidationDistance = 1 - m.liquidationLevel*status.TotalLiability/status.TotalAsset
+	}
+	return status
+}
+
+func (m *RiskMonitor) isolatedStatus(a IsolatedMarginAsset, price float64) *RiskStatus {
+	status := &RiskStatus{
+		Symbol:      a.Symbol,
+		BaseAsset:   a.BaseAsset.Asset,
+		QuoteAsset:  a.QuoteAsset.Asset,
+		MarginLevel: parseFloat(a.MarginLevel),
+		IndexPrice:  price,
+		Time:        currentTimestamp(),
+	}
+	for _, ua := range []IsolatedUserAsset{a.BaseAsset, a.QuoteAsset} {
+		status.Assets = append(status.Assets, RiskAsset{
+			Asset:    ua.Asset,
+			Free:     parseFloat(ua.Free),
+			Locked:   parseFloat(ua.Locked),
+			Borrowed: parseFloat(ua.Borrowed),
+			Interest: parseFloat(ua.Interest),
+		})
+	}
+	base, quote := status.Assets[0], status.Assets[1]
+	baseTotal, quoteTotal := base.Free+base.Locked, quote.Free+quote.Locked
+	status.TotalAsset = baseTotal*price + quoteTotal
+	status.TotalLiability = base.Debt()*price + quote.Debt()
+	if status.TotalLiability > 0 {
+		status.ComputedMarginLevel = status.TotalAsset / status.TotalLiability
+	}
+	// 风险率为 k 时的价格: baseTotal*P + quoteTotal = k*(baseDebt*P + quoteDebt)
+	levelPrice := func(k float64) float64 {
+		d := baseTotal - k*base.Debt()
+		if d == 0 {
+			return 0
+		}
+		p := (k*quote.Debt() - quoteTotal) / d
+		if p <= 0 || math.IsInf(p, 0) {
+			return 0
+		}
+		return p
+	}
+	status.MarginCallPrice = levelPrice(m.marginCallLevel)
+	status.LiquidationPrice = levelPrice(m.liquidationLevel)
+	if price > 0 && status.MarginCallPrice > 0 {
+		status.MarginCallDistance = (status.MarginCallPrice - price) / price
+	}
+	if price > 0 && status.LiquidationPrice > 0 {
+		status.LiquidationDistance = (status.LiquidationPrice - price) / price
+	}
+	return status
+}
+
+// handleErr pass err to the error handler when set
+func (m *RiskMonitor) handleErr(err error) {
+	if m.errHandler != nil {
+		m.errHandler(err)
+	}
+}
+
+// evaluate fire alerts of levels newly crossed, levels are re-armed once the margin level recovers above them
+func (m *RiskMonitor) evaluate(ctx context.Context, status *RiskStatus) {
+	m.mu.Lock()
+	breached := m.breached[status.Symbol]
+	if breached == nil {
+		breached = make(map[int]bool)
+		m.breached[status.Symbol] = breached
+	}
+	var crossed []RiskLevel
+	for i, level := range m.levels {
+		below := status.TotalLiability > 0 && status.MarginLevel <= level.MarginLevel
+		if below && !breached[i] {
+			crossed = append(crossed, level)
+		}
+		breached[i] = below
+	}
+	m.mu.Unlock()
+
+	for _, level := range crossed {
+		if m.handler != nil {
+			m.handler(&RiskAlert{Level: level, Status: status})
+		}
+		for _, action := range level.Actions {
+			if err := action(ctx, m, status); err != nil {
+				m.handleErr(err)
+			}
+		}
+	}
+}
+
+// RepayFromFreeBalance repay the debt of each asset with its free balance
+func RepayFromFreeBalance() RiskAction {
+	return func(ctx context.Context, m *RiskMonitor, status *RiskStatus) error {
+		for _, a := range status.Assets {
+			amount := math.Min(a.Free, a.Debt())
+			if amount <= 0 {
+				continue
+			}
+			s := m.c.NewMarginRepayService().Asset(a.Asset).Amount(formatFloat(amount))
+			if status.IsIsolated() {
+				s.IsIsolated(true).Symbol(status.Symbol)
+			}
+			if _, err := s.Do(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// CancelOpenOrders cancel the open orders of the account, releasing the locked balance
+func CancelOpenOrders() RiskAction {
+	return func(ctx context.Context, m *RiskMonitor, status *RiskStatus) error {
+		if status.IsIsolated() {
+			_, err := m.c.NewCancelMarginOpenOrdersService().Symbol(status.Symbol).IsIsolated(true).Do(ctx)
+			return err
+		}
+		orders, err := m.c.NewListMarginOpenOrdersService().Do(ctx)
+		if err != nil {
+			return err
+		}
+		canceled := make(map[string]bool)
+		for _, o := range orders {
+			if canceled[o.Symbol] {
+				continue
+			}
+			canceled[o.Symbol] = true
+			if _, err := m.c.NewCancelMarginOpenOrdersService().Symbol(o.Symbol).IsIsolated(false).Do(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// ReducePositions close fraction of the positions with AUTO_REPAY market orders: borrowed assets are bought back
+// and, when the quote asset is borrowed, held assets are sold. quoteAsset is the asset traded against on the cross
+// margin account, isolated pairs use their own quote asset
+func ReducePositions(quoteAsset string, fraction float64) RiskAction {
+	return func(ctx context.Context, m *RiskMonitor, status *RiskStatus) error {
+		quote := quoteAsset
+		if status.IsIsolated() {
+			quote = status.QuoteAsset
+		}
+		var quoteDebt float64
+		for _, a := range status.Assets {
+			if a.Asset == quote {
+				quoteDebt = a.Debt()
+			}
+		}
+		for _, a := range status.Assets {
+			if a.Asset == quote {
+				continue
+			}
+			var side SideType
+			var quantity float64
+			switch {
+			case a.Debt() > 0:
+				side, quantity = SideTypeBuy, a.Debt()*fraction
+			case quoteDebt > 0 && a.Free > 0:
+				side, quantity = SideTypeSell, a.Free*fraction
+			default:
+				continue
+			}
+			symbol := a.Asset + quote
+			_, err := m.c.NewCreateMarginOrderService().Symbol(symbol).IsIsolated(status.IsIsolated()).
+				Side(side).Type(OrderTypeMarket).Quantity(m.formatQuantity(symbol, quantity)).
+				SideEffectType(SideEffectTypeAutoRepay).Do(ctx)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,662 @@
+package margin
+
+import (
+	"context"
+	"net/http"
+)
+
+// Simple Earn flexible rewards type
+const (
+	SimpleEarnRewardsBonus    = "BONUS"    // tiered APR rewards
+	SimpleEarnRewardsRealTime = "REALTIME" // real time APR rewards
+	SimpleEarnRewardsRewards  = "REWARDS"  // historical rewards
+)
+
+// simpleEarnHistory filters shared by the Simple Earn history services
+type simpleEarnHistory struct {
+	asset     string
+	startTime int64
+	endTime   int64
+	current   int64
+	size      int64
+}
+
+func (h *simpleEarnHistory) params() params {
+	m := simpleEarnPageParams(h.asset, h.current, h.size)
+	if h.startTime != 0 {
+		m["startTime"] = h.startTime
+	}
+	if h.endTime != 0 {
+		m["endTime"] = h.endTime
+	}
+	return m
+}
+
+// ListSimpleEarnFlexibleSubscriptionsService https://developers.binance.com/docs/simple_earn/history/Get-Flexible-Subscription-Record
+type ListSimpleEarnFlexibleSubscriptionsService struct {
+	c *Client
+	simpleEarnHistory
+	productId  string
+	purchaseId string
+}
+
+// SetProductId sets the productId parameter.
+func (s *ListSimpleEarnFlexibleSubscriptionsService) SetProductId(productId string) *ListSimpleEarnFlexibleSubscriptionsService {
+	s.productId = productId
+	return s
+}
+
+// SetPurchaseId sets the purchaseId parameter.
+func (s *ListSimpleEarnFlexibleSubscriptionsService) SetPurchaseId(purchaseId string) *ListSimpleEarnFlexibleSubscriptionsService {
+	s.purchaseId = purchaseId
+	return s
+}
+
+// SetAsset sets the asset parameter.
+func (s *ListSimpleEarnFlexibleSubscriptionsService) SetAsset(asset string) *ListSimpleEarnFlexibleSubscriptionsService {
+	s.asset = asset
+	return s
+}
+
+// SetStartTime sets the startTime parameter. Only the last 90 days can be queried, 30 days at most per request
+func (s *ListSimpleEarnFlexibleSubscriptionsService) SetStartTime(startTime int64) *ListSimpleEarnFlexibleSubscriptionsService {
+	s.startTime = startTime
+	return s
+}
+
+// SetEndTime sets the endTime parameter.
+func (s *ListSimpleEarnFlexibleSubscriptionsService) SetEndTime(endTime int64) *ListSimpleEarnFlexibleSubscriptionsService {
+	s.endTime = endTime
+	return s
+}
+
+// SetCurrent query page. Default: 1, Min: 1
+func (s *ListSimpleEarnFlexibleSubscriptionsService) SetCurrent(current int64) *ListSimpleEarnFlexibleSubscriptionsService {
+	s.current = current
+	return s
+}
+
+// SetSize Default: 10, Max: 100
+func (s *ListSimpleEarnFlexibleSubscriptionsService) SetSize(size int64) *ListSimpleEarnFlexibleSubscriptionsService {
+	s.size = size
+	return s
+}
+
+// Do send request
+func (s *ListSimpleEarnFlexibleSubscriptionsService) Do(ctx context.Context, opts ...RequestOption) (*SimpleEarnFlexibleSubscriptionList, error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/flexible/history/subscriptionRecord",
+		secType:  secTypeSigned,
+	}
+	m := s.params()
+	if s.productId != "" {
+		m["productId"] = s.productId
+	}
+	if s.purchaseId != "" {
+		m["purchaseId"] = s.purchaseId
+	}
+	r.setParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(SimpleEarnFlexibleSubscriptionList)
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnFlexibleSubscriptionList define a page of flexible subscriptions
+type SimpleEarnFlexibleSubscriptionList struct {
+	Rows  []*SimpleEarnFlexibleSubscription `json:"rows"`
+	Total int64                             `json:"total"`
+}
+
+// SimpleEarnFlexibleSubscription define a flexible subscription
+type SimpleEarnFlexibleSubscription struct {
+	Amount         string `json:"amount"`
+	Asset          string `json:"asset"`
+	Time           int64  `json:"time"`
+	PurchaseId     int64  `json:"purchaseId"`
+	ProductId      string `json:"productId"`
+	Type           string `json:"type"` // AUTO, ACTIVITY, TRIAL or NORMAL
+	SourceAccount  string `json:"sourceAccount"`
+	AmtFromSpot    string `json:"amtFromSpot"`
+	AmtFromFunding string `json:"amtFromFunding"`
+	Status         string `json:"status"` // PURCHASING, SUCCESS or FAILED
+}
+
+// ListSimpleEarnLockedSubscriptionsService https://developers.binance.com/docs/simple_earn/history/Get-Locked-Subscription-Record
+type ListSimpleEarnLockedSubscriptionsService struct {
+	c *Client
+	simpleEarnHistory
+	purchaseId string
+}
+
+// SetPurchaseId sets the purchaseId parameter.
+func (s *ListSimpleEarnLockedSubscriptionsService) SetPurchaseId(purchaseId string) *ListSimpleEarnLockedSubscriptionsService {
+	s.purchaseId = purchaseId
+	return s
+}
+
+// SetAsset sets the asset parameter.
+func (s *ListSimpleEarnLockedSubscriptionsService) SetAsset(asset string) *ListSimpleEarnLockedSubscriptionsService {
+	s.asset = asset
+	return s
+}
+
+// SetStartTime sets the startTime parameter. Only the last 90 days can be queried, 30 days at most per request
+func (s *ListSimpleEarnLockedSubscriptionsService) SetStartTime(startTime int64) *ListSimpleEarnLockedSubscriptionsService {
+	s.startTime = startTime
+	return s
+}
+
+// SetEndTime sets the endTime parameter.
+func (s *ListSimpleEarnLockedSubscriptionsService) SetEndTime(endTime int64) *ListSimpleEarnLockedSubscriptionsService {
+	s.endTime = endTime
+	return s
+}
+
+// SetCurrent query page. Default: 1, Min: 1
+func (s *ListSimpleEarnLockedSubscriptionsService) SetCurrent(current int64) *ListSimpleEarnLockedSubscriptionsService {
+	s.current = current
+	return s
+}
+
+// SetSize Default: 10, Max: 100
+func (s *ListSimpleEarnLockedSubscriptionsService) SetSize(size int64) *ListSimpleEarnLockedSubscriptionsService {
+	s.size = size
+	return s
+}
+
+// Do send request
+func (s *ListSimpleEarnLockedSubscriptionsService) Do(ctx context.Context, opts ...RequestOption) (*SimpleEarnLockedSubscriptionList, error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/locked/history/subscriptionRecord",
+		secType:  secTypeSigned,
+	}
+	m := s.params()
+	if s.purchaseId != "" {
+		m["purchaseId"] = s.purchaseId
+	}
+	r.setParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(SimpleEarnLockedSubscriptionList)
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnLockedSubscriptionList define a page of locked subscriptions
+type SimpleEarnLockedSubscriptionList struct {
+	Rows  []*SimpleEarnLockedSubscription `json:"rows"`
+	Total int64                           `json:"total"`
+}
+
+// SimpleEarnLockedSubscription define a locked subscription
+type SimpleEarnLockedSubscription struct {
+	PositionId     int64  `json:"positionId"`
+	PurchaseId     int64  `json:"purchaseId"`
+	ProjectId      string `json:"projectId"`
+	Time           int64  `json:"time"`
+	Asset          string `json:"asset"`
+	Amount         string `json:"amount"`
+	LockPeriod     string `json:"lockPeriod"`
+	Type           string `json:"type"` // AUTO, ACTIVITY or NORMAL
+	SourceAccount  string `json:"sourceAccount"`
+	AmtFromSpot    string `json:"amtFromSpot"`
+	AmtFromFunding string `json:"amtFromFunding"`
+	Status         string `json:"status"` // PURCHASING, SUCCESS or FAILED
+}
+
+// ListSimpleEarnFlexibleRedemptionsService https://developers.binance.com/docs/simple_earn/history/Get-Flexible-Redemption-Record
+type ListSimpleEarnFlexibleRedemptionsService struct {
+	c *Client
+	simpleEarnHistory
+	productId string
+	redeemId  string
+}
+
+// SetProductId sets the productId parameter.
+func (s *ListSimpleEarnFlexibleRedemptionsService) SetProductId(productId string) *ListSimpleEarnFlexibleRedemptionsService {
+	s.productId = productId
+	return s
+}
+
+// SetRedeemId sets the redeemId parameter.
+func (s *ListSimpleEarnFlexibleRedemptionsService) SetRedeemId(redeemId string) *ListSimpleEarnFlexibleRedemptionsService {
+	s.redeemId = redeemId
+	return s
+}
+
+// SetAsset sets the asset parameter.
+func (s *ListSimpleEarnFlexibleRedemptionsService) SetAsset(asset string) *ListSimpleEarnFlexibleRedemptionsService {
+	s.asset = asset
+	return s
+}
+
+// SetStartTime sets the startTime parameter. Only the last 90 days can be queried, 30 days at most per request
+func (s *ListSimpleEarnFlexibleRedemptionsService) SetStartTime(startTime int64) *ListSimpleEarnFlexibleRedemptionsService {
+	s.startTime = startTime
+	return s
+}
+
+// SetEndTime sets the endTime parameter.
+func (s *ListSimpleEarnFlexibleRedemptionsService) SetEndTime(endTime int64) *ListSimpleEarnFlexibleRedemptionsService {
+	s.endTime = endTime
+	return s
+}
+
+// SetCurrent query page. Default: 1, Min: 1
+func (s *ListSimpleEarnFlexibleRedemptionsService) SetCurrent(current int64) *ListSimpleEarnFlexibleRedemptionsService {
+	s.current = current
+	return s
+}
+
+// SetSize Default: 10, Max: 100
+func (s *ListSimpleEarnFlexibleRedemptionsService) SetSize(size int64) *ListSimpleEarnFlexibleRedemptionsService {
+	s.size = size
+	return s
+}
+
+// Do send request
+func (s *ListSimpleEarnFlexibleRedemptionsService) Do(ctx context.Context, opts ...RequestOption) (*SimpleEarnFlexibleRedemptionList, error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/flexible/history/redemptionRecord",
+		secType:  secTypeSigned,
+	}
+	m := s.params()
+	if s.productId != "" {
+		m["productId"] = s.productId
+	}
+	if s.redeemId != "" {
+		m["redeemId"] = s.redeemId
+	}
+	r.setParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(SimpleEarnFlexibleRedemptionList)
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnFlexibleRedemptionList define a page of flexible redemptions
+type SimpleEarnFlexibleRedemptionList struct {
+	Rows  []*SimpleEarnFlexibleRedemption `json:"rows"`
+	Total int64                           `json:"total"`
+}
+
+// SimpleEarnFlexibleRedemption define a flexible redemption
+type SimpleEarnFlexibleRedemption struct {
+	Amount      string `json:"amount"`
+	Asset       string `json:"asset"`
+	Time        int64  `json:"time"`
+	ProductId   string `json:"productId"`
+	RedeemId    int64  `json:"redeemId"`
+	DestAccount string `json:"destAccount"`
+	Status      string `json:"status"` // PAID or PAYING
+}
+
+// ListSimpleEarnLockedRedemptionsService https://developers.binance.com/docs/simple_earn/history/Get-Locked-Redemption-Record
+type ListSimpleEarnLockedRedemptionsService struct {
+	c *Client
+	simpleEarnHistory
+	positionId string
+	redeemId   string
+}
+
+// SetPositionId sets the positionId parameter.
+func (s *ListSimpleEarnLockedRedemptionsService) SetPositionId(positionId string) *ListSimpleEarnLockedRedemptionsService {
+	s.positionId = positionId
+	return s
+}
+
+// SetRedeemId sets the redeemId parameter.
+func (s *ListSimpleEarnLockedRedemptionsService) SetRedeemId(redeemId string) *ListSimpleEarnLockedRedemptionsService {
+	s.redeemId = redeemId
+	return s
+}
+
+// SetAsset sets the asset parameter.
+func (s *ListSimpleEarnLockedRedemptionsService) SetAsset(asset string) *ListSimpleEarnLockedRedemptionsService {
+	s.asset = asset
+	return s
+}
+
+// SetStartTime sets the startTime parameter. Only the last 90 days can be queried, 30 days at most per request
+func (s *ListSimpleEarnLockedRedemptionsService) SetStartTime(startTime int64) *ListSimpleEarnLockedRedemptionsService {
+	s.startTime = startTime
+	return s
+}
+
+// SetEndTime sets the endTime parameter.
+func (s *ListSimpleEarnLockedRedemptionsService) SetEndTime(endTime int64) *ListSimpleEarnLockedRedemptionsService {
+	s.endTime = endTime
+	return s
+}
+
+// SetCurrent query page. Default: 1, Min: 1
+func (s *ListSimpleEarnLockedRedemptionsService) SetCurrent(current int64) *ListSimpleEarnLockedRedemptionsService {
+	s.current = current
+	return s
+}
+
+// SetSize Default: 10, Max: 100
+func (s *ListSimpleEarnLockedRedemptionsService) SetSize(size int64) *ListSimpleEarnLockedRedemptionsService {
+	s.size = size
+	return s
+}
+
+// Do send request
+func (s *ListSimpleEarnLockedRedemptionsService) Do(ctx context.Context, opts ...RequestOption) (*SimpleEarnLockedRedemptionList, error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/locked/history/redemptionRecord",
+		secType:  secTypeSigned,
+	}
+	m := s.params()
+	if s.positionId != "" {
+		m["positionId"] = s.positionId
+	}
+	if s.redeemId != "" {
+		m["redeemId"] = s.redeemId
+	}
+	r.setParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(SimpleEarnLockedRedemptionList)
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnLockedRedemptionList define a page of locked redemptions
+type SimpleEarnLockedRedemptionList struct {
+	Rows  []*SimpleEarnLockedRedemption `json:"rows"`
+	Total int64                         `json:"total"`
+}
+
+// SimpleEarnLockedRedemption define a locked redemption
+type SimpleEarnLockedRedemption struct {
+	PositionId  int64  `json:"positionId"`
+	RedeemId    int64  `json:"redeemId"`
+	Time        int64  `json:"time"`
+	Asset       string `json:"asset"`
+	LockPeriod  string `json:"lockPeriod"`
+	Amount      string `json:"amount"`
+	Type        string `json:"type"` // MATURE, NEW_TRANSFERRED or AHEAD
+	DeliverDate string `json:"deliverDate"`
+	Status      string `json:"status"` // PAID or PAYING
+}
+
+// ListSimpleEarnFlexibleRewardsService https://developers.binance.com/docs/simple_earn/history/Get-Flexible-Rewards-History
+type ListSimpleEarnFlexibleRewardsService struct {
+	c *Client
+	simpleEarnHistory
+	productId   string
+	rewardsType string
+}
+
+// SetProductId sets the productId parameter.
+func (s *ListSimpleEarnFlexibleRewardsService) SetProductId(productId string) *ListSimpleEarnFlexibleRewardsService {
+	s.productId = productId
+	return s
+}
+
+// SetType ("BONUS", "REALTIME", "REWARDS") - Default: "REALTIME"
+func (s *ListSimpleEarnFlexibleRewardsService) SetType(rewardsType string) *ListSimpleEarnFlexibleRewardsService {
+	s.rewardsType = rewardsType
+	return s
+}
+
+// SetAsset sets the asset parameter.
+func (s *ListSimpleEarnFlexibleRewardsService) SetAsset(asset string) *ListSimpleEarnFlexibleRewardsService {
+	s.asset = asset
+	return s
+}
+
+// SetStartTime sets the startTime parameter. Only the last 90 days can be queried, 30 days at most per request
+func (s *ListSimpleEarnFlexibleRewardsService) SetStartTime(startTime int64) *ListSimpleEarnFlexibleRewardsService {
+	s.startTime = startTime
+	return s
+}
+
+// SetEndTime sets the endTime parameter.
+func (s *ListSimpleEarnFlexibleRewardsService) SetEndTime(endTime int64) *ListSimpleEarnFlexibleRewardsService {
+	s.endTime = endTime
+	return s
+}
+
+// SetCurrent query page. Default: 1, Min: 1
+func (s *ListSimpleEarnFlexibleRewardsService) SetCurrent(current int64) *ListSimpleEarnFlexibleRewardsService {
+	s.current = current
+	return s
+}
+
+// SetSize Default: 10, Max: 100
+func (s *ListSimpleEarnFlexibleRewardsService) SetSize(size int64) *ListSimpleEarnFlexibleRewardsService {
+	s.size = size
+	return s
+}
+
+// Do send request
+func (s *ListSimpleEarnFlexibleRewardsService) Do(ctx context.Context, opts ...RequestOption) (*SimpleEarnFlexibleRewardList, error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/flexible/history/rewardsRecord",
+		secType:  secTypeSigned,
+	}
+	m := s.params()
+	if s.productId != "" {
+		m["productId"] = s.productId
+	}
+	if s.rewardsType != "" {
+		m["type"] = s.rewardsType
+	} else {
+		m["type"] = SimpleEarnRewardsRealTime
+	}
+	r.setParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(SimpleEarnFlexibleRewardList)
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnFlexibleRewardList define a page of flexible rewards
+type SimpleEarnFlexibleRewardList struct {
+	Rows  []*SimpleEarnFlexibleReward `json:"rows"`
+	Total int64                       `json:"total"`
+}
+
+// SimpleEarnFlexibleReward define a flexible reward
+type SimpleEarnFlexibleReward struct {
+	Asset     string `json:"asset"`
+	Rewards   string `json:"rewards"`
+	ProductId string `json:"projectId"`
+	Type      string `json:"type"` // Bonus tiered APR, Realtime APR or Rewards
+	Time      int64  `json:"time"`
+}
+
+// ListSimpleEarnLockedRewardsService https://developers.binance.com/docs/simple_earn/history/Get-Locked-Rewards-History
+type ListSimpleEarnLockedRewardsService struct {
+	c *Client
+	simpleEarnHistory
+	positionId string
+}
+
+// SetPositionId sets the positionId parameter.
+func (s *ListSimpleEarnLockedRewardsService) SetPositionId(positionId string) *ListSimpleEarnLockedRewardsService {
+	s.positionId = positionId
+	return s
+}
+
+// SetAsset sets the asset parameter.
+func (s *ListSimpleEarnLockedRewardsService) SetAsset(asset string) *ListSimpleEarnLockedRewardsService {
+	s.asset = asset
+	return s
+}
+
+// SetStartTime sets the startTime parameter. Only the last 90 days can be queried, 30 days at most per request
+func (s *ListSimpleEarnLockedRewardsService) SetStartTime(startTime int64) *ListSimpleEarnLockedRewardsService {
+	s.startTime = startTime
+	return s
+}
+
+// SetEndTime sets the endTime parameter.
+func (s *ListSimpleEarnLockedRewardsService) SetEndTime(endTime int64) *ListSimpleEarnLockedRewardsService {
+	s.endTime = endTime
+	return s
+}
+
+// SetCurrent query page. Default: 1, Min: 1
+func (s *ListSimpleEarnLockedRewardsService) SetCurrent(current int64) *ListSimpleEarnLockedRewardsService {
+	s.current = current
+	return s
+}
+
+// SetSize Default: 10, Max: 100
+func (s *ListSimpleEarnLockedRewardsService) SetSize(size int64) *ListSimpleEarnLockedRewardsService {
+	s.size = size
+	return s
+}
+
+// Do send request
+func (s *ListSimpleEarnLockedRewardsService) Do(ctx context.Context, opts ...RequestOption) (*SimpleEarnLockedRewardList, error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/locked/history/rewardsRecord",
+		secType:  secTypeSigned,
+	}
+	m := s.params()
+	if s.positionId != "" {
+		m["positionId"] = s.positionId
+	}
+	r.setParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(SimpleEarnLockedRewardList)
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnLockedRewardList define a page of locked rewards
+type SimpleEarnLockedRewardList struct {
+	Rows  []*SimpleEarnLockedReward `json:"rows"`
+	Total int64                     `json:"total"`
+}
+
+// SimpleEarnLockedReward define a locked reward
+type SimpleEarnLockedReward struct {
+	PositionId int64  `json:"positionId"`
+	Time       int64  `json:"time"`
+	Asset      string `json:"asset"`
+	LockPeriod string `json:"lockPeriod"`
+	Amount     string `json:"amount"`
+	Type       string `json:"type"` // Locked Rewards or Locked Extra Rewards
+}
+
+// ListSimpleEarnFlexibleRateHistoryService https://developers.binance.com/docs/simple_earn/history/Get-Rate-History
+type ListSimpleEarnFlexibleRateHistoryService struct {
+	c *Client
+	simpleEarnHistory
+	productId string
+	aprPeriod string
+}
+
+// SetProductId sets the productId parameter (MANDATORY).
+func (s *ListSimpleEarnFlexibleRateHistoryService) SetProductId(productId string) *ListSimpleEarnFlexibleRateHistoryService {
+	s.productId = productId
+	return s
+}
+
+// SetAprPeriod ("DAY", "YEAR") - Default: "DAY"
+func (s *ListSimpleEarnFlexibleRateHistoryService) SetAprPeriod(aprPeriod string) *ListSimpleEarnFlexibleRateHistoryService {
+	s.aprPeriod = aprPeriod
+	return s
+}
+
+// SetStartTime sets the startTime parameter. Only the last year can be queried, 3 months at most per request
+func (s *ListSimpleEarnFlexibleRateHistoryService) SetStartTime(startTime int64) *ListSimpleEarnFlexibleRateHistoryService {
+	s.startTime = startTime
+	return s
+}
+
+// SetEndTime sets the endTime parameter.
+func (s *ListSimpleEarnFlexibleRateHistoryService) SetEndTime(endTime int64) *ListSimpleEarnFlexibleRateHistoryService {
+	s.endTime = endTime
+	return s
+}
+
+// SetCurrent query page. Default: 1, Min: 1
+func (s *ListSimpleEarnFlexibleRateHistoryService) SetCurrent(current int64) *ListSimpleEarnFlexibleRateHistoryService {
+	s.current = current
+	return s
+}
+
+// SetSize Default: 10, Max: 100
+func (s *ListSimpleEarnFlexibleRateHistoryService) SetSize(size int64) *ListSimpleEarnFlexibleRateHistoryService {
+	s.size = size
+	return s
+}
+
+// Do send request
+func (s *ListSimpleEarnFlexibleRateHistoryService) Do(ctx context.Context, opts ...RequestOption) (*SimpleEarnRateHistoryList, error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/flexible/history/rateHistory",
+		secType:  secTypeSigned,
+	}
+	m := s.params()
+	m["productId"] = s.productId
+	if s.aprPeriod != "" {
+		m["aprPeriod"] = s.aprPeriod
+	}
+	r.setParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(SimpleEarnRateHistoryList)
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnRateHistoryList define a page of rate history
+type SimpleEarnRateHistoryList struct {
+	Rows  []*SimpleEarnRate `json:"rows"`
+	Total int64             `json:"total"`
+}
+
+// SimpleEarnRate define the annual percentage rate of a flexible product at a time
+type SimpleEarnRate struct {
+	ProductId            string `json:"productId"`
+	Asset                string `json:"asset"`
+	AnnualPercentageRate string `json:"annualPercentageRate"`
+	Time                 int64  `json:"time"`
+}
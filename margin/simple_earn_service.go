@@ -0,0 +1,859 @@
+package margin
+
+import (
+	"context"
+	"math"
+	"net/http"
+)
+
+// Simple Earn source account of subscriptions and destination account of redemptions
+const (
+	SimpleEarnAccountSpot = "SPOT"
+	SimpleEarnAccountFund = "FUND"
+	SimpleEarnAccountAll  = "ALL" // source only
+)
+
+// ListSimpleEarnFlexibleProductsService https://developers.binance.com/docs/simple_earn/account/Get-Simple-Earn-Flexible-Product-List
+type ListSimpleEarnFlexibleProductsService struct {
+	c       *Client
+	asset   string
+	current int64
+	size    int64
+}
+
+// SetAsset desired asset
+func (s *ListSimpleEarnFlexibleProductsService) SetAsset(asset string) *ListSimpleEarnFlexibleProductsService {
+	s.asset = asset
+	return s
+}
+
+// SetCurrent query page. Default: 1, Min: 1
+func (s *ListSimpleEarnFlexibleProductsService) SetCurrent(current int64) *ListSimpleEarnFlexibleProductsService {
+	s.current = current
+	return s
+}
+
+// SetSize Default: 10, Max: 100
+func (s *ListSimpleEarnFlexibleProductsService) SetSize(size int64) *ListSimpleEarnFlexibleProductsService {
+	s.size = size
+	return s
+}
+
+// Do send request
+func (s *ListSimpleEarnFlexibleProductsService) Do(ctx context.Context, opts ...RequestOption) (*SimpleEarnFlexibleProductList, error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/flexible/list",
+		secType:  secTypeSigned,
+	}
+	r.setParams(simpleEarnPageParams(s.asset, s.current, s.size))
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(SimpleEarnFlexibleProductList)
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnFlexibleProductList define a page of flexible products
+type SimpleEarnFlexibleProductList struct {
+	Rows  []*SimpleEarnFlexibleProduct `json:"rows"`
+	Total int64                        `json:"total"`
+}
+
+// SimpleEarnFlexibleProduct define a flexible product (Simple Earn)
+type SimpleEarnFlexibleProduct struct {
+	Asset                      string            `json:"asset"`
+	LatestAnnualPercentageRate string            `json:"latestAnnualPercentageRate"`
+	TierAnnualPercentageRate   map[string]string `json:"tierAnnualPercentageRate"` // extra rate by amount tier, e.g. "0-5BTC"
+	AirDropPercentageRate      string            `json:"airDropPercentageRate"`
+	CanPurchase                bool              `json:"canPurchase"`
+	CanRedeem                  bool              `json:"canRedeem"`
+	IsSoldOut                  bool              `json:"isSoldOut"`
+	Hot                        bool              `json:"hot"`
+	MinPurchaseAmount          string            `json:"minPurchaseAmount"`
+	ProductId                  string            `json:"productId"`
+	SubscriptionStartTime      int64             `json:"subscriptionStartTime"`
+	Status                     string            `json:"status"` // PREHEATING, PURCHASING or END
+}
+
+// ToSavings convert to the retired Savings model. Rates are the latest annual rate, limits aren't returned by
+// Simple Earn and are left empty
+func (p *SimpleEarnFlexibleProduct) ToSavings() *SavingsFlexibleProduct {
+	return &SavingsFlexibleProduct{
+		Asset:                    p.Asset,
+		AvgAnnualInterestRate:    p.LatestAnnualPercentageRate,
+		CanPurchase:              p.CanPurchase,
+		CanRedeem:                p.CanRedeem,
+		DailyInterestPerThousand: formatRate(parseFloat(p.LatestAnnualPercentageRate) / 365 * 1000),
+		Featured:                 p.Hot,
+		MinPurchaseAmount:        p.MinPurchaseAmount,
+		ProductId:                p.ProductId,
+		Status:                   p.Status,
+	}
+}
+
+// ListSimpleEarnLockedProductsService https://developers.binance.com/docs/simple_earn/account/Get-Simple-Earn-Locked-Product-List
+type ListSimpleEarnLockedProductsService struct {
+	c       *Client
+	asset   string
+	current int64
+	size    int64
+}
+
+// SetAsset desired asset
+func (s *ListSimpleEarnLockedProductsService) SetAsset(asset string) *ListSimpleEarnLockedProductsService {
+	s.asset = asset
+	return s
+}
+
+// SetCurrent query page. Default: 1, Min: 1
+func (s *ListSimpleEarnLockedProductsService) SetCurrent(current int64) *ListSimpleEarnLockedProductsService {
+	s.current = current
+	return s
+}
+
+// SetSize Default: 10, Max: 100
+func (s *ListSimpleEarnLockedProductsService) SetSize(size int64) *ListSimpleEarnLockedProductsService {
+	s.size = size
+	return s
+}
+
+// Do send request
+func (s *ListSimpleEarnLockedProductsService) Do(ctx context.Context, opts ...RequestOption) (*SimpleEarnLockedProductList, error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/locked/list",
+		secType:  secTypeSigned,
+	}
+	r.setParams(simpleEarnPageParams(s.asset, s.current, s.size))
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(SimpleEarnLockedProductList)
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnLockedProductList define a page of locked products
+type SimpleEarnLockedProductList struct {
+	Rows  []*SimpleEarnLockedProduct `json:"rows"`
+	Total int64                      `json:"total"`
+}
+
+// SimpleEarnLockedProduct define a locked product (Simple Earn)
+type SimpleEarnLockedProduct struct {
+	ProjectId string `json:"projectId"`
+	Detail    struct {
+		Asset                 string `json:"asset"`
+		RewardAsset           string `json:"rewardAsset"`
+		Duration              int64  `json:"duration"` // days
+		Renewable             bool   `json:"renewable"`
+		IsSoldOut             bool   `json:"isSoldOut"`
+		APR                   string `json:"apr"`
+		Status                string `json:"status"` // CREATED or END
+		SubscriptionStartTime int64  `json:"subscriptionStartTime"`
+		ExtraRewardAsset      string `json:"extraRewardAsset"`
+		ExtraRewardAPR        string `json:"extraRewardAPR"`
+	} `json:"detail"`
+	Quota struct {
+		TotalPersonalQuota string `json:"totalPersonalQuota"`
+		Minimum            string `json:"minimum"`
+	} `json:"quota"`
+}
+
+// ToSavings convert to the retired Savings model of fixed projects, lots are of 1 unit of the asset
+func (p *SimpleEarnLockedProduct) ToSavings() *SavingsFixedProduct {
+	status := "PURCHASING"
+	if p.Detail.IsSoldOut || p.Detail.Status == "END" {
+		status = "END"
+	}
+	return &SavingsFixedProduct{
+		Asset:        p.Detail.Asset,
+		Duration:     int(p.Detail.Duration),
+		InterestRate: p.Detail.APR,
+		LotSize:      "1",
+		ProjectId:    p.ProjectId,
+		ProjectName:  p.Detail.Asset,
+		Status:       status,
+		Type:         "CUSTOMIZED_FIXED",
+	}
+}
+
+// SubscribeSimpleEarnFlexibleProductService https://developers.binance.com/docs/simple_earn/earn/Subscribe-Flexible-Product
+type SubscribeSimpleEarnFlexibleProductService struct {
+	c             *Client
+	productId     string
+	amount        float64
+	autoSubscribe *bool
+	sourceAccount string
+}
+
+// SetProductId represent the id of the flexible product to subscribe
+func (s *SubscribeSimpleEarnFlexibleProductService) SetProductId(productId string) *SubscribeSimpleEarnFlexibleProductService {
+	s.productId = productId
+	return s
+}
+
+// SetAmount is the quantity of the product to subscribe
+func (s *SubscribeSimpleEarnFlexibleProductService) SetAmount(amount float64) *SubscribeSimpleEarnFlexibleProductService {
+	s.amount = amount
+	return s
+}
+
+// SetAutoSubscribe Default: true
+func (s *SubscribeSimpleEarnFlexibleProductService) SetAutoSubscribe(autoSubscribe bool) *SubscribeSimpleEarnFlexibleProductService {
+	s.autoSubscribe = &autoSubscribe
+	return s
+}
+
+// SetSourceAccount ("SPOT", "FUND", "ALL") - Default: "SPOT"
+func (s *SubscribeSimpleEarnFlexibleProductService) SetSourceAccount(sourceAccount string) *SubscribeSimpleEarnFlexibleProductService {
+	s.sourceAccount = sourceAccount
+	return s
+}
+
+// Do send request
+func (s *SubscribeSimpleEarnFlexibleProductService) Do(ctx context.Context, opts ...RequestOption) (*SimpleEarnSubscribeResponse, error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/simple-earn/flexible/subscribe",
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"productId": s.productId,
+		"amount":    s.amount,
+	}
+	if s.autoSubscribe != nil {
+		m["autoSubscribe"] = *s.autoSubscribe
+	}
+	if s.sourceAccount != "" {
+		m["sourceAccount"] = s.sourceAccount
+	}
+	r.setParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(SimpleEarnSubscribeResponse)
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SubscribeSimpleEarnLockedProductService https://developers.binance.com/docs/simple_earn/earn/Subscribe-Locked-Product
+type SubscribeSimpleEarnLockedProductService struct {
+	c             *Client
+	projectId     string
+	amount        float64
+	autoSubscribe *bool
+	sourceAccount string
+	redeemTo      string
+}
+
+// SetProjectId represent the id of the locked product to subscribe
+func (s *SubscribeSimpleEarnLockedProductService) SetProjectId(projectId string) *SubscribeSimpleEarnLockedProductService {
+	s.projectId = projectId
+	return s
+}
+
+// SetAmount is the quantity of the product to subscribe
+func (s *SubscribeSimpleEarnLockedProductService) SetAmount(amount float64) *SubscribeSimpleEarnLockedProductService {
+	s.amount = amount
+	return s
+}
+
+// SetAutoSubscribe Default: true
+func (s *SubscribeSimpleEarnLockedProductService) SetAutoSubscribe(autoSubscribe bool) *SubscribeSimpleEarnLockedProductService {
+	s.autoSubscribe = &autoSubscribe
+	return s
+}
+
+// SetSourceAccount ("SPOT", "FUND", "ALL") - Default: "SPOT"
+func (s *SubscribeSimpleEarnLockedProductService) SetSourceAccount(sourceAccount string) *SubscribeSimpleEarnLockedProductService {
+	s.sourceAccount = sourceAccount
+	return s
+}
+
+// SetRedeemTo ("SPOT", "FLEXIBLE") - Default: "SPOT", only when autoSubscribe is false
+func (s *SubscribeSimpleEarnLockedProductService) SetRedeemTo(redeemTo string) *SubscribeSimpleEarnLockedProductService {
+	s.redeemTo = redeemTo
+	return s
+}
+
+// Do send request
+func (s *SubscribeSimpleEarnLockedProductService) Do(ctx context.Context, opts ...RequestOption) (*SimpleEarnSubscribeResponse, error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/simple-earn/locked/subscribe",
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"projectId": s.projectId,
+		"amount":    s.amount,
+	}
+	if s.autoSubscribe != nil {
+		m["autoSubscribe"] = *s.autoSubscribe
+	}
+	if s.sourceAccount != "" {
+		m["sourceAccount"] = s.sourceAccount
+	}
+	if s.redeemTo != "" {
+		m["redeemTo"] = s.redeemTo
+	}
+	r.setParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(SimpleEarnSubscribeResponse)
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnSubscribeResponse define subscribe response
+type SimpleEarnSubscribeResponse struct {
+	PurchaseId int64  `json:"purchaseId"`
+	PositionId string `json:"positionId"` // locked only
+	Success    bool   `json:"success"`
+}
+
+// RedeemSimpleEarnFlexibleProductService https://developers.binance.com/docs/simple_earn/earn/Redeem-Flexible-Product
+type RedeemSimpleEarnFlexibleProductService struct {
+	c           *Client
+	productId   string
+	redeemAll   bool
+	amount      float64
+	destAccount string
+}
+
+// SetProductId represent the id of the flexible product to redeem
+func (s *RedeemSimpleEarnFlexibleProductService) SetProductId(productId string) *RedeemSimpleEarnFlexibleProductService {
+	s.productId = productId
+	return s
+}
+
+// SetRedeemAll redeem the whole position, amount is ignored
+func (s *RedeemSimpleEarnFlexibleProductService) SetRedeemAll(redeemAll bool) *RedeemSimpleEarnFlexibleProductService {
+	s.redeemAll = redeemAll
+	return s
+}
+
+// SetAmount is the quantity of the product to redeem
+func (s *RedeemSimpleEarnFlexibleProductService) SetAmount(amount float64) *RedeemSimpleEarnFlexibleProductService {
+	s.amount = amount
+	return s
+}
+
+// SetDestAccount ("SPOT", "FUND") - Default: "SPOT"
+func (s *RedeemSimpleEarnFlexibleProductService) SetDestAccount(destAccount string) *RedeemSimpleEarnFlexibleProductService {
+	s.destAccount = destAccount
+	return s
+}
+
+// Do send request
+func (s *RedeemSimpleEarnFlexibleProductService) Do(ctx context.Context, opts ...RequestOption) (*SimpleEarnRedeemResponse, error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/simple-earn/flexible/redeem",
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"productId": s.productId,
+	}
+	if s.redeemAll {
+		m["redeemAll"] = true
+	} else {
+		m["amount"] = s.amount
+	}
+	if s.destAccount != "" {
+		m["destAccount"] = s.destAccount
+	}
+	r.setParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(SimpleEarnRedeemResponse)
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// RedeemSimpleEarnLockedProductService https://developers.binance.com/docs/simple_earn/earn/Redeem-Locked-Product
+type RedeemSimpleEarnLockedProductService struct {
+	c          *Client
+	positionId string
+}
+
+// SetPositionId represent the id of the locked position to redeem
+func (s *RedeemSimpleEarnLockedProductService) SetPositionId(positionId string) *RedeemSimpleEarnLockedProductService {
+	s.positionId = positionId
+	return s
+}
+
+// Do send request
+func (s *RedeemSimpleEarnLockedProductService) Do(ctx context.Context, opts ...RequestOption) (*SimpleEarnRedeemResponse, error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/simple-earn/locked/redeem",
+		secType:  secTypeSigned,
+	}
+	r.setParams(params{
+		"positionId": s.positionId,
+	})
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(SimpleEarnRedeemResponse)
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnRedeemResponse define redeem response
+type SimpleEarnRedeemResponse struct {
+	RedeemId int64 `json:"redeemId"`
+	Success  bool  `json:"success"`
+}
+
+// SimpleEarnFlexiblePositionsService https://developers.binance.com/docs/simple_earn/account/Get-Flexible-Product-Position
+type SimpleEarnFlexiblePositionsService struct {
+	c         *Client
+	asset     string
+	productId string
+	current   int64
+	size      int64
+}
+
+// SetAsset sets the asset parameter.
+func (s *SimpleEarnFlexiblePositionsService) SetAsset(asset string) *SimpleEarnFlexiblePositionsService {
+	s.asset = asset
+	return s
+}
+
+// SetProductId sets the productId parameter.
+func (s *SimpleEarnFlexiblePositionsService) SetProductId(productId string) *SimpleEarnFlexiblePositionsService {
+	s.productId = productId
+	return s
+}
+
+// SetCurrent query page. Default: 1, Min: 1
+func (s *SimpleEarnFlexiblePositionsService) SetCurrent(current int64) *SimpleEarnFlexiblePositionsService {
+	s.current = current
+	return s
+}
+
+// SetSize Default: 10, Max: 100
+func (s *SimpleEarnFlexiblePositionsService) SetSize(size int64) *SimpleEarnFlexiblePositionsService {
+	s.size = size
+	return s
+}
+
+// Do send request
+func (s *SimpleEarnFlexiblePositionsService) Do(ctx context.Context, opts ...RequestOption) (*SimpleEarnFlexiblePositionList, error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/flexible/position",
+		secType:  secTypeSigned,
+	}
+	m := simpleEarnPageParams(s.asset, s.current, s.size)
+	if s.productId != "" {
+		m["productId"] = s.productId
+	}
+	r.setParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(SimpleEarnFlexiblePositionList)
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnFlexiblePositionList define a page of flexible positions
+type SimpleEarnFlexiblePositionList struct {
+	Rows  []*SimpleEarnFlexiblePosition `json:"rows"`
+	Total int64                         `json:"total"`
+}
+
+// SimpleEarnFlexiblePosition define a flexible product position (Simple Earn)
+type SimpleEarnFlexiblePosition struct {
+	TotalAmount                    string            `json:"totalAmount"`
+	TierAnnualPercentageRate       map[string]string `json:"tierAnnualPercentageRate"`
+	LatestAnnualPercentageRate     string            `json:"latestAnnualPercentageRate"`
+	YesterdayAirdropPercentageRate string            `json:"yesterdayAirdropPercentageRate"`
+	Asset                          string            `json:"asset"`
+	AirDropAsset                   string            `json:"airDropAsset"`
+	CanRedeem                      bool              `json:"canRedeem"`
+	CollateralAmount               string            `json:"collateralAmount"`
+	ProductId                      string            `json:"productId"`
+	YesterdayRealTimeRewards       string            `json:"yesterdayRealTimeRewards"`
+	CumulativeBonusRewards         string            `json:"cumulativeBonusRewards"`
+	CumulativeRealTimeRewards      string            `json:"cumulativeRealTimeRewards"`
+	CumulativeTotalRewards         string            `json:"cumulativeTotalRewards"`
+	AutoSubscribe                  bool              `json:"autoSubscribe"`
+}
+
+// ToSavings convert to the retired Savings model, the amount used as loan collateral is reported as locked
+func (p *SimpleEarnFlexiblePosition) ToSavings() *SavingFlexibleProductPosition {
+	apr := parseFloat(p.LatestAnnualPercentageRate)
+	return &SavingFlexibleProductPosition{
+		Asset:                 p.Asset,
+		ProductId:             p.ProductId,
+		ProductName:           p.Asset,
+		AvgAnnualInterestRate: p.LatestAnnualPercentageRate,
+		AnnualInterestRate:    p.LatestAnnualPercentageRate,
+		DailyInterestRate:     formatRate(apr / 365),
+		TotalInterest:         p.CumulativeTotalRewards,
+		TotalAmount:           p.TotalAmount,
+		FreeAmount:            formatFloat(parseFloat(p.TotalAmount) - parseFloat(p.CollateralAmount)),
+		LockedAmount:          p.CollateralAmount,
+		CanRedeem:             p.CanRedeem,
+	}
+}
+
+// SimpleEarnLockedPositionsService https://developers.binance.com/docs/simple_earn/account/Get-Locked-Product-Position
+type SimpleEarnLockedPositionsService struct {
+	c          *Client
+	asset      string
+	positionId string
+	projectId  string
+	current    int64
+	size       int64
+}
+
+// SetAsset sets the asset parameter.
+func (s *SimpleEarnLockedPositionsService) SetAsset(asset string) *SimpleEarnLockedPositionsService {
+	s.asset = asset
+	return s
+}
+
+// SetPositionId sets the positionId parameter.
+func (s *SimpleEarnLockedPositionsService) SetPositionId(positionId string) *SimpleEarnLockedPositionsService {
+	s.positionId = positionId
+	return s
+}
+
+// SetProjectId sets the projectId parameter.
+func (s *SimpleEarnLockedPositionsService) SetProjectId(projectId string) *SimpleEarnLockedPositionsService {
+	s.projectId = projectId
+	return s
+}
+
+// SetCurrent query page. Default: 1, Min: 1
+func (s *SimpleEarnLockedPositionsService) SetCurrent(current int64) *SimpleEarnLockedPositionsService {
+	s.current = current
+	return s
+}
+
+// SetSize Default: 10, Max: 100
+func (s *SimpleEarnLockedPositionsService) SetSize(size int64) *SimpleEarnLockedPositionsService {
+	s.size = size
+	return s
+}
+
+// Do send request
+func (s *SimpleEarnLockedPositionsService) Do(ctx context.Context, opts ...RequestOption) (*SimpleEarnLockedPositionList, error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/locked/position",
+		secType:  secTypeSigned,
+	}
+	m := simpleEarnPageParams(s.asset, s.current, s.size)
+	if s.positionId != "" {
+		m["positionId"] = s.positionId
+	}
+	if s.projectId != "" {
+		m["projectId"] = s.projectId
+	}
+	r.setParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(SimpleEarnLockedPositionList)
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnLockedPositionList define a page of locked positions
+type SimpleEarnLockedPositionList struct {
+	Rows  []*SimpleEarnLockedPosition `json:"rows"`
+	Total int64                       `json:"total"`
+}
+
+// SimpleEarnLockedPosition define a locked product position (Simple Earn)
+type SimpleEarnLockedPosition struct {
+	PositionId            int64  `json:"positionId"`
+	ParentPositionId      int64  `json:"parentPositionId"`
+	ProjectId             string `json:"projectId"`
+	Asset                 string `json:"asset"`
+	Amount                string `json:"amount"`
+	PurchaseTime          string `json:"purchaseTime"`
+	Duration              string `json:"duration"`
+	AccrualDays           string `json:"accrualDays"`
+	RewardAsset           string `json:"rewardAsset"`
+	APY                   string `json:"APY"`
+	RewardAmt             string `json:"rewardAmt"`
+	ExtraRewardAsset      string `json:"extraRewardAsset"`
+	ExtraRewardAPR        string `json:"extraRewardAPR"`
+	EstExtraRewardAmt     string `json:"estExtraRewardAmt"`
+	NextPay               string `json:"nextPay"`
+	NextPayDate           string `json:"nextPayDate"`
+	PayPeriod             string `json:"payPeriod"`
+	RedeemAmountEarly     string `json:"redeemAmountEarly"`
+	RewardsEndDate        string `json:"rewardsEndDate"`
+	DeliverDate           string `json:"deliverDate"`
+	RedeemPeriod          string `json:"redeemPeriod"`
+	RedeemingAmt          string `json:"redeemingAmt"`
+	RedeemTo              string `json:"redeemTo"`
+	PartialAmtDeliverDate string `json:"partialAmtDeliverDate"`
+	CanRedeemEarly        bool   `json:"canRedeemEarly"`
+	CanFastRedemption     bool   `json:"canFastRedemption"`
+	AutoSubscribe         bool   `json:"autoSubscribe"`
+	Type                  string `json:"type"`   // AUTO or NORMAL
+	Status                string `json:"status"` // HOLDING or REDEEMED
+	CanReStake            bool   `json:"canReStake"`
+}
+
+// ToSavings convert to the retired Savings model of fixed project positions
+func (p *SimpleEarnLockedPosition) ToSavings() *SavingFixedProjectPosition {
+	purchaseTime := int64(parseFloat(p.PurchaseTime))
+	return &SavingFixedProjectPosition{
+		Asset:           p.Asset,
+		CreateTimestamp: purchaseTime,
+		Duration:        int64(parseFloat(p.Duration)),
+		StartTime:       purchaseTime,
+		EndTime:         int64(parseFloat(p.RewardsEndDate)),
+		PurchaseTime:    purchaseTime,
+		RedeemDate:      p.DeliverDate,
+		Interest:        p.RewardAmt,
+		InterestRate:    p.APY,
+		PositionId:      p.PositionId,
+		Principal:       p.Amount,
+		ProjectId:       p.ProjectId,
+		ProjectName:     p.Asset,
+		Status:          p.Status,
+		ProjectType:     "CUSTOMIZED_FIXED",
+	}
+}
+
+// GetSimpleEarnAccountService https://developers.binance.com/docs/simple_earn/account/Simple-Account
+type GetSimpleEarnAccountService struct {
+	c *Client
+}
+
+// Do send request
+func (s *GetSimpleEarnAccountService) Do(ctx context.Context, opts ...RequestOption) (*SimpleEarnAccount, error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/account",
+		secType:  secTypeSigned,
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(SimpleEarnAccount)
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnAccount define the Simple Earn account
+type SimpleEarnAccount struct {
+	TotalAmountInBTC          string `json:"totalAmountInBTC"`
+	TotalAmountInUSDT         string `json:"totalAmountInUSDT"`
+	TotalFlexibleAmountInBTC  string `json:"totalFlexibleAmountInBTC"`
+	TotalFlexibleAmountInUSDT string `json:"totalFlexibleAmountInUSDT"`
+	TotalLockedInBTC          string `json:"totalLockedInBTC"`
+	TotalLockedInUSDT         string `json:"totalLockedInUSDT"`
+}
+
+// GetSimpleEarnFlexibleQuotaService https://developers.binance.com/docs/simple_earn/account/Get-Flexible-Personal-Left-Quota
+type GetSimpleEarnFlexibleQuotaService struct {
+	c         *Client
+	productId string
+}
+
+// SetProductId sets the productId parameter.
+func (s *GetSimpleEarnFlexibleQuotaService) SetProductId(productId string) *GetSimpleEarnFlexibleQuotaService {
+	s.productId = productId
+	return s
+}
+
+// Do send request
+func (s *GetSimpleEarnFlexibleQuotaService) Do(ctx context.Context, opts ...RequestOption) (*SimpleEarnQuota, error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/flexible/personalLeftQuota",
+		secType:  secTypeSigned,
+	}
+	r.setParams(params{
+		"productId": s.productId,
+	})
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(SimpleEarnQuota)
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetSimpleEarnLockedQuotaService https://developers.binance.com/docs/simple_earn/account/Get-Locked-Personal-Left-Quota
+type GetSimpleEarnLockedQuotaService struct {
+	c         *Client
+	projectId string
+}
+
+// SetProjectId sets the projectId parameter.
+func (s *GetSimpleEarnLockedQuotaService) SetProjectId(projectId string) *GetSimpleEarnLockedQuotaService {
+	s.projectId = projectId
+	return s
+}
+
+// Do send request
+func (s *GetSimpleEarnLockedQuotaService) Do(ctx context.Context, opts ...RequestOption) (*SimpleEarnQuota, error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/simple-earn/locked/personalLeftQuota",
+		secType:  secTypeSigned,
+	}
+	r.setParams(params{
+		"projectId": s.projectId,
+	})
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(SimpleEarnQuota)
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SimpleEarnQuota define the personal left quota of a product
+type SimpleEarnQuota struct {
+	LeftPersonalQuota string `json:"leftPersonalQuota"`
+}
+
+// SetSimpleEarnFlexibleAutoSubscribeService https://developers.binance.com/docs/simple_earn/earn/Set-Flexible-Auto-Subscribe
+type SetSimpleEarnFlexibleAutoSubscribeService struct {
+	c             *Client
+	productId     string
+	autoSubscribe bool
+}
+
+// SetProductId sets the productId parameter.
+func (s *SetSimpleEarnFlexibleAutoSubscribeService) SetProductId(productId string) *SetSimpleEarnFlexibleAutoSubscribeService {
+	s.productId = productId
+	return s
+}
+
+// SetAutoSubscribe sets the autoSubscribe parameter.
+func (s *SetSimpleEarnFlexibleAutoSubscribeService) SetAutoSubscribe(autoSubscribe bool) *SetSimpleEarnFlexibleAutoSubscribeService {
+	s.autoSubscribe = autoSubscribe
+	return s
+}
+
+// Do send request
+func (s *SetSimpleEarnFlexibleAutoSubscribeService) Do(ctx context.Context, opts ...RequestOption) (bool, error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/simple-earn/flexible/setAutoSubscribe",
+		secType:  secTypeSigned,
+	}
+	r.setParams(params{
+		"productId":     s.productId,
+		"autoSubscribe": s.autoSubscribe,
+	})
+	return s.c.simpleEarnSuccess(ctx, r, opts...)
+}
+
+// SetSimpleEarnLockedAutoSubscribeService https://developers.binance.com/docs/simple_earn/earn/Set-Locked-Auto-Subscribe
+type SetSimpleEarnLockedAutoSubscribeService struct {
+	c             *Client
+	positionId    string
+	autoSubscribe bool
+}
+
+// SetPositionId sets the positionId parameter.
+func (s *SetSimpleEarnLockedAutoSubscribeService) SetPositionId(positionId string) *SetSimpleEarnLockedAutoSubscribeService {
+	s.positionId = positionId
+	return s
+}
+
+// SetAutoSubscribe sets the autoSubscribe parameter.
+func (s *SetSimpleEarnLockedAutoSubscribeService) SetAutoSubscribe(autoSubscribe bool) *SetSimpleEarnLockedAutoSubscribeService {
+	s.autoSubscribe = autoSubscribe
+	return s
+}
+
+// Do send request
+func (s *SetSimpleEarnLockedAutoSubscribeService) Do(ctx context.Context, opts ...RequestOption) (bool, error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/simple-earn/locked/setAutoSubscribe",
+		secType:  secTypeSigned,
+	}
+	r.setParams(params{
+		"positionId":    s.positionId,
+		"autoSubscribe": s.autoSubscribe,
+	})
+	return s.c.simpleEarnSuccess(ctx, r, opts...)
+}
+
+func (c *Client) simpleEarnSuccess(ctx context.Context, r *request, opts ...RequestOption) (bool, error) {
+	data, err := c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return false, err
+	}
+	var res struct {
+		Success bool `json:"success"`
+	}
+	if err = json.Unmarshal(data, &res); err != nil {
+		return false, err
+	}
+	return res.Success, nil
+}
+
+func simpleEarnPageParams(asset string, current, size int64) params {
+	m := params{}
+	if asset != "" {
+		m["asset"] = asset
+	}
+	if current != 0 {
+		m["current"] = current
+	}
+	if size != 0 {
+		m["size"] = size
+	}
+	return m
+}
+
+// formatRate format a rate derived from an annual rate, rounded to 8 decimals
+func formatRate(rate float64) string {
+	return formatFloat(math.Round(rate*1e8) / 1e8)
+}
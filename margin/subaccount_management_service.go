@@ -0,0 +1,907 @@
+package margin
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Futures type of sub-account futures services
+const (
+	SubAccountFuturesTypeUSDT = 1 // USDⓈ-M futures
+	SubAccountFuturesTypeCoin = 2 // COIN-M futures
+)
+
+// Transfer type of SubAccountFuturesTransferService
+const (
+	SubAccountFuturesTransferSpotToUSDT = 1 // from the spot account of the sub-account to its USDⓈ-M futures account
+	SubAccountFuturesTransferUSDTToSpot = 2 // from the USDⓈ-M futures account of the sub-account to its spot account
+	SubAccountFuturesTransferSpotToCoin = 3 // from the spot account of the sub-account to its COIN-M futures account
+	SubAccountFuturesTransferCoinToSpot = 4 // from the COIN-M futures account of the sub-account to its spot account
+)
+
+// Transfer type of SubAccountMarginTransferService
+const (
+	SubAccountMarginTransferSpotToMargin = 1 // from the spot account of the sub-account to its margin account
+	SubAccountMarginTransferMarginToSpot = 2 // from the margin account of the sub-account to its spot account
+)
+
+// CreateSubAccountService create a virtual sub-account
+// See https://developers.binance.com/docs/sub_account/account-management/Create-a-Virtual-Sub-account
+type CreateSubAccountService struct {
+	c                *Client
+	subAccountString string
+}
+
+// SubAccountString set the name of the sub-account, the email is generated from it
+func (s *CreateSubAccountService) SubAccountString(v string) *CreateSubAccountService {
+	s.subAccountString = v
+	return s
+}
+
+// Do send request
+func (s *CreateSubAccountService) Do(ctx context.Context, opts ...RequestOption) (res *CreateSubAccountResponse, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/sub-account/virtualSubAccount",
+		secType:  secTypeSigned,
+	}
+	r.setParam("subAccountString", s.subAccountString)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CreateSubAccountResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateSubAccountResponse define create sub-account response
+type CreateSubAccountResponse struct {
+	Email string `json:"email"`
+}
+
+// EnableSubAccountFuturesService enable futures for a sub-account
+// See https://developers.binance.com/docs/sub_account/account-management/Enable-Futures-for-Sub-account
+type EnableSubAccountFuturesService struct {
+	c     *Client
+	email string
+}
+
+// Email set email
+func (s *EnableSubAccountFuturesService) Email(v string) *EnableSubAccountFuturesService {
+	s.email = v
+	return s
+}
+
+// Do send request
+func (s *EnableSubAccountFuturesService) Do(ctx context.Context, opts ...RequestOption) (res *EnableSubAccountFuturesResponse, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/sub-account/futures/enable",
+		secType:  secTypeSigned,
+	}
+	r.setParam("email", s.email)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(EnableSubAccountFuturesResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// EnableSubAccountFuturesResponse define enable futures response
+type EnableSubAccountFuturesResponse struct {
+	Email            string `json:"email"`
+	IsFuturesEnabled bool   `json:"isFuturesEnabled"`
+}
+
+// EnableSubAccountMarginService enable margin for a sub-account
+// See https://developers.binance.com/docs/sub_account/account-management/Enable-Margin-for-Sub-account
+type EnableSubAccountMarginService struct {
+	c     *Client
+	email string
+}
+
+// Email set email
+func (s *EnableSubAccountMarginService) Email(v string) *EnableSubAccountMarginService {
+	s.email = v
+	return s
+}
+
+// Do send request
+func (s *EnableSubAccountMarginService) Do(ctx context.Context, opts ...RequestOption) (res *EnableSubAccountMarginResponse, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/sub-account/margin/enable",
+		secType:  secTypeSigned,
+	}
+	r.setParam("email", s.email)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(EnableSubAccountMarginResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// EnableSubAccountMarginResponse define enable margin response
+type EnableSubAccountMarginResponse struct {
+	Email           string `json:"email"`
+	IsMarginEnabled bool   `json:"isMarginEnabled"`
+}
+
+// SubAccountFuturesSummaryService get the futures account summary of the sub-accounts
+// See https://developers.binance.com/docs/sub_account/asset-management/Get-Summary-of-Sub-account's-Futures-Account-V2
+type SubAccountFuturesSummaryService struct {
+	c           *Client
+	futuresType int
+	page        int
+	limit       int
+}
+
+// FuturesType set futuresType, SubAccountFuturesTypeUSDT or SubAccountFuturesTypeCoin
+func (s *SubAccountFuturesSummaryService) FuturesType(v int) *SubAccountFuturesSummaryService {
+	s.futuresType = v
+	return s
+}
+
+// Page set page, 1 by default
+func (s *SubAccountFuturesSummaryService) Page(v int) *SubAccountFuturesSummaryService {
+	s.page = v
+	return s
+}
+
+// Limit set limit, 10 by default, 20 at most
+func (s *SubAccountFuturesSummaryService) Limit(v int) *SubAccountFuturesSummaryService {
+	s.limit = v
+	return s
+}
+
+// Do send request
+func (s *SubAccountFuturesSummaryService) Do(ctx context.Context, opts ...RequestOption) (res *SubAccountFuturesSummary, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v2/sub-account/futures/accountSummary",
+		secType:  secTypeSigned,
+	}
+	r.setParam("futuresType", s.futuresType)
+	if s.page > 0 {
+		r.setParam("page", s.page)
+	}
+	if s.limit > 0 {
+		r.setParam("limit", s.limit)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SubAccountFuturesSummary)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SubAccountFuturesSummary define the futures account summary, USDⓈ-M in FutureAccountSummary and COIN-M in
+// DeliveryAccountSummary
+type SubAccountFuturesSummary struct {
+	FutureAccountSummary   *SubAccountUSDTFuturesSummary `json:"futureAccountSummaryResp,omitempty"`
+	DeliveryAccountSummary *SubAccountCoinFuturesSummary `json:"deliveryAccountSummaryResp,omitempty"`
+}
+
+// SubAccountUSDTFuturesSummary define the USDⓈ-M futures account summary of the sub-accounts
+type SubAccountUSDTFuturesSummary struct {
+	TotalInitialMargin          string                          `json:"totalInitialMargin"`
+	TotalMaintenanceMargin      string                          `json:"totalMaintenanceMargin"`
+	TotalMarginBalance          string                          `json:"totalMarginBalance"`
+	TotalOpenOrderInitialMargin string                          `json:"totalOpenOrderInitialMargin"`
+	TotalPositionInitialMargin  string                          `json:"totalPositionInitialMargin"`
+	TotalUnrealizedProfit       string                          `json:"totalUnrealizedProfit"`
+	TotalWalletBalance          string                          `json:"totalWalletBalance"`
+	Asset                       string                          `json:"asset"`
+	SubAccountList              []*SubAccountUSDTFuturesAccount `json:"subAccountList"`
+}
+
+// SubAccountUSDTFuturesAccount define the USDⓈ-M futures account summary of a sub-account
+type SubAccountUSDTFuturesAccount struct {
+	Email                       string `json:"email"`
+	TotalInitialMargin          string `json:"totalInitialMargin"`
+	TotalMaintenanceMargin      string `json:"totalMaintenanceMargin"`
+	TotalMarginBalance          string `json:"totalMarginBalance"`
+	TotalOpenOrderInitialMargin string `json:"totalOpenOrderInitialMargin"`
+	TotalPositionInitialMargin  string `json:"totalPositionInitialMargin"`
+	TotalUnrealizedProfit       string `json:"totalUnrealizedProfit"`
+	TotalWalletBalance          string `json:"totalWalletBalance"`
+	Asset                       string `json:"asset"`
+}
+
+// SubAccountCoinFuturesSummary define the COIN-M futures account summary of the sub-accounts
+type SubAccountCoinFuturesSummary struct {
+	TotalMarginBalanceOfBTC    string                          `json:"totalMarginBalanceOfBTC"`
+	TotalUnrealizedProfitOfBTC string                          `json:"totalUnrealizedProfitOfBTC"`
+	TotalWalletBalanceOfBTC    string                          `json:"totalWalletBalanceOfBTC"`
+	Asset                      string                          `json:"asset"`
+	SubAccountList             []*SubAccountCoinFuturesAccount `json:"subAccountList"`
+}
+
+// SubAccountCoinFuturesAccount define the COIN-M futures account summary of a sub-account
+type SubAccountCoinFuturesAccount struct {
+	Email                 string `json:"email"`
+	TotalMarginBalance    string `json:"totalMarginBalance"`
+	TotalUnrealizedProfit string `json:"totalUnrealizedProfit"`
+	TotalWalletBalance    string `json:"totalWalletBalance"`
+	Asset                 string `json:"asset"`
+}
+
+// SubAccountMarginSummaryService get the margin account summary of the sub-accounts
+// See https://developers.binance.com/docs/sub_account/asset-management/Get-Summary-of-Sub-account's-Margin-Account
+type SubAccountMarginSummaryService struct {
+	c *Client
+}
+
+// Do send request
+func (s *SubAccountMarginSummaryService) Do(ctx context.Context, opts ...RequestOption) (res *SubAccountMarginSummary, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/sub-account/margin/accountSummary",
+		secType:  secTypeSigned,
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SubAccountMarginSummary)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SubAccountMarginSummary define the margin account summary of the sub-accounts, values in BTC
+type SubAccountMarginSummary struct {
+	TotalAssetOfBtc     string                     `json:"totalAssetOfBtc"`
+	TotalLiabilityOfBtc string                     `json:"totalLiabilityOfBtc"`
+	TotalNetAssetOfBtc  string                     `json:"totalNetAssetOfBtc"`
+	SubAccountList      []*SubAccountMarginAccount `json:"subAccountList"`
+}
+
+// SubAccountMarginAccount define the margin account summary of a sub-account
+type SubAccountMarginAccount struct {
+	Email               string `json:"email"`
+	TotalAssetOfBtc     string `json:"totalAssetOfBtc"`
+	TotalLiabilityOfBtc string `json:"totalLiabilityOfBtc"`
+	TotalNetAssetOfBtc  string `json:"totalNetAssetOfBtc"`
+}
+
+// SubAccountFuturesPositionRiskService get the futures positions of a sub-account
+// See https://developers.binance.com/docs/sub_account/account-management/Get-Futures-Position-Risk-of-Sub-account-V2
+type SubAccountFuturesPositionRiskService struct {
+	c           *Client
+	email       string
+	futuresType int
+}
+
+// Email set email
+func (s *SubAccountFuturesPositionRiskService) Email(v string) *SubAccountFuturesPositionRiskService {
+	s.email = v
+	return s
+}
+
+// FuturesType set futuresType, SubAccountFuturesTypeUSDT or SubAccountFuturesTypeCoin
+func (s *SubAccountFuturesPositionRiskService) FuturesType(v int) *SubAccountFuturesPositionRiskService {
+	s.futuresType = v
+	return s
+}
+
+// Do send request
+func (s *SubAccountFuturesPositionRiskService) Do(ctx context.Context, opts ...RequestOption) (res *SubAccountFuturesPositionRisk, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v2/sub-account/futures/positionRisk",
+		secType:  secTypeSigned,
+	}
+	r.setParam("email", s.email)
+	r.setParam("futuresType", s.futuresType)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SubAccountFuturesPositionRisk)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SubAccountFuturesPositionRisk define the futures positions of a sub-account, USDⓈ-M in FuturePositions and
+// COIN-M in DeliveryPositions
+type SubAccountFuturesPositionRisk struct {
+	FuturePositions   []*SubAccountPosition `json:"futurePositionRiskVos"`
+	DeliveryPositions []*SubAccountPosition `json:"deliveryPositionRiskVos"`
+}
+
+// SubAccountPosition define a futures position of a sub-account
+type SubAccountPosition struct {
+	Symbol           string `json:"symbol"`
+	PositionAmount   string `json:"positionAmount"`
+	EntryPrice       string `json:"entryPrice"`
+	MarkPrice        string `json:"markPrice"`
+	Leverage         string `json:"leverage"`
+	LiquidationPrice string `json:"liquidationPrice"`
+	UnrealizedProfit string `json:"unrealizedProfit"`
+	MaxNotional      string `json:"maxNotional"`      // USDⓈ-M only
+	Isolated         bool   `json:"isolated"`         // COIN-M only
+	IsolatedWallet   string `json:"isolatedWallet"`   // COIN-M only
+	IsolatedMargin   string `json:"isolatedMargin"`   // COIN-M only
+	IsAutoAddMargin  bool   `json:"isAutoAddMargin"`  // COIN-M only
+	PositionSide     string `json:"positionSide"`     // COIN-M only
+	MaxQty           string `json:"maxQty,omitempty"` // COIN-M only
+}
+
+// SubAccountFuturesTransferService transfer between the spot and futures accounts of a sub-account
+// See https://developers.binance.com/docs/sub_account/asset-management/Futures-Transfer-for-Sub-account
+type SubAccountFuturesTransferService struct {
+	c            *Client
+	email        string
+	asset        string
+	amount       string
+	transferType int
+}
+
+// Email set email
+func (s *SubAccountFuturesTransferService) Email(v string) *SubAccountFuturesTransferService {
+	s.email = v
+	return s
+}
+
+// Asset set asset
+func (s *SubAccountFuturesTransferService) Asset(v string) *SubAccountFuturesTransferService {
+	s.asset = v
+	return s
+}
+
+// Amount set amount
+func (s *SubAccountFuturesTransferService) Amount(v string) *SubAccountFuturesTransferService {
+	s.amount = v
+	return s
+}
+
+// TransferType set type, one of SubAccountFuturesTransferSpotToUSDT and the like
+func (s *SubAccountFuturesTransferService) TransferType(v int) *SubAccountFuturesTransferService {
+	s.transferType = v
+	return s
+}
+
+// Do send request
+func (s *SubAccountFuturesTransferService) Do(ctx context.Context, opts ...RequestOption) (res *TransferToSubAccountResponse, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/sub-account/futures/transfer",
+		secType:  secTypeSigned,
+	}
+	r.setParams(params{
+		"email":  s.email,
+		"asset":  s.asset,
+		"amount": s.amount,
+		"type":   s.transferType,
+	})
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(TransferToSubAccountResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SubAccountMarginTransferService transfer between the spot and margin accounts of a sub-account
+// See https://developers.binance.com/docs/sub_account/asset-management/Margin-Transfer-for-Sub-account
+type SubAccountMarginTransferService struct {
+	c            *Client
+	email        string
+	asset        string
+	amount       string
+	transferType int
+}
+
+// Email set email
+func (s *SubAccountMarginTransferService) Email(v string) *SubAccountMarginTransferService {
+	s.email = v
+	return s
+}
+
+// Asset set asset
+func (s *SubAccountMarginTransferService) Asset(v string) *SubAccountMarginTransferService {
+	s.asset = v
+	return s
+}
+
+// Amount set amount
+func (s *SubAccountMarginTransferService) Amount(v string) *SubAccountMarginTransferService {
+	s.amount = v
+	return s
+}
+
+// TransferType set type, SubAccountMarginTransferSpotToMargin or SubAccountMarginTransferMarginToSpot
+func (s *SubAccountMarginTransferService) TransferType(v int) *SubAccountMarginTransferService {
+	s.transferType = v
+	return s
+}
+
+// Do send request
+func (s *SubAccountMarginTransferService) Do(ctx context.Context, opts ...RequestOption) (res *TransferToSubAccountResponse, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/sub-account/margin/transfer",
+		secType:  secTypeSigned,
+	}
+	r.setParams(params{
+		"email":  s.email,
+		"asset":  s.asset,
+		"amount": s.amount,
+		"type":   s.transferType,
+	})
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(TransferToSubAccountResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SubAccountUniversalTransferHistoryService list the universal transfers of the master account
+// See https://developers.binance.com/docs/sub_account/asset-management/Query-Universal-Transfer-History
+type SubAccountUniversalTransferHistoryService struct {
+	c            *Client
+	fromEmail    *string
+	toEmail      *string
+	clientTranID *string
+	startTime    *int64
+	endTime      *int64
+	page         int
+	limit        int
+}
+
+// FromEmail set fromEmail
+func (s *SubAccountUniversalTransferHistoryService) FromEmail(v string) *SubAccountUniversalTransferHistoryService {
+	s.fromEmail = &v
+	return s
+}
+
+// ToEmail set toEmail
+func (s *SubAccountUniversalTransferHistoryService) ToEmail(v string) *SubAccountUniversalTransferHistoryService {
+	s.toEmail = &v
+	return s
+}
+
+// ClientTranID set clientTranId
+func (s *SubAccountUniversalTransferHistoryService) ClientTranID(v string) *SubAccountUniversalTransferHistoryService {
+	s.clientTranID = &v
+	return s
+}
+
+// StartTime set startTime
+func (s *SubAccountUniversalTransferHistoryService) StartTime(v int64) *SubAccountUniversalTransferHistoryService {
+	s.startTime = &v
+	return s
+}
+
+// EndTime set endTime
+func (s *SubAccountUniversalTransferHistoryService) EndTime(v int64) *SubAccountUniversalTransferHistoryService {
+	s.endTime = &v
+	return s
+}
+
+// Page set page, 1 by default
+func (s *SubAccountUniversalTransferHistoryService) Page(v int) *SubAccountUniversalTransferHistoryService {
+	s.page = v
+	return s
+}
+
+// Limit set limit, 500 by default, 500 at most
+func (s *SubAccountUniversalTransferHistoryService) Limit(v int) *SubAccountUniversalTransferHistoryService {
+	s.limit = v
+	return s
+}
+
+// Do send request
+func (s *SubAccountUniversalTransferHistoryService) Do(ctx context.Context, opts ...RequestOption) (res *SubAccountUniversalTransferHistory, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/sub-account/universalTransfer",
+		secType:  secTypeSigned,
+	}
+	if s.fromEmail != nil {
+		r.setParam("fromEmail", *s.fromEmail)
+	}
+	if s.toEmail != nil {
+		r.setParam("toEmail", *s.toEmail)
+	}
+	if s.clientTranID != nil {
+		r.setParam("clientTranId", *s.clientTranID)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.page > 0 {
+		r.setParam("page", s.page)
+	}
+	if s.limit > 0 {
+		r.setParam("limit", s.limit)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SubAccountUniversalTransferHistory)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SubAccountUniversalTransferHistory define universal transfer history
+type SubAccountUniversalTransferHistory struct {
+	Result     []*SubAccountUniversalTransfer `json:"result"`
+	TotalCount int64                          `json:"totalCount"`
+}
+
+// SubAccountUniversalTransfer define a universal transfer
+type SubAccountUniversalTransfer struct {
+	TranID          int64  `json:"tranId"`
+	FromEmail       string `json:"fromEmail"`
+	ToEmail         string `json:"toEmail"`
+	Asset           string `json:"asset"`
+	Amount          string `json:"amount"`
+	CreateTimeStamp int64  `json:"createTimeStamp"`
+	FromAccountType string `json:"fromAccountType"`
+	ToAccountType   string `json:"toAccountType"`
+	Status          string `json:"status"` // SUCCESS, PROCESS or FAILURE
+	ClientTranID    string `json:"clientTranId"`
+}
+
+// GetSubAccountIPRestrictionService get the IP restriction of a sub-account API key
+// See https://developers.binance.com/docs/sub_account/api-management/Get-IP-Restriction-for-a-Sub-account-API-Key
+type GetSubAccountIPRestrictionService struct {
+	c                *Client
+	email            string
+	subAccountApiKey string
+}
+
+// Email set email
+func (s *GetSubAccountIPRestrictionService) Email(v string) *GetSubAccountIPRestrictionService {
+	s.email = v
+	return s
+}
+
+// SubAccountApiKey set subAccountApiKey
+func (s *GetSubAccountIPRestrictionService) SubAccountApiKey(v string) *GetSubAccountIPRestrictionService {
+	s.subAccountApiKey = v
+	return s
+}
+
+// Do send request
+func (s *GetSubAccountIPRestrictionService) Do(ctx context.Context, opts ...RequestOption) (res *SubAccountIPRestriction, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/sub-account/subAccountApi/ipRestriction",
+		secType:  secTypeSigned,
+	}
+	r.setParam("email", s.email)
+	r.setParam("subAccountApiKey", s.subAccountApiKey)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SubAccountIPRestriction)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SubAccountIPRestriction define the IP restriction of a sub-account API key
+type SubAccountIPRestriction struct {
+	IPRestrict string   `json:"ipRestrict"` // "true" when the key is restricted to IPList
+	IPList     []string `json:"ipList"`
+	UpdateTime int64    `json:"updateTime"`
+	APIKey     string   `json:"apiKey"`
+}
+
+// IsRestricted return whether the API key is restricted to IPList
+func (r *SubAccountIPRestriction) IsRestricted() bool {
+	return r.IPRestrict == "true"
+}
+
+// AddSubAccountIPRestrictionService restrict a sub-account API key to IP addresses, or lift the restriction
+// See https://developers.binance.com/docs/sub_account/api-management/Add-IP-Restriction-for-Sub-Account-API-key
+type AddSubAccountIPRestrictionService struct {
+	c                *Client
+	email            string
+	subAccountApiKey string
+	restricted       bool
+	ipAddresses      []string
+}
+
+// Email set email
+func (s *AddSubAccountIPRestrictionService) Email(v string) *AddSubAccountIPRestrictionService {
+	s.email = v
+	return s
+}
+
+// SubAccountApiKey set subAccountApiKey
+func (s *AddSubAccountIPRestrictionService) SubAccountApiKey(v string) *AddSubAccountIPRestrictionService {
+	s.subAccountApiKey = v
+	return s
+}
+
+// Restrict restrict the API key to ipAddresses, added to the addresses already allowed
+func (s *AddSubAccountIPRestrictionService) Restrict(ipAddresses ...string) *AddSubAccountIPRestrictionService {
+	s.restricted = true
+	s.ipAddresses = ipAddresses
+	return s
+}
+
+// Do send request, without Restrict the restriction is lifted
+func (s *AddSubAccountIPRestrictionService) Do(ctx context.Context, opts ...RequestOption) (res *SubAccountIPRestriction, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v2/sub-account/subAccountApi/ipRestriction",
+		secType:  secTypeSigned,
+	}
+	r.setParam("email", s.email)
+	r.setParam("subAccountApiKey", s.subAccountApiKey)
+	if s.restricted {
+		r.setParam("status", "2")
+		if len(s.ipAddresses) > 0 {
+			r.setParam("ipAddress", strings.Join(s.ipAddresses, ","))
+		}
+	} else {
+		r.setParam("status", "1")
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SubAccountIPRestriction)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DeleteSubAccountIPRestrictionService remove IP addresses from the restriction of a sub-account API key
+// See https://developers.binance.com/docs/sub_account/api-management/Delete-IP-List-For-a-Sub-account-API-Key
+type DeleteSubAccountIPRestrictionService struct {
+	c                *Client
+	email            string
+	subAccountApiKey string
+	ipAddresses      []string
+}
+
+// Email set email
+func (s *DeleteSubAccountIPRestrictionService) Email(v string) *DeleteSubAccountIPRestrictionService {
+	s.email = v
+	return s
+}
+
+// SubAccountApiKey set subAccountApiKey
+func (s *DeleteSubAccountIPRestrictionService) SubAccountApiKey(v string) *DeleteSubAccountIPRestrictionService {
+	s.subAccountApiKey = v
+	return s
+}
+
+// IPAddresses set the IP addresses to remove
+func (s *DeleteSubAccountIPRestrictionService) IPAddresses(v ...string) *DeleteSubAccountIPRestrictionService {
+	s.ipAddresses = v
+	return s
+}
+
+// Do send request
+func (s *DeleteSubAccountIPRestrictionService) Do(ctx context.Context, opts ...RequestOption) (res *SubAccountIPRestriction, err error) {
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: "/sapi/v1/sub-account/subAccountApi/ipRestriction/ipList",
+		secType:  secTypeSigned,
+	}
+	r.setParam("email", s.email)
+	r.setParam("subAccountApiKey", s.subAccountApiKey)
+	r.setParam("ipAddress", strings.Join(s.ipAddresses, ","))
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SubAccountIPRestriction)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListManagedSubAccountsService list the managed sub-accounts of the investor master account
+// See https://developers.binance.com/docs/sub_account/managed-sub-account/Query-Managed-Sub-account-List
+type ListManagedSubAccountsService struct {
+	c     *Client
+	email *string
+	page  int
+	limit int
+}
+
+// Email set email
+func (s *ListManagedSubAccountsService) Email(v string) *ListManagedSubAccountsService {
+	s.email = &v
+	return s
+}
+
+// Page set page, 1 by default
+func (s *ListManagedSubAccountsService) Page(v int) *ListManagedSubAccountsService {
+	s.page = v
+	return s
+}
+
+// Limit set limit, 20 by default, 20 at most
+func (s *ListManagedSubAccountsService) Limit(v int) *ListManagedSubAccountsService {
+	s.limit = v
+	return s
+}
+
+// Do send request
+func (s *ListManagedSubAccountsService) Do(ctx context.Context, opts ...RequestOption) (res *ManagedSubAccountList, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/managed-subaccount/info",
+		secType:  secTypeSigned,
+	}
+	if s.email != nil {
+		r.setParam("email", *s.email)
+	}
+	if s.page > 0 {
+		r.setParam("page", s.page)
+	}
+	if s.limit > 0 {
+		r.setParam("limit", s.limit)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(ManagedSubAccountList)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ManagedSubAccountList define managed sub-account list
+type ManagedSubAccountList struct {
+	Total       int64                `json:"total"`
+	SubAccounts []*ManagedSubAccount `json:"managerSubUserInfoVoList"`
+}
+
+// ManagedSubAccount define a managed sub-account
+type ManagedSubAccount struct {
+	RootUserID               int64  `json:"rootUserId"`
+	ManagerSubUserID         int64  `json:"managersubUserId"`
+	BindParentUserID         int64  `json:"bindParentUserId"`
+	Email                    string `json:"email"`
+	InsertTimeStamp          int64  `json:"insertTimeStamp"`
+	BindParentEmail          string `json:"bindParentEmail"`
+	IsSubUserEnabled         bool   `json:"isSubUserEnabled"`
+	IsUserActive             bool   `json:"isUserActive"`
+	IsMarginEnabled          bool   `json:"isMarginEnabled"`
+	IsFutureEnabled          bool   `json:"isFutureEnabled"`
+	IsSignedLVTRiskAgreement bool   `json:"isSignedLVTRiskAgreement"`
+}
+
+// GetManagedSubAccountAssetService get the assets of a managed sub-account
+// See https://developers.binance.com/docs/sub_account/managed-sub-account/Query-Managed-Sub-account-Asset-Details
+type GetManagedSubAccountAssetService struct {
+	c     *Client
+	email string
+}
+
+// Email set email
+func (s *GetManagedSubAccountAssetService) Email(v string) *GetManagedSubAccountAssetService {
+	s.email = v
+	return s
+}
+
+// Do send request
+func (s *GetManagedSubAccountAssetService) Do(ctx context.Context, opts ...RequestOption) (res []*ManagedSubAccountAsset, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/managed-subaccount/asset",
+		secType:  secTypeSigned,
+	}
+	r.setParam("email", s.email)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*ManagedSubAccountAsset{}, err
+	}
+	res = make([]*ManagedSubAccountAsset, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*ManagedSubAccountAsset{}, err
+	}
+	return res, nil
+}
+
+// ManagedSubAccountAsset define an asset of a managed sub-account
+type ManagedSubAccountAsset struct {
+	Coin             string `json:"coin"`
+	Name             string `json:"name"`
+	TotalBalance     string `json:"totalBalance"`
+	AvailableBalance string `json:"availableBalance"`
+	InOrder          string `json:"inOrder"`
+	BtcValue         string `json:"btcValue"`
+}
+
+// SubAccountProvision define a sub-account to provision
+type SubAccountProvision struct {
+	Name          string // subAccountString of the virtual sub-account
+	EnableFutures bool
+	EnableMargin  bool
+}
+
+// ProvisionedSubAccount define a provisioned sub-account
+type ProvisionedSubAccount struct {
+	Email            string
+	IsFuturesEnabled bool
+	IsMarginEnabled  bool
+}
+
+// ProvisionSubAccount create a virtual sub-account and enable futures and margin on it as asked.
+// On error the sub-account created so far is returned with the error, so the provisioning can be completed
+// with the enable services
+func (c *Client) ProvisionSubAccount(ctx context.Context, p SubAccountProvision, opts ...RequestOption) (*ProvisionedSubAccount, error) {
+	created, err := c.NewCreateSubAccountService().SubAccountString(p.Name).Do(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := &ProvisionedSubAccount{Email: created.Email}
+	if p.EnableFutures {
+		enabled, err := c.NewEnableSubAccountFuturesService().Email(res.Email).Do(ctx, opts...)
+		if err != nil {
+			return res, err
+		}
+		res.IsFuturesEnabled = enabled.IsFuturesEnabled
+	}
+	if p.EnableMargin {
+		enabled, err := c.NewEnableSubAccountMarginService().Email(res.Email).Do(ctx, opts...)
+		if err != nil {
+			return res, err
+		}
+		res.IsMarginEnabled = enabled.IsMarginEnabled
+	}
+	return res, nil
+}
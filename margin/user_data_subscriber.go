@@ -0,0 +1,162 @@
+package margin
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// userDataKeepaliveInterval listen keys expire 60 minutes after the last keepalive
+const userDataKeepaliveInterval = 30 * time.Minute
+
+// UserDataSubscriber keep the user data stream of the cross margin account, or of one isolated pair,
+// alive and decode its events. The listen key is extended every 30 minutes and renewed when it expires
+type UserDataSubscriber struct {
+	c          *Client
+	symbol     string // isolated pair, empty for the cross margin account
+	handler    WsUserDataHandler
+	errHandler ErrHandler
+
+	mu        sync.Mutex
+	listenKey string
+	done      chan struct{}
+	stop      chan struct{}
+}
+
+// NewUserDataSubscriber init a subscriber of the cross margin user data stream
+func (c *Client) NewUserDataSubscriber(handler WsUserDataHandler, errHandler ErrHandler) *UserDataSubscriber {
+	return &UserDataSubscriber{c: c, handler: handler, errHandler: errHandler}
+}
+
+// NewIsolatedUserDataSubscriber init a subscriber of the user data stream of an isolated pair
+func (c *Client) NewIsolatedUserDataSubscriber(symbol string, handler WsUserDataHandler, errHandler ErrHandler) *UserDataSubscriber {
+	return &UserDataSubscriber{c: c, symbol: symbol, handler: handler, errHandler: errHandler}
+}
+
+// Symbol return the isolated pair of the subscriber, empty for the cross margin account
+func (s *UserDataSubscriber) Symbol() string {
+	return s.symbol
+}
+
+// ListenKey return the listen key in use
+func (s *UserDataSubscriber) ListenKey() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.listenKey
+}
+
+// Start create a listen key, connect the stream and keep it alive until Stop
+func (s *UserDataSubscriber) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop != nil {
+		return nil
+	}
+	if err := s.connect(ctx); err != nil {
+		return err
+	}
+	s.stop = make(chan struct{})
+	go s.keepalive(s.stop)
+	return nil
+}
+
+// Stop close the stream and the listen key
+func (s *UserDataSubscriber) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop == nil {
+		return nil
+	}
+	close(s.stop)
+	s.stop = nil
+	s.disconnect()
+	listenKey := s.listenKey
+	s.listenKey = ""
+	if s.symbol == "" {
+		return s.c.NewCloseMarginUserStreamService().ListenKey(listenKey).Do(ctx)
+	}
+	return s.c.NewCloseIsolatedMarginUserStreamService().Symbol(s.symbol).ListenKey(listenKey).Do(ctx)
+}
+
+// connect create a listen key and serve its stream, must hold the lock
+func (s *UserDataSubscriber) connect(ctx context.Context) (err error) {
+	var listenKey string
+	if s.symbol == "" {
+		listenKey, err = s.c.NewStartMarginUserStreamService().Do(ctx)
+	} else {
+		listenKey, err = s.c.NewStartIsolatedMarginUserStreamService().Symbol(s.symbol).Do(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	done, err := WsUserDataServe(listenKey, s.handle, s.errHandler)
+	if err != nil {
+		return err
+	}
+	s.listenKey, s.done = listenKey, done
+	return nil
+}
+
+// disconnect close the stream, must hold the lock
+func (s *UserDataSubscriber) disconnect() {
+	if s.done != nil {
+		done := s.done
+		s.done = nil
+		go func() { done <- struct{}{} }()
+	}
+}
+
+// handle pass the event on, and renew the stream when its listen key expired
+func (s *UserDataSubscriber) handle(event *WsUserDataEvent) {
+	s.handler(event)
+	if event.Event == UserDataEventTypeListenKeyExpired {
+		go s.renew(event.ListenKeyExpired.ListenKey)
+	}
+}
+
+// renew reconnect with a new listen key, unless stopped or already renewed
+func (s *UserDataSubscriber) renew(expired string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stop == nil || s.listenKey != expired {
+		return
+	}
+	s.disconnect()
+	if err := s.connect(context.Background()); err != nil {
+		s.errHandler(err)
+	}
+}
+
+func (s *UserDataSubscriber) keepalive(stop chan struct{}) {
+	ticker := time.NewTicker(userDataKeepaliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		s.mu.Lock()
+		listenKey := s.listenKey
+		s.mu.Unlock()
+		var err error
+		if s.symbol == "" {
+			err = s.c.NewKeepaliveMarginUserStreamService().ListenKey(listenKey).Do(context.Background())
+		} else {
+			err = s.c.NewKeepaliveIsolatedMarginUserStreamService().Symbol(s.symbol).ListenKey(listenKey).Do(context.Background())
+		}
+		if err == nil {
+			continue
+		}
+		// the key may already be gone, e.g. after a long disconnect, start over with a new one
+		s.errHandler(err)
+		s.mu.Lock()
+		if s.stop == stop {
+			s.disconnect()
+			if err := s.connect(context.Background()); err != nil {
+				s.errHandler(err)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
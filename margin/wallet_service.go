@@ -0,0 +1,263 @@
+package margin
+
+import (
+	"context"
+	"net/http"
+)
+
+// GetWalletBalanceService get the balance of every wallet of the account valued in a quote asset
+// See https://developers.binance.com/docs/wallet/asset/query-user-wallet-balance
+type GetWalletBalanceService struct {
+	c          *Client
+	quoteAsset *string
+}
+
+// QuoteAsset sets the quoteAsset parameter, e.g. USDT, ETH, USDC or BNB. BTC by default.
+func (s *GetWalletBalanceService) QuoteAsset(quoteAsset string) *GetWalletBalanceService {
+	s.quoteAsset = &quoteAsset
+	return s
+}
+
+// Do send request
+func (s *GetWalletBalanceService) Do(ctx context.Context, opts ...RequestOption) (res []*WalletBalance, err error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/asset/wallet/balance",
+		secType:  secTypeSigned,
+	}
+	if s.quoteAsset != nil {
+		r.setParam("quoteAsset", *s.quoteAsset)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*WalletBalance{}, err
+	}
+	res = make([]*WalletBalance, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*WalletBalance{}, err
+	}
+	return res, nil
+}
+
+// WalletBalance define the balance of one wallet, such as Spot, Funding, Cross Margin or USDⓈ-M Futures
+type WalletBalance struct {
+	Activate   bool   `json:"activate"`
+	Balance    string `json:"balance"`
+	WalletName string `json:"walletName"`
+}
+
+// GetFundingAssetService get the assets of the funding wallet
+// See https://developers.binance.com/docs/wallet/asset/funding-wallet
+type GetFundingAssetService struct {
+	c                *Client
+	asset            *string
+	needBtcValuation bool
+}
+
+// Asset sets the asset parameter.
+func (s *GetFundingAssetService) Asset(asset string) *GetFundingAssetService {
+	s.asset = &asset
+	return s
+}
+
+// NeedBtcValuation sets the needBtcValuation parameter.
+func (s *GetFundingAssetService) NeedBtcValuation(val bool) *GetFundingAssetService {
+	s.needBtcValuation = val
+	return s
+}
+
+// Do send request
+func (s *GetFundingAssetService) Do(ctx context.Context, opts ...RequestOption) (res []*FundingAsset, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/asset/get-funding-asset",
+		secType:  secTypeSigned,
+	}
+	if s.asset != nil {
+		r.setParam("asset", *s.asset)
+	}
+	if s.needBtcValuation {
+		r.setParam("needBtcValuation", "true")
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*FundingAsset{}, err
+	}
+	res = make([]*FundingAsset, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*FundingAsset{}, err
+	}
+	return res, nil
+}
+
+// FundingAsset define an asset of the funding wallet
+type FundingAsset struct {
+	Asset        string `json:"asset"`
+	Free         string `json:"free"`
+	Locked       string `json:"locked"`
+	Freeze       string `json:"freeze"`
+	Withdrawing  string `json:"withdrawing"`
+	BtcValuation string `json:"btcValuation"`
+}
+
+// ListDustAssetsService list the assets that can be converted into BNB
+// See https://developers.binance.com/docs/wallet/asset/assets-can-convert-bnb
+type ListDustAssetsService struct {
+	c *Client
+}
+
+// Do send request
+func (s *ListDustAssetsService) Do(ctx context.Context, opts ...RequestOption) (*DustAssets, error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/sapi/v1/asset/dust-btc",
+		secType:  secTypeSigned,
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(DustAssets)
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// DustAssets define the assets that can be converted into BNB
+type DustAssets struct {
+	Details            []*DustAsset `json:"details"`
+	TotalTransferBtc   string       `json:"totalTransferBtc"`
+	TotalTransferBNB   string       `json:"totalTransferBNB"`
+	DribbletPercentage string       `json:"dribbletPercentage"` // commission fee
+}
+
+// DustAsset define an asset that can be converted into BNB
+type DustAsset struct {
+	Asset            string `json:"asset"`
+	AssetFullName    string `json:"assetFullName"`
+	AmountFree       string `json:"amountFree"`
+	ToBTC            string `json:"toBTC"`            // BTC value
+	ToBNB            string `json:"toBNB"`            // BNB value before the commission fee
+	ToBNBOffExchange string `json:"toBNBOffExchange"` // BNB value after the commission fee
+	Exchange         string `json:"exchange"`         // commission fee
+}
+
+// GetSystemStatusService get the system status
+// See https://developers.binance.com/docs/wallet/others/system-status
+type GetSystemStatusService struct {
+	c *Client
+}
+
+// Do send request
+func (s *GetSystemStatusService) Do(ctx context.Context, opts ...RequestOption) (*SystemStatus, error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/system/status",
+		secType:  setTypeNone,
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(SystemStatus)
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SystemStatus define the system status
+type SystemStatus struct {
+	Status int    `json:"status"` // 0: normal, 1: system maintenance
+	Msg    string `json:"msg"`    // "normal" or "system_maintenance"
+}
+
+// IsNormal return whether the system is not under maintenance
+func (s *SystemStatus) IsNormal() bool {
+	return s.Status == 0
+}
+
+// GetAccountStatusService get the account status
+// See https://developers.binance.com/docs/wallet/account/account-status
+type GetAccountStatusService struct {
+	c *Client
+}
+
+// Do send request
+func (s *GetAccountStatusService) Do(ctx context.Context, opts ...RequestOption) (*AccountStatus, error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/account/status",
+		secType:  secTypeSigned,
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(AccountStatus)
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AccountStatus define the account status
+type AccountStatus struct {
+	Data string `json:"data"` // "Normal" when the account is not restricted
+}
+
+// GetApiTradingStatusService get the API trading status of the account
+// See https://developers.binance.com/docs/wallet/account/account-api-trading-status
+type GetApiTradingStatusService struct {
+	c *Client
+}
+
+// Do send request
+func (s *GetApiTradingStatusService) Do(ctx context.Context, opts ...RequestOption) (*ApiTradingStatus, error) {
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/sapi/v1/account/apiTradingStatus",
+		secType:  secTypeSigned,
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res := new(ApiTradingStatus)
+	if err = json.Unmarshal(data, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ApiTradingStatus define the API trading status of the account
+type ApiTradingStatus struct {
+	Data ApiTradingStatusData `json:"data"`
+}
+
+// ApiTradingStatusData define the API trading status and the quantitative rules triggering a lock
+type ApiTradingStatusData struct {
+	IsLocked           bool                        `json:"isLocked"`           // API trading function is locked or not
+	PlannedRecoverTime int64                       `json:"plannedRecoverTime"` // if API trading function is locked, this is the planned recover time
+	TriggerCondition   ApiTradingTriggerCondition  `json:"triggerCondition"`
+	UpdateTime         int64                       `json:"updateTime"`
+	Indicators         map[string][]*ApiIndicators `json:"indicators"` // indicators by symbol
+}
+
+// ApiTradingTriggerCondition define the thresholds of the quantitative rules
+type ApiTradingTriggerCondition struct {
+	GCR  int64 `json:"GCR"`  // number of GTC orders
+	IFER int64 `json:"IFER"` // number of FOK/IOC orders
+	UFR  int64 `json:"UFR"`  // number of orders
+}
+
+// ApiIndicators define a quantitative rules indicator of a symbol
+type ApiIndicators struct {
+	Indicator    string  `json:"i"` // indicator name, UFR, IFER or GCR
+	Count        int64   `json:"c"` // count of all orders
+	CurrentValue float64 `json:"v"` // current value
+	TriggerValue float64 `json:"t"` // trigger value
+}
@@ -0,0 +1,66 @@
+package margin
+
+import (
+	"github.com/BobHye/binance-go/log"
+	"github.com/BobHye/wsc"
+)
+
+// WsHandler handle raw websocket message
+type WsHandler func(message []byte)
+
+// ErrHandler handle errors
+type ErrHandler func(err error)
+
+// WsConfig webservice configuration
+type WsConfig struct {
+	Endpoint string
+}
+
+func newWsConfig(endpoint string) *WsConfig {
+	return &WsConfig{
+		Endpoint: endpoint,
+	}
+}
+
+var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (done chan struct{}, err error) {
+	done = make(chan struct{})
+
+	go func() {
+		ws := wsc.New(cfg.Endpoint)
+		ws.OnConnected(func() {
+			if log.Default.OnConnected {
+				log.Default.Log("websocket connected")
+			}
+		})
+		ws.OnConnectError(errHandler)
+		ws.OnDisconnected(errHandler)
+		ws.OnClose(func(code int, text string) {
+			if log.Default.OnClose {
+				log.Default.Log("websocket closed, code: %d, message: %s", code, text)
+			}
+		})
+		ws.OnSentError(errHandler)
+		ws.OnPingReceived(func(appData string) {
+			if log.Default.OnPingReceived {
+				log.Default.Log("ping received, data: %s", appData)
+			}
+		})
+		ws.OnPongReceived(func(appData string) {
+			if log.Default.OnPongReceived {
+				log.Default.Log("pong received, data: %s", appData)
+			}
+		})
+		ws.OnTextMessageReceived(handler)
+		ws.OnKeepalive(func() {
+			if log.Default.OnKeepalive {
+				log.Default.Log("keep alive")
+			}
+		})
+		ws.Connect()
+		for range done {
+			ws.Close()
+			return
+		}
+	}()
+	return
+}
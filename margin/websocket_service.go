@@ -0,0 +1,160 @@
+package margin
+
+import (
+	"fmt"
+)
+
+// Endpoints
+const (
+	baseWsMainURL    = "wss://stream.binance.com:9443/ws"
+	baseWsTestnetURL = "wss://testnet.binance.vision/ws"
+)
+
+// getWsEndpoint return the base endpoint of the WS according the UseTestnet flag
+func getWsEndpoint() string {
+	if UseTestnet {
+		return baseWsTestnetURL
+	}
+	return baseWsMainURL
+}
+
+// WsUserDataEvent define margin user data event, only the field matching Event is set
+type WsUserDataEvent struct {
+	Event                   UserDataEventType         `json:"e"`
+	Time                    int64                     `json:"E"`
+	AccountUpdate           WsAccountUpdateList       `json:"-"`
+	BalanceUpdate           WsBalanceUpdate           `json:"-"`
+	OrderUpdate             WsOrderUpdate             `json:"-"`
+	LiabilityUpdate         WsLiabilityUpdate         `json:"-"`
+	MarginLevelStatusUpdate WsMarginLevelStatusUpdate `json:"-"`
+	ListenKeyExpired        WsListenKeyExpired        `json:"-"`
+}
+
+// WsAccountUpdateList define outboundAccountPosition event | 账户余额发生变化时推送
+type WsAccountUpdateList struct {
+	AccountUpdateTime int64             `json:"u"` // 账户末次更新时间戳
+	WsAccountUpdates  []WsAccountUpdate `json:"B"` // 余额
+}
+
+// WsAccountUpdate define account update of one asset
+type WsAccountUpdate struct {
+	Asset  string `json:"a"` // 资产名称
+	Free   string `json:"f"` // 可用余额
+	Locked string `json:"l"` // 冻结余额
+}
+
+// WsBalanceUpdate define balanceUpdate event | 充值、提取或账户之间转移资金时推送
+type WsBalanceUpdate struct {
+	Asset           string `json:"a"` // 资产名称
+	Change          string `json:"d"` // 余额变化量
+	TransactionTime int64  `json:"T"` // 清算时间
+}
+
+// WsOrderUpdate define executionReport event | 订单更新
+type WsOrderUpdate struct {
+	Symbol                  string             `json:"s"`  // 交易对
+	ClientOrderID           string             `json:"c"`  // 客户自定义订单ID
+	Side                    SideType           `json:"S"`  // 订单方向
+	Type                    OrderType          `json:"o"`  // 订单类型
+	TimeInForce             TimeInForceType    `json:"f"`  // 有效方式
+	Volume                  string             `json:"q"`  // 订单原始数量
+	Price                   string             `json:"p"`  // 订单原始价格
+	StopPrice               string             `json:"P"`  // 止盈止损单触发价格
+	IceBergVolume           string             `json:"F"`  // 冰山订单数量
+	OrderListID             int64              `json:"g"`  // 订单列表ID
+	OrigClientOrderID       string             `json:"C"`  // 原始订单自定义ID(撤单时)
+	ExecutionType           OrderExecutionType `json:"x"`  // 本次事件的具体执行类型
+	Status                  OrderStatusType    `json:"X"`  // 订单的当前状态
+	RejectReason            string             `json:"r"`  // 订单被拒绝的原因
+	ID                      int64              `json:"i"`  // 订单ID
+	LatestVolume            string             `json:"l"`  // 订单末次成交量
+	FilledVolume            string             `json:"z"`  // 订单累计已成交量
+	LatestPrice             string             `json:"L"`  // 订单末次成交价格
+	FeeCost                 string             `json:"n"`  // 手续费数量
+	FeeAsset                string             `json:"N"`  // 手续费资产类别
+	TransactionTime         int64              `json:"T"`  // 成交时间
+	TradeID                 int64              `json:"t"`  // 成交ID
+	IsInBook                bool               `json:"w"`  // 订单是否在订单簿上
+	IsMaker                 bool               `json:"m"`  // 该成交是作为挂单成交吗
+	CreateTime              int64              `json:"O"`  // 订单创建时间
+	FilledQuoteVolume       string             `json:"Z"`  // 订单累计已成交金额
+	LatestQuoteVolume       string             `json:"Y"`  // 订单末次成交金额
+	QuoteVolume             string             `json:"Q"`  // 报价订单数量
+	WorkingTime             int64              `json:"W"`  // 订单添加到 order book 的时间
+	StrategyID              int64              `json:"j"`  // 订单策略ID, 下单时设置才推送
+	StrategyType            int                `json:"J"`  // 订单策略类型, 下单时设置才推送
+	SelfTradePreventionMode string             `json:"V"`  // 自成交保护模式
+	PreventedMatchID        int64              `json:"v"`  // 因自成交保护过期时推送
+	PreventedQuantity       string             `json:"A"`  // 因自成交保护过期的数量
+	LastPreventedQuantity   string             `json:"B"`  // 末次因自成交保护过期的数量
+	TradeGroupID            int64              `json:"u"`  // 交易组ID
+	CounterOrderID          int64              `json:"U"`  // 自成交的对手订单ID
+	CounterSymbol           string             `json:"Cs"` // 自成交的对手交易对
+}
+
+// WsLiabilityUpdate define USER_LIABILITY_CHANGE event, pushed when the debt of an asset changes | 负债变化
+type WsLiabilityUpdate struct {
+	Asset         string `json:"a"` // 资产名称
+	Type          string `json:"t"` // 负债类型, 如 BORROW
+	TransactionID int64  `json:"T"` // 交易ID
+	Principal     string `json:"p"` // 本金
+	Interest      string `json:"i"` // 利息
+}
+
+// WsMarginLevelStatusUpdate define MARGIN_LEVEL_STATUS_CHANGE event, pushed when the margin level crosses a status | 风险率状态变化
+type WsMarginLevelStatusUpdate struct {
+	MarginLevel string                `json:"l"` // 风险率
+	Status      MarginLevelStatusType `json:"s"` // 风险率状态
+}
+
+// WsListenKeyExpired define listenKeyExpired event, the stream stops after it | listenKey 过期
+type WsListenKeyExpired struct {
+	ListenKey string `json:"listenKey"`
+}
+
+// UnmarshalJSON decode the event fields into the struct matching its type
+func (e *WsUserDataEvent) UnmarshalJSON(data []byte) error {
+	var head struct {
+		Event UserDataEventType `json:"e"`
+		Time  int64             `json:"E"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return err
+	}
+	e.Event = head.Event
+	e.Time = head.Time
+	switch e.Event {
+	case UserDataEventTypeOutboundAccountPosition:
+		return json.Unmarshal(data, &e.AccountUpdate)
+	case UserDataEventTypeBalanceUpdate:
+		return json.Unmarshal(data, &e.BalanceUpdate)
+	case UserDataEventTypeExecutionReport:
+		return json.Unmarshal(data, &e.OrderUpdate)
+	case UserDataEventTypeLiabilityChange:
+		return json.Unmarshal(data, &e.LiabilityUpdate)
+	case UserDataEventTypeMarginLevelStatusChange:
+		return json.Unmarshal(data, &e.MarginLevelStatusUpdate)
+	case UserDataEventTypeListenKeyExpired:
+		return json.Unmarshal(data, &e.ListenKeyExpired)
+	}
+	return nil
+}
+
+// WsUserDataHandler handle WsUserDataEvent
+type WsUserDataHandler func(event *WsUserDataEvent)
+
+// WsUserDataServe serve margin user data handler with listen key of the cross margin account or an isolated pair
+func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (done chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s", getWsEndpoint(), listenKey)
+	cfg := newWsConfig(endpoint)
+	wsHandler := func(message []byte) {
+		event := new(WsUserDataEvent)
+		err := json.Unmarshal(message, event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
@@ -0,0 +1,532 @@
+package margin
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Withdraw status
+const (
+	WithdrawStatusEmailSent        = 0
+	WithdrawStatusCancelled        = 1
+	WithdrawStatusAwaitingApproval = 2
+	WithdrawStatusRejected         = 3
+	WithdrawStatusProcessing       = 4
+	WithdrawStatusFailure          = 5
+	WithdrawStatusCompleted        = 6
+)
+
+// DefaultWithdrawApprovalTTL time a withdraw proposal can be approved in, see WithdrawManager.ApprovalTTL
+const DefaultWithdrawApprovalTTL = 15 * time.Minute
+
+// Errors of WithdrawManager
+var (
+	ErrWithdrawNotWhitelisted   = errors.New("withdraw address not whitelisted")
+	ErrWithdrawNetwork          = errors.New("withdraw network not available")
+	ErrWithdrawAmount           = errors.New("withdraw amount not allowed")
+	ErrWithdrawAddress          = errors.New("withdraw address invalid")
+	ErrWithdrawMemo             = errors.New("withdraw memo invalid")
+	ErrWithdrawDailyLimit       = errors.New("withdraw daily limit exceeded")
+	ErrWithdrawDuplicate        = errors.New("withdraw already submitted")
+	ErrWithdrawProposalNotFound = errors.New("withdraw proposal not found")
+	ErrWithdrawProposalExpired  = errors.New("withdraw proposal expired")
+	ErrWithdrawApprovalToken    = errors.New("withdraw approval token invalid")
+	ErrWithdrawNotifier         = errors.New("withdraw approval notifier not set")
+	ErrWithdrawFailed           = errors.New("withdraw failed")
+)
+
+// WithdrawAddress define a whitelisted withdraw destination
+type WithdrawAddress struct {
+	Coin       string
+	Network    string
+	Address    string
+	AddressTag string // memo, required by the network when its config has SameAddress
+	Name       string
+}
+
+// WithdrawRequest define a withdraw to check and propose. Reference is the caller's id of the withdraw: with the
+// coin, network, address, memo and amount it makes the withdrawOrderId, so proposing the same request twice can't
+// withdraw twice. Use different references for legitimately repeated withdraws
+type WithdrawRequest struct {
+	Coin       string
+	Network    string // default network of the coin if empty
+	Address    string
+	AddressTag string
+	Amount     string
+	Reference  string
+}
+
+// WithdrawProposal define a checked withdraw waiting for approval
+type WithdrawProposal struct {
+	ID         string // withdrawOrderId
+	Request    WithdrawRequest
+	Network    Network
+	Fee        string
+	ExpireTime int64
+	CreateTime int64
+	Approved   bool
+	WithdrawID string // id of the withdraw once submitted
+}
+
+// WithdrawNotifier deliver the approval token of a new proposal to the approver, out of band of Propose.
+// It's called without holding the manager's lock and may call the manager. The proposal is dropped when it returns
+// an error
+type WithdrawNotifier func(p WithdrawProposal, token string) error
+
+// WithdrawManager guard withdraws: requests are checked against the network config of the coin, the address
+// whitelist and the daily limit of the coin, proposed, and only submitted once approved with the token sent to
+// the notifier. Proposals count against the daily limit until they expire or are rejected. Coins without a daily
+// limit can't be withdrawn
+type WithdrawManager struct {
+	c           *Client
+	whitelist   map[string]WithdrawAddress
+	limits      map[string]float64
+	approvalTTL time.Duration
+	interval    time.Duration
+	notifier    WithdrawNotifier
+
+	mu        sync.Mutex
+	proposals map[string]*WithdrawProposal
+	tokens    map[string]string
+	approving map[string]*WithdrawProposal
+	submitted map[string]*WithdrawProposal
+}
+
+// NewWithdrawManager init a withdraw manager with an empty whitelist and no limits
+func (c *Client) NewWithdrawManager() *WithdrawManager {
+	return &WithdrawManager{
+		c:           c,
+		whitelist:   make(map[string]WithdrawAddress),
+		limits:      make(map[string]float64),
+		approvalTTL: DefaultWithdrawApprovalTTL,
+		interval:    30 * time.Second,
+		proposals:   make(map[string]*WithdrawProposal),
+		tokens:      make(map[string]string),
+		approving:   make(map[string]*WithdrawProposal),
+		submitted:   make(map[string]*WithdrawProposal),
+	}
+}
+
+// Whitelist add addresses to the whitelist
+func (m *WithdrawManager) Whitelist(addresses ...WithdrawAddress) *WithdrawManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, a := range addresses {
+		m.whitelist[withdrawAddressKey(a.Coin, a.Network, a.Address, a.AddressTag)] = a
+	}
+	return m
+}
+
+// DailyLimit set the amount of coin that can be withdrawn per UTC day, withdraw fees excluded
+func (m *WithdrawManager) DailyLimit(coin string, amount float64) *WithdrawManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.limits[coin] = amount
+	return m
+}
+
+// ApprovalTTL set the time a proposal can be approved in, 15 minutes by default
+func (m *WithdrawManager) ApprovalTTL(ttl time.Duration) *WithdrawManager {
+	m.approvalTTL = ttl
+	return m
+}
+
+// Interval set the poll interval of Track, 30 seconds by default
+func (m *WithdrawManager) Interval(interval time.Duration) *WithdrawManager {
+	m.interval = interval
+	return m
+}
+
+// Notifier set the notifier the approval tokens are sent to, required by Propose
+func (m *WithdrawManager) Notifier(notifier WithdrawNotifier) *WithdrawManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.notifier = notifier
+	return m
+}
+
+// Propose check the request, reserve its amount in the daily limit and send the approval token to the notifier.
+// Proposing a request already proposed and not expired return the same proposal without a new token
+func (m *WithdrawManager) Propose(ctx context.Context, req WithdrawRequest, opts ...RequestOption) (*WithdrawProposal, error) {
+	network, err := m.check(ctx, &req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	id := withdrawOrderID(req)
+	used, seen, err := m.withdrawnToday(ctx, req.Coin, id, opts...)
+	if err != nil {
+		return nil, err
+	}
+	token, err := withdrawToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	m.mu.Lock()
+	notifier := m.notifier
+	if notifier == nil {
+		m.mu.Unlock()
+		return nil, ErrWithdrawNotifier
+	}
+	if p, ok := m.submitted[id]; ok {
+		m.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s", ErrWithdrawDuplicate, p.WithdrawID)
+	}
+	if _, ok := m.approving[id]; ok {
+		m.mu.Unlock()
+		return nil, fmt.Errorf("%w: %s is being approved", ErrWithdrawDuplicate, id)
+	}
+	if p, ok := m.proposals[id]; ok && p.ExpireTime > now.UnixMilli() {
+		res := *p
+		m.mu.Unlock()
+		return &res, nil
+	}
+	if err = m.reserve(&req, id, used, seen); err != nil {
+		m.mu.Unlock()
+		return nil, err
+	}
+	// 先记录提案占用额度, 通知时不持有锁
+	p := &WithdrawProposal{
+		ID:         id,
+		Request:    req,
+		Network:    *network,
+		Fee:        network.WithdrawFee,
+		ExpireTime: now.Add(m.approvalTTL).UnixMilli(),
+		CreateTime: now.UnixMilli(),
+	}
+	m.proposals[id] = p
+	m.tokens[id] = token
+	res := *p
+	m.mu.Unlock()
+
+	if err = notifier(res, token); err != nil {
+		m.mu.Lock()
+		if m.proposals[id] == p {
+			delete(m.proposals, id)
+			delete(m.tokens, id)
+		}
+		m.mu.Unlock()
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Proposals return the proposals waiting for approval
+func (m *WithdrawManager) Proposals() []*WithdrawProposal {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	res := make([]*WithdrawProposal, 0, len(m.proposals))
+	for _, p := range m.proposals {
+		c := *p
+		res = append(res, &c)
+	}
+	return res
+}
+
+// Reject drop a proposal and release its amount
+func (m *WithdrawManager) Reject(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.proposals, id)
+	delete(m.tokens, id)
+}
+
+// Approve check the token, the request and the daily limit again, and submit the withdraw with the proposal ID as
+// withdrawOrderId. The amount stays reserved while approving, so concurrent approvals can't exceed the daily limit.
+// A withdraw with this withdrawOrderId already in the history isn't submitted again. When the check or the
+// submission fails the proposal is kept and can be approved again
+func (m *WithdrawManager) Approve(ctx context.Context, id, token string, opts ...RequestOption) (*WithdrawProposal, error) {
+	m.mu.Lock()
+	p, ok := m.proposals[id]
+	if !ok {
+		m.mu.Unlock()
+		return nil, ErrWithdrawProposalNotFound
+	}
+	if subtle.ConstantTimeCompare([]byte(m.tokens[id]), []byte(token)) != 1 {
+		m.mu.Unlock()
+		return nil, ErrWithdrawApprovalToken
+	}
+	if p.ExpireTime <= time.Now().UnixMilli() {
+		delete(m.proposals, id)
+		delete(m.tokens, id)
+		m.mu.Unlock()
+		return nil, ErrWithdrawProposalExpired
+	}
+	// 移出待审批列表以避免并发重复提交, 额度保留到提交完成
+	delete(m.proposals, id)
+	m.approving[id] = p
+	m.mu.Unlock()
+
+	req := p.Request
+	if _, err := m.check(ctx, &req, opts...); err != nil {
+		m.restore(p)
+		return nil, err
+	}
+	used, seen, err := m.withdrawnToday(ctx, req.Coin, id, opts...)
+	if err == nil {
+		m.mu.Lock()
+		err = m.reserve(&req, id, used, seen)
+		m.mu.Unlock()
+	}
+	if err != nil {
+		m.restore(p)
+		return nil, err
+	}
+	history, err := m.c.NewListWithdrawsService().Coin(req.Coin).WithdrawOrderId(id).Do(ctx, opts...)
+	if err != nil {
+		m.restore(p)
+		return nil, err
+	}
+	for _, w := range history {
+		if w.WithdrawOrderID == id {
+			return m.markSubmitted(p, w.ID), nil
+		}
+	}
+	s := m.c.NewCreateWithdrawService().
+		Coin(req.Coin).
+		Network(req.Network).
+		Address(req.Address).
+		Amount(req.Amount).
+		WithdrawOrderID(id)
+	if req.AddressTag != "" {
+		s.AddressTag(req.AddressTag)
+	}
+	res, err := s.Do(ctx, opts...)
+	if err != nil {
+		m.restore(p)
+		return nil, err
+	}
+	return m.markSubmitted(p, res.ID), nil
+}
+
+// Track poll the withdraw of a submitted proposal until it completes, fails or ctx is done.
+// A cancelled, rejected or failed withdraw return ErrWithdrawFailed with the withdraw
+func (m *WithdrawManager) Track(ctx context.Context, id string, opts ...RequestOption) (*Withdraw, error) {
+	m.mu.Lock()
+	p, ok := m.submitted[id]
+	m.mu.Unlock()
+	if !ok {
+		return nil, ErrWithdrawProposalNotFound
+	}
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		history, err := m.c.NewListWithdrawsService().Coin(p.Request.Coin).WithdrawOrderId(id).Do(ctx, opts...)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range history {
+			if w.WithdrawOrderID != id {
+				continue
+			}
+			switch w.Status {
+			case WithdrawStatusCompleted:
+				return w, nil
+			case WithdrawStatusCancelled, WithdrawStatusRejected, WithdrawStatusFailure:
+				return w, fmt.Errorf("%w: status %d %s", ErrWithdrawFailed, w.Status, w.Info)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *WithdrawManager) restore(p *WithdrawProposal) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.approving, p.ID)
+	m.proposals[p.ID] = p
+}
+
+func (m *WithdrawManager) markSubmitted(p *WithdrawProposal, withdrawID string) *WithdrawProposal {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.approving, p.ID)
+	delete(m.tokens, p.ID)
+	p.Approved = true
+	p.WithdrawID = withdrawID
+	m.submitted[p.ID] = p
+	res := *p
+	return &res
+}
+
+// check validate req against the whitelist and the network config, and fill its default network.
+// The daily limit is checked by reserve
+func (m *WithdrawManager) check(ctx context.Context, req *WithdrawRequest, opts ...RequestOption) (*Network, error) {
+	amount := parseFloat(req.Amount)
+	if amount <= 0 {
+		return nil, fmt.Errorf("%w: %s", ErrWithdrawAmount, req.Amount)
+	}
+	m.mu.Lock()
+	_, hasLimit := m.limits[req.Coin]
+	m.mu.Unlock()
+	if !hasLimit {
+		return nil, fmt.Errorf("%w: no daily limit for %s", ErrWithdrawDailyLimit, req.Coin)
+	}
+
+	coins, err := m.c.NewGetAllCoinsInfoService().Do(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var network *Network
+	for _, coin := range coins {
+		if coin.Coin != req.Coin {
+			continue
+		}
+		if !coin.WithdrawAllEnable {
+			return nil, fmt.Errorf("%w: %s withdraw disabled", ErrWithdrawNetwork, req.Coin)
+		}
+		for i := range coin.NetworkList {
+			n := &coin.NetworkList[i]
+			if n.Network == req.Network || (req.Network == "" && n.IsDefault) {
+				network = n
+				break
+			}
+		}
+	}
+	if network == nil {
+		return nil, fmt.Errorf("%w: %s %s", ErrWithdrawNetwork, req.Coin, req.Network)
+	}
+	req.Network = network.Network
+	if !network.WithdrawEnable {
+		return nil, fmt.Errorf("%w: %s %s withdraw disabled %s", ErrWithdrawNetwork, req.Coin, req.Network, network.WithdrawDesc)
+	}
+
+	m.mu.Lock()
+	_, whitelisted := m.whitelist[withdrawAddressKey(req.Coin, req.Network, req.Address, req.AddressTag)]
+	m.mu.Unlock()
+	if !whitelisted {
+		return nil, fmt.Errorf("%w: %s %s %s", ErrWithdrawNotWhitelisted, req.Coin, req.Network, req.Address)
+	}
+
+	if minimum := parseFloat(network.WithdrawMin); amount < minimum {
+		return nil, fmt.Errorf("%w: %s below minimum %s", ErrWithdrawAmount, req.Amount, network.WithdrawMin)
+	}
+	if maximum := parseFloat(network.WithdrawMax); maximum > 0 && amount > maximum {
+		return nil, fmt.Errorf("%w: %s above maximum %s", ErrWithdrawAmount, req.Amount, network.WithdrawMax)
+	}
+	if multiple := parseFloat(network.WithdrawIntegerMultiple); multiple > 0 {
+		n := amount / multiple
+		if math.Abs(n-math.Round(n)) > 1e-9 {
+			return nil, fmt.Errorf("%w: %s not a multiple of %s", ErrWithdrawAmount, req.Amount, network.WithdrawIntegerMultiple)
+		}
+	}
+	if network.AddressRegex != "" {
+		re, err := regexp.Compile(network.AddressRegex)
+		if err != nil {
+			return nil, err
+		}
+		if !re.MatchString(req.Address) {
+			return nil, fmt.Errorf("%w: %s doesn't match %s", ErrWithdrawAddress, req.Address, network.AddressRegex)
+		}
+	}
+	if network.SameAddress && req.AddressTag == "" {
+		return nil, fmt.Errorf("%w: %s %s requires a memo", ErrWithdrawMemo, req.Coin, req.Network)
+	}
+	if req.AddressTag != "" && network.MemoRegex != "" {
+		re, err := regexp.Compile(network.MemoRegex)
+		if err != nil {
+			return nil, err
+		}
+		if !re.MatchString(req.AddressTag) {
+			return nil, fmt.Errorf("%w: %s doesn't match %s", ErrWithdrawMemo, req.AddressTag, network.MemoRegex)
+		}
+	}
+	return network, nil
+}
+
+// reserve check that req fits in the daily limit of its coin with used, the amount withdrawn today from the
+// history, and the proposals, approvals and submissions of m not seen in the history, excluding the withdraw
+// of the given withdrawOrderId. The caller must hold m.mu and record the reservation before releasing it
+func (m *WithdrawManager) reserve(req *WithdrawRequest, exclude string, used float64, seen map[string]bool) error {
+	limit, hasLimit := m.limits[req.Coin]
+	if !hasLimit {
+		return fmt.Errorf("%w: no daily limit for %s", ErrWithdrawDailyLimit, req.Coin)
+	}
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	count := func(ps map[string]*WithdrawProposal, pending bool) {
+		for id, p := range ps {
+			if id == exclude || seen[id] || p.Request.Coin != req.Coin {
+				continue
+			}
+			if pending && p.ExpireTime <= now.UnixMilli() {
+				continue
+			}
+			if !pending && time.UnixMilli(p.CreateTime).Before(start) {
+				continue
+			}
+			used += parseFloat(p.Request.Amount)
+		}
+	}
+	count(m.proposals, true)
+	count(m.approving, false)
+	count(m.submitted, false)
+	if amount := parseFloat(req.Amount); used+amount > limit {
+		return fmt.Errorf("%w: %s %s withdrawn or reserved today, limit %s", ErrWithdrawDailyLimit,
+			formatFloat(used), req.Coin, formatFloat(limit))
+	}
+	return nil
+}
+
+// withdrawnToday amount of coin withdrawn since the start of the UTC day, not cancelled, rejected or failed,
+// excluding the withdraw of the given withdrawOrderId, and the withdrawOrderIds seen in the history
+func (m *WithdrawManager) withdrawnToday(ctx context.Context, coin, exclude string, opts ...RequestOption) (float64, map[string]bool, error) {
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	history, err := m.c.NewListWithdrawsService().
+		Coin(coin).
+		StartTime(start.UnixMilli()).
+		EndTime(now.UnixMilli()).
+		Do(ctx, opts...)
+	if err != nil {
+		return 0, nil, err
+	}
+	seen := make(map[string]bool, len(history))
+	var used float64
+	for _, w := range history {
+		seen[w.WithdrawOrderID] = true
+		if w.WithdrawOrderID == exclude {
+			continue
+		}
+		switch w.Status {
+		case WithdrawStatusCancelled, WithdrawStatusRejected, WithdrawStatusFailure:
+			continue
+		}
+		used += parseFloat(w.Amount)
+	}
+	return used, seen, nil
+}
+
+func withdrawAddressKey(coin, network, address, tag string) string {
+	return strings.Join([]string{coin, network, address, tag}, "|")
+}
+
+// withdrawOrderID deterministic withdrawOrderId of req
+func withdrawOrderID(req WithdrawRequest) string {
+	h := sha256.Sum256([]byte(strings.Join([]string{
+		req.Reference, req.Coin, req.Network, req.Address, req.AddressTag, formatFloat(parseFloat(req.Amount)),
+	}, "|")))
+	return "wd" + hex.EncodeToString(h[:15])
+}
+
+func withdrawToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
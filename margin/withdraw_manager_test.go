@@ -0,0 +1,267 @@
+package margin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var withdrawTestAddress = "T" + strings.Repeat("a", 33)
+
+// withdrawExchange 模拟币种配置、提现记录和提现接口
+type withdrawExchange struct {
+	mu        sync.Mutex
+	withdraws []*Withdraw
+	applied   int
+}
+
+func (e *withdrawExchange) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	switch r.URL.Path {
+	case "/sapi/v1/capital/config/getall":
+		fmt.Fprintf(w, `[{"coin":"USDT","withdrawAllEnable":true,"networkList":[{"coin":"USDT","network":"TRX","isDefault":true,`+
+			`"withdrawEnable":true,"withdrawFee":"1","withdrawMin":"10","withdrawMax":"10000","withdrawIntegerMultiple":"0.000001",`+
+			`"addressRegex":"^T[0-9A-Za-z]{33}$"}]}]`)
+	case "/sapi/v1/capital/withdraw/history":
+		res := make([]*Withdraw, 0, len(e.withdraws))
+		for _, wd := range e.withdraws {
+			if id := r.URL.Query().Get("withdrawOrderId"); id == "" || id == wd.WithdrawOrderID {
+				res = append(res, wd)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(res)
+	case "/sapi/v1/capital/withdraw/apply":
+		_ = r.ParseForm()
+		e.applied++
+		wd := &Withdraw{
+			ID:              fmt.Sprintf("w%d", e.applied),
+			Coin:            r.Form.Get("coin"),
+			Amount:          r.Form.Get("amount"),
+			Address:         r.Form.Get("address"),
+			WithdrawOrderID: r.Form.Get("withdrawOrderId"),
+			Status:          WithdrawStatusProcessing,
+		}
+		e.withdraws = append(e.withdraws, wd)
+		fmt.Fprintf(w, `{"id":%q}`, wd.ID)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (e *withdrawExchange) add(w *Withdraw) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.withdraws = append(e.withdraws, w)
+}
+
+func (e *withdrawExchange) appliedCount() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.applied
+}
+
+// newTestWithdrawManager 返回白名单内 USDT 日限额为 limit 的管理器, tokens 记录通知的审批令牌
+func newTestWithdrawManager(t *testing.T, limit float64) (*WithdrawManager, *withdrawExchange, *sync.Map) {
+	t.Helper()
+	exchange := new(withdrawExchange)
+	srv := httptest.NewServer(exchange)
+	t.Cleanup(srv.Close)
+	c := NewClient("key", "secret")
+	c.BaseURL = srv.URL
+	tokens := new(sync.Map)
+	m := c.NewWithdrawManager().
+		Whitelist(WithdrawAddress{Coin: "USDT", Network: "TRX", Address: withdrawTestAddress}).
+		DailyLimit("USDT", limit).
+		Notifier(func(p WithdrawProposal, token string) error {
+			tokens.Store(p.ID, token)
+			return nil
+		})
+	return m, exchange, tokens
+}
+
+func withdrawTestRequest(amount, reference string) WithdrawRequest {
+	return WithdrawRequest{Coin: "USDT", Address: withdrawTestAddress, Amount: amount, Reference: reference}
+}
+
+func approvalToken(tokens *sync.Map, id string) string {
+	token, _ := tokens.Load(id)
+	s, _ := token.(string)
+	return s
+}
+
+func TestWithdrawManagerDailyLimitConcurrentApprovals(t *testing.T) {
+	m, exchange, tokens := newTestWithdrawManager(t, 100)
+	ctx := context.Background()
+	a, err := m.Propose(ctx, withdrawTestRequest("60", "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := m.Propose(ctx, withdrawTestRequest("40", "b"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 待审批的提案占用额度
+	if _, err = m.Propose(ctx, withdrawTestRequest("10", "c")); !errors.Is(err, ErrWithdrawDailyLimit) {
+		t.Fatalf("propose over the limit: got %v", err)
+	}
+
+	// 提案之后在管理器之外提现了 30, 两个提案都不能再提交
+	exchange.add(&Withdraw{ID: "external", Coin: "USDT", Amount: "30", Status: WithdrawStatusCompleted})
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, p := range []*WithdrawProposal{a, b} {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			_, errs[i] = m.Approve(ctx, id, approvalToken(tokens, id))
+		}(i, p.ID)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if !errors.Is(err, ErrWithdrawDailyLimit) {
+			t.Errorf("approve over the limit: got %v", err)
+		}
+	}
+	if n := exchange.appliedCount(); n != 0 {
+		t.Fatalf("%d withdraws submitted over the limit", n)
+	}
+
+	// 拒绝 a 释放额度后 b 可以提交, 并发审批只提交一次
+	m.Reject(a.ID)
+	results := make(chan error, 8)
+	for i := 0; i < cap(results); i++ {
+		go func() {
+			_, err := m.Approve(ctx, b.ID, approvalToken(tokens, b.ID))
+			results <- err
+		}()
+	}
+	var approved int
+	for i := 0; i < cap(results); i++ {
+		if err := <-results; err == nil {
+			approved++
+		} else if !errors.Is(err, ErrWithdrawProposalNotFound) {
+			t.Errorf("concurrent approve: got %v", err)
+		}
+	}
+	if approved != 1 || exchange.appliedCount() != 1 {
+		t.Fatalf("approved %d times, submitted %d withdraws", approved, exchange.appliedCount())
+	}
+	if _, err = m.Propose(ctx, withdrawTestRequest("40", "d")); !errors.Is(err, ErrWithdrawDailyLimit) {
+		t.Fatalf("propose after the limit is used: got %v", err)
+	}
+}
+
+func TestWithdrawManagerDuplicateWithdrawOrderID(t *testing.T) {
+	m, exchange, tokens := newTestWithdrawManager(t, 1000)
+	ctx := context.Background()
+	notified := 0
+	m.Notifier(func(p WithdrawProposal, token string) error {
+		notified++
+		tokens.Store(p.ID, token)
+		return nil
+	})
+	p, err := m.Propose(ctx, withdrawTestRequest("50", "invoice-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := m.Propose(ctx, withdrawTestRequest("50.0", "invoice-1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.ID != p.ID || notified != 1 {
+		t.Fatalf("same request proposed twice: ids %s %s, %d notifications", p.ID, again.ID, notified)
+	}
+	if other, _ := m.Propose(ctx, withdrawTestRequest("50", "invoice-2")); other == nil || other.ID == p.ID {
+		t.Fatalf("another reference must make another withdrawOrderId")
+	}
+
+	// 交易所已有该 withdrawOrderId 的提现(如上次提交后进程退出), 不再重复提交
+	exchange.add(&Withdraw{ID: "earlier", Coin: "USDT", Amount: "50", WithdrawOrderID: p.ID, Status: WithdrawStatusProcessing})
+	res, err := m.Approve(ctx, p.ID, approvalToken(tokens, p.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.WithdrawID != "earlier" || exchange.appliedCount() != 0 {
+		t.Fatalf("withdraw submitted again: %+v, %d submitted", res, exchange.appliedCount())
+	}
+	if _, err = m.Propose(ctx, withdrawTestRequest("50", "invoice-1")); !errors.Is(err, ErrWithdrawDuplicate) {
+		t.Fatalf("propose a submitted withdraw: got %v", err)
+	}
+}
+
+func TestWithdrawManagerApprovalToken(t *testing.T) {
+	m, exchange, tokens := newTestWithdrawManager(t, 100)
+	ctx := context.Background()
+	p, err := m.Propose(ctx, withdrawTestRequest("50", "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = m.Approve(ctx, p.ID, "wrong"); !errors.Is(err, ErrWithdrawApprovalToken) {
+		t.Fatalf("approve with a wrong token: got %v", err)
+	}
+	if len(m.Proposals()) != 1 || exchange.appliedCount() != 0 {
+		t.Fatal("a wrong token must keep the proposal without submitting it")
+	}
+	if _, err = m.Approve(ctx, p.ID, approvalToken(tokens, p.ID)); err != nil {
+		t.Fatal(err)
+	}
+	if exchange.appliedCount() != 1 {
+		t.Fatalf("%d withdraws submitted", exchange.appliedCount())
+	}
+}
+
+func TestWithdrawManagerProposalExpired(t *testing.T) {
+	m, exchange, tokens := newTestWithdrawManager(t, 100)
+	ctx := context.Background()
+	m.ApprovalTTL(time.Millisecond)
+	p, err := m.Propose(ctx, withdrawTestRequest("100", "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if _, err = m.Approve(ctx, p.ID, approvalToken(tokens, p.ID)); !errors.Is(err, ErrWithdrawProposalExpired) {
+		t.Fatalf("approve an expired proposal: got %v", err)
+	}
+	if exchange.appliedCount() != 0 {
+		t.Fatal("expired proposal submitted")
+	}
+	// 过期的提案不再占用额度
+	m.ApprovalTTL(time.Minute)
+	if _, err = m.Propose(ctx, withdrawTestRequest("100", "b")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWithdrawManagerNotifier(t *testing.T) {
+	m, _, _ := newTestWithdrawManager(t, 100)
+	ctx := context.Background()
+	failure := errors.New("notify failed")
+	// 通知时不持有锁, 通知器可以调用管理器
+	m.Notifier(func(p WithdrawProposal, token string) error {
+		if len(m.Proposals()) != 1 {
+			t.Errorf("proposal not reserved while notifying")
+		}
+		return failure
+	})
+	if _, err := m.Propose(ctx, withdrawTestRequest("100", "a")); !errors.Is(err, failure) {
+		t.Fatalf("propose with a failing notifier: got %v", err)
+	}
+	if len(m.Proposals()) != 0 {
+		t.Fatal("failed notification must drop the proposal")
+	}
+	m.Notifier(nil)
+	if _, err := m.Propose(ctx, withdrawTestRequest("100", "a")); !errors.Is(err, ErrWithdrawNotifier) {
+		t.Fatalf("propose without a notifier: got %v", err)
+	}
+	m.Notifier(func(p WithdrawProposal, token string) error { return nil })
+	if _, err := m.Propose(ctx, withdrawTestRequest("100", "a")); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,141 @@
+package portfolio
+
+import (
+	"context"
+	"github.com/BobHye/binance-go/common"
+	"net/http"
+)
+
+// GetBalanceService get account balance | 查询账户余额
+type GetBalanceService struct {
+	c     *Client
+	asset *string
+}
+
+// SetAsset set asset, all assets are returned when not set
+func (s *GetBalanceService) SetAsset(asset string) *GetBalanceService {
+	s.asset = &asset
+	return s
+}
+
+// Do send request
+func (s *GetBalanceService) Do(ctx context.Context, opts ...RequestOption) (res []*Balance, err error) {
+	// GET /papi/v1/balance | 查询账户余额
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/balance",
+		secType:  secTypeSigned,
+	}
+	if s.asset != nil {
+		r.setParam("asset", *s.asset)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*Balance{}, err
+	}
+	data = common.ToJSONList(data)
+	res = make([]*Balance, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*Balance{}, err
+	}
+	return res, nil
+}
+
+// Balance define user balance of one asset across the portfolio margin account | 统一账户资产余额
+type Balance struct {
+	Asset               string  `json:"asset"`                      // 资产
+	TotalWalletBalance  float64 `json:"totalWalletBalance,string"`  // 钱包余额 = 全仓杠杆未锁定 + 全仓杠杆锁定 + U本位合约钱包余额 + 币本位合约钱包余额
+	CrossMarginAsset    float64 `json:"crossMarginAsset,string"`    // 全仓资产 = 全仓杠杆未锁定 + 全仓杠杆锁定
+	CrossMarginBorrowed float64 `json:"crossMarginBorrowed,string"` // 全仓杠杆借贷
+	CrossMarginFree     float64 `json:"crossMarginFree,string"`     // 全仓杠杆未锁定
+	CrossMarginInterest float64 `json:"crossMarginInterest,string"` // 全仓杠杆利息
+	CrossMarginLocked   float64 `json:"crossMarginLocked,string"`   // 全仓杠杆锁定
+	UMWalletBalance     float64 `json:"umWalletBalance,string"`     // U本位合约钱包余额
+	UMUnrealizedPNL     float64 `json:"umUnrealizedPNL,string"`     // U本位未实现盈亏
+	CMWalletBalance     float64 `json:"cmWalletBalance,string"`     // 币本位合约钱包余额
+	CMUnrealizedPNL     float64 `json:"cmUnrealizedPNL,string"`     // 币本位未实现盈亏
+	NegativeBalance     float64 `json:"negativeBalance,string"`     // 负余额
+	UpdateTime          int64   `json:"updateTime"`                 // 更新时间
+}
+
+// GetAccountService get account info | 查询账户信息
+type GetAccountService struct {
+	c *Client
+}
+
+// Do send request
+func (s *GetAccountService) Do(ctx context.Context, opts ...RequestOption) (res *Account, err error) {
+	// GET /papi/v1/account | 查询账户信息
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/account",
+		secType:  secTypeSigned,
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(Account)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Account define portfolio margin account info | 统一账户信息
+type Account struct {
+	UniMMR                   float64 `json:"uniMMR,string"`                   // 统一账户维持保证金率
+	AccountEquity            float64 `json:"accountEquity,string"`            // 以USD计价的账户权益
+	ActualEquity             float64 `json:"actualEquity,string"`             // 不考虑质押率的以USD计价账户权益
+	AccountInitialMargin     float64 `json:"accountInitialMargin,string"`     // 初始保证金
+	AccountMaintMargin       float64 `json:"accountMaintMargin,string"`       // 以USD计价统一账户维持保证金
+	AccountStatus            string  `json:"accountStatus"`                   // 统一账户状态 [NORMAL/MARGIN_CALL/SUPPLY_MARGIN/REDUCE_ONLY/ACTIVE_LIQUIDATION/FORCE_LIQUIDATION/BANKRUPTED]
+	VirtualMaxWithdrawAmount float64 `json:"virtualMaxWithdrawAmount,string"` // 以USD计价的最大可转出
+	TotalAvailableBalance    float64 `json:"totalAvailableBalance,string"`    // 以USD计价的可用余额
+	TotalMarginOpenLoss      float64 `json:"totalMarginOpenLoss,string"`      // 以USD计价的杠杆挂单预扣损失
+	UpdateTime               int64   `json:"updateTime"`                      // 更新时间
+}
+
+// AutoCollectionService collect all assets from the futures accounts to the margin account | 资金归集
+type AutoCollectionService struct {
+	c *Client
+}
+
+// Do send request
+func (s *AutoCollectionService) Do(ctx context.Context, opts ...RequestOption) (err error) {
+	// POST /papi/v1/auto-collection | 资金归集, 将U本位及币本位合约钱包中的资产归集到杠杆钱包
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/papi/v1/auto-collection",
+		secType:  secTypeSigned,
+	}
+	_, err = s.c.callAPI(ctx, r, opts...)
+	return err
+}
+
+// AssetCollectionService collect one asset from the futures accounts to the margin account | 特定资产资金归集
+type AssetCollectionService struct {
+	c     *Client
+	asset string
+}
+
+// SetAsset set asset
+func (s *AssetCollectionService) SetAsset(asset string) *AssetCollectionService {
+	s.asset = asset
+	return s
+}
+
+// Do send request
+func (s *AssetCollectionService) Do(ctx context.Context, opts ...RequestOption) (err error) {
+	// POST /papi/v1/asset-collection | 特定资产资金归集
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/papi/v1/asset-collection",
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("asset", s.asset)
+	_, err = s.c.callAPI(ctx, r, opts...)
+	return err
+}
@@ -0,0 +1,546 @@
+package portfolio
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"fmt"
+	"github.com/BobHye/binance-go/common"
+	jsoniter "github.com/json-iterator/go"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+// SideType define side type of order | 订单方向类型
+type SideType string
+
+// PositionSideType define position side type of order | 持仓方向类型
+type PositionSideType string
+
+// OrderType define order type | 订单类型
+type OrderType string
+
+// StrategyType define conditional order type | 条件单类型
+type StrategyType string
+
+// StrategyStatusType define conditional order status | 条件单状态
+type StrategyStatusType string
+
+// TimeInForceType define time in force type of order | 有效方式类型
+type TimeInForceType string
+
+// NewOrderRespType define response JSON verbosity | 响应类型 [ACK/RESULT/FULL]
+type NewOrderRespType string
+
+// OrderExecutionType define order execution type | 订单执行类型
+type OrderExecutionType string
+
+// OrderStatusType define order status type | 订单状态类型
+type OrderStatusType string
+
+// WorkingType define working type | 条件价格触发类型 [MARK_PRICE/CONTRACT_PRICE]
+type WorkingType string
+
+// SideEffectType define side effect type for margin orders | 杠杆下单的借还款类型
+type SideEffectType string
+
+// UserDataEventType define user data event type | 账户信息事件类型
+type UserDataEventType string
+
+// UserDataEventReasonType define reason type for user data event | 账户信息事件推出的原因类型
+type UserDataEventReasonType string
+
+// FuturesProductType define which futures product a user data event belongs to | 合约推送的业务类型 [UM/CM]
+type FuturesProductType string
+
+// Redefining the standard package
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// Endpoints
+const (
+	baseApiMainUrl = "https://papi.binance.com"
+)
+
+// Global enums
+const (
+	SideTypeBuy  SideType = "BUY"  // 买入
+	SideTypeSell SideType = "SELL" // 卖出
+
+	PositionSideTypeBoth  PositionSideType = "BOTH"  // 单一持仓方向
+	PositionSideTypeLong  PositionSideType = "LONG"  // 多头(双向持仓下)
+	PositionSideTypeShort PositionSideType = "SHORT" // 空头(双向持仓下)
+
+	OrderTypeLimit           OrderType = "LIMIT"             // 限价单
+	OrderTypeMarket          OrderType = "MARKET"            // 市价单
+	OrderTypeLimitMaker      OrderType = "LIMIT_MAKER"       // 限价只挂单, 仅杠杆
+	OrderTypeStopLoss        OrderType = "STOP_LOSS"         // 止损市价单, 仅杠杆
+	OrderTypeStopLossLimit   OrderType = "STOP_LOSS_LIMIT"   // 止损限价单, 仅杠杆
+	OrderTypeTakeProfit      OrderType = "TAKE_PROFIT"       // 止盈市价单, 仅杠杆
+	OrderTypeTakeProfitLimit OrderType = "TAKE_PROFIT_LIMIT" // 止盈限价单, 仅杠杆
+
+	StrategyTypeStop               StrategyType = "STOP"                 // 止损限价单
+	StrategyTypeStopMarket         StrategyType = "STOP_MARKET"          // 止损市价单
+	StrategyTypeTakeProfit         StrategyType = "TAKE_PROFIT"          // 止盈限价单
+	StrategyTypeTakeProfitMarket   StrategyType = "TAKE_PROFIT_MARKET"   // 止盈市价单
+	StrategyTypeTrailingStopMarket StrategyType = "TRAILING_STOP_MARKET" // 跟踪止损单
+
+	StrategyStatusTypeNew       StrategyStatusType = "NEW"       // 未触发
+	StrategyStatusTypeCanceled  StrategyStatusType = "CANCELED"  // 已撤销
+	StrategyStatusTypeTriggered StrategyStatusType = "TRIGGERED" // 已触发, 下单成功
+	StrategyStatusTypeFinished  StrategyStatusType = "FINISHED"  // 触发后下单的订单已结束
+	StrategyStatusTypeExpired   StrategyStatusType = "EXPIRED"   // 已过期
+
+	TimeInForceTypeGTC TimeInForceType = "GTC" // Good Till Cancel 成交为止
+	TimeInForceTypeIOC TimeInForceType = "IOC" // Immediate or Cancel 无法立即成交(吃单)的部分就撤销
+	TimeInForceTypeFOK TimeInForceType = "FOK" // Fill or Kill 无法全部立即成交就撤销
+	TimeInForceTypeGTX TimeInForceType = "GTX" // Good Till Crossing 无法成为挂单方就撤销
+	TimeInForceTypeGTD TimeInForceType = "GTD" // Good Till Date 到达 goodTillDate 时撤销, 仅U本位
+
+	NewOrderRespTypeACK    NewOrderRespType = "ACK"
+	NewOrderRespTypeRESULT NewOrderRespType = "RESULT"
+	NewOrderRespTypeFULL   NewOrderRespType = "FULL" // 仅杠杆
+
+	OrderExecutionTypeNew        OrderExecutionType = "NEW"
+	OrderExecutionTypeTrade      OrderExecutionType = "TRADE"
+	OrderExecutionTypeCanceled   OrderExecutionType = "CANCELED"
+	OrderExecutionTypeCalculated OrderExecutionType = "CALCULATED"
+	OrderExecutionTypeExpired    OrderExecutionType = "EXPIRED"
+	OrderExecutionTypeAmendment  OrderExecutionType = "AMENDMENT"
+	OrderExecutionTypeRejected   OrderExecutionType = "REJECTED"
+
+	OrderStatusTypeNew             OrderStatusType = "NEW"              // 新建订单
+	OrderStatusTypePartiallyFilled OrderStatusType = "PARTIALLY_FILLED" // 部分成交
+	OrderStatusTypeFilled          OrderStatusType = "FILLED"           // 全部成交
+	OrderStatusTypeCanceled        OrderStatusType = "CANCELED"         // 已撤销
+	OrderStatusTypeRejected        OrderStatusType = "REJECTED"         // 订单被拒绝
+	OrderStatusTypeExpired         OrderStatusType = "EXPIRED"          // 订单过期(根据timeInForce参数规则)
+
+	WorkingTypeMarkPrice     WorkingType = "MARK_PRICE"     // 标记价格
+	WorkingTypeContractPrice WorkingType = "CONTRACT_PRICE" // 合约价格
+
+	SideEffectTypeNoSideEffect SideEffectType = "NO_SIDE_EFFECT" // 普通交易
+	SideEffectTypeMarginBuy    SideEffectType = "MARGIN_BUY"     // 自动借款交易
+	SideEffectTypeAutoRepay    SideEffectType = "AUTO_REPAY"     // 自动还款交易
+
+	FuturesProductTypeUM FuturesProductType = "UM" // U本位合约
+	FuturesProductTypeCM FuturesProductType = "CM" // 币本位合约
+
+	UserDataEventTypeListenKeyExpired            UserDataEventType = "listenKeyExpired"               // listenKey 过期推送
+	UserDataEventTypeAccountUpdate               UserDataEventType = "ACCOUNT_UPDATE"                 // 合约 Balance 和 Position 更新推送
+	UserDataEventTypeOrderTradeUpdate            UserDataEventType = "ORDER_TRADE_UPDATE"             // 合约订单/交易 更新推送
+	UserDataEventTypeConditionalOrderTradeUpdate UserDataEventType = "CONDITIONAL_ORDER_TRADE_UPDATE" // 合约条件单 更新推送
+	UserDataEventTypeAccountConfigUpdate         UserDataEventType = "ACCOUNT_CONFIG_UPDATE"          // 合约杠杆倍数 更新推送
+	UserDataEventTypeExecutionReport             UserDataEventType = "executionReport"                // 杠杆订单更新推送
+	UserDataEventTypeOutboundAccountPosition     UserDataEventType = "outboundAccountPosition"        // 杠杆账户余额更新推送
+	UserDataEventTypeBalanceUpdate               UserDataEventType = "balanceUpdate"                  // 杠杆账户划转推送
+	UserDataEventTypeLiabilityChange             UserDataEventType = "liabilityChange"                // 杠杆借贷变化推送
+	UserDataEventTypeOpenOrderLoss               UserDataEventType = "openOrderLoss"                  // 杠杆挂单预扣损失推送
+	UserDataEventTypeRiskLevelChange             UserDataEventType = "riskLevelChange"                // 账户风险等级变化推送
+
+	UserDataEventReasonTypeDeposit        UserDataEventReasonType = "DEPOSIT"
+	UserDataEventReasonTypeWithdraw       UserDataEventReasonType = "WITHDRAW"
+	UserDataEventReasonTypeOrder          UserDataEventReasonType = "ORDER"
+	UserDataEventReasonTypeFundingFee     UserDataEventReasonType = "FUNDING_FEE"
+	UserDataEventReasonTypeAdjustment     UserDataEventReasonType = "ADJUSTMENT"
+	UserDataEventReasonTypeInsuranceClear UserDataEventReasonType = "INSURANCE_CLEAR"
+	UserDataEventReasonTypeAssetTransfer  UserDataEventReasonType = "ASSET_TRANSFER"
+	UserDataEventReasonTypeAutoExchange   UserDataEventReasonType = "AUTO_EXCHANGE"
+
+	timestampKey  = "timestamp"
+	signatureKey  = "signature"
+	recvWindowKey = "recvWindow"
+
+	productUM = "um"
+	productCM = "cm"
+)
+
+// currentTimestamp 当前时间戳
+func currentTimestamp() int64 {
+	return time.Now().UnixMilli()
+}
+
+// Client 定义统一账户API客户端
+type Client struct {
+	APIKey     string
+	SecretKey  string
+	BaseURL    string
+	UserAgent  string
+	HTTPClient *http.Client
+	Debug      bool
+	Logger     *log.Logger
+	TimeOffset int64
+	do         doFunc
+}
+
+// NewClient initialize an API client instance with API key and secret key of a Portfolio Margin account.
+// Services will be created by the form client.NewXXXService().
+// 创建新的统一账户API Client
+func NewClient(apiKey, secretKey string) *Client {
+	return &Client{
+		APIKey:     apiKey,
+		SecretKey:  secretKey,
+		BaseURL:    baseApiMainUrl,
+		UserAgent:  "Binance/golang",
+		HTTPClient: http.DefaultClient,
+		Logger:     log.New(os.Stderr, "Binance-golang ", log.LstdFlags),
+	}
+}
+
+// NewProxiedClient passing a proxy url
+func NewProxiedClient(apiKey, secretKey, proxyUrl string) *Client {
+	proxy, err := url.Parse(proxyUrl)
+	if err != nil {
+		log.Fatal(err)
+	}
+	tr := &http.Transport{
+		Proxy:           http.ProxyURL(proxy),
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	}
+	return &Client{
+		APIKey:    apiKey,
+		SecretKey: secretKey,
+		BaseURL:   baseApiMainUrl,
+		UserAgent: "Binance/golang",
+		HTTPClient: &http.Client{
+			Transport: tr,
+		},
+		Logger: log.New(os.Stderr, "Binance-golang ", log.LstdFlags),
+	}
+}
+
+type doFunc func(req *http.Request) (*http.Response, error)
+
+// debug 输出调试信息
+func (c *Client) debug(format string, v ...interface{}) {
+	if c.Debug {
+		c.Logger.Printf(format, v...)
+	}
+}
+
+// parseRequest 解释请求
+func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
+	// set request options from user
+	for _, opt := range opts {
+		opt(r)
+	}
+	err = r.validate()
+	if err != nil {
+		return err
+	}
+
+	fullURL := fmt.Sprintf("%s%s", c.BaseURL, r.endpoint)
+	if r.recvWindow > 0 {
+		r.setParam(recvWindowKey, r.recvWindow)
+	}
+	if r.secType == secTypeSigned {
+		r.setParam(timestampKey, currentTimestamp()-c.TimeOffset)
+	}
+	queryString := r.query.Encode()
+	body := &bytes.Buffer{}
+	bodyString := r.form.Encode()
+	header := http.Header{}
+	if r.header != nil {
+		header = r.header.Clone()
+	}
+	if bodyString != "" {
+		header.Set("Content-Type", "application/x-www-form-urlencoded")
+		body = bytes.NewBufferString(bodyString)
+	}
+	if r.secType == secTypeAPIKey || r.secType == secTypeSigned {
+		header.Set("X-MBX-APIKEY", c.APIKey)
+	}
+
+	if r.secType == secTypeSigned {
+		raw := fmt.Sprintf("%s%s", queryString, bodyString)
+		mac := hmac.New(sha256.New, []byte(c.SecretKey))
+		_, err = mac.Write([]byte(raw))
+		if err != nil {
+			return err
+		}
+		v := url.Values{}
+		v.Set(signatureKey, fmt.Sprintf("%x", mac.Sum(nil)))
+		if queryString == "" {
+			queryString = v.Encode()
+		} else {
+			queryString = fmt.Sprintf("%s&%s", queryString, v.Encode())
+		}
+	}
+	if queryString != "" {
+		fullURL = fmt.Sprintf("%s?%s", fullURL, queryString)
+	}
+	c.debug("full url: %s, body: %s", fullURL, bodyString)
+
+	r.fullURL = fullURL
+	r.header = header
+	r.body = body
+	return nil
+}
+
+// callAPI 调用API请求
+func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption) (data []byte, err error) {
+	err = c.parseRequest(r, opts...)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	req, err := http.NewRequest(r.method, r.fullURL, r.body)
+	if err != nil {
+		return []byte{}, err
+	}
+	req = req.WithContext(ctx)
+	req.Header = r.header
+	c.debug("request: %#v", req)
+	fun := c.do
+	if fun == nil {
+		fun = c.HTTPClient.Do
+	}
+	res, err := fun(req)
+	if err != nil {
+		return []byte{}, err
+	}
+	data, err = io.ReadAll(res.Body)
+	if err != nil {
+		return []byte{}, err
+	}
+	defer func() {
+		cerr := res.Body.Close()
+		// Only overwrite the retured error if the original error was nil and an
+		// error occurred while closing the body.
+		if err == nil && cerr != nil {
+			err = cerr
+		}
+	}()
+	c.debug("response: %#v", res)
+	c.debug("response body: %s", string(data))
+	c.debug("response status code: %d", res.StatusCode)
+	if res.StatusCode >= http.StatusBadRequest {
+		apiErr := new(common.APIError)
+		e := json.Unmarshal(data, apiErr)
+		if e != nil {
+			c.debug("failed to unmarshal json: %s", e)
+		}
+		return nil, apiErr
+	}
+	return data, nil
+}
+
+// NewPingService init ping service
+func (c *Client) NewPingService() *PingService {
+	return &PingService{c: c}
+}
+
+// NewCreateUMOrderService init creating UM order service
+func (c *Client) NewCreateUMOrderService() *CreateOrderService {
+	return &CreateOrderService{c: c, product: productUM}
+}
+
+// NewCreateCMOrderService init creating CM order service
+func (c *Client) NewCreateCMOrderService() *CreateOrderService {
+	return &CreateOrderService{c: c, product: productCM}
+}
+
+// NewCancelUMOrderService init cancel UM order service
+func (c *Client) NewCancelUMOrderService() *CancelOrderService {
+	return &CancelOrderService{c: c, product: productUM}
+}
+
+// NewCancelCMOrderService init cancel CM order service
+func (c *Client) NewCancelCMOrderService() *CancelOrderService {
+	return &CancelOrderService{c: c, product: productCM}
+}
+
+// NewCancelAllUMOpenOrdersService init cancel all UM open orders service
+func (c *Client) NewCancelAllUMOpenOrdersService() *CancelAllOpenOrdersService {
+	return &CancelAllOpenOrdersService{c: c, product: productUM}
+}
+
+// NewCancelAllCMOpenOrdersService init cancel all CM open orders service
+func (c *Client) NewCancelAllCMOpenOrdersService() *CancelAllOpenOrdersService {
+	return &CancelAllOpenOrdersService{c: c, product: productCM}
+}
+
+// NewGetUMOrderService init get UM order service
+func (c *Client) NewGetUMOrderService() *GetOrderService {
+	return &GetOrderService{c: c, product: productUM}
+}
+
+// NewGetCMOrderService init get CM order service
+func (c *Client) NewGetCMOrderService() *GetOrderService {
+	return &GetOrderService{c: c, product: productCM}
+}
+
+// NewListUMOpenOrdersService init list UM open orders service
+func (c *Client) NewListUMOpenOrdersService() *ListOpenOrdersService {
+	return &ListOpenOrdersService{c: c, product: productUM}
+}
+
+// NewListCMOpenOrdersService init list CM open orders service
+func (c *Client) NewListCMOpenOrdersService() *ListOpenOrdersService {
+	return &ListOpenOrdersService{c: c, product: productCM}
+}
+
+// NewListUMOrdersService init listing all UM orders service
+func (c *Client) NewListUMOrdersService() *ListOrdersService {
+	return &ListOrdersService{c: c, product: productUM}
+}
+
+// NewListCMOrdersService init listing all CM orders service
+func (c *Client) NewListCMOrdersService() *ListOrdersService {
+	return &ListOrdersService{c: c, product: productCM}
+}
+
+// NewCreateUMConditionalOrderService init creating UM conditional order service
+func (c *Client) NewCreateUMConditionalOrderService() *CreateConditionalOrderService {
+	return &CreateConditionalOrderService{c: c, product: productUM}
+}
+
+// NewCreateCMConditionalOrderService init creating CM conditional order service
+func (c *Client) NewCreateCMConditionalOrderService() *CreateConditionalOrderService {
+	return &CreateConditionalOrderService{c: c, product: productCM}
+}
+
+// NewCancelUMConditionalOrderService init cancel UM conditional order service
+func (c *Client) NewCancelUMConditionalOrderService() *CancelConditionalOrderService {
+	return &CancelConditionalOrderService{c: c, product: productUM}
+}
+
+// NewCancelCMConditionalOrderService init cancel CM conditional order service
+func (c *Client) NewCancelCMConditionalOrderService() *CancelConditionalOrderService {
+	return &CancelConditionalOrderService{c: c, product: productCM}
+}
+
+// NewCancelAllUMConditionalOrdersService init cancel all UM conditional orders service
+func (c *Client) NewCancelAllUMConditionalOrdersService() *CancelAllConditionalOrdersService {
+	return &CancelAllConditionalOrdersService{c: c, product: productUM}
+}
+
+// NewCancelAllCMConditionalOrdersService init cancel all CM conditional orders service
+func (c *Client) NewCancelAllCMConditionalOrdersService() *CancelAllConditionalOrdersService {
+	return &CancelAllConditionalOrdersService{c: c, product: productCM}
+}
+
+// NewListUMConditionalOpenOrdersService init list UM conditional open orders service
+func (c *Client) NewListUMConditionalOpenOrdersService() *ListConditionalOpenOrdersService {
+	return &ListConditionalOpenOrdersService{c: c, product: productUM}
+}
+
+// NewListCMConditionalOpenOrdersService init list CM conditional open orders service
+func (c *Client) NewListCMConditionalOpenOrdersService() *ListConditionalOpenOrdersService {
+	return &ListConditionalOpenOrdersService{c: c, product: productCM}
+}
+
+// NewCreateMarginOrderService init creating margin order service
+func (c *Client) NewCreateMarginOrderService() *CreateMarginOrderService {
+	return &CreateMarginOrderService{c: c}
+}
+
+// NewCancelMarginOrderService init cancel margin order service
+func (c *Client) NewCancelMarginOrderService() *CancelMarginOrderService {
+	return &CancelMarginOrderService{c: c}
+}
+
+// NewGetMarginOrderService init get margin order service
+func (c *Client) NewGetMarginOrderService() *GetMarginOrderService {
+	return &GetMarginOrderService{c: c}
+}
+
+// NewListMarginOpenOrdersService init list margin open orders service
+func (c *Client) NewListMarginOpenOrdersService() *ListMarginOpenOrdersService {
+	return &ListMarginOpenOrdersService{c: c}
+}
+
+// NewMarginLoanService init margin loan service
+func (c *Client) NewMarginLoanService() *MarginLoanService {
+	return &MarginLoanService{c: c}
+}
+
+// NewMarginRepayService init margin repay service
+func (c *Client) NewMarginRepayService() *MarginRepayService {
+	return &MarginRepayService{c: c}
+}
+
+// NewGetMaxBorrowableService init get max borrowable service
+func (c *Client) NewGetMaxBorrowableService() *GetMaxBorrowableService {
+	return &GetMaxBorrowableService{c: c}
+}
+
+// NewGetBalanceService init balance service
+func (c *Client) NewGetBalanceService() *GetBalanceService {
+	return &GetBalanceService{c: c}
+}
+
+// NewGetAccountService init account service
+func (c *Client) NewGetAccountService() *GetAccountService {
+	return &GetAccountService{c: c}
+}
+
+// NewGetUMPositionRiskService init UM position risk service
+func (c *Client) NewGetUMPositionRiskService() *GetPositionRiskService {
+	return &GetPositionRiskService{c: c, product: productUM}
+}
+
+// NewGetCMPositionRiskService init CM position risk service
+func (c *Client) NewGetCMPositionRiskService() *GetPositionRiskService {
+	return &GetPositionRiskService{c: c, product: productCM}
+}
+
+// NewChangeUMLeverageService init change UM leverage service
+func (c *Client) NewChangeUMLeverageService() *ChangeLeverageService {
+	return &ChangeLeverageService{c: c, product: productUM}
+}
+
+// NewChangeCMLeverageService init change CM leverage service
+func (c *Client) NewChangeCMLeverageService() *ChangeLeverageService {
+	return &ChangeLeverageService{c: c, product: productCM}
+}
+
+// NewChangeUMPositionModeService init change UM position mode service
+func (c *Client) NewChangeUMPositionModeService() *ChangePositionModeService {
+	return &ChangePositionModeService{c: c, product: productUM}
+}
+
+// NewChangeCMPositionModeService init change CM position mode service
+func (c *Client) NewChangeCMPositionModeService() *ChangePositionModeService {
+	return &ChangePositionModeService{c: c, product: productCM}
+}
+
+// NewGetUMPositionModeService init get UM position mode service
+func (c *Client) NewGetUMPositionModeService() *GetPositionModeService {
+	return &GetPositionModeService{c: c, product: productUM}
+}
+
+// NewGetCMPositionModeService init get CM position mode service
+func (c *Client) NewGetCMPositionModeService() *GetPositionModeService {
+	return &GetPositionModeService{c: c, product: productCM}
+}
+
+// NewAutoCollectionService init fund auto-collection service
+func (c *Client) NewAutoCollectionService() *AutoCollectionService {
+	return &AutoCollectionService{c: c}
+}
+
+// NewAssetCollectionService init fund collection by asset service
+func (c *Client) NewAssetCollectionService() *AssetCollectionService {
+	return &AssetCollectionService{c: c}
+}
+
+// NewStartUserStreamService init starting user stream service
+func (c *Client) NewStartUserStreamService() *StartUserStreamService {
+	return &StartUserStreamService{c: c}
+}
+
+// NewKeepaliveUserStreamService init keep alive user stream service
+func (c *Client) NewKeepaliveUserStreamService() *KeepaliveUserStreamService {
+	return &KeepaliveUserStreamService{c: c}
+}
+
+// NewCloseUserStreamService init closing user stream service
+func (c *Client) NewCloseUserStreamService() *CloseUserStreamService {
+	return &CloseUserStreamService{c: c}
+}
@@ -0,0 +1,312 @@
+package portfolio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// CreateConditionalOrderService create a UM or CM conditional order | 统一账户合约条件单下单
+type CreateConditionalOrderService struct {
+	c                   *Client
+	product             string
+	symbol              string
+	side                SideType
+	positionSide        *PositionSideType
+	strategyType        StrategyType
+	timeInForce         *TimeInForceType
+	quantity            *string
+	reduceOnly          *bool
+	price               *string
+	workingType         *WorkingType
+	priceProtect        *bool
+	newClientStrategyID *string
+	stopPrice           *string
+	activationPrice     *string
+	callbackRate        *string
+}
+
+// SetSymbol set symbol
+func (s *CreateConditionalOrderService) SetSymbol(symbol string) *CreateConditionalOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// SetSide set side
+func (s *CreateConditionalOrderService) SetSide(side SideType) *CreateConditionalOrderService {
+	s.side = side
+	return s
+}
+
+// SetPositionSide set positionSide
+func (s *CreateConditionalOrderService) SetPositionSide(positionSide PositionSideType) *CreateConditionalOrderService {
+	s.positionSide = &positionSide
+	return s
+}
+
+// SetStrategyType set strategyType
+func (s *CreateConditionalOrderService) SetStrategyType(strategyType StrategyType) *CreateConditionalOrderService {
+	s.strategyType = strategyType
+	return s
+}
+
+// SetTimeInForce set timeInForce
+func (s *CreateConditionalOrderService) SetTimeInForce(timeInForce TimeInForceType) *CreateConditionalOrderService {
+	s.timeInForce = &timeInForce
+	return s
+}
+
+// SetQuantity set quantity
+func (s *CreateConditionalOrderService) SetQuantity(quantity string) *CreateConditionalOrderService {
+	s.quantity = &quantity
+	return s
+}
+
+// SetReduceOnly set reduceOnly
+func (s *CreateConditionalOrderService) SetReduceOnly(reduceOnly bool) *CreateConditionalOrderService {
+	s.reduceOnly = &reduceOnly
+	return s
+}
+
+// SetPrice set price
+func (s *CreateConditionalOrderService) SetPrice(price string) *CreateConditionalOrderService {
+	s.price = &price
+	return s
+}
+
+// SetWorkingType set workingType
+func (s *CreateConditionalOrderService) SetWorkingType(workingType WorkingType) *CreateConditionalOrderService {
+	s.workingType = &workingType
+	return s
+}
+
+// SetPriceProtect set priceProtect
+func (s *CreateConditionalOrderService) SetPriceProtect(priceProtect bool) *CreateConditionalOrderService {
+	s.priceProtect = &priceProtect
+	return s
+}
+
+// SetNewClientStrategyID set newClientStrategyId
+func (s *CreateConditionalOrderService) SetNewClientStrategyID(newClientStrategyID string) *CreateConditionalOrderService {
+	s.newClientStrategyID = &newClientStrategyID
+	return s
+}
+
+// SetStopPrice set stopPrice
+func (s *CreateConditionalOrderService) SetStopPrice(stopPrice string) *CreateConditionalOrderService {
+	s.stopPrice = &stopPrice
+	return s
+}
+
+// SetActivationPrice set activationPrice, TRAILING_STOP_MARKET only
+func (s *CreateConditionalOrderService) SetActivationPrice(activationPrice string) *CreateConditionalOrderService {
+	s.activationPrice = &activationPrice
+	return s
+}
+
+// SetCallbackRate set callbackRate, TRAILING_STOP_MARKET only
+func (s *CreateConditionalOrderService) SetCallbackRate(callbackRate string) *CreateConditionalOrderService {
+	s.callbackRate = &callbackRate
+	return s
+}
+
+// Do send request
+func (s *CreateConditionalOrderService) Do(ctx context.Context, opts ...RequestOption) (res *ConditionalOrder, err error) {
+	// POST /papi/v1/um/conditional/order, /papi/v1/cm/conditional/order | 条件单下单
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: fmt.Sprintf("/papi/v1/%s/conditional/order", s.product),
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"symbol":       s.symbol,
+		"side":         s.side,
+		"strategyType": s.strategyType,
+	}
+	if s.positionSide != nil {
+		m["positionSide"] = *s.positionSide
+	}
+	if s.timeInForce != nil {
+		m["timeInForce"] = *s.timeInForce
+	}
+	if s.quantity != nil {
+		m["quantity"] = *s.quantity
+	}
+	if s.reduceOnly != nil {
+		m["reduceOnly"] = *s.reduceOnly
+	}
+	if s.price != nil {
+		m["price"] = *s.price
+	}
+	if s.workingType != nil {
+		m["workingType"] = *s.workingType
+	}
+	if s.priceProtect != nil {
+		m["priceProtect"] = *s.priceProtect
+	}
+	if s.newClientStrategyID != nil {
+		m["newClientStrategyId"] = *s.newClientStrategyID
+	}
+	if s.stopPrice != nil {
+		m["stopPrice"] = *s.stopPrice
+	}
+	if s.activationPrice != nil {
+		m["activationPrice"] = *s.activationPrice
+	}
+	if s.callbackRate != nil {
+		m["callbackRate"] = *s.callbackRate
+	}
+	r.setFormParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(ConditionalOrder)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ConditionalOrder define UM or CM conditional order info | 条件单
+type ConditionalOrder struct {
+	NewClientStrategyID     string             `json:"newClientStrategyId"`     // 用户自定义的条件单号
+	StrategyID              int64              `json:"strategyId"`              // 系统条件单号
+	StrategyStatus          StrategyStatusType `json:"strategyStatus"`          // 条件单状态
+	StrategyType            StrategyType       `json:"strategyType"`            // 条件单类型
+	OrigQuantity            float64            `json:"origQty,string"`          // 原始委托数量
+	Price                   float64            `json:"price,string"`            // 委托价格
+	ReduceOnly              bool               `json:"reduceOnly"`              // 仅减仓
+	Side                    SideType           `json:"side"`                    // 买卖方向
+	PositionSide            PositionSideType   `json:"positionSide"`            // 持仓方向
+	StopPrice               float64            `json:"stopPrice,string"`        // 触发价
+	Symbol                  string             `json:"symbol"`                  // 交易对
+	Pair                    string             `json:"pair"`                    // 标的交易对, 仅币本位
+	TimeInForce             TimeInForceType    `json:"timeInForce"`             // 有效方法
+	ActivatePrice           float64            `json:"activatePrice,string"`    // 跟踪止损激活价格
+	PriceRate               float64            `json:"priceRate,string"`        // 跟踪止损回调比例
+	BookTime                int64              `json:"bookTime"`                // 下单时间
+	UpdateTime              int64              `json:"updateTime"`              // 更新时间
+	WorkingType             WorkingType        `json:"workingType"`             // 条件价格触发类型
+	PriceProtect            bool               `json:"priceProtect"`            // 是否开启条件单触发保护
+	SelfTradePreventionMode string             `json:"selfTradePreventionMode"` // 订单自成交保护模式
+	GoodTillDate            int64              `json:"goodTillDate"`            // 订单TIF为GTD时的自动取消时间
+	PriceMatch              string             `json:"priceMatch"`              // 盘口价格下单模式
+}
+
+// CancelConditionalOrderService cancel a UM or CM conditional order | 取消条件单
+type CancelConditionalOrderService struct {
+	c                   *Client
+	product             string
+	symbol              string
+	strategyID          *int64
+	newClientStrategyID *string
+}
+
+// SetSymbol set symbol
+func (s *CancelConditionalOrderService) SetSymbol(symbol string) *CancelConditionalOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// SetStrategyID set strategyId
+func (s *CancelConditionalOrderService) SetStrategyID(strategyID int64) *CancelConditionalOrderService {
+	s.strategyID = &strategyID
+	return s
+}
+
+// SetNewClientStrategyID set newClientStrategyId
+func (s *CancelConditionalOrderService) SetNewClientStrategyID(newClientStrategyID string) *CancelConditionalOrderService {
+	s.newClientStrategyID = &newClientStrategyID
+	return s
+}
+
+// Do send request
+func (s *CancelConditionalOrderService) Do(ctx context.Context, opts ...RequestOption) (res *ConditionalOrder, err error) {
+	// DELETE /papi/v1/um/conditional/order, /papi/v1/cm/conditional/order | 取消条件单
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: fmt.Sprintf("/papi/v1/%s/conditional/order", s.product),
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("symbol", s.symbol)
+	if s.strategyID != nil {
+		r.setFormParam("strategyId", *s.strategyID)
+	}
+	if s.newClientStrategyID != nil {
+		r.setFormParam("newClientStrategyId", *s.newClientStrategyID)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(ConditionalOrder)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CancelAllConditionalOrdersService cancel all UM or CM conditional orders of a symbol | 取消全部条件单
+type CancelAllConditionalOrdersService struct {
+	c       *Client
+	product string
+	symbol  string
+}
+
+// SetSymbol set symbol
+func (s *CancelAllConditionalOrdersService) SetSymbol(symbol string) *CancelAllConditionalOrdersService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *CancelAllConditionalOrdersService) Do(ctx context.Context, opts ...RequestOption) (err error) {
+	// DELETE /papi/v1/um/conditional/allOpenOrders, /papi/v1/cm/conditional/allOpenOrders | 取消全部条件单
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: fmt.Sprintf("/papi/v1/%s/conditional/allOpenOrders", s.product),
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("symbol", s.symbol)
+	_, err = s.c.callAPI(ctx, r, opts...)
+	return err
+}
+
+// ListConditionalOpenOrdersService list UM or CM conditional open orders | 查看当前全部条件单
+type ListConditionalOpenOrdersService struct {
+	c       *Client
+	product string
+	symbol  string
+}
+
+// SetSymbol set symbol
+func (s *ListConditionalOpenOrdersService) SetSymbol(symbol string) *ListConditionalOpenOrdersService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *ListConditionalOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*ConditionalOrder, err error) {
+	// GET /papi/v1/um/conditional/openOrders, /papi/v1/cm/conditional/openOrders | 查看当前全部条件单
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: fmt.Sprintf("/papi/v1/%s/conditional/openOrders", s.product),
+		secType:  secTypeSigned,
+	}
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*ConditionalOrder{}, err
+	}
+	res = make([]*ConditionalOrder, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*ConditionalOrder{}, err
+	}
+	return res, nil
+}
@@ -0,0 +1,479 @@
+package portfolio
+
+import (
+	"context"
+	"net/http"
+)
+
+// CreateMarginOrderService create a cross margin order | 杠杆账户下单
+type CreateMarginOrderService struct {
+	c                       *Client
+	symbol                  string
+	side                    SideType
+	orderType               OrderType
+	quantity                *string
+	quoteOrderQty           *string
+	price                   *string
+	stopPrice               *string
+	newClientOrderID        *string
+	icebergQuantity         *string
+	newOrderRespType        *NewOrderRespType
+	sideEffectType          *SideEffectType
+	timeInForce             *TimeInForceType
+	selfTradePreventionMode *string
+	autoRepayAtCancel       *bool
+}
+
+// SetSymbol set symbol
+func (s *CreateMarginOrderService) SetSymbol(symbol string) *CreateMarginOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// SetSide set side
+func (s *CreateMarginOrderService) SetSide(side SideType) *CreateMarginOrderService {
+	s.side = side
+	return s
+}
+
+// SetType set type
+func (s *CreateMarginOrderService) SetType(orderType OrderType) *CreateMarginOrderService {
+	s.orderType = orderType
+	return s
+}
+
+// SetQuantity set quantity
+func (s *CreateMarginOrderService) SetQuantity(quantity string) *CreateMarginOrderService {
+	s.quantity = &quantity
+	return s
+}
+
+// SetQuoteOrderQty set quoteOrderQty
+func (s *CreateMarginOrderService) SetQuoteOrderQty(quoteOrderQty string) *CreateMarginOrderService {
+	s.quoteOrderQty = &quoteOrderQty
+	return s
+}
+
+// SetPrice set price
+func (s *CreateMarginOrderService) SetPrice(price string) *CreateMarginOrderService {
+	s.price = &price
+	return s
+}
+
+// SetStopPrice set stopPrice
+func (s *CreateMarginOrderService) SetStopPrice(stopPrice string) *CreateMarginOrderService {
+	s.stopPrice = &stopPrice
+	return s
+}
+
+// SetNewClientOrderID set newClientOrderID
+func (s *CreateMarginOrderService) SetNewClientOrderID(newClientOrderID string) *CreateMarginOrderService {
+	s.newClientOrderID = &newClientOrderID
+	return s
+}
+
+// SetIcebergQuantity set icebergQuantity
+func (s *CreateMarginOrderService) SetIcebergQuantity(icebergQuantity string) *CreateMarginOrderService {
+	s.icebergQuantity = &icebergQuantity
+	return s
+}
+
+// SetNewOrderRespType set newOrderRespType
+func (s *CreateMarginOrderService) SetNewOrderRespType(newOrderRespType NewOrderRespType) *CreateMarginOrderService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+// SetSideEffectType set sideEffectType
+func (s *CreateMarginOrderService) SetSideEffectType(sideEffectType SideEffectType) *CreateMarginOrderService {
+	s.sideEffectType = &sideEffectType
+	return s
+}
+
+// SetTimeInForce set timeInForce
+func (s *CreateMarginOrderService) SetTimeInForce(timeInForce TimeInForceType) *CreateMarginOrderService {
+	s.timeInForce = &timeInForce
+	return s
+}
+
+// SetSelfTradePreventionMode set selfTradePreventionMode
+func (s *CreateMarginOrderService) SetSelfTradePreventionMode(selfTradePreventionMode string) *CreateMarginOrderService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
+// SetAutoRepayAtCancel set autoRepayAtCancel, repay the borrowed amount when a MARGIN_BUY order is canceled | 撤单时是否归还借款
+func (s *CreateMarginOrderService) SetAutoRepayAtCancel(autoRepayAtCancel bool) *CreateMarginOrderService {
+	s.autoRepayAtCancel = &autoRepayAtCancel
+	return s
+}
+
+// Do send request
+func (s *CreateMarginOrderService) Do(ctx context.Context, opts ...RequestOption) (res *MarginOrder, err error) {
+	// POST /papi/v1/margin/order | 杠杆账户下单
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/papi/v1/margin/order",
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"symbol": s.symbol,
+		"side":   s.side,
+		"type":   s.orderType,
+	}
+	if s.quantity != nil {
+		m["quantity"] = *s.quantity
+	}
+	if s.quoteOrderQty != nil {
+		m["quoteOrderQty"] = *s.quoteOrderQty
+	}
+	if s.price != nil {
+		m["price"] = *s.price
+	}
+	if s.stopPrice != nil {
+		m["stopPrice"] = *s.stopPrice
+	}
+	if s.newClientOrderID != nil {
+		m["newClientOrderId"] = *s.newClientOrderID
+	}
+	if s.icebergQuantity != nil {
+		m["icebergQty"] = *s.icebergQuantity
+	}
+	if s.newOrderRespType != nil {
+		m["newOrderRespType"] = *s.newOrderRespType
+	}
+	if s.sideEffectType != nil {
+		m["sideEffectType"] = *s.sideEffectType
+	}
+	if s.timeInForce != nil {
+		m["timeInForce"] = *s.timeInForce
+	}
+	if s.selfTradePreventionMode != nil {
+		m["selfTradePreventionMode"] = *s.selfTradePreventionMode
+	}
+	if s.autoRepayAtCancel != nil {
+		m["autoRepayAtCancel"] = *s.autoRepayAtCancel
+	}
+	r.setFormParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(MarginOrder)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// MarginOrder define cross margin order info | 杠杆订单
+type MarginOrder struct {
+	Symbol                   string          `json:"symbol"`                       // 交易对
+	OrderID                  int64           `json:"orderId"`                      // 系统订单号
+	OrderListID              int64           `json:"orderListId"`                  // 订单列表ID, 不属于订单列表时为 -1
+	ClientOrderID            string          `json:"clientOrderId"`                // 用户自定义的订单号
+	OrigClientOrderID        string          `json:"origClientOrderId"`            // 撤单时原始的自定义订单号
+	TransactTime             int64           `json:"transactTime"`                 // 交易时间
+	Price                    float64         `json:"price,string"`                 // 委托价格
+	OrigQuantity             float64         `json:"origQty,string"`               // 原始委托数量
+	ExecutedQuantity         float64         `json:"executedQty,string"`           // 成交量
+	CummulativeQuoteQuantity float64         `json:"cummulativeQuoteQty,string"`   // 成交金额
+	Status                   OrderStatusType `json:"status"`                       // 订单状态
+	TimeInForce              TimeInForceType `json:"timeInForce"`                  // 有效方法
+	Type                     OrderType       `json:"type"`                         // 订单类型
+	Side                     SideType        `json:"side"`                         // 买卖方向
+	StopPrice                float64         `json:"stopPrice,string"`             // 触发价
+	IcebergQuantity          float64         `json:"icebergQty,string"`            // 冰山数量
+	Time                     int64           `json:"time"`                         // 订单时间
+	UpdateTime               int64           `json:"updateTime"`                   // 更新时间
+	IsWorking                bool            `json:"isWorking"`                    // 订单是否出现在orderbook中
+	SelfTradePreventionMode  string          `json:"selfTradePreventionMode"`      // 订单自成交保护模式
+	MarginBuyBorrowAmount    float64         `json:"marginBuyBorrowAmount,string"` // 下单后借款数量
+	MarginBuyBorrowAsset     string          `json:"marginBuyBorrowAsset"`         // 借款资产
+	Fills                    []*Fill         `json:"fills"`                        // 成交明细, 仅 FULL 响应
+}
+
+// Fill define a fill of a margin order | 杠杆订单成交明细
+type Fill struct {
+	TradeID         int64   `json:"tradeId"`
+	Price           float64 `json:"price,string"`
+	Quantity        float64 `json:"qty,string"`
+	Commission      float64 `json:"commission,string"`
+	CommissionAsset string  `json:"commissionAsset"`
+}
+
+// CancelMarginOrderService cancel a cross margin order | 杠杆账户撤销订单
+type CancelMarginOrderService struct {
+	c                 *Client
+	symbol            string
+	orderID           *int64
+	origClientOrderID *string
+	newClientOrderID  *string
+}
+
+// SetSymbol set symbol
+func (s *CancelMarginOrderService) SetSymbol(symbol string) *CancelMarginOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// SetOrderID set orderID
+func (s *CancelMarginOrderService) SetOrderID(orderID int64) *CancelMarginOrderService {
+	s.orderID = &orderID
+	return s
+}
+
+// SetOrigClientOrderID set origClientOrderID
+func (s *CancelMarginOrderService) SetOrigClientOrderID(origClientOrderID string) *CancelMarginOrderService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// SetNewClientOrderID set newClientOrderID
+func (s *CancelMarginOrderService) SetNewClientOrderID(newClientOrderID string) *CancelMarginOrderService {
+	s.newClientOrderID = &newClientOrderID
+	return s
+}
+
+// Do send request
+func (s *CancelMarginOrderService) Do(ctx context.Context, opts ...RequestOption) (res *MarginOrder, err error) {
+	// DELETE /papi/v1/margin/order | 杠杆账户撤销订单
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: "/papi/v1/margin/order",
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("symbol", s.symbol)
+	if s.orderID != nil {
+		r.setFormParam("orderId", *s.orderID)
+	}
+	if s.origClientOrderID != nil {
+		r.setFormParam("origClientOrderId", *s.origClientOrderID)
+	}
+	if s.newClientOrderID != nil {
+		r.setFormParam("newClientOrderId", *s.newClientOrderID)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(MarginOrder)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetMarginOrderService get a cross margin order | 查询杠杆账户订单
+type GetMarginOrderService struct {
+	c                 *Client
+	symbol            string
+	orderID           *int64
+	origClientOrderID *string
+}
+
+// SetSymbol set symbol
+func (s *GetMarginOrderService) SetSymbol(symbol string) *GetMarginOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// SetOrderID set orderID
+func (s *GetMarginOrderService) SetOrderID(orderID int64) *GetMarginOrderService {
+	s.orderID = &orderID
+	return s
+}
+
+// SetOrigClientOrderID set origClientOrderID
+func (s *GetMarginOrderService) SetOrigClientOrderID(origClientOrderID string) *GetMarginOrderService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// Do send request
+func (s *GetMarginOrderService) Do(ctx context.Context, opts ...RequestOption) (res *MarginOrder, err error) {
+	// GET /papi/v1/margin/order | 查询杠杆账户订单
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/margin/order",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.orderID != nil {
+		r.setParam("orderId", *s.orderID)
+	}
+	if s.origClientOrderID != nil {
+		r.setParam("origClientOrderId", *s.origClientOrderID)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(MarginOrder)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListMarginOpenOrdersService list cross margin open orders | 查询杠杆账户挂单
+type ListMarginOpenOrdersService struct {
+	c      *Client
+	symbol string
+}
+
+// SetSymbol set symbol
+func (s *ListMarginOpenOrdersService) SetSymbol(symbol string) *ListMarginOpenOrdersService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *ListMarginOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*MarginOrder, err error) {
+	// GET /papi/v1/margin/openOrders | 查询杠杆账户挂单
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/margin/openOrders",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*MarginOrder{}, err
+	}
+	res = make([]*MarginOrder, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*MarginOrder{}, err
+	}
+	return res, nil
+}
+
+// MarginLoanService borrow an asset in the cross margin account | 杠杆账户借贷
+type MarginLoanService struct {
+	c      *Client
+	asset  string
+	amount string
+}
+
+// SetAsset set asset
+func (s *MarginLoanService) SetAsset(asset string) *MarginLoanService {
+	s.asset = asset
+	return s
+}
+
+// SetAmount set amount
+func (s *MarginLoanService) SetAmount(amount string) *MarginLoanService {
+	s.amount = amount
+	return s
+}
+
+// Do send request
+func (s *MarginLoanService) Do(ctx context.Context, opts ...RequestOption) (res *TransactionResponse, err error) {
+	// POST /papi/v1/marginLoan | 杠杆账户借贷
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/papi/v1/marginLoan",
+		secType:  secTypeSigned,
+	}
+	r.setFormParams(params{"asset": s.asset, "amount": s.amount})
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(TransactionResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// TransactionResponse define transaction response | 借还款响应
+type TransactionResponse struct {
+	TranID int64 `json:"tranId"` // 交易ID
+}
+
+// MarginRepayService repay an asset in the cross margin account | 杠杆账户还款
+type MarginRepayService struct {
+	c      *Client
+	asset  string
+	amount string
+}
+
+// SetAsset set asset
+func (s *MarginRepayService) SetAsset(asset string) *MarginRepayService {
+	s.asset = asset
+	return s
+}
+
+// SetAmount set amount
+func (s *MarginRepayService) SetAmount(amount string) *MarginRepayService {
+	s.amount = amount
+	return s
+}
+
+// Do send request
+func (s *MarginRepayService) Do(ctx context.Context, opts ...RequestOption) (res *TransactionResponse, err error) {
+	// POST /papi/v1/repayLoan | 杠杆账户还款
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/papi/v1/repayLoan",
+		secType:  secTypeSigned,
+	}
+	r.setFormParams(params{"asset": s.asset, "amount": s.amount})
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(TransactionResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetMaxBorrowableService get the max borrowable amount of an asset | 查询杠杆账户最大可借
+type GetMaxBorrowableService struct {
+	c     *Client
+	asset string
+}
+
+// SetAsset set asset
+func (s *GetMaxBorrowableService) SetAsset(asset string) *GetMaxBorrowableService {
+	s.asset = asset
+	return s
+}
+
+// Do send request
+func (s *GetMaxBorrowableService) Do(ctx context.Context, opts ...RequestOption) (res *MaxBorrowable, err error) {
+	// GET /papi/v1/margin/maxBorrowable | 查询杠杆账户最大可借
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/papi/v1/margin/maxBorrowable",
+		secType:  secTypeSigned,
+	}
+	r.setParam("asset", s.asset)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(MaxBorrowable)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// MaxBorrowable define max borrowable amount | 最大可借
+type MaxBorrowable struct {
+	Amount      float64 `json:"amount,string"`      // 账户可借额度
+	BorrowLimit float64 `json:"borrowLimit,string"` // 平台可借额度
+}
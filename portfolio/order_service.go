@@ -0,0 +1,439 @@
+package portfolio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// CreateOrderService create a UM or CM order | 统一账户合约下单
+type CreateOrderService struct {
+	c                       *Client
+	product                 string
+	symbol                  string
+	side                    SideType
+	positionSide            *PositionSideType
+	orderType               OrderType
+	timeInForce             *TimeInForceType
+	quantity                string
+	reduceOnly              *bool
+	price                   *string
+	newClientOrderID        *string
+	newOrderRespType        NewOrderRespType
+	priceMatch              *string
+	selfTradePreventionMode *string
+	goodTillDate            *int64
+}
+
+// SetSymbol set symbol
+func (s *CreateOrderService) SetSymbol(symbol string) *CreateOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// SetSide set side
+func (s *CreateOrderService) SetSide(side SideType) *CreateOrderService {
+	s.side = side
+	return s
+}
+
+// SetPositionSide set positionSide
+func (s *CreateOrderService) SetPositionSide(positionSide PositionSideType) *CreateOrderService {
+	s.positionSide = &positionSide
+	return s
+}
+
+// SetType set type, LIMIT or MARKET
+func (s *CreateOrderService) SetType(orderType OrderType) *CreateOrderService {
+	s.orderType = orderType
+	return s
+}
+
+// SetTimeInForce set timeInForce
+func (s *CreateOrderService) SetTimeInForce(timeInForce TimeInForceType) *CreateOrderService {
+	s.timeInForce = &timeInForce
+	return s
+}
+
+// SetQuantity set quantity
+func (s *CreateOrderService) SetQuantity(quantity string) *CreateOrderService {
+	s.quantity = quantity
+	return s
+}
+
+// SetReduceOnly set reduceOnly
+func (s *CreateOrderService) SetReduceOnly(reduceOnly bool) *CreateOrderService {
+	s.reduceOnly = &reduceOnly
+	return s
+}
+
+// SetPrice set price
+func (s *CreateOrderService) SetPrice(price string) *CreateOrderService {
+	s.price = &price
+	return s
+}
+
+// SetNewClientOrderID set newClientOrderID
+func (s *CreateOrderService) SetNewClientOrderID(newClientOrderID string) *CreateOrderService {
+	s.newClientOrderID = &newClientOrderID
+	return s
+}
+
+// SetNewOrderResponseType set newOrderResponseType
+func (s *CreateOrderService) SetNewOrderResponseType(newOrderResponseType NewOrderRespType) *CreateOrderService {
+	s.newOrderRespType = newOrderResponseType
+	return s
+}
+
+// SetPriceMatch set priceMatch, UM only | 盘口价格下单模式, 仅U本位
+func (s *CreateOrderService) SetPriceMatch(priceMatch string) *CreateOrderService {
+	s.priceMatch = &priceMatch
+	return s
+}
+
+// SetSelfTradePreventionMode set selfTradePreventionMode, UM only | 自成交保护模式, 仅U本位
+func (s *CreateOrderService) SetSelfTradePreventionMode(selfTradePreventionMode string) *CreateOrderService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
+// SetGoodTillDate set goodTillDate for GTD orders, UM only | TIF为GTD时订单的自动取消时间, 仅U本位
+func (s *CreateOrderService) SetGoodTillDate(goodTillDate int64) *CreateOrderService {
+	s.goodTillDate = &goodTillDate
+	return s
+}
+
+// Do send request
+func (s *CreateOrderService) Do(ctx context.Context, opts ...RequestOption) (res *Order, err error) {
+	// POST /papi/v1/um/order, /papi/v1/cm/order | 下单
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: fmt.Sprintf("/papi/v1/%s/order", s.product),
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"symbol":   s.symbol,
+		"side":     s.side,
+		"type":     s.orderType,
+		"quantity": s.quantity,
+	}
+	if s.positionSide != nil {
+		m["positionSide"] = *s.positionSide
+	}
+	if s.timeInForce != nil {
+		m["timeInForce"] = *s.timeInForce
+	}
+	if s.reduceOnly != nil {
+		m["reduceOnly"] = *s.reduceOnly
+	}
+	if s.price != nil {
+		m["price"] = *s.price
+	}
+	if s.newClientOrderID != nil {
+		m["newClientOrderId"] = *s.newClientOrderID
+	}
+	if s.newOrderRespType != "" {
+		m["newOrderRespType"] = s.newOrderRespType
+	}
+	if s.priceMatch != nil {
+		m["priceMatch"] = *s.priceMatch
+	}
+	if s.selfTradePreventionMode != nil {
+		m["selfTradePreventionMode"] = *s.selfTradePreventionMode
+	}
+	if s.goodTillDate != nil {
+		m["goodTillDate"] = *s.goodTillDate
+	}
+	r.setFormParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(Order)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Order define UM or CM order info | 合约订单
+type Order struct {
+	ClientOrderID           string           `json:"clientOrderId"`           // 用户自定义的订单号
+	CumQty                  float64          `json:"cumQty,string"`           // 成交量
+	CumQuote                float64          `json:"cumQuote,string"`         // 成交金额, 仅U本位
+	CumBase                 float64          `json:"cumBase,string"`          // 成交额(标的数量), 仅币本位
+	ExecutedQuantity        float64          `json:"executedQty,string"`      // 成交量
+	OrderID                 int64            `json:"orderId"`                 // 系统订单号
+	AvgPrice                float64          `json:"avgPrice,string"`         // 平均成交价
+	OrigQuantity            float64          `json:"origQty,string"`          // 原始委托数量
+	Price                   float64          `json:"price,string"`            // 委托价格
+	ReduceOnly              bool             `json:"reduceOnly"`              // 仅减仓
+	Side                    SideType         `json:"side"`                    // 买卖方向
+	PositionSide            PositionSideType `json:"positionSide"`            // 持仓方向
+	Status                  OrderStatusType  `json:"status"`                  // 订单状态
+	Symbol                  string           `json:"symbol"`                  // 交易对
+	Pair                    string           `json:"pair"`                    // 标的交易对, 仅币本位
+	TimeInForce             TimeInForceType  `json:"timeInForce"`             // 有效方法
+	Type                    OrderType        `json:"type"`                    // 订单类型
+	OrigType                OrderType        `json:"origType"`                // 触发前订单类型
+	Time                    int64            `json:"time"`                    // 订单时间
+	UpdateTime              int64            `json:"updateTime"`              // 更新时间
+	PriceMatch              string           `json:"priceMatch"`              // 盘口价格下单模式
+	SelfTradePreventionMode string           `json:"selfTradePreventionMode"` // 订单自成交保护模式
+	GoodTillDate            int64            `json:"goodTillDate"`            // 订单TIF为GTD时的自动取消时间
+}
+
+// CancelOrderService cancel a UM or CM order | 撤销订单
+type CancelOrderService struct {
+	c                 *Client
+	product           string
+	symbol            string
+	orderID           *int64
+	origClientOrderID *string
+}
+
+// SetSymbol set symbol
+func (s *CancelOrderService) SetSymbol(symbol string) *CancelOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// SetOrderID set orderID
+func (s *CancelOrderService) SetOrderID(orderID int64) *CancelOrderService {
+	s.orderID = &orderID
+	return s
+}
+
+// SetOrigClientOrderID set origClientOrderID
+func (s *CancelOrderService) SetOrigClientOrderID(origClientOrderID string) *CancelOrderService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// Do send request
+func (s *CancelOrderService) Do(ctx context.Context, opts ...RequestOption) (res *Order, err error) {
+	// DELETE /papi/v1/um/order, /papi/v1/cm/order | 撤销订单
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: fmt.Sprintf("/papi/v1/%s/order", s.product),
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("symbol", s.symbol)
+	if s.orderID != nil {
+		r.setFormParam("orderId", *s.orderID)
+	}
+	if s.origClientOrderID != nil {
+		r.setFormParam("origClientOrderId", *s.origClientOrderID)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(Order)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CancelAllOpenOrdersService cancel all UM or CM open orders of a symbol | 撤销全部订单
+type CancelAllOpenOrdersService struct {
+	c       *Client
+	product string
+	symbol  string
+}
+
+// SetSymbol set symbol
+func (s *CancelAllOpenOrdersService) SetSymbol(symbol string) *CancelAllOpenOrdersService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *CancelAllOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (err error) {
+	// DELETE /papi/v1/um/allOpenOrders, /papi/v1/cm/allOpenOrders | 撤销全部订单
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: fmt.Sprintf("/papi/v1/%s/allOpenOrders", s.product),
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("symbol", s.symbol)
+	_, err = s.c.callAPI(ctx, r, opts...)
+	return err
+}
+
+// GetOrderService get a UM or CM order | 查询订单
+type GetOrderService struct {
+	c                 *Client
+	product           string
+	symbol            string
+	orderID           *int64
+	origClientOrderID *string
+}
+
+// SetSymbol set symbol
+func (s *GetOrderService) SetSymbol(symbol string) *GetOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// SetOrderID set orderID
+func (s *GetOrderService) SetOrderID(orderID int64) *GetOrderService {
+	s.orderID = &orderID
+	return s
+}
+
+// SetOrigClientOrderID set origClientOrderID
+func (s *GetOrderService) SetOrigClientOrderID(origClientOrderID string) *GetOrderService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// Do send request
+func (s *GetOrderService) Do(ctx context.Context, opts ...RequestOption) (res *Order, err error) {
+	// GET /papi/v1/um/order, /papi/v1/cm/order | 查询订单
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: fmt.Sprintf("/papi/v1/%s/order", s.product),
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.orderID != nil {
+		r.setParam("orderId", *s.orderID)
+	}
+	if s.origClientOrderID != nil {
+		r.setParam("origClientOrderId", *s.origClientOrderID)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(Order)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListOpenOrdersService list UM or CM open orders | 查看当前全部挂单
+type ListOpenOrdersService struct {
+	c       *Client
+	product string
+	symbol  string
+	pair    string
+}
+
+// SetSymbol set symbol
+func (s *ListOpenOrdersService) SetSymbol(symbol string) *ListOpenOrdersService {
+	s.symbol = symbol
+	return s
+}
+
+// SetPair set pair, CM only
+func (s *ListOpenOrdersService) SetPair(pair string) *ListOpenOrdersService {
+	s.pair = pair
+	return s
+}
+
+// Do send request
+func (s *ListOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*Order, err error) {
+	// GET /papi/v1/um/openOrders, /papi/v1/cm/openOrders | 查看当前全部挂单
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: fmt.Sprintf("/papi/v1/%s/openOrders", s.product),
+		secType:  secTypeSigned,
+	}
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
+	}
+	if s.pair != "" {
+		r.setParam("pair", s.pair)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*Order{}, err
+	}
+	res = make([]*Order, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*Order{}, err
+	}
+	return res, nil
+}
+
+// ListOrdersService list all UM or CM orders; active, canceled, or filled | 查询所有订单
+type ListOrdersService struct {
+	c         *Client
+	product   string
+	symbol    string
+	orderID   *int64
+	startTime *int64
+	endTime   *int64
+	limit     *int
+}
+
+// SetSymbol set symbol
+func (s *ListOrdersService) SetSymbol(symbol string) *ListOrdersService {
+	s.symbol = symbol
+	return s
+}
+
+// SetOrderID set orderID
+func (s *ListOrdersService) SetOrderID(orderID int64) *ListOrdersService {
+	s.orderID = &orderID
+	return s
+}
+
+// SetStartTime set startTime
+func (s *ListOrdersService) SetStartTime(startTime int64) *ListOrdersService {
+	s.startTime = &startTime
+	return s
+}
+
+// SetEndTime set endTime
+func (s *ListOrdersService) SetEndTime(endTime int64) *ListOrdersService {
+	s.endTime = &endTime
+	return s
+}
+
+// SetLimit set limit
+func (s *ListOrdersService) SetLimit(limit int) *ListOrdersService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *ListOrdersService) Do(ctx context.Context, opts ...RequestOption) (res []*Order, err error) {
+	// GET /papi/v1/um/allOrders, /papi/v1/cm/allOrders | 查询所有订单
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: fmt.Sprintf("/papi/v1/%s/allOrders", s.product),
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.orderID != nil {
+		r.setParam("orderId", *s.orderID)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*Order{}, err
+	}
+	res = make([]*Order, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*Order{}, err
+	}
+	return res, nil
+}
@@ -0,0 +1,180 @@
+package portfolio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// GetPositionRiskService get UM or CM position risk | 查询UM/CM持仓风险
+type GetPositionRiskService struct {
+	c       *Client
+	product string
+	symbol  *string
+	pair    *string
+}
+
+// SetSymbol set symbol
+func (s *GetPositionRiskService) SetSymbol(symbol string) *GetPositionRiskService {
+	s.symbol = &symbol
+	return s
+}
+
+// SetPair set pair, CM only | 标的交易对, 仅币本位
+func (s *GetPositionRiskService) SetPair(pair string) *GetPositionRiskService {
+	s.pair = &pair
+	return s
+}
+
+// Do send request
+func (s *GetPositionRiskService) Do(ctx context.Context, opts ...RequestOption) (res []*PositionRisk, err error) {
+	// GET /papi/v1/{um,cm}/positionRisk | 查询UM/CM持仓风险
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: fmt.Sprintf("/papi/v1/%s/positionRisk", s.product),
+		secType:  secTypeSigned,
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	if s.pair != nil {
+		r.setParam("pair", *s.pair)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*PositionRisk{}, err
+	}
+	res = make([]*PositionRisk, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*PositionRisk{}, err
+	}
+	return res, nil
+}
+
+// PositionRisk define position risk info, a superset of the UM and CM fields | 持仓风险
+type PositionRisk struct {
+	Symbol           string           `json:"symbol"`                  // 交易对
+	PositionSide     PositionSideType `json:"positionSide"`            // 持仓方向
+	PositionAmt      float64          `json:"positionAmt,string"`      // 头寸数量, 符号代表多空方向; 币本位为张数
+	EntryPrice       float64          `json:"entryPrice,string"`       // 开仓均价
+	BreakEvenPrice   float64          `json:"breakEvenPrice,string"`   // 盈亏平衡价
+	MarkPrice        float64          `json:"markPrice,string"`        // 当前标记价格
+	UnRealizedProfit float64          `json:"unRealizedProfit,string"` // 持仓未实现盈亏
+	LiquidationPrice float64          `json:"liquidationPrice,string"` // 参考强平价格
+	Leverage         float64          `json:"leverage,string"`         // 当前杠杆倍数
+	MaxNotionalValue float64          `json:"maxNotionalValue,string"` // 当前杠杆倍数允许的名义价值上限, 仅U本位
+	MaxQty           float64          `json:"maxQty,string"`           // 当前杠杆倍数允许的数量上限, 仅币本位
+	Notional         float64          `json:"notional,string"`         // 名义价值, 仅U本位
+	NotionalValue    float64          `json:"notionalValue,string"`    // 以基础资产计的名义价值, 仅币本位
+	UpdateTime       int64            `json:"updateTime"`              // 更新时间
+}
+
+// ChangeLeverageService change UM or CM initial leverage | 调整UM/CM开仓杠杆
+type ChangeLeverageService struct {
+	c        *Client
+	product  string
+	symbol   string
+	leverage int
+}
+
+// SetSymbol set symbol
+func (s *ChangeLeverageService) SetSymbol(symbol string) *ChangeLeverageService {
+	s.symbol = symbol
+	return s
+}
+
+// SetLeverage set leverage
+func (s *ChangeLeverageService) SetLeverage(leverage int) *ChangeLeverageService {
+	s.leverage = leverage
+	return s
+}
+
+// Do send request
+func (s *ChangeLeverageService) Do(ctx context.Context, opts ...RequestOption) (res *SymbolLeverage, err error) {
+	// POST /papi/v1/{um,cm}/leverage | 调整UM/CM开仓杠杆
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: fmt.Sprintf("/papi/v1/%s/leverage", s.product),
+		secType:  secTypeSigned,
+	}
+	r.setFormParams(params{
+		"symbol":   s.symbol,
+		"leverage": s.leverage,
+	})
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(SymbolLeverage)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SymbolLeverage define leverage info of symbol | 交易对杠杆
+type SymbolLeverage struct {
+	Leverage         int     `json:"leverage"`                // 杠杆倍数
+	MaxNotionalValue float64 `json:"maxNotionalValue,string"` // 名义价值上限, 仅U本位
+	MaxQty           float64 `json:"maxQty,string"`           // 数量上限, 仅币本位
+	Symbol           string  `json:"symbol"`                  // 交易对
+}
+
+// ChangePositionModeService change UM or CM position mode | 更改UM/CM持仓模式
+type ChangePositionModeService struct {
+	c        *Client
+	product  string
+	dualSide bool
+}
+
+// SetDualSide set dual side, true for hedge mode | true 为双向持仓模式, false 为单向持仓模式
+func (s *ChangePositionModeService) SetDualSide(dualSide bool) *ChangePositionModeService {
+	s.dualSide = dualSide
+	return s
+}
+
+// Do send request
+func (s *ChangePositionModeService) Do(ctx context.Context, opts ...RequestOption) (err error) {
+	// POST /papi/v1/{um,cm}/positionSide/dual | 更改UM/CM持仓模式
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: fmt.Sprintf("/papi/v1/%s/positionSide/dual", s.product),
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("dualSidePosition", s.dualSide)
+	_, err = s.c.callAPI(ctx, r, opts...)
+	return err
+}
+
+// GetPositionModeService get UM or CM position mode | 查询UM/CM持仓模式
+type GetPositionModeService struct {
+	c       *Client
+	product string
+}
+
+// Do send request
+func (s *GetPositionModeService) Do(ctx context.Context, opts ...RequestOption) (res *PositionMode, err error) {
+	// GET /papi/v1/{um,cm}/positionSide/dual | 查询UM/CM持仓模式
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: fmt.Sprintf("/papi/v1/%s/positionSide/dual", s.product),
+		secType:  secTypeSigned,
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(PositionMode)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// PositionMode define position mode | 持仓模式
+type PositionMode struct {
+	DualSidePosition bool `json:"dualSidePosition"` // true 为双向持仓模式, false 为单向持仓模式
+}
@@ -0,0 +1,66 @@
+package portfolio
+
+import (
+	"context"
+	"net/http"
+)
+
+// StartUserStreamService create listen key for user stream service | 生成listenKey
+type StartUserStreamService struct {
+	c *Client
+}
+
+// Do send request
+func (s *StartUserStreamService) Do(ctx context.Context, opts ...RequestOption) (listenKey string, err error) {
+	// POST /papi/v1/listenKey | 生成listenKey, 如果该帐户具有有效的listenKey, 则将返回该listenKey并将其有效期延长60分钟
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/papi/v1/listenKey",
+		secType:  secTypeAPIKey,
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return "", err
+	}
+	var res ListenKey
+	err = json.Unmarshal(data, &res)
+	return res.ListenKey, err
+}
+
+type ListenKey struct {
+	ListenKey string `json:"listenKey"`
+}
+
+// KeepaliveUserStreamService update listen key. The account has a single listen key, so no parameter is needed | 延长listenKey有效期
+type KeepaliveUserStreamService struct {
+	c *Client
+}
+
+// Do send request
+func (s *KeepaliveUserStreamService) Do(ctx context.Context, opts ...RequestOption) (err error) {
+	// PUT /papi/v1/listenKey | 有效期延长至本次调用后60分钟
+	r := &request{
+		method:   http.MethodPut,
+		endpoint: "/papi/v1/listenKey",
+		secType:  secTypeAPIKey,
+	}
+	_, err = s.c.callAPI(ctx, r, opts...)
+	return err
+}
+
+// CloseUserStreamService delete listen key | 关闭账户数据流
+type CloseUserStreamService struct {
+	c *Client
+}
+
+// Do send request
+func (s *CloseUserStreamService) Do(ctx context.Context, opts ...RequestOption) (err error) {
+	// DELETE /papi/v1/listenKey | 关闭账户数据流
+	r := &request{
+		method:   http.MethodDelete,
+		endpoint: "/papi/v1/listenKey",
+		secType:  secTypeAPIKey,
+	}
+	_, err = s.c.callAPI(ctx, r, opts...)
+	return err
+}
@@ -0,0 +1,282 @@
+package portfolio
+
+import (
+	"fmt"
+	"github.com/BobHye/wsc"
+)
+
+// Endpoints
+const (
+	baseWsMainUrl = "wss://fstream.binance.com/pm/ws"
+)
+
+// getWsEndpoint return the base endpoint of the WS
+func getWsEndpoint() string {
+	return baseWsMainUrl
+}
+
+// WsUserDataEvent define user data event. Only the struct matching Event is filled | 统一账户信息推送, 仅与事件类型对应的字段有值
+type WsUserDataEvent struct {
+	Event           UserDataEventType  `json:"e"`  // 事件类型
+	Time            int64              `json:"E"`  // 事件时间
+	TransactionTime int64              `json:"T"`  // 撮合时间
+	BusinessUnit    FuturesProductType `json:"fs"` // 业务类型, 仅合约事件 [UM/CM]
+
+	AccountUpdate               WsAccountUpdate               // ACCOUNT_UPDATE
+	OrderTradeUpdate            WsOrderTradeUpdate            // ORDER_TRADE_UPDATE
+	ConditionalOrderTradeUpdate WsConditionalOrderTradeUpdate // CONDITIONAL_ORDER_TRADE_UPDATE
+	AccountConfigUpdate         WsAccountConfigUpdate         // ACCOUNT_CONFIG_UPDATE
+	MarginOrderUpdate           WsMarginOrderUpdate           // executionReport
+	MarginAccountUpdate         WsMarginAccountUpdate         // outboundAccountPosition
+	MarginBalanceUpdate         WsMarginBalanceUpdate         // balanceUpdate
+	LiabilityChange             WsLiabilityChange             // liabilityChange
+	OpenOrderLoss               WsOpenOrderLoss               // openOrderLoss
+	RiskLevelChange             WsRiskLevelChange             // riskLevelChange
+}
+
+// UnmarshalJSON decode the event fields into the struct matching its type
+func (e *WsUserDataEvent) UnmarshalJSON(data []byte) error {
+	var head struct {
+		Event           UserDataEventType  `json:"e"`
+		Time            int64              `json:"E"`
+		TransactionTime int64              `json:"T"`
+		BusinessUnit    FuturesProductType `json:"fs"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return err
+	}
+	e.Event = head.Event
+	e.Time = head.Time
+	e.TransactionTime = head.TransactionTime
+	e.BusinessUnit = head.BusinessUnit
+	switch e.Event {
+	case UserDataEventTypeAccountUpdate:
+		var body struct {
+			AccountUpdate *WsAccountUpdate `json:"a"`
+		}
+		body.AccountUpdate = &e.AccountUpdate
+		return json.Unmarshal(data, &body)
+	case UserDataEventTypeOrderTradeUpdate:
+		var body struct {
+			OrderTradeUpdate *WsOrderTradeUpdate `json:"o"`
+		}
+		body.OrderTradeUpdate = &e.OrderTradeUpdate
+		return json.Unmarshal(data, &body)
+	case UserDataEventTypeConditionalOrderTradeUpdate:
+		var body struct {
+			ConditionalOrderTradeUpdate *WsConditionalOrderTradeUpdate `json:"so"`
+		}
+		body.ConditionalOrderTradeUpdate = &e.ConditionalOrderTradeUpdate
+		return json.Unmarshal(data, &body)
+	case UserDataEventTypeAccountConfigUpdate:
+		var body struct {
+			AccountConfigUpdate *WsAccountConfigUpdate `json:"ac"`
+		}
+		body.AccountConfigUpdate = &e.AccountConfigUpdate
+		return json.Unmarshal(data, &body)
+	case UserDataEventTypeExecutionReport:
+		return json.Unmarshal(data, &e.MarginOrderUpdate)
+	case UserDataEventTypeOutboundAccountPosition:
+		return json.Unmarshal(data, &e.MarginAccountUpdate)
+	case UserDataEventTypeBalanceUpdate:
+		return json.Unmarshal(data, &e.MarginBalanceUpdate)
+	case UserDataEventTypeLiabilityChange:
+		return json.Unmarshal(data, &e.LiabilityChange)
+	case UserDataEventTypeOpenOrderLoss:
+		return json.Unmarshal(data, &e.OpenOrderLoss)
+	case UserDataEventTypeRiskLevelChange:
+		return json.Unmarshal(data, &e.RiskLevelChange)
+	}
+	return nil
+}
+
+// WsAccountUpdate define futures ACCOUNT_UPDATE event | 合约 Balance 和 Position 更新
+type WsAccountUpdate struct {
+	Reason    UserDataEventReasonType `json:"m"` // 事件推出原因
+	Balances  []WsBalance             `json:"B"` // 余额信息
+	Positions []WsPosition            `json:"P"` // 持仓信息
+}
+
+// WsBalance define futures balance
+type WsBalance struct {
+	Asset              string  `json:"a"`         // 资产名称
+	Balance            float64 `json:"wb,string"` // 钱包余额
+	CrossWalletBalance float64 `json:"cw,string"` // 除去逐仓仓位保证金的钱包余额
+	ChangeBalance      float64 `json:"bc,string"` // 除去盈亏与交易手续费以外的钱包余额改变量
+}
+
+// WsPosition define futures position
+type WsPosition struct {
+	Symbol              string           `json:"s"`          // 交易对
+	Side                PositionSideType `json:"ps"`         // 持仓方向
+	Amount              float64          `json:"pa,string"`  // 仓位
+	EntryPrice          float64          `json:"ep,string"`  // 入仓价格
+	AccumulatedRealized float64          `json:"cr,string"`  // (费前)累计实现损益
+	UnrealizedPnL       float64          `json:"up,string"`  // 持仓未实现盈亏
+	BreakEvenPrice      float64          `json:"bep,string"` // 盈亏平衡价
+}
+
+// WsOrderTradeUpdate define futures ORDER_TRADE_UPDATE event | 合约订单/交易更新
+type WsOrderTradeUpdate struct {
+	Symbol               string             `json:"s"`         // 交易对
+	ClientOrderID        string             `json:"c"`         // 客户端自定订单ID
+	Side                 SideType           `json:"S"`         // 订单方向
+	Type                 OrderType          `json:"o"`         // 订单类型
+	TimeInForce          TimeInForceType    `json:"f"`         // 有效方式
+	OriginalQty          float64            `json:"q,string"`  // 订单原始数量
+	OriginalPrice        float64            `json:"p,string"`  // 订单原始价格
+	AveragePrice         float64            `json:"ap,string"` // 订单平均价格
+	StopPrice            float64            `json:"sp,string"` // 条件订单触发价格
+	ExecutionType        OrderExecutionType `json:"x"`         // 本次事件的具体执行类型
+	Status               OrderStatusType    `json:"X"`         // 订单的当前状态
+	ID                   int64              `json:"i"`         // 订单ID
+	LastFilledQty        float64            `json:"l,string"`  // 订单末次成交量
+	AccumulatedFilledQty float64            `json:"z,string"`  // 订单累计已成交量
+	LastFilledPrice      float64            `json:"L,string"`  // 订单末次成交价格
+	CommissionAsset      string             `json:"N"`         // 手续费资产类型
+	Commission           float64            `json:"n,string"`  // 手续费数量
+	TradeTime            int64              `json:"T"`         // 成交时间
+	TradeID              int64              `json:"t"`         // 成交ID
+	BidsNotional         float64            `json:"b,string"`  // 买单净值
+	AsksNotional         float64            `json:"a,string"`  // 卖单净值
+	IsMaker              bool               `json:"m"`         // 该成交是作为挂单成交吗
+	IsReduceOnly         bool               `json:"R"`         // 是否是只减仓单
+	PositionSide         PositionSideType   `json:"ps"`        // 持仓方向
+	RealizedPnL          float64            `json:"rp,string"` // 该交易实现盈亏
+	StrategyType         StrategyType       `json:"st"`        // 由条件单触发时的条件单类型
+	StrategyID           int64              `json:"si"`        // 由条件单触发时的条件单ID
+}
+
+// WsConditionalOrderTradeUpdate define futures CONDITIONAL_ORDER_TRADE_UPDATE event | 合约条件单更新
+type WsConditionalOrderTradeUpdate struct {
+	Symbol                  string             `json:"s"`         // 交易对
+	ClientStrategyID        string             `json:"c"`         // 客户端自定条件单ID
+	StrategyID              int64              `json:"si"`        // 条件单ID
+	Side                    SideType           `json:"S"`         // 订单方向
+	StrategyType            StrategyType       `json:"st"`        // 条件单类型
+	TimeInForce             TimeInForceType    `json:"f"`         // 有效方式
+	OriginalQty             float64            `json:"q,string"`  // 订单原始数量
+	OriginalPrice           float64            `json:"p,string"`  // 订单原始价格
+	StopPrice               float64            `json:"sp,string"` // 触发价格
+	StrategyStatus          StrategyStatusType `json:"os"`        // 条件单当前状态
+	BookTime                int64              `json:"T"`         // 下单时间
+	UpdateTime              int64              `json:"ut"`        // 更新时间
+	IsReduceOnly            bool               `json:"R"`         // 是否是只减仓单
+	WorkingType             WorkingType        `json:"wt"`        // 触发价类型
+	PositionSide            PositionSideType   `json:"ps"`        // 持仓方向
+	IsClosingPosition       bool               `json:"cp"`        // 是否为触发平仓单
+	ActivationPrice         float64            `json:"AP,string"` // 追踪止损激活价格
+	CallbackRate            float64            `json:"cr,string"` // 追踪止损回调比例
+	OrderID                 int64              `json:"i"`         // 触发后生成的订单ID
+	SelfTradePreventionMode string             `json:"V"`         // 自成交保护模式
+	GoodTillDate            int64              `json:"gtd"`       // TIF为GTD时的自动取消时间
+}
+
+// WsAccountConfigUpdate define futures ACCOUNT_CONFIG_UPDATE event | 合约杠杆倍数更新
+type WsAccountConfigUpdate struct {
+	Symbol   string `json:"s"` // 交易对
+	Leverage int    `json:"l"` // 杠杆倍数
+}
+
+// WsMarginOrderUpdate define margin executionReport event | 杠杆订单更新
+type WsMarginOrderUpdate struct {
+	Symbol            string             `json:"s"`        // 交易对
+	ClientOrderID     string             `json:"c"`        // 客户自定义订单ID
+	Side              SideType           `json:"S"`        // 订单方向
+	Type              OrderType          `json:"o"`        // 订单类型
+	TimeInForce       TimeInForceType    `json:"f"`        // 有效方式
+	Quantity          float64            `json:"q,string"` // 订单原始数量
+	Price             float64            `json:"p,string"` // 订单原始价格
+	StopPrice         float64            `json:"P,string"` // 止盈止损单触发价格
+	IcebergQuantity   float64            `json:"F,string"` // 冰山订单数量
+	OrderListID       int64              `json:"g"`        // 订单列表ID
+	OrigClientOrderID string             `json:"C"`        // 原始订单自定义ID(撤单时)
+	ExecutionType     OrderExecutionType `json:"x"`        // 本次事件的具体执行类型
+	Status            OrderStatusType    `json:"X"`        // 订单的当前状态
+	RejectReason      string             `json:"r"`        // 订单被拒绝的原因
+	ID                int64              `json:"i"`        // 订单ID
+	LastFilledQty     float64            `json:"l,string"` // 订单末次成交量
+	FilledQty         float64            `json:"z,string"` // 订单累计已成交量
+	LastFilledPrice   float64            `json:"L,string"` // 订单末次成交价格
+	Commission        float64            `json:"n,string"` // 手续费数量
+	CommissionAsset   string             `json:"N"`        // 手续费资产类别
+	TradeTime         int64              `json:"T"`        // 成交时间
+	TradeID           int64              `json:"t"`        // 成交ID
+	IsInBook          bool               `json:"w"`        // 订单是否在订单簿上
+	IsMaker           bool               `json:"m"`        // 该成交是作为挂单成交吗
+	CreateTime        int64              `json:"O"`        // 订单创建时间
+	FilledQuoteQty    float64            `json:"Z,string"` // 订单累计已成交金额
+	LastQuoteQty      float64            `json:"Y,string"` // 订单末次成交金额
+	QuoteOrderQty     float64            `json:"Q,string"` // 报价订单数量
+}
+
+// WsMarginAccountUpdate define margin outboundAccountPosition event | 杠杆账户余额更新
+type WsMarginAccountUpdate struct {
+	LastUpdateTime int64             `json:"u"` // 账户末次更新时间
+	UpdateID       int64             `json:"U"` // 更新ID
+	Balances       []WsMarginBalance `json:"B"` // 余额
+}
+
+// WsMarginBalance define margin balance of one asset
+type WsMarginBalance struct {
+	Asset  string  `json:"a"`        // 资产名称
+	Free   float64 `json:"f,string"` // 可用余额
+	Locked float64 `json:"l,string"` // 冻结余额
+}
+
+// WsMarginBalanceUpdate define margin balanceUpdate event | 杠杆账户划转
+type WsMarginBalanceUpdate struct {
+	Asset     string  `json:"a"`        // 资产名称
+	Change    float64 `json:"d,string"` // 余额变化量
+	UpdateID  int64   `json:"U"`        // 更新ID
+	ClearTime int64   `json:"T"`        // 清算时间
+}
+
+// WsLiabilityChange define margin liabilityChange event | 杠杆借贷变化
+type WsLiabilityChange struct {
+	Asset          string  `json:"a"`        // 资产名称
+	Type           string  `json:"t"`        // 类型 [BORROW]
+	TranID         int64   `json:"T"`        // 交易ID
+	Principal      float64 `json:"p,string"` // 本金
+	Interest       float64 `json:"i,string"` // 利息
+	TotalLiability float64 `json:"l,string"` // 总负债
+}
+
+// WsOpenOrderLoss define margin openOrderLoss event | 杠杆挂单预扣损失
+type WsOpenOrderLoss struct {
+	Losses []WsOrderLoss `json:"O"`
+}
+
+// WsOrderLoss define open order loss of one asset
+type WsOrderLoss struct {
+	Asset  string  `json:"a"`        // 资产名称
+	Amount float64 `json:"o,string"` // 预扣损失, 为负数
+}
+
+// WsRiskLevelChange define riskLevelChange event | 账户风险等级变化
+type WsRiskLevelChange struct {
+	UniMMR        float64 `json:"u,string"`  // 统一账户维持保证金率
+	Status        string  `json:"s"`         // 风险等级 [MARGIN_CALL/SUPPLY_MARGIN/REDUCE_ONLY/FORCE_LIQUIDATION]
+	AccountEquity float64 `json:"eq,string"` // 以USD计价的账户权益
+	ActualEquity  float64 `json:"ae,string"` // 不考虑质押率的以USD计价账户权益
+	MaintMargin   float64 `json:"m,string"`  // 以USD计价统一账户维持保证金
+}
+
+// WsUserDataHandler handle WsUserDataEvent
+type WsUserDataHandler func(event *WsUserDataEvent)
+
+// WsUserDataServe serve user data handler with listen key | 订阅统一账户信息推送
+func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (ws *wsc.Wsc, done chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s", getWsEndpoint(), listenKey)
+	cfg := newWsConfig(endpoint)
+	wsHandler := func(message []byte) {
+		event := new(WsUserDataEvent)
+		err := json.Unmarshal(message, event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
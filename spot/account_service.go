@@ -51,3 +51,61 @@ type Balance struct {
 	Free   float64 `json:"free,string"`
 	Locked float64 `json:"locked,string"`
 }
+
+// GetCommissionService get the commission rates of a symbol for the account
+type GetCommissionService struct {
+	c      *Client
+	symbol string
+}
+
+// SetSymbol set symbol
+func (s *GetCommissionService) SetSymbol(symbol string) *GetCommissionService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *GetCommissionService) Do(ctx context.Context, opts ...RequestOption) (res *AccountCommission, err error) {
+	// GET /api/v3/account/commission | 查询账户手续费率
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/api/v3/account/commission",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(AccountCommission)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AccountCommission define the commission rates of a symbol
+type AccountCommission struct {
+	Symbol             string             `json:"symbol"`
+	StandardCommission CommissionRate     `json:"standardCommission"` // 标准手续费率
+	SpecialCommission  CommissionRate     `json:"specialCommission"`  // 特殊手续费率
+	TaxCommission      CommissionRate     `json:"taxCommission"`      // 税费率
+	Discount           CommissionDiscount `json:"discount"`           // 使用 BNB 支付手续费的折扣
+}
+
+// CommissionRate define commission rates, buyer and seller are only set for the account rates
+type CommissionRate struct {
+	Maker  string `json:"maker"`
+	Taker  string `json:"taker"`
+	Buyer  string `json:"buyer"`
+	Seller string `json:"seller"`
+}
+
+// CommissionDiscount define the discount when paying commission with discountAsset
+type CommissionDiscount struct {
+	EnabledForAccount bool   `json:"enabledForAccount"` // 账户是否开启了折扣
+	EnabledForSymbol  bool   `json:"enabledForSymbol"`  // 交易对是否支持折扣
+	DiscountAsset     string `json:"discountAsset"`     // 抵扣手续费的资产, 如 BNB
+	Discount          string `json:"discount"`          // 折扣比例
+}
@@ -0,0 +1,363 @@
+package spot
+
+import (
+	"context"
+	"github.com/BobHye/binance-go/common"
+	"strconv"
+)
+
+// Adapter implement common.Exchange over a spot client. Spot has no positions | 现货的统一接口实现
+type Adapter struct {
+	c *Client
+}
+
+var _ common.Exchange = (*Adapter)(nil)
+
+// NewAdapter create an adapter over client | 创建统一接口适配器
+func NewAdapter(c *Client) *Adapter {
+	return &Adapter{c: c}
+}
+
+// Klines return the latest klines of symbol | 查询K线
+func (a *Adapter) Klines(ctx context.Context, symbol string, interval string, limit int) ([]*common.Kline, error) {
+	klines, err := a.c.NewKlinesService().SetSymbol(symbol).SetInterval(interval).SetLimit(limit).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*common.Kline, 0, len(klines))
+	for _, k := range klines {
+		res = append(res, ToCommonKline(k))
+	}
+	return res, nil
+}
+
+// BookTicker return the best bid and ask of symbol | 查询最优挂单
+func (a *Adapter) BookTicker(ctx context.Context, symbol string) (*common.BookTicker, error) {
+	tickers, err := a.c.NewListBookTickersService().SetSymbol(symbol).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(tickers) == 0 {
+		return nil, &common.APIError{Code: -1121, Message: "Invalid symbol."}
+	}
+	return ToCommonBookTicker(tickers[0]), nil
+}
+
+// CreateOrder place a new order. PositionSide and ReduceOnly are ignored | 下单
+func (a *Adapter) CreateOrder(ctx context.Context, req *common.OrderRequest) (*common.Order, error) {
+	orderType, err := FromCommonOrderType(req.Type)
+	if err != nil {
+		return nil, err
+	}
+	s := a.c.NewCreateOrderService().
+		SetSymbol(req.Symbol).
+		SetSide(SideType(req.Side)).
+		SetType(orderType).
+		SetQuantity(formatFloat(req.Quantity)).
+		SetNewOrderRespType(NewOrderRespTypeRESULT)
+	switch orderType {
+	case OrderTypeLimit, OrderTypeStopLossLimit, OrderTypeTakeProfitLimit:
+		tif := TimeInForceType(req.TimeInForce)
+		if tif == "" {
+			tif = TimeInForceTypeGTC
+		}
+		s.SetTimeInForce(tif)
+	}
+	if req.Price > 0 {
+		s.SetPrice(formatFloat(req.Price))
+	}
+	if req.StopPrice > 0 {
+		s.SetStopPrice(formatFloat(req.StopPrice))
+	}
+	if req.ClientOrderID != "" {
+		s.SetNewClientOrderID(req.ClientOrderID)
+	}
+	res, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ToCommonOrder(&Order{
+		Symbol:                   res.Symbol,
+		OrderID:                  res.OrderID,
+		ClientOrderID:            res.ClientOrderID,
+		Price:                    res.Price,
+		OrigQuantity:             res.OrigQuantity,
+		ExecutedQuantity:         res.ExecutedQuantity,
+		CummulativeQuoteQuantity: res.CummulativeQuoteQuantity,
+		Status:                   res.Status,
+		TimeInForce:              res.TimeInForce,
+		Type:                     res.Type,
+		Side:                     res.Side,
+		StopPrice:                formatFloat(req.StopPrice),
+		Time:                     res.TransactTime,
+		UpdateTime:               res.TransactTime,
+	}), nil
+}
+
+// CancelOrder cancel an open order | 撤单
+func (a *Adapter) CancelOrder(ctx context.Context, symbol string, orderID int64, clientOrderID string) (*common.Order, error) {
+	s := a.c.NewCancelOrderService().SetSymbol(symbol)
+	if orderID != 0 {
+		s.SetOrderID(orderID)
+	} else {
+		s.SetOrigClientOrderID(clientOrderID)
+	}
+	res, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ToCommonOrder(&Order{
+		Symbol:                   res.Symbol,
+		OrderID:                  res.OrderID,
+		ClientOrderID:            res.OrigClientOrderID,
+		Price:                    res.Price,
+		OrigQuantity:             res.OrigQuantity,
+		ExecutedQuantity:         res.ExecutedQuantity,
+		CummulativeQuoteQuantity: res.CummulativeQuoteQuantity,
+		Status:                   res.Status,
+		TimeInForce:              res.TimeInForce,
+		Type:                     res.Type,
+		Side:                     res.Side,
+		UpdateTime:               res.TransactTime,
+	}), nil
+}
+
+// GetOrder query an order | 查询订单
+func (a *Adapter) GetOrder(ctx context.Context, symbol string, orderID int64, clientOrderID string) (*common.Order, error) {
+	s := a.c.NewGetOrderService().SetSymbol(symbol)
+	if orderID != 0 {
+		s.SetOrderID(orderID)
+	} else {
+		s.SetOrigClientOrderID(clientOrderID)
+	}
+	o, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ToCommonOrder(o), nil
+}
+
+// ListOpenOrders query open orders | 查询当前挂单
+func (a *Adapter) ListOpenOrders(ctx context.Context, symbol string) ([]*common.Order, error) {
+	s := a.c.NewListOpenOrdersService()
+	if symbol != "" {
+		s.SetSymbol(symbol)
+	}
+	orders, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*common.Order, 0, len(orders))
+	for _, o := range orders {
+		res = append(res, ToCommonOrder(o))
+	}
+	return res, nil
+}
+
+// ListFills query trades of symbol | 查询成交历史
+func (a *Adapter) ListFills(ctx context.Context, symbol string, startTime, endTime int64) ([]*common.Fill, error) {
+	s := a.c.NewListTradesService().Symbol(symbol)
+	if startTime > 0 {
+		s.SetStartTime(startTime)
+	}
+	if endTime > 0 {
+		s.SetEndTime(endTime)
+	}
+	trades, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*common.Fill, 0, len(trades))
+	for _, t := range trades {
+		res = append(res, ToCommonFill(t))
+	}
+	return res, nil
+}
+
+// Balances return non-zero balances | 查询资产余额
+func (a *Adapter) Balances(ctx context.Context) ([]*common.Balance, error) {
+	account, err := a.c.NewGetAccountService().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*common.Balance, 0)
+	for _, b := range account.Balances {
+		if b.Free != 0 || b.Locked != 0 {
+			res = append(res, ToCommonBalance(b))
+		}
+	}
+	return res, nil
+}
+
+// Positions return no positions | 现货没有持仓
+func (a *Adapter) Positions(ctx context.Context) ([]*common.Position, error) {
+	return []*common.Position{}, nil
+}
+
+// FromCommonOrderType convert the normalized order type to the spot one | 统一订单类型转为现货订单类型
+func FromCommonOrderType(t common.OrderType) (OrderType, error) {
+	switch t {
+	case common.OrderTypeLimit:
+		return OrderTypeLimit, nil
+	case common.OrderTypeMarket:
+		return OrderTypeMarket, nil
+	case common.OrderTypeLimitMaker:
+		return OrderTypeLimitMaker, nil
+	case common.OrderTypeStop:
+		return OrderTypeStopLossLimit, nil
+	case common.OrderTypeStopMarket:
+		return OrderTypeStopLoss, nil
+	case common.OrderTypeTakeProfit:
+		return OrderTypeTakeProfitLimit, nil
+	case common.OrderTypeTakeProfitMarket:
+		return OrderTypeTakeProfit, nil
+	}
+	return "", common.ErrNotSupported
+}
+
+// ToCommonOrderType convert the spot order type to the normalized one | 现货订单类型转为统一订单类型
+func ToCommonOrderType(t OrderType) common.OrderType {
+	switch t {
+	case OrderTypeStopLossLimit:
+		return common.OrderTypeStop
+	case OrderTypeStopLoss:
+		return common.OrderTypeStopMarket
+	case OrderTypeTakeProfitLimit:
+		return common.OrderTypeTakeProfit
+	case OrderTypeTakeProfit:
+		return common.OrderTypeTakeProfitMarket
+	}
+	return common.OrderType(t)
+}
+
+// ToCommonKline convert kline to the normalized model | 转换K线
+func ToCommonKline(k *Kline) *common.Kline {
+	return &common.Kline{
+		OpenTime:    k.OpenTime,
+		Open:        parseFloat(k.Open),
+		High:        parseFloat(k.High),
+		Low:         parseFloat(k.Low),
+		Close:       parseFloat(k.Close),
+		Volume:      parseFloat(k.Volume),
+		CloseTime:   k.CloseTime,
+		QuoteVolume: parseFloat(k.QuoteAssetVolume),
+		TradeNum:    k.TradeNum,
+	}
+}
+
+// ToCommonBookTicker convert book ticker to the normalized model | 转换最优挂单
+func ToCommonBookTicker(t *BookTicker) *common.BookTicker {
+	return &common.BookTicker{
+		Symbol:      t.Symbol,
+		BidPrice:    parseFloat(t.BidPrice),
+		BidQuantity: parseFloat(t.BidQuantity),
+		AskPrice:    parseFloat(t.AskPrice),
+		AskQuantity: parseFloat(t.AskQuantity),
+	}
+}
+
+// ToCommonOrder convert order to the normalized model | 转换订单
+func ToCommonOrder(o *Order) *common.Order {
+	res := &common.Order{
+		Symbol:           o.Symbol,
+		OrderID:          o.OrderID,
+		ClientOrderID:    o.ClientOrderID,
+		Side:             common.Side(o.Side),
+		Type:             ToCommonOrderType(o.Type),
+		TimeInForce:      common.TimeInForce(o.TimeInForce),
+		Status:           common.OrderStatus(o.Status),
+		Price:            parseFloat(o.Price),
+		StopPrice:        parseFloat(o.StopPrice),
+		Quantity:         parseFloat(o.OrigQuantity),
+		ExecutedQuantity: parseFloat(o.ExecutedQuantity),
+		QuoteQuantity:    parseFloat(o.CummulativeQuoteQuantity),
+		Time:             o.Time,
+		UpdateTime:       o.UpdateTime,
+	}
+	if res.ExecutedQuantity > 0 {
+		res.AvgPrice = res.QuoteQuantity / res.ExecutedQuantity
+	}
+	return res
+}
+
+// ToCommonFill convert trade to the normalized model | 转换成交
+func ToCommonFill(t *TradeV3) *common.Fill {
+	side := common.SideSell
+	if t.IsBuyer {
+		side = common.SideBuy
+	}
+	return &common.Fill{
+		Symbol:          t.Symbol,
+		TradeID:         t.ID,
+		OrderID:         t.OrderID,
+		Side:            side,
+		Price:           parseFloat(t.Price),
+		Quantity:        parseFloat(t.Quantity),
+		QuoteQuantity:   parseFloat(t.QuoteQuantity),
+		Commission:      parseFloat(t.Commission),
+		CommissionAsset: t.CommissionAsset,
+		Maker:           t.IsMaker,
+		Time:            t.Time,
+	}
+}
+
+// ToCommonOrderUpdate convert executionReport to the normalized model | 转换订单推送
+func ToCommonOrderUpdate(e *WsOrderUpdate) *common.OrderUpdate {
+	// 撤单推送中 c 为撤单请求的ID, 原始的客户自定义订单ID在 C 中
+	clientOrderID := e.ClientOrderID
+	if e.OrigClientOrderID != "" {
+		clientOrderID = e.OrigClientOrderID
+	}
+	order := &common.Order{
+		Symbol:           e.Symbol,
+		OrderID:          e.ID,
+		ClientOrderID:    clientOrderID,
+		Side:             common.Side(e.Side),
+		Type:             ToCommonOrderType(e.Type),
+		TimeInForce:      common.TimeInForce(e.TimeInForce),
+		Status:           common.OrderStatus(e.Status),
+		Price:            parseFloat(e.Price),
+		StopPrice:        parseFloat(e.StopPrice),
+		Quantity:         parseFloat(e.Volume),
+		ExecutedQuantity: parseFloat(e.FilledVolume),
+		QuoteQuantity:    parseFloat(e.FilledQuoteVolume),
+		Time:             e.CreateTime,
+		UpdateTime:       e.TransactionTime,
+	}
+	if order.ExecutedQuantity > 0 {
+		order.AvgPrice = order.QuoteQuantity / order.ExecutedQuantity
+	}
+	res := &common.OrderUpdate{Order: order, Execution: string(e.ExecutionType)}
+	if e.ExecutionType == OrderExecutionTypeTrade {
+		res.Fill = &common.Fill{
+			Symbol:          e.Symbol,
+			TradeID:         e.TradeID,
+			OrderID:         e.ID,
+			Side:            common.Side(e.Side),
+			Price:           parseFloat(e.LatestPrice),
+			Quantity:        parseFloat(e.LatestVolume),
+			QuoteQuantity:   parseFloat(e.LatestQuoteVolume),
+			Commission:      parseFloat(e.FeeCost),
+			CommissionAsset: e.FeeAsset,
+			Maker:           e.IsMaker,
+			Time:            e.TransactionTime,
+		}
+	}
+	return res
+}
+
+// ToCommonBalance convert balance to the normalized model | 转换资产余额
+func ToCommonBalance(b Balance) *common.Balance {
+	return &common.Balance{
+		Asset:  b.Asset,
+		Total:  b.Free + b.Locked,
+		Free:   b.Free,
+		Locked: b.Locked,
+	}
+}
+
+// parseFloat 解析数值字符串, 空字符串或非法值返回 0
+func parseFloat(s string) float64 {
+	f, _ := strconv.ParseFloat(s, 64)
+	return f
+}
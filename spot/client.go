@@ -35,6 +35,21 @@ type NewOrderRespType string
 // OrderStatusType define order status type
 type OrderStatusType string
 
+// SelfTradePreventionModeType define self-trade prevention mode | 自成交保护模式
+type SelfTradePreventionModeType string
+
+// CancelReplaceModeType define what happens to the new order when the cancel fails | 撤消挂单再下单时撤单失败的处理方式
+type CancelReplaceModeType string
+
+// CancelRestrictionsType define the order status required for a cancel to succeed | 撤单的订单状态限制
+type CancelRestrictionsType string
+
+// CancelReplaceResultType define the result of each step of a cancel-replace | 撤消挂单再下单各步骤的结果
+type CancelReplaceResultType string
+
+// OrderExecutionType define order execution type of executionReport
+type OrderExecutionType string
+
 // SymbolType define symbol type
 type SymbolType string
 
@@ -122,7 +137,7 @@ const (
 	OrderTypeTakeProfitLimit OrderType = "TAKE_PROFIT_LIMIT"
 
 	TimeInForceTypeGTC TimeInForceType = "GTC"
-	TimeInForceTypeIOC TimeInForceType = "GTC"
+	TimeInForceTypeIOC TimeInForceType = "IOC"
 	TimeInForceTypeFOK TimeInForceType = "FOK"
 
 	NewOrderRespTypeACK    NewOrderRespType = "ACK"
@@ -137,6 +152,31 @@ const (
 	OrderStatusTypeRejected        OrderStatusType = "REJECTED"
 	OrderStatusTypeExpired         OrderStatusType = "EXPIRED"
 
+	CancelReplaceModeTypeStopOnFailure CancelReplaceModeType = "STOP_ON_FAILURE" // 撤单失败时不下新单
+	CancelReplaceModeTypeAllowFailure  CancelReplaceModeType = "ALLOW_FAILURE"   // 无论撤单是否成功都下新单
+
+	CancelRestrictionsTypeOnlyNew             CancelRestrictionsType = "ONLY_NEW"              // 仅在订单状态为 NEW 时撤单
+	CancelRestrictionsTypeOnlyPartiallyFilled CancelRestrictionsType = "ONLY_PARTIALLY_FILLED" // 仅在订单状态为 PARTIALLY_FILLED 时撤单
+
+	CancelReplaceResultTypeSuccess      CancelReplaceResultType = "SUCCESS"
+	CancelReplaceResultTypeFailure      CancelReplaceResultType = "FAILURE"
+	CancelReplaceResultTypeNotAttempted CancelReplaceResultType = "NOT_ATTEMPTED"
+
+	OrderExecutionTypeNew      OrderExecutionType = "NEW"
+	OrderExecutionTypeCanceled OrderExecutionType = "CANCELED"
+	OrderExecutionTypeReplaced OrderExecutionType = "REPLACED"
+	OrderExecutionTypeRejected OrderExecutionType = "REJECTED"
+	OrderExecutionTypeTrade    OrderExecutionType = "TRADE"
+	OrderExecutionTypeExpired  OrderExecutionType = "EXPIRED"
+
+	OrderExecutionTypeTradePrevention OrderExecutionType = "TRADE_PREVENTION" // 订单因自成交保护过期
+
+	SelfTradePreventionModeTypeNone        SelfTradePreventionModeType = "NONE"         // 不防止自成交
+	SelfTradePreventionModeTypeExpireTaker SelfTradePreventionModeType = "EXPIRE_TAKER" // 过期吃单方订单
+	SelfTradePreventionModeTypeExpireMaker SelfTradePreventionModeType = "EXPIRE_MAKER" // 过期挂单方订单
+	SelfTradePreventionModeTypeExpireBoth  SelfTradePreventionModeType = "EXPIRE_BOTH"  // 过期双方订单
+	SelfTradePreventionModeTypeDecrement   SelfTradePreventionModeType = "DECREMENT"    // 双方订单减去可能成交的数量
+
 	SymbolTypeSpot SymbolType = "SPOT"
 
 	SymbolStatusTypePreTrading   SymbolStatusType = "PRE_TRADING"
@@ -250,6 +290,7 @@ type Client struct {
 	Debug      bool
 	Logger     *log.Logger
 	TimeOffset int64
+	OrderIDs   *common.ClientOrderIDGenerator // 下单未指定 newClientOrderId 时用于生成, 为空则由交易所分配
 	do         doFunc
 }
 
@@ -352,15 +393,15 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 			return err
 		}
 		v := url.Values{}
-		v.Set(signatureKey, fmt.Sprintf("x", (mac.Sum(nil))))
+		v.Set(signatureKey, fmt.Sprintf("%x", (mac.Sum(nil))))
 		if queryString == "" {
 			queryString = v.Encode()
 		} else {
-			queryString = fmt.Sprintf("%s%s", queryString, v.Encode())
+			queryString = fmt.Sprintf("%s&%s", queryString, v.Encode())
 		}
 	}
 	if queryString != "" {
-		fullURL = fmt.Sprintf("%s%s", fullURL, queryString)
+		fullURL = fmt.Sprintf("%s?%s", fullURL, queryString)
 	}
 	c.debug("full url: %s, body: %s", fullURL, bodyString)
 
@@ -371,26 +412,45 @@ func (c *Client) parseRequest(r *request, opts ...RequestOption) (err error) {
 }
 
 func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption) (data []byte, err error) {
+	data, statusCode, err := c.doAPI(ctx, r, opts...)
+	if err != nil {
+		return []byte{}, err
+	}
+	if statusCode >= http.StatusBadRequest {
+		apiErr := new(common.APIError)
+		e := json.Unmarshal(data, apiErr)
+		if e != nil {
+			c.debug("failed to unmarshal json: %s", e)
+		}
+		return nil, apiErr
+	}
+	return data, nil
+}
+
+// doAPI send the request and return the response body and status code, error responses included
+func (c *Client) doAPI(ctx context.Context, r *request, opts ...RequestOption) (data []byte, statusCode int, err error) {
 	err = c.parseRequest(r, opts...)
+	if err != nil {
+		return []byte{}, 0, err
+	}
 	req, err := http.NewRequest(r.method, r.fullURL, r.body)
 	if err != nil {
-		return []byte{}, err
+		return []byte{}, 0, err
 	}
 	req = req.WithContext(ctx)
 	req.Header = r.header
 	c.debug("request: %#v", req)
-	c.debug("request: %#v", req)
 	f := c.do
 	if f == nil {
 		f = c.HTTPClient.Do
 	}
 	res, err := f(req)
 	if err != nil {
-		return []byte{}, err
+		return []byte{}, 0, err
 	}
 	data, err = io.ReadAll(res.Body)
 	if err != nil {
-		return []byte{}, err
+		return []byte{}, 0, err
 	}
 	defer func() {
 		cerr := res.Body.Close()
@@ -403,16 +463,7 @@ func (c *Client) callAPI(ctx context.Context, r *request, opts ...RequestOption)
 	c.debug("response: %#v", res)
 	c.debug("response body: %s", string(data))
 	c.debug("response status code: %d", res.StatusCode)
-
-	if res.StatusCode >= http.StatusBadRequest {
-		apiErr := new(common.APIError)
-		e := json.Unmarshal(data, apiErr)
-		if e != nil {
-			c.debug("failed to unmarshal json: %s", e)
-		}
-		return nil, apiErr
-	}
-	return data, nil
+	return data, res.StatusCode, nil
 }
 
 // NewPingService init ping service
@@ -480,6 +531,66 @@ func (c *Client) NewCreateOCOService() *CreateOCOService {
 	return &CreateOCOService{c: c}
 }
 
+// NewCancelReplaceService init cancel-replace order service
+func (c *Client) NewCancelReplaceService() *CancelReplaceService {
+	return &CancelReplaceService{c: c}
+}
+
+// NewAmendOrderKeepPriorityService init amending order quantity with priority kept service
+func (c *Client) NewAmendOrderKeepPriorityService() *AmendOrderKeepPriorityService {
+	return &AmendOrderKeepPriorityService{c: c}
+}
+
+// NewListPreventedMatchesService init listing prevented matches service
+func (c *Client) NewListPreventedMatchesService() *ListPreventedMatchesService {
+	return &ListPreventedMatchesService{c: c}
+}
+
+// NewCreateSOROrderService init creating SOR order service
+func (c *Client) NewCreateSOROrderService() *CreateSOROrderService {
+	return &CreateSOROrderService{c: c}
+}
+
+// NewMyAllocationsService init listing SOR allocations service
+func (c *Client) NewMyAllocationsService() *MyAllocationsService {
+	return &MyAllocationsService{c: c}
+}
+
+// NewCreateOrderListOCOService init creating order list OCO service
+func (c *Client) NewCreateOrderListOCOService() *CreateOrderListOCOService {
+	return &CreateOrderListOCOService{c: c}
+}
+
+// NewCreateOrderListOTOService init creating order list OTO service
+func (c *Client) NewCreateOrderListOTOService() *CreateOrderListOTOService {
+	return &CreateOrderListOTOService{c: c, endpoint: "/api/v3/orderList/oto"}
+}
+
+// NewCreateOrderListOPOService init creating order list OPO service
+func (c *Client) NewCreateOrderListOPOService() *CreateOrderListOTOService {
+	return &CreateOrderListOTOService{c: c, endpoint: "/api/v3/orderList/opo"}
+}
+
+// NewCreateOrderListOTOCOService init creating order list OTOCO service
+func (c *Client) NewCreateOrderListOTOCOService() *CreateOrderListOTOCOService {
+	return &CreateOrderListOTOCOService{c: c, endpoint: "/api/v3/orderList/otoco"}
+}
+
+// NewCreateOrderListOPOCOService init creating order list OPOCO service
+func (c *Client) NewCreateOrderListOPOCOService() *CreateOrderListOTOCOService {
+	return &CreateOrderListOTOCOService{c: c, endpoint: "/api/v3/orderList/opoco"}
+}
+
+// NewGetOrderListService init get order list service
+func (c *Client) NewGetOrderListService() *GetOrderListService {
+	return &GetOrderListService{c: c}
+}
+
+// NewListAllOrderListsService init list all order lists service
+func (c *Client) NewListAllOrderListsService() *ListAllOrderListsService {
+	return &ListAllOrderListsService{c: c}
+}
+
 // NewCancelOCOService init cancel OCO service
 func (c *Client) NewCancelOCOService() *CancelOCOService {
 	return &CancelOCOService{c: c}
@@ -520,6 +631,11 @@ func (c *Client) NewGetAccountService() *GetAccountService {
 	return &GetAccountService{c: c}
 }
 
+// NewGetCommissionService init getting account commission service
+func (c *Client) NewGetCommissionService() *GetCommissionService {
+	return &GetCommissionService{c: c}
+}
+
 // NewListTradesService init listing trades service
 func (c *Client) NewListTradesService() *ListTradesService {
 	return &ListTradesService{c: c}
@@ -0,0 +1,503 @@
+package spot
+
+import (
+	"context"
+	"net/http"
+)
+
+// OrderListLeg define one order of an order list, built with NewOrderListLeg | 订单列表中的一个订单
+type OrderListLeg struct {
+	orderType       OrderType
+	side            *SideType
+	quantity        *string
+	price           *string
+	stopPrice       *string
+	trailingDelta   *string
+	timeInForce     *TimeInForceType
+	clientOrderID   *string
+	icebergQuantity *string
+	strategyID      *int64
+	strategyType    *int
+}
+
+// NewOrderListLeg init an order list leg of orderType
+func NewOrderListLeg(orderType OrderType) *OrderListLeg {
+	return &OrderListLeg{orderType: orderType}
+}
+
+// SetSide set side. Ignored for OCO legs and OTOCO pending legs, whose side is set on the list
+func (l *OrderListLeg) SetSide(side SideType) *OrderListLeg {
+	l.side = &side
+	return l
+}
+
+// SetQuantity set quantity. Ignored for OCO legs and OTOCO pending legs, whose quantity is set on the list
+func (l *OrderListLeg) SetQuantity(quantity string) *OrderListLeg {
+	l.quantity = &quantity
+	return l
+}
+
+// SetPrice set price
+func (l *OrderListLeg) SetPrice(price string) *OrderListLeg {
+	l.price = &price
+	return l
+}
+
+// SetStopPrice set stopPrice
+func (l *OrderListLeg) SetStopPrice(stopPrice string) *OrderListLeg {
+	l.stopPrice = &stopPrice
+	return l
+}
+
+// SetTrailingDelta set trailingDelta
+func (l *OrderListLeg) SetTrailingDelta(trailingDelta string) *OrderListLeg {
+	l.trailingDelta = &trailingDelta
+	return l
+}
+
+// SetTimeInForce set timeInForce
+func (l *OrderListLeg) SetTimeInForce(timeInForce TimeInForceType) *OrderListLeg {
+	l.timeInForce = &timeInForce
+	return l
+}
+
+// SetClientOrderID set clientOrderId of the leg
+func (l *OrderListLeg) SetClientOrderID(clientOrderID string) *OrderListLeg {
+	l.clientOrderID = &clientOrderID
+	return l
+}
+
+// SetIcebergQuantity set icebergQty
+func (l *OrderListLeg) SetIcebergQuantity(icebergQuantity string) *OrderListLeg {
+	l.icebergQuantity = &icebergQuantity
+	return l
+}
+
+// SetStrategyID set strategyId of the leg
+func (l *OrderListLeg) SetStrategyID(strategyID int64) *OrderListLeg {
+	l.strategyID = &strategyID
+	return l
+}
+
+// SetStrategyType set strategyType of the leg, must be at least 1000000
+func (l *OrderListLeg) SetStrategyType(strategyType int) *OrderListLeg {
+	l.strategyType = &strategyType
+	return l
+}
+
+// setParams 以 prefix 为前缀写入订单参数, 如 prefix 为 above 时写入 aboveType, abovePrice...
+// withSideQuantity 为 false 时方向和数量由订单列表统一指定
+func (l *OrderListLeg) setParams(m params, prefix string, withSideQuantity bool) {
+	if l == nil {
+		return
+	}
+	m[prefix+"Type"] = l.orderType
+	if withSideQuantity && l.side != nil {
+		m[prefix+"Side"] = *l.side
+	}
+	if withSideQuantity && l.quantity != nil {
+		m[prefix+"Quantity"] = *l.quantity
+	}
+	if l.price != nil {
+		m[prefix+"Price"] = *l.price
+	}
+	if l.stopPrice != nil {
+		m[prefix+"StopPrice"] = *l.stopPrice
+	}
+	if l.trailingDelta != nil {
+		m[prefix+"TrailingDelta"] = *l.trailingDelta
+	}
+	if l.timeInForce != nil {
+		m[prefix+"TimeInForce"] = *l.timeInForce
+	}
+	if l.clientOrderID != nil {
+		m[prefix+"ClientOrderId"] = *l.clientOrderID
+	}
+	if l.icebergQuantity != nil {
+		m[prefix+"IcebergQty"] = *l.icebergQuantity
+	}
+	if l.strategyID != nil {
+		m[prefix+"StrategyId"] = *l.strategyID
+	}
+	if l.strategyType != nil {
+		m[prefix+"StrategyType"] = *l.strategyType
+	}
+}
+
+// orderListParams 订单列表的公共参数
+type orderListParams struct {
+	listClientOrderID       *string
+	newOrderRespType        *NewOrderRespType
+	selfTradePreventionMode *SelfTradePreventionModeType
+}
+
+func (p *orderListParams) setParams(m params) {
+	if p.listClientOrderID != nil {
+		m["listClientOrderId"] = *p.listClientOrderID
+	}
+	if p.newOrderRespType != nil {
+		m["newOrderRespType"] = *p.newOrderRespType
+	}
+	if p.selfTradePreventionMode != nil {
+		m["selfTradePreventionMode"] = *p.selfTradePreventionMode
+	}
+}
+
+// createOrderList 发送下单请求并解析订单列表
+func createOrderList(ctx context.Context, c *Client, endpoint string, m params, opts ...RequestOption) (res *OrderList, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: endpoint,
+		secType:  secTypeSigned,
+	}
+	r.setFormParams(m)
+	data, err := c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(OrderList)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateOrderListOCOService create an OCO order list with an above and a below leg | 下 OCO 订单列表
+type CreateOrderListOCOService struct {
+	c        *Client
+	symbol   string
+	side     SideType
+	quantity string
+	above    *OrderListLeg
+	below    *OrderListLeg
+	orderListParams
+}
+
+// SetSymbol set symbol
+func (s *CreateOrderListOCOService) SetSymbol(symbol string) *CreateOrderListOCOService {
+	s.symbol = symbol
+	return s
+}
+
+// SetSide set side of both legs
+func (s *CreateOrderListOCOService) SetSide(side SideType) *CreateOrderListOCOService {
+	s.side = side
+	return s
+}
+
+// SetQuantity set quantity of both legs
+func (s *CreateOrderListOCOService) SetQuantity(quantity string) *CreateOrderListOCOService {
+	s.quantity = quantity
+	return s
+}
+
+// SetAbove set the above leg: STOP_LOSS_LIMIT, STOP_LOSS, LIMIT_MAKER, TAKE_PROFIT or TAKE_PROFIT_LIMIT
+func (s *CreateOrderListOCOService) SetAbove(above *OrderListLeg) *CreateOrderListOCOService {
+	s.above = above
+	return s
+}
+
+// SetBelow set the below leg: STOP_LOSS, STOP_LOSS_LIMIT, TAKE_PROFIT or TAKE_PROFIT_LIMIT
+func (s *CreateOrderListOCOService) SetBelow(below *OrderListLeg) *CreateOrderListOCOService {
+	s.below = below
+	return s
+}
+
+// SetListClientOrderID set listClientOrderId
+func (s *CreateOrderListOCOService) SetListClientOrderID(listClientOrderID string) *CreateOrderListOCOService {
+	s.listClientOrderID = &listClientOrderID
+	return s
+}
+
+// SetSelfTradePreventionMode set selfTradePreventionMode of all orders in the list
+func (s *CreateOrderListOCOService) SetSelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionModeType) *CreateOrderListOCOService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
+// SetNewOrderRespType set newOrderRespType
+func (s *CreateOrderListOCOService) SetNewOrderRespType(newOrderRespType NewOrderRespType) *CreateOrderListOCOService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+// Do send request
+func (s *CreateOrderListOCOService) Do(ctx context.Context, opts ...RequestOption) (res *OrderList, err error) {
+	// POST /api/v3/orderList/oco | 下 OCO 订单列表 (TRADE)
+	// 上方订单和下方订单其中一个成交或触发时, 另一个订单被撤销
+	m := params{
+		"symbol":   s.symbol,
+		"side":     s.side,
+		"quantity": s.quantity,
+	}
+	s.above.setParams(m, "above", false)
+	s.below.setParams(m, "below", false)
+	s.orderListParams.setParams(m)
+	return createOrderList(ctx, s.c, "/api/v3/orderList/oco", m, opts...)
+}
+
+// CreateOrderListOTOService create an OTO order list, or an OPO one when built with NewCreateOrderListOPOService | 下 OTO/OPO 订单列表
+type CreateOrderListOTOService struct {
+	c        *Client
+	endpoint string
+	symbol   string
+	working  *OrderListLeg
+	pending  *OrderListLeg
+	orderListParams
+}
+
+// SetSymbol set symbol
+func (s *CreateOrderListOTOService) SetSymbol(symbol string) *CreateOrderListOTOService {
+	s.symbol = symbol
+	return s
+}
+
+// SetWorking set the working leg, LIMIT or LIMIT_MAKER, with its side and quantity
+func (s *CreateOrderListOTOService) SetWorking(working *OrderListLeg) *CreateOrderListOTOService {
+	s.working = working
+	return s
+}
+
+// SetPending set the pending leg with its side and quantity, placed once the working leg is filled
+func (s *CreateOrderListOTOService) SetPending(pending *OrderListLeg) *CreateOrderListOTOService {
+	s.pending = pending
+	return s
+}
+
+// SetListClientOrderID set listClientOrderId
+func (s *CreateOrderListOTOService) SetListClientOrderID(listClientOrderID string) *CreateOrderListOTOService {
+	s.listClientOrderID = &listClientOrderID
+	return s
+}
+
+// SetSelfTradePreventionMode set selfTradePreventionMode of all orders in the list
+func (s *CreateOrderListOTOService) SetSelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionModeType) *CreateOrderListOTOService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
+// SetNewOrderRespType set newOrderRespType
+func (s *CreateOrderListOTOService) SetNewOrderRespType(newOrderRespType NewOrderRespType) *CreateOrderListOTOService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+// Do send request
+func (s *CreateOrderListOTOService) Do(ctx context.Context, opts ...RequestOption) (res *OrderList, err error) {
+	// POST /api/v3/orderList/oto | 下 OTO 订单列表 (TRADE), 生效订单完全成交后下待处理订单
+	// POST /api/v3/orderList/opo | 下 OPO 订单列表 (TRADE), 生效订单部分成交即按成交量下待处理订单
+	m := params{
+		"symbol": s.symbol,
+	}
+	s.working.setParams(m, "working", true)
+	s.pending.setParams(m, "pending", true)
+	s.orderListParams.setParams(m)
+	return createOrderList(ctx, s.c, s.endpoint, m, opts...)
+}
+
+// CreateOrderListOTOCOService create an OTOCO order list, or an OPOCO one when built with NewCreateOrderListOPOCOService | 下 OTOCO/OPOCO 订单列表
+type CreateOrderListOTOCOService struct {
+	c               *Client
+	endpoint        string
+	symbol          string
+	working         *OrderListLeg
+	pendingSide     SideType
+	pendingQuantity string
+	pendingAbove    *OrderListLeg
+	pendingBelow    *OrderListLeg
+	orderListParams
+}
+
+// SetSymbol set symbol
+func (s *CreateOrderListOTOCOService) SetSymbol(symbol string) *CreateOrderListOTOCOService {
+	s.symbol = symbol
+	return s
+}
+
+// SetWorking set the working leg, LIMIT or LIMIT_MAKER, with its side and quantity
+func (s *CreateOrderListOTOCOService) SetWorking(working *OrderListLeg) *CreateOrderListOTOCOService {
+	s.working = working
+	return s
+}
+
+// SetPendingSide set side of both pending legs
+func (s *CreateOrderListOTOCOService) SetPendingSide(pendingSide SideType) *CreateOrderListOTOCOService {
+	s.pendingSide = pendingSide
+	return s
+}
+
+// SetPendingQuantity set quantity of both pending legs
+func (s *CreateOrderListOTOCOService) SetPendingQuantity(pendingQuantity string) *CreateOrderListOTOCOService {
+	s.pendingQuantity = pendingQuantity
+	return s
+}
+
+// SetPendingAbove set the pending above leg of the OCO placed once the working leg is filled
+func (s *CreateOrderListOTOCOService) SetPendingAbove(pendingAbove *OrderListLeg) *CreateOrderListOTOCOService {
+	s.pendingAbove = pendingAbove
+	return s
+}
+
+// SetPendingBelow set the pending below leg of the OCO placed once the working leg is filled
+func (s *CreateOrderListOTOCOService) SetPendingBelow(pendingBelow *OrderListLeg) *CreateOrderListOTOCOService {
+	s.pendingBelow = pendingBelow
+	return s
+}
+
+// SetListClientOrderID set listClientOrderId
+func (s *CreateOrderListOTOCOService) SetListClientOrderID(listClientOrderID string) *CreateOrderListOTOCOService {
+	s.listClientOrderID = &listClientOrderID
+	return s
+}
+
+// SetSelfTradePreventionMode set selfTradePreventionMode of all orders in the list
+func (s *CreateOrderListOTOCOService) SetSelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionModeType) *CreateOrderListOTOCOService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
+// SetNewOrderRespType set newOrderRespType
+func (s *CreateOrderListOTOCOService) SetNewOrderRespType(newOrderRespType NewOrderRespType) *CreateOrderListOTOCOService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+// Do send request
+func (s *CreateOrderListOTOCOService) Do(ctx context.Context, opts ...RequestOption) (res *OrderList, err error) {
+	// POST /api/v3/orderList/otoco | 下 OTOCO 订单列表 (TRADE), 生效订单完全成交后下一组 OCO 订单
+	// POST /api/v3/orderList/opoco | 下 OPOCO 订单列表 (TRADE), 生效订单部分成交即按成交量下 OCO 订单
+	m := params{
+		"symbol":          s.symbol,
+		"pendingSide":     s.pendingSide,
+		"pendingQuantity": s.pendingQuantity,
+	}
+	s.working.setParams(m, "working", true)
+	s.pendingAbove.setParams(m, "pendingAbove", false)
+	s.pendingBelow.setParams(m, "pendingBelow", false)
+	s.orderListParams.setParams(m)
+	return createOrderList(ctx, s.c, s.endpoint, m, opts...)
+}
+
+// OrderList define order list info
+type OrderList struct {
+	OrderListID       int64             `json:"orderListId"`       // 订单列表ID
+	ContingencyType   string            `json:"contingencyType"`   // 订单列表类型 [OCO/OTO]
+	ListStatusType    string            `json:"listStatusType"`    // 订单列表状态 [RESPONSE/EXEC_STARTED/ALL_DONE]
+	ListOrderStatus   string            `json:"listOrderStatus"`   // 订单列表中订单的状态 [EXECUTING/ALL_DONE/REJECT]
+	ListClientOrderID string            `json:"listClientOrderId"` // 用户自定义的订单列表ID
+	TransactionTime   int64             `json:"transactionTime"`   // 交易时间
+	Symbol            string            `json:"symbol"`            // 交易对
+	Orders            []*OCOOrder       `json:"orders"`            // 订单列表中的订单
+	OrderReports      []*OCOOrderReport `json:"orderReports"`      // 下单时返回的订单详情
+}
+
+// GetOrderListService get an order list by orderListId or origClientOrderId
+type GetOrderListService struct {
+	c                 *Client
+	orderListID       *int64
+	origClientOrderID *string
+}
+
+// SetOrderListID set orderListId
+func (s *GetOrderListService) SetOrderListID(orderListID int64) *GetOrderListService {
+	s.orderListID = &orderListID
+	return s
+}
+
+// SetOrigClientOrderID set origClientOrderId, the listClientOrderId of the order list
+func (s *GetOrderListService) SetOrigClientOrderID(origClientOrderID string) *GetOrderListService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// Do send request
+func (s *GetOrderListService) Do(ctx context.Context, opts ...RequestOption) (res *OrderList, err error) {
+	// GET /api/v3/orderList | 查询订单列表 (USER_DATA)
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/api/v3/orderList",
+		secType:  secTypeSigned,
+	}
+	if s.orderListID != nil {
+		r.setParam("orderListId", *s.orderListID)
+	}
+	if s.origClientOrderID != nil {
+		r.setParam("origClientOrderId", *s.origClientOrderID)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(OrderList)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListAllOrderListsService list all order lists of the account
+type ListAllOrderListsService struct {
+	c         *Client
+	fromID    *int64
+	startTime *int64
+	endTime   *int64
+	limit     *int
+}
+
+// SetFromID set fromId, cannot be used with startTime or endTime
+func (s *ListAllOrderListsService) SetFromID(fromID int64) *ListAllOrderListsService {
+	s.fromID = &fromID
+	return s
+}
+
+// SetStartTime set startTime
+func (s *ListAllOrderListsService) SetStartTime(startTime int64) *ListAllOrderListsService {
+	s.startTime = &startTime
+	return s
+}
+
+// SetEndTime set endTime
+func (s *ListAllOrderListsService) SetEndTime(endTime int64) *ListAllOrderListsService {
+	s.endTime = &endTime
+	return s
+}
+
+// SetLimit set limit, default 500, max 1000
+func (s *ListAllOrderListsService) SetLimit(limit int) *ListAllOrderListsService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *ListAllOrderListsService) Do(ctx context.Context, opts ...RequestOption) (res []*OrderList, err error) {
+	// GET /api/v3/allOrderList | 查询所有订单列表 (USER_DATA)
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/api/v3/allOrderList",
+		secType:  secTypeSigned,
+	}
+	if s.fromID != nil {
+		r.setParam("fromId", *s.fromID)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*OrderList{}, err
+	}
+	res = make([]*OrderList, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*OrderList{}, err
+	}
+	return res, nil
+}
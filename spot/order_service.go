@@ -3,7 +3,10 @@ package spot
 import (
 	"context"
 	stdjson "encoding/json"
+	"fmt"
 	"net/http"
+
+	"github.com/BobHye/binance-go/common"
 )
 
 // CreateOrderService create order
@@ -21,6 +24,10 @@ type CreateOrderService struct {
 	stopPrice        *string // 仅 STOP_LOSS, STOP_LOSS_LIMIT, TAKE_PROFIT, TAKE_PROFIT_LIMIT 需要此参数。
 	trailingDelta    *string // 用于 STOP_LOSS, STOP_LOSS_LIMIT, TAKE_PROFIT, 和 TAKE_PROFIT_LIMIT 类型的订单。
 	icebergQuantity  *string
+	strategyID       *int64 // 订单策略ID, 用于标识订单所属的策略
+	strategyType     *int   // 订单策略类型, 不能小于 1000000
+	// 自成交保护模式: 同一账户(或同一 tradeGroupId)的订单相互成交时的处理方式
+	selfTradePreventionMode *SelfTradePreventionModeType
 }
 
 // SetSymbol set symbol
@@ -95,7 +102,25 @@ func (s *CreateOrderService) SetNewOrderRespType(newOrderRespType NewOrderRespTy
 	return s
 }
 
-func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, err error) {
+// SetStrategyID set strategyId
+func (s *CreateOrderService) SetStrategyID(strategyID int64) *CreateOrderService {
+	s.strategyID = &strategyID
+	return s
+}
+
+// SetStrategyType set strategyType, must be at least 1000000
+func (s *CreateOrderService) SetStrategyType(strategyType int) *CreateOrderService {
+	s.strategyType = &strategyType
+	return s
+}
+
+// SetSelfTradePreventionMode set selfTradePreventionMode
+func (s *CreateOrderService) SetSelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionModeType) *CreateOrderService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
+func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, extra params, opts ...RequestOption) (data []byte, err error) {
 	r := &request{
 		method:   http.MethodPost,
 		endpoint: endpoint,
@@ -120,6 +145,9 @@ func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, o
 	}
 	if s.newClientOrderID != nil {
 		m["newClientOrderId"] = *s.newClientOrderID
+	} else if s.c.OrderIDs != nil {
+		// 生成的订单号只用于本次请求, 不写回 service
+		m["newClientOrderId"] = s.c.OrderIDs.Next()
 	}
 	if s.stopPrice != nil {
 		m["stopPrice"] = *s.stopPrice
@@ -133,6 +161,18 @@ func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, o
 	if s.newOrderRespType != nil {
 		m["newOrderRespType"] = *s.newOrderRespType
 	}
+	if s.strategyID != nil {
+		m["strategyId"] = *s.strategyID
+	}
+	if s.strategyType != nil {
+		m["strategyType"] = *s.strategyType
+	}
+	if s.selfTradePreventionMode != nil {
+		m["selfTradePreventionMode"] = *s.selfTradePreventionMode
+	}
+	for k, v := range extra {
+		m[k] = v
+	}
 	r.setFormParams(m)
 	data, err = s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -144,7 +184,7 @@ func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, o
 // Do send request
 func (s *CreateOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CreateOrderResponse, err error) {
 	// POST /api/v3/order | 下单 (TRADE)
-	data, err := s.createOrder(ctx, "/api/v3/order", opts...)
+	data, err := s.createOrder(ctx, "/api/v3/order", nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -158,10 +198,34 @@ func (s *CreateOrderService) Do(ctx context.Context, opts ...RequestOption) (res
 
 // Test send test api to check if the request is valid
 func (s *CreateOrderService) Test(ctx context.Context, opts ...RequestOption) (err error) {
-	_, err = s.createOrder(ctx, "/api/v3/order/test", opts...)
+	_, err = s.createOrder(ctx, "/api/v3/order/test", nil, opts...)
 	return err
 }
 
+// TestCommission send test api with computeCommissionRates=true, the order is validated
+// but not sent to the matching engine, the response holds the commission it would pay
+func (s *CreateOrderService) TestCommission(ctx context.Context, opts ...RequestOption) (res *OrderCommission, err error) {
+	// POST /api/v3/order/test | 测试下单并计算手续费 (TRADE)
+	data, err := s.createOrder(ctx, "/api/v3/order/test", params{"computeCommissionRates": true}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(OrderCommission)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// OrderCommission define the commission rates of a test order | 测试订单的手续费率
+type OrderCommission struct {
+	StandardCommissionForOrder CommissionRate     `json:"standardCommissionForOrder"` // 订单的标准手续费率
+	SpecialCommissionForOrder  CommissionRate     `json:"specialCommissionForOrder"`  // 订单的特殊手续费率
+	TaxCommissionForOrder      CommissionRate     `json:"taxCommissionForOrder"`      // 订单的税费率
+	Discount                   CommissionDiscount `json:"discount"`                   // 使用 BNB 支付手续费的折扣
+}
+
 // CreateOrderResponse define create order response
 type CreateOrderResponse struct {
 	Symbol                   string `json:"symbol"`        // 交易对
@@ -179,6 +243,15 @@ type CreateOrderResponse struct {
 	Type        OrderType       `json:"type"`
 	Side        SideType        `json:"side"`
 
+	WorkingTime             int64  `json:"workingTime"`             // 订单添加到 order book 的时间
+	SelfTradePreventionMode string `json:"selfTradePreventionMode"` // 自成交保护模式
+	StrategyID              int64  `json:"strategyId"`              // 订单策略ID, 下单时设置才返回
+	StrategyType            int    `json:"strategyType"`            // 订单策略类型, 下单时设置才返回
+	PreventedMatchID        int64  `json:"preventedMatchId"`        // 因自成交保护过期时返回
+	PreventedQuantity       string `json:"preventedQuantity"`       // 因自成交保护过期的数量
+	WorkingFloor            string `json:"workingFloor"`            // 订单的挂单层, EXCHANGE 或 SOR
+	UsedSor                 bool   `json:"usedSor"`                 // 是否通过 SOR 下单
+
 	// for order response is set to FULL
 	Fills                 []*Fill `json:"fills"`
 	MarginBuyBorrowAmount string  `json:"marginBuyBorrowAmount"` // for margin
@@ -192,6 +265,8 @@ type Fill struct {
 	Quantity        string `json:"qty"`
 	Commission      string `json:"commission"`
 	CommissionAsset string `json:"commissionAsset"`
+	MatchType       string `json:"matchType"` // SOR 订单在其他订单簿成交时为 ONE_PARTY_TRADE_REPORT
+	AllocID         int64  `json:"allocId"`   // SOR 订单的分配ID
 }
 
 // CreateOCOService create order
@@ -402,6 +477,8 @@ type OCOOrderReport struct {
 	Side                     SideType        `json:"side"`
 	StopPrice                string          `json:"stopPrice"`
 	IcebergQuantity          string          `json:"icebergQty"`
+	WorkingTime              int64           `json:"workingTime"`
+	SelfTradePreventionMode  string          `json:"selfTradePreventionMode"`
 }
 
 // ListOpenOcoService list opened oco
@@ -552,6 +629,12 @@ type Order struct {
 	//IsIsolated               bool            `json:"isIsolated"`
 	OrigQuoteOrderQuantity  string `json:"origQuoteOrderQty"`       // 原始的交易金额
 	SelfTradePreventionMode string `json:"selfTradePreventionMode"` // 如何处理自我交易模式
+	PreventedMatchID        int64  `json:"preventedMatchId"`        // 因自成交保护过期时返回
+	PreventedQuantity       string `json:"preventedQuantity"`       // 因自成交保护过期的数量
+	StrategyID              int64  `json:"strategyId"`              // 订单策略ID, 下单时设置才返回
+	StrategyType            int    `json:"strategyType"`            // 订单策略类型, 下单时设置才返回
+	WorkingFloor            string `json:"workingFloor"`            // 订单的挂单层, EXCHANGE 或 SOR
+	UsedSor                 bool   `json:"usedSor"`                 // 是否通过 SOR 下单
 }
 
 // ListOrdersService all account orders; active, canceled, or filled
@@ -829,6 +912,7 @@ type CancelOrderResponse struct {
 	TimeInForce              TimeInForceType `json:"timeInForce"`
 	Type                     OrderType       `json:"type"`
 	Side                     SideType        `json:"side"`
+	SelfTradePreventionMode  string          `json:"selfTradePreventionMode"`
 }
 
 // CancelOCOResponse may be returned included in a CancelOpenOrdersResponse.
@@ -843,3 +927,372 @@ type CancelOCOResponse struct {
 	Orders            []*OCOOrder       `json:"orders"`
 	OrderReports      []*OCOOrderReport `json:"orderReports"`
 }
+
+// CancelReplaceService cancel an existing order and place a new order on the same symbol | 撤消挂单再下单
+type CancelReplaceService struct {
+	c                       *Client
+	symbol                  string
+	side                    SideType
+	orderType               OrderType
+	cancelReplaceMode       CancelReplaceModeType
+	timeInForce             *TimeInForceType
+	quantity                *string
+	quoteOrderQty           *string
+	price                   *string
+	cancelNewClientOrderID  *string
+	cancelOrigClientOrderID *string
+	cancelOrderID           *int64
+	newClientOrderID        *string
+	stopPrice               *string
+	trailingDelta           *string
+	icebergQuantity         *string
+	newOrderRespType        *NewOrderRespType
+	cancelRestrictions      *CancelRestrictionsType
+	strategyID              *int64
+	strategyType            *int
+	selfTradePreventionMode *SelfTradePreventionModeType
+}
+
+// SetSymbol set symbol
+func (s *CancelReplaceService) SetSymbol(symbol string) *CancelReplaceService {
+	s.symbol = symbol
+	return s
+}
+
+// SetSide set side
+func (s *CancelReplaceService) SetSide(side SideType) *CancelReplaceService {
+	s.side = side
+	return s
+}
+
+// SetType set type
+func (s *CancelReplaceService) SetType(orderType OrderType) *CancelReplaceService {
+	s.orderType = orderType
+	return s
+}
+
+// SetCancelReplaceMode set cancelReplaceMode
+func (s *CancelReplaceService) SetCancelReplaceMode(cancelReplaceMode CancelReplaceModeType) *CancelReplaceService {
+	s.cancelReplaceMode = cancelReplaceMode
+	return s
+}
+
+// SetTimeInForce set timeInForce
+func (s *CancelReplaceService) SetTimeInForce(timeInForce TimeInForceType) *CancelReplaceService {
+	s.timeInForce = &timeInForce
+	return s
+}
+
+// SetQuantity set quantity
+func (s *CancelReplaceService) SetQuantity(quantity string) *CancelReplaceService {
+	s.quantity = &quantity
+	return s
+}
+
+// SetQuoteOrderQty set quoteOrderQty
+func (s *CancelReplaceService) SetQuoteOrderQty(quoteOrderQty string) *CancelReplaceService {
+	s.quoteOrderQty = &quoteOrderQty
+	return s
+}
+
+// SetPrice set price
+func (s *CancelReplaceService) SetPrice(price string) *CancelReplaceService {
+	s.price = &price
+	return s
+}
+
+// SetCancelNewClientOrderID set cancelNewClientOrderId, the new client order id of the canceled order
+func (s *CancelReplaceService) SetCancelNewClientOrderID(cancelNewClientOrderID string) *CancelReplaceService {
+	s.cancelNewClientOrderID = &cancelNewClientOrderID
+	return s
+}
+
+// SetCancelOrigClientOrderID set cancelOrigClientOrderId
+func (s *CancelReplaceService) SetCancelOrigClientOrderID(cancelOrigClientOrderID string) *CancelReplaceService {
+	s.cancelOrigClientOrderID = &cancelOrigClientOrderID
+	return s
+}
+
+// SetCancelOrderID set cancelOrderId
+func (s *CancelReplaceService) SetCancelOrderID(cancelOrderID int64) *CancelReplaceService {
+	s.cancelOrderID = &cancelOrderID
+	return s
+}
+
+// SetNewClientOrderID set newClientOrderId of the new order
+func (s *CancelReplaceService) SetNewClientOrderID(newClientOrderID string) *CancelReplaceService {
+	s.newClientOrderID = &newClientOrderID
+	return s
+}
+
+// SetStopPrice set stopPrice
+func (s *CancelReplaceService) SetStopPrice(stopPrice string) *CancelReplaceService {
+	s.stopPrice = &stopPrice
+	return s
+}
+
+// SetTrailingDelta set trailingDelta
+func (s *CancelReplaceService) SetTrailingDelta(trailingDelta string) *CancelReplaceService {
+	s.trailingDelta = &trailingDelta
+	return s
+}
+
+// SetIcebergQuantity set icebergQuantity
+func (s *CancelReplaceService) SetIcebergQuantity(icebergQuantity string) *CancelReplaceService {
+	s.icebergQuantity = &icebergQuantity
+	return s
+}
+
+// SetNewOrderRespType set newOrderRespType
+func (s *CancelReplaceService) SetNewOrderRespType(newOrderRespType NewOrderRespType) *CancelReplaceService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+// SetCancelRestrictions set cancelRestrictions
+func (s *CancelReplaceService) SetCancelRestrictions(cancelRestrictions CancelRestrictionsType) *CancelReplaceService {
+	s.cancelRestrictions = &cancelRestrictions
+	return s
+}
+
+// SetStrategyID set strategyId of the new order
+func (s *CancelReplaceService) SetStrategyID(strategyID int64) *CancelReplaceService {
+	s.strategyID = &strategyID
+	return s
+}
+
+// SetStrategyType set strategyType of the new order, must be at least 1000000
+func (s *CancelReplaceService) SetStrategyType(strategyType int) *CancelReplaceService {
+	s.strategyType = &strategyType
+	return s
+}
+
+// SetSelfTradePreventionMode set selfTradePreventionMode of the new order
+func (s *CancelReplaceService) SetSelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionModeType) *CancelReplaceService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
+// Do send request. When either step fails the error is returned together with the combined response,
+// so the caller can tell whether the old order is still working and whether the new one was placed
+func (s *CancelReplaceService) Do(ctx context.Context, opts ...RequestOption) (res *CancelReplaceResponse, err error) {
+	// POST /api/v3/order/cancelReplace | 撤消挂单再下单 (TRADE)
+	// STOP_ON_FAILURE: 撤单失败时不下新单; ALLOW_FAILURE: 无论撤单是否成功都下新单
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: "/api/v3/order/cancelReplace",
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"symbol":            s.symbol,
+		"side":              s.side,
+		"type":              s.orderType,
+		"cancelReplaceMode": s.cancelReplaceMode,
+	}
+	if s.timeInForce != nil {
+		m["timeInForce"] = *s.timeInForce
+	}
+	if s.quantity != nil {
+		m["quantity"] = *s.quantity
+	}
+	if s.quoteOrderQty != nil {
+		m["quoteOrderQty"] = *s.quoteOrderQty
+	}
+	if s.price != nil {
+		m["price"] = *s.price
+	}
+	if s.cancelNewClientOrderID != nil {
+		m["cancelNewClientOrderId"] = *s.cancelNewClientOrderID
+	}
+	if s.cancelOrigClientOrderID != nil {
+		m["cancelOrigClientOrderId"] = *s.cancelOrigClientOrderID
+	}
+	if s.cancelOrderID != nil {
+		m["cancelOrderId"] = *s.cancelOrderID
+	}
+	if s.newClientOrderID != nil {
+		m["newClientOrderId"] = *s.newClientOrderID
+	}
+	if s.stopPrice != nil {
+		m["stopPrice"] = *s.stopPrice
+	}
+	if s.trailingDelta != nil {
+		m["trailingDelta"] = *s.trailingDelta
+	}
+	if s.icebergQuantity != nil {
+		m["icebergQty"] = *s.icebergQuantity
+	}
+	if s.newOrderRespType != nil {
+		m["newOrderRespType"] = *s.newOrderRespType
+	}
+	if s.cancelRestrictions != nil {
+		m["cancelRestrictions"] = *s.cancelRestrictions
+	}
+	if s.strategyID != nil {
+		m["strategyId"] = *s.strategyID
+	}
+	if s.strategyType != nil {
+		m["strategyType"] = *s.strategyType
+	}
+	if s.selfTradePreventionMode != nil {
+		m["selfTradePreventionMode"] = *s.selfTradePreventionMode
+	}
+	r.setFormParams(m)
+	data, statusCode, err := s.c.doAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if statusCode >= http.StatusBadRequest {
+		// 撤单或下单失败时, 错误响应的 data 字段仍携带两个步骤各自的结果
+		apiErr := new(common.APIError)
+		body := new(cancelReplaceError)
+		if e := json.Unmarshal(data, body); e != nil {
+			s.c.debug("failed to unmarshal json: %s", e)
+		}
+		apiErr.Code, apiErr.Message = body.Code, body.Message
+		return body.Data, apiErr
+	}
+	res = new(CancelReplaceResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CancelReplaceResponse define cancel-replace response
+type CancelReplaceResponse struct {
+	CancelResult     CancelReplaceResultType        `json:"cancelResult"`     // 撤单结果
+	NewOrderResult   CancelReplaceResultType        `json:"newOrderResult"`   // 下单结果
+	CancelResponse   *CancelReplaceCancelResponse   `json:"cancelResponse"`   // 撤单响应, 失败时仅有 Code 和 Message
+	NewOrderResponse *CancelReplaceNewOrderResponse `json:"newOrderResponse"` // 下单响应, 未尝试时为 nil, 失败时仅有 Code 和 Message
+}
+
+// CancelReplaceCancelResponse define the cancel step of a cancel-replace
+type CancelReplaceCancelResponse struct {
+	CancelOrderResponse
+	Code    int64  `json:"code"` // 撤单失败时的错误码
+	Message string `json:"msg"`  // 撤单失败时的错误信息
+}
+
+// CancelReplaceNewOrderResponse define the new order step of a cancel-replace
+type CancelReplaceNewOrderResponse struct {
+	CreateOrderResponse
+	Code    int64  `json:"code"` // 下单失败时的错误码
+	Message string `json:"msg"`  // 下单失败时的错误信息
+}
+
+// cancelReplaceError 撤消挂单再下单失败时的错误响应: -2021 部分失败, -2022 全部失败
+type cancelReplaceError struct {
+	Code    int64                  `json:"code"`
+	Message string                 `json:"msg"`
+	Data    *CancelReplaceResponse `json:"data"`
+}
+
+func (e *cancelReplaceError) Error() string {
+	return fmt.Sprintf("<APIError> code=%d, msg=%s", e.Code, e.Message)
+}
+
+// AmendOrderKeepPriorityService reduce the quantity of an open order without losing its queue priority | 修改订单数量并保留优先级
+type AmendOrderKeepPriorityService struct {
+	c                 *Client
+	symbol            string
+	orderID           *int64
+	origClientOrderID *string
+	newClientOrderID  *string
+	newQuantity       string
+}
+
+// SetSymbol set symbol
+func (s *AmendOrderKeepPriorityService) SetSymbol(symbol string) *AmendOrderKeepPriorityService {
+	s.symbol = symbol
+	return s
+}
+
+// SetOrderID set orderId
+func (s *AmendOrderKeepPriorityService) SetOrderID(orderID int64) *AmendOrderKeepPriorityService {
+	s.orderID = &orderID
+	return s
+}
+
+// SetOrigClientOrderID set origClientOrderId
+func (s *AmendOrderKeepPriorityService) SetOrigClientOrderID(origClientOrderID string) *AmendOrderKeepPriorityService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// SetNewClientOrderID set newClientOrderId, the client order id after the amendment
+func (s *AmendOrderKeepPriorityService) SetNewClientOrderID(newClientOrderID string) *AmendOrderKeepPriorityService {
+	s.newClientOrderID = &newClientOrderID
+	return s
+}
+
+// SetNewQuantity set newQty, must be greater than 0 and less than the original quantity
+func (s *AmendOrderKeepPriorityService) SetNewQuantity(newQuantity string) *AmendOrderKeepPriorityService {
+	s.newQuantity = newQuantity
+	return s
+}
+
+// Do send request
+func (s *AmendOrderKeepPriorityService) Do(ctx context.Context, opts ...RequestOption) (res *AmendOrderResponse, err error) {
+	// PUT /api/v3/order/amend/keepPriority | 修改订单数量并保留优先级 (TRADE)
+	// 新数量必须小于原数量; 订单属于订单列表时同时返回订单列表状态
+	r := &request{
+		method:   http.MethodPut,
+		endpoint: "/api/v3/order/amend/keepPriority",
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"symbol": s.symbol,
+		"newQty": s.newQuantity,
+	}
+	if s.orderID != nil {
+		m["orderId"] = *s.orderID
+	}
+	if s.origClientOrderID != nil {
+		m["origClientOrderId"] = *s.origClientOrderID
+	}
+	if s.newClientOrderID != nil {
+		m["newClientOrderId"] = *s.newClientOrderID
+	}
+	r.setFormParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(AmendOrderResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AmendOrderResponse define amend order response
+type AmendOrderResponse struct {
+	TransactTime int64         `json:"transactTime"`
+	ExecutionID  int64         `json:"executionId"`
+	AmendedOrder *AmendedOrder `json:"amendedOrder"`
+	ListStatus   *OrderList    `json:"listStatus"` // 订单属于订单列表时返回
+}
+
+// AmendedOrder define the order after the amendment
+type AmendedOrder struct {
+	Symbol                  string          `json:"symbol"`
+	OrderID                 int64           `json:"orderId"`
+	OrderListID             int64           `json:"orderListId"`
+	OrigClientOrderID       string          `json:"origClientOrderId"`
+	ClientOrderID           string          `json:"clientOrderId"`
+	Price                   string          `json:"price"`
+	Quantity                string          `json:"qty"`
+	ExecutedQuantity        string          `json:"executedQty"`
+	PreventedQuantity       string          `json:"preventedQty"`
+	QuoteOrderQuantity      string          `json:"quoteOrderQty"`
+	CumulativeQuoteQuantity string          `json:"cumulativeQuoteQty"`
+	Status                  OrderStatusType `json:"status"`
+	TimeInForce             TimeInForceType `json:"timeInForce"`
+	Type                    OrderType       `json:"type"`
+	Side                    SideType        `json:"side"`
+	WorkingTime             int64           `json:"workingTime"`
+	SelfTradePreventionMode string          `json:"selfTradePreventionMode"`
+}
@@ -0,0 +1,1156 @@
+package spot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/BobHye/binance-go/common"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 模拟盘: 订单和账户接口由本地模拟账本响应, 行情来自实盘推送
+
+const (
+	paperDefaultCommission = 0.001
+)
+
+var (
+	paperListenKeysMu sync.Mutex
+	paperListenKeys   = make(map[string]*PaperExchange)
+)
+
+// paperExchangeByListenKey 返回 listenKey 对应的模拟盘, WsUserDataServe 据此将订阅转到本地
+func paperExchangeByListenKey(listenKey string) *PaperExchange {
+	paperListenKeysMu.Lock()
+	defer paperListenKeysMu.Unlock()
+	return paperListenKeys[listenKey]
+}
+
+// paperEndpoints 模拟盘在本地响应的接口, 其余接口(行情等)仍然请求交易所.
+// 订单列表在模拟盘中不支持, 也在本地拒绝, 以免误下真实订单
+var paperEndpoints = map[string]bool{
+	"/api/v3/order":                    true,
+	"/api/v3/order/test":               true,
+	"/api/v3/order/cancelReplace":      true,
+	"/api/v3/order/amend/keepPriority": true,
+	"/api/v3/myPreventedMatches":       true,
+	"/api/v3/sor/order":                true,
+	"/api/v3/sor/order/test":           true,
+	"/api/v3/myAllocations":            true,
+	"/api/v3/order/oco":                true,
+	"/api/v3/orderList/oco":            true,
+	"/api/v3/orderList/oto":            true,
+	"/api/v3/orderList/otoco":          true,
+	"/api/v3/orderList/opo":            true,
+	"/api/v3/orderList/opoco":          true,
+	"/api/v3/orderList":                true,
+	"/api/v3/allOrderList":             true,
+	"/api/v3/openOrderList":            true,
+	"/api/v3/openOrders":               true,
+	"/api/v3/allOrders":                true,
+	"/api/v3/myTrades":                 true,
+	"/api/v3/account":                  true,
+	"/api/v3/account/commission":       true,
+	"/api/v3/userDataStream":           true,
+}
+
+// paperBalance 资产余额
+type paperBalance struct {
+	free   float64
+	locked float64
+}
+
+// paperSymbol 交易对资产与最优挂单
+type paperSymbol struct {
+	base  string
+	quote string
+	bid   float64
+	ask   float64
+	done  chan struct{}
+}
+
+// paperOrder 模拟盘订单
+type paperOrder struct {
+	order     *Order
+	symbol    *paperSymbol
+	price     float64
+	stopPrice float64
+	quantity  float64
+	quoteQty  float64 // 按金额下的市价单
+	executed  float64
+	cumQuote  float64
+	locked    float64 // 冻结的资产数量, 买单冻结计价资产, 卖单冻结基础资产
+	triggered bool
+	fills     []*Fill
+}
+
+// PaperExchange serve the order and account endpoints from a local ledger, filling orders against the live book ticker | 模拟盘交易所
+type PaperExchange struct {
+	mu              sync.Mutex
+	live            *Client
+	balances        map[string]*paperBalance
+	symbols         map[string]*paperSymbol
+	orders          map[int64]*paperOrder
+	clientOrders    map[string]int64
+	openOrders      []int64
+	trades          []*TradeV3
+	nextOrderID     int64
+	nextTradeID     int64
+	makerCommission float64
+	takerCommission float64
+	now             int64
+	listenKey       string
+	handlers        []WsUserDataHandler
+	events          []*WsUserDataEvent
+	errHandler      ErrHandler
+}
+
+// NewPaperExchange create a paper exchange holding balances, e.g. {"USDT": 10000} | 创建模拟盘交易所
+func NewPaperExchange(balances map[string]float64) *PaperExchange {
+	p := &PaperExchange{
+		balances:        make(map[string]*paperBalance),
+		symbols:         make(map[string]*paperSymbol),
+		orders:          make(map[int64]*paperOrder),
+		clientOrders:    make(map[string]int64),
+		nextOrderID:     1,
+		nextTradeID:     1,
+		makerCommission: paperDefaultCommission,
+		takerCommission: paperDefaultCommission,
+		errHandler:      func(err error) {},
+	}
+	for asset, free := range balances {
+		p.balances[asset] = &paperBalance{free: free}
+	}
+	return p
+}
+
+// SetCommissionRate set maker and taker commission rates, 0.001 by default | 设置手续费率
+func (p *PaperExchange) SetCommissionRate(maker, taker float64) *PaperExchange {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.makerCommission = maker
+	p.takerCommission = taker
+	return p
+}
+
+// SetErrHandler set the handler of errors from the live streams | 设置行情推送的错误处理器
+func (p *PaperExchange) SetErrHandler(errHandler ErrHandler) *PaperExchange {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.errHandler = errHandler
+	return p
+}
+
+// UsePaperTrading serve the order, account and user data endpoints of the client from p, while market data is still
+// requested from the exchange. Strategies keep calling the same services | 将客户端切换到模拟盘
+func (c *Client) UsePaperTrading(p *PaperExchange) *Client {
+	live := *c
+	p.mu.Lock()
+	if p.live == nil {
+		p.live = &live
+	}
+	p.mu.Unlock()
+	c.do = p.do
+	return c
+}
+
+// Subscribe start following the live book ticker of symbols. Symbols are also subscribed on their first order | 订阅交易对实时最优挂单
+func (p *PaperExchange) Subscribe(symbols ...string) error {
+	for _, symbol := range symbols {
+		if err := p.subscribe(symbol); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// subscribe 查询交易对资产, 以 REST 最优挂单初始化价格, 然后跟随实时推送
+func (p *PaperExchange) subscribe(symbol string) error {
+	p.mu.Lock()
+	if _, ok := p.symbols[symbol]; ok {
+		p.mu.Unlock()
+		return nil
+	}
+	if p.live == nil {
+		p.live = NewClient("", "")
+	}
+	live := p.live
+	p.mu.Unlock()
+
+	info, err := live.NewExchangeInfoService().SetSymbol(symbol).Do(context.Background())
+	if err != nil {
+		return err
+	}
+	if len(info.Symbols) == 0 {
+		return &common.APIError{Code: -1121, Message: "Invalid symbol."}
+	}
+	tickers, err := live.NewListBookTickersService().SetSymbol(symbol).Do(context.Background())
+	if err != nil {
+		return err
+	}
+	sym := &paperSymbol{base: info.Symbols[0].BaseAsset, quote: info.Symbols[0].QuoteAsset}
+	for _, t := range tickers {
+		sym.bid, _ = strconv.ParseFloat(t.BidPrice, 64)
+		sym.ask, _ = strconv.ParseFloat(t.AskPrice, 64)
+	}
+
+	p.mu.Lock()
+	if _, ok := p.symbols[symbol]; ok {
+		p.mu.Unlock()
+		return nil
+	}
+	p.symbols[symbol] = sym
+	p.mu.Unlock()
+
+	done, err := WsBookTickerServe(symbol, func(event *WsBookTickerEvent) {
+		bid, _ := strconv.ParseFloat(event.BestBidPrice, 64)
+		ask, _ := strconv.ParseFloat(event.BestAskPrice, 64)
+		p.ProcessBookTicker(event.Symbol, bid, ask, time.Now().UnixMilli())
+	}, p.handleErr)
+	p.mu.Lock()
+	sym.done = done
+	p.mu.Unlock()
+	return err
+}
+
+// handleErr 每次读取当前的错误处理器, 使 SetErrHandler 对已订阅的推送生效
+func (p *PaperExchange) handleErr(err error) {
+	p.mu.Lock()
+	errHandler := p.errHandler
+	p.mu.Unlock()
+	errHandler(err)
+}
+
+// Close stop following the live streams | 停止实时推送
+func (p *PaperExchange) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, sym := range p.symbols {
+		if sym.done != nil {
+			done := sym.done
+			sym.done = nil
+			go func() { done <- struct{}{} }()
+		}
+	}
+}
+
+// ProcessBookTicker move the best bid and ask of a subscribed symbol, filling buy orders against the ask and sell orders against the bid | 以最优挂单推进行情
+func (p *PaperExchange) ProcessBookTicker(symbol string, bid, ask float64, time int64) {
+	p.mu.Lock()
+	sym, ok := p.symbols[symbol]
+	if !ok {
+		p.mu.Unlock()
+		return
+	}
+	if time > p.now {
+		p.now = time
+	}
+	sym.bid, sym.ask = bid, ask
+	for _, id := range append([]int64(nil), p.openOrders...) {
+		if po := p.orders[id]; po.order.Symbol == symbol {
+			p.match(po)
+		}
+	}
+	events := p.flush()
+	p.mu.Unlock()
+	p.dispatch(events)
+}
+
+// wsUserDataServe 订阅本地推送
+func (p *PaperExchange) wsUserDataServe(handler WsUserDataHandler) chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.handlers = append(p.handlers, handler)
+	return make(chan struct{})
+}
+
+// userStream 返回模拟盘的 listenKey, 首次调用时注册
+func (p *PaperExchange) userStream() string {
+	if p.listenKey != "" {
+		return p.listenKey
+	}
+	paperListenKeysMu.Lock()
+	defer paperListenKeysMu.Unlock()
+	p.listenKey = fmt.Sprintf("paper%d", len(paperListenKeys)+1)
+	paperListenKeys[p.listenKey] = p
+	return p.listenKey
+}
+
+// do 模拟盘接口在本地响应, 下单前先订阅交易对行情; 其余请求发往交易所
+func (p *PaperExchange) do(req *http.Request) (*http.Response, error) {
+	if !paperEndpoints[req.URL.Path] {
+		p.mu.Lock()
+		live := p.live
+		p.mu.Unlock()
+		return live.HTTPClient.Do(req)
+	}
+	values := req.URL.Query()
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range form {
+			values[k] = append(values[k], v...)
+		}
+	}
+	if req.Method == http.MethodPost && values.Get("symbol") != "" {
+		if err := p.subscribe(values.Get("symbol")); err != nil {
+			return nil, err
+		}
+	}
+
+	p.mu.Lock()
+	if now := time.Now().UnixMilli(); now > p.now {
+		p.now = now
+	}
+	res, err := p.route(req.Method, req.URL.Path, values)
+	events := p.flush()
+	p.mu.Unlock()
+	p.dispatch(events)
+
+	status := http.StatusOK
+	if err != nil {
+		status = http.StatusBadRequest
+		switch e := err.(type) {
+		case *common.APIError, *cancelReplaceError:
+			res = e
+		default:
+			res = &common.APIError{Code: -1000, Message: err.Error()}
+		}
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:     http.StatusText(status),
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(data)),
+		Request:    req,
+	}, nil
+}
+
+// route 按请求方法和路径分发, 需持有锁
+func (p *PaperExchange) route(method, path string, values url.Values) (interface{}, error) {
+	switch method + " " + path {
+	case "POST /api/v3/userDataStream", "PUT /api/v3/userDataStream", "DELETE /api/v3/userDataStream":
+		return &ListenKey{ListenKey: p.userStream()}, nil
+	case "POST /api/v3/order/test", "POST /api/v3/sor/order/test":
+		po, err := p.newOrder(path, values)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.check(po); err != nil {
+			return nil, err
+		}
+		if values.Get("computeCommissionRates") != "true" {
+			return struct{}{}, nil
+		}
+		rates := p.commission(po.order.Symbol)
+		return &OrderCommission{
+			StandardCommissionForOrder: CommissionRate{Maker: rates.StandardCommission.Maker, Taker: rates.StandardCommission.Taker},
+			SpecialCommissionForOrder:  CommissionRate{Maker: rates.SpecialCommission.Maker, Taker: rates.SpecialCommission.Taker},
+			TaxCommissionForOrder:      CommissionRate{Maker: rates.TaxCommission.Maker, Taker: rates.TaxCommission.Taker},
+			Discount:                   rates.Discount,
+		}, nil
+	case "POST /api/v3/order", "POST /api/v3/sor/order":
+		po, err := p.newOrder(path, values)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.placeOrder(po); err != nil {
+			return nil, err
+		}
+		return p.createResponse(po), nil
+	case "GET /api/v3/order":
+		po, err := p.findOrder(values)
+		if err != nil {
+			return nil, err
+		}
+		return po.order, nil
+	case "DELETE /api/v3/order":
+		po, err := p.findOrder(values)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.cancelOrder(po); err != nil {
+			return nil, err
+		}
+		return p.cancelResponse(po), nil
+	case "POST /api/v3/order/cancelReplace":
+		return p.cancelReplace(values)
+	case "PUT /api/v3/order/amend/keepPriority":
+		return p.amendKeepPriority(values)
+	case "GET /api/v3/myPreventedMatches":
+		// 模拟订单只与行情撮合, 不会发生自成交
+		return []*PreventedMatch{}, nil
+	case "GET /api/v3/myAllocations":
+		// SOR 订单在本地只按本交易对行情撮合, 不会产生分配
+		return []*Allocation{}, nil
+	case "GET /api/v3/openOrders":
+		res := make([]*Order, 0)
+		for _, id := range p.openOrders {
+			if o := p.orders[id].order; values.Get("symbol") == "" || o.Symbol == values.Get("symbol") {
+				res = append(res, o)
+			}
+		}
+		return res, nil
+	case "DELETE /api/v3/openOrders":
+		res := make([]*CancelOrderResponse, 0)
+		for _, id := range append([]int64(nil), p.openOrders...) {
+			if po := p.orders[id]; po.order.Symbol == values.Get("symbol") {
+				_ = p.cancelOrder(po)
+				res = append(res, p.cancelResponse(po))
+			}
+		}
+		if len(res) == 0 {
+			return nil, &common.APIError{Code: -2011, Message: "Unknown order sent."}
+		}
+		return res, nil
+	case "GET /api/v3/allOrders":
+		return p.allOrders(values), nil
+	case "GET /api/v3/myTrades":
+		return p.myTrades(values), nil
+	case "GET /api/v3/account":
+		return p.account(), nil
+	case "GET /api/v3/account/commission":
+		if _, ok := p.symbols[values.Get("symbol")]; !ok {
+			return nil, &common.APIError{Code: -1121, Message: "Invalid symbol."}
+		}
+		return p.commission(values.Get("symbol")), nil
+	}
+	return nil, &common.APIError{Code: -1000, Message: fmt.Sprintf("%s %s is not supported in paper trading.", method, path)}
+}
+
+// cancelReplace 撤消挂单再下单, 两个步骤的结果都在响应中返回
+func (p *PaperExchange) cancelReplace(values url.Values) (*CancelReplaceResponse, error) {
+	mode := CancelReplaceModeType(values.Get("cancelReplaceMode"))
+	if mode != CancelReplaceModeTypeStopOnFailure && mode != CancelReplaceModeTypeAllowFailure {
+		return nil, &common.APIError{Code: -1102, Message: "Mandatory parameter 'cancelReplaceMode' was not sent, was empty/null, or malformed."}
+	}
+	res := &CancelReplaceResponse{
+		CancelResult:   CancelReplaceResultTypeSuccess,
+		NewOrderResult: CancelReplaceResultTypeNotAttempted,
+	}
+	po, err := p.findOrder(url.Values{
+		"symbol":            {values.Get("symbol")},
+		"orderId":           {values.Get("cancelOrderId")},
+		"origClientOrderId": {values.Get("cancelOrigClientOrderId")},
+	})
+	if err == nil {
+		switch CancelRestrictionsType(values.Get("cancelRestrictions")) {
+		case CancelRestrictionsTypeOnlyNew:
+			if po.order.Status != OrderStatusTypeNew {
+				err = &common.APIError{Code: -2011, Message: "Order was not canceled due to cancel restrictions."}
+			}
+		case CancelRestrictionsTypeOnlyPartiallyFilled:
+			if po.order.Status != OrderStatusTypePartiallyFilled {
+				err = &common.APIError{Code: -2011, Message: "Order was not canceled due to cancel restrictions."}
+			}
+		}
+	}
+	if err == nil {
+		err = p.cancelOrder(po)
+	}
+	if err != nil {
+		res.CancelResult = CancelReplaceResultTypeFailure
+		res.CancelResponse = new(CancelReplaceCancelResponse)
+		res.CancelResponse.Code, res.CancelResponse.Message = apiErrorOf(err)
+	} else {
+		res.CancelResponse = &CancelReplaceCancelResponse{CancelOrderResponse: *p.cancelResponse(po)}
+	}
+
+	if res.CancelResult == CancelReplaceResultTypeSuccess || mode == CancelReplaceModeTypeAllowFailure {
+		po, err := p.newOrder("/api/v3/order", values)
+		if err == nil {
+			err = p.placeOrder(po)
+		}
+		if err != nil {
+			res.NewOrderResult = CancelReplaceResultTypeFailure
+			res.NewOrderResponse = new(CancelReplaceNewOrderResponse)
+			res.NewOrderResponse.Code, res.NewOrderResponse.Message = apiErrorOf(err)
+		} else {
+			res.NewOrderResult = CancelReplaceResultTypeSuccess
+			res.NewOrderResponse = &CancelReplaceNewOrderResponse{CreateOrderResponse: *p.createResponse(po)}
+		}
+	}
+
+	switch {
+	case res.CancelResult == CancelReplaceResultTypeSuccess && res.NewOrderResult == CancelReplaceResultTypeSuccess:
+		return res, nil
+	case res.CancelResult == CancelReplaceResultTypeSuccess || res.NewOrderResult == CancelReplaceResultTypeSuccess:
+		return nil, &cancelReplaceError{Code: -2021, Message: "Order cancel-replace partially failed.", Data: res}
+	}
+	return nil, &cancelReplaceError{Code: -2022, Message: "Order cancel-replace failed.", Data: res}
+}
+
+// amendKeepPriority 减少挂单数量, 重新计算冻结的资产
+func (p *PaperExchange) amendKeepPriority(values url.Values) (*AmendOrderResponse, error) {
+	po, err := p.findOrder(values)
+	if err != nil {
+		return nil, err
+	}
+	if !p.isOpen(po.order.OrderID) {
+		return nil, &common.APIError{Code: -2038, Message: "Order amend rejected: Order is not in an amendable state."}
+	}
+	newQty, err := strconv.ParseFloat(values.Get("newQty"), 64)
+	if err != nil || newQty <= 0 || po.quantity == 0 || newQty >= po.quantity {
+		return nil, &common.APIError{Code: -2038, Message: "Order amend (quantity increase) is not supported."}
+	}
+	o := po.order
+	origClientOrderID := o.ClientOrderID
+	if id := values.Get("newClientOrderId"); id != "" {
+		delete(p.clientOrders, o.ClientOrderID)
+		o.ClientOrderID = id
+		p.clientOrders[id] = o.OrderID
+	}
+	asset := p.unlock(po)
+	po.quantity = newQty
+	_, amount := po.required()
+	b := p.balance(asset)
+	b.free -= amount
+	b.locked += amount
+	po.locked = amount
+	o.UpdateTime = p.now
+	p.sync(po)
+	p.orderEvent(po, OrderExecutionTypeReplaced, 0, 0, "", 0, false, 0)
+	p.accountEvent(asset)
+	return &AmendOrderResponse{
+		TransactTime: p.now,
+		AmendedOrder: &AmendedOrder{
+			Symbol:                  o.Symbol,
+			OrderID:                 o.OrderID,
+			OrderListID:             o.OrderListId,
+			OrigClientOrderID:       origClientOrderID,
+			ClientOrderID:           o.ClientOrderID,
+			Price:                   o.Price,
+			Quantity:                o.OrigQuantity,
+			ExecutedQuantity:        o.ExecutedQuantity,
+			PreventedQuantity:       "0",
+			QuoteOrderQuantity:      o.OrigQuoteOrderQuantity,
+			CumulativeQuoteQuantity: o.CummulativeQuoteQuantity,
+			Status:                  o.Status,
+			TimeInForce:             o.TimeInForce,
+			Type:                    o.Type,
+			Side:                    o.Side,
+			WorkingTime:             o.WorkingTime,
+			SelfTradePreventionMode: o.SelfTradePreventionMode,
+		},
+	}, nil
+}
+
+// apiErrorOf 返回错误码和错误信息, 非 API 错误时错误码为 -1000
+func apiErrorOf(err error) (int64, string) {
+	if apiErr, ok := err.(*common.APIError); ok {
+		return apiErr.Code, apiErr.Message
+	}
+	return -1000, err.Error()
+}
+
+// newOrder 根据下单参数构造订单, path 区分普通下单与 SOR 下单
+func (p *PaperExchange) newOrder(path string, values url.Values) (*paperOrder, error) {
+	sym, ok := p.symbols[values.Get("symbol")]
+	if !ok {
+		return nil, &common.APIError{Code: -1121, Message: "Invalid symbol."}
+	}
+	o := &Order{
+		Symbol:                  values.Get("symbol"),
+		OrderListId:             -1,
+		ClientOrderID:           values.Get("newClientOrderId"),
+		Side:                    SideType(values.Get("side")),
+		Type:                    OrderType(values.Get("type")),
+		TimeInForce:             TimeInForceType(values.Get("timeInForce")),
+		SelfTradePreventionMode: values.Get("selfTradePreventionMode"),
+		WorkingFloor:            "EXCHANGE",
+	}
+	if strings.HasPrefix(path, "/api/v3/sor/") {
+		// SOR 只支持 LIMIT 和 MARKET, 模拟盘不跨订单簿路由, 只在本交易对撮合
+		if o.Type != OrderTypeLimit && o.Type != OrderTypeMarket {
+			return nil, &common.APIError{Code: -1116, Message: "Invalid orderType."}
+		}
+		o.WorkingFloor, o.UsedSor = "SOR", true
+	}
+	if o.SelfTradePreventionMode == "" {
+		o.SelfTradePreventionMode = string(SelfTradePreventionModeTypeNone)
+	}
+	o.StrategyID, _ = strconv.ParseInt(values.Get("strategyId"), 10, 64)
+	o.StrategyType, _ = strconv.Atoi(values.Get("strategyType"))
+	po := &paperOrder{order: o, symbol: sym}
+	if o.Side != SideTypeBuy && o.Side != SideTypeSell {
+		return nil, &common.APIError{Code: -1102, Message: "Mandatory parameter 'side' was not sent, was empty/null, or malformed."}
+	}
+	var needPrice, needStop bool
+	switch o.Type {
+	case OrderTypeLimit:
+		needPrice = true
+	case OrderTypeLimitMaker:
+		needPrice = true
+		o.TimeInForce = ""
+	case OrderTypeMarket:
+		o.TimeInForce = ""
+	case OrderTypeStopLoss, OrderTypeTakeProfit:
+		needStop = true
+		o.TimeInForce = ""
+	case OrderTypeStopLossLimit, OrderTypeTakeProfitLimit:
+		needPrice, needStop = true, true
+	default:
+		return nil, &common.APIError{Code: -1116, Message: "Invalid orderType."}
+	}
+	if needPrice && o.Type != OrderTypeLimitMaker && o.TimeInForce == "" {
+		return nil, &common.APIError{Code: -1102, Message: "Mandatory parameter 'timeInForce' was not sent, was empty/null, or malformed."}
+	}
+	for _, f := range []struct {
+		key      string
+		dest     *float64
+		required bool
+	}{
+		{"quantity", &po.quantity, o.Type != OrderTypeMarket || values.Get("quoteOrderQty") == ""},
+		{"quoteOrderQty", &po.quoteQty, false},
+		{"price", &po.price, needPrice},
+		{"stopPrice", &po.stopPrice, needStop},
+	} {
+		v := values.Get(f.key)
+		if v == "" {
+			if f.required {
+				return nil, &common.APIError{Code: -1102, Message: fmt.Sprintf("Mandatory parameter '%s' was not sent, was empty/null, or malformed.", f.key)}
+			}
+			continue
+		}
+		var err error
+		if *f.dest, err = strconv.ParseFloat(v, 64); err != nil || *f.dest <= 0 {
+			return nil, &common.APIError{Code: -1013, Message: fmt.Sprintf("Invalid %s.", f.key)}
+		}
+	}
+	if po.quoteQty > 0 && o.Type != OrderTypeMarket {
+		return nil, &common.APIError{Code: -1106, Message: "Parameter 'quoteOrderQty' sent when not required."}
+	}
+	if o.Type == OrderTypeMarket && po.quantity > 0 && po.quoteQty > 0 {
+		return nil, &common.APIError{Code: -1106, Message: "Parameter 'quoteOrderQty' sent when not required."}
+	}
+	return po, nil
+}
+
+// takerPrice 吃单成交价, 买单为卖一价, 卖单为买一价
+func (po *paperOrder) takerPrice() float64 {
+	if po.order.Side == SideTypeBuy {
+		return po.symbol.ask
+	}
+	return po.symbol.bid
+}
+
+// marketable 限价单能否立即成交
+func (po *paperOrder) marketable() bool {
+	if po.order.Side == SideTypeBuy {
+		return po.price >= po.symbol.ask
+	}
+	return po.price <= po.symbol.bid
+}
+
+// triggers 条件单在当前价格下是否触发
+func (po *paperOrder) triggers() bool {
+	price := po.takerPrice()
+	switch po.order.Type {
+	case OrderTypeStopLoss, OrderTypeStopLossLimit:
+		if po.order.Side == SideTypeBuy {
+			return price >= po.stopPrice
+		}
+		return price <= po.stopPrice
+	case OrderTypeTakeProfit, OrderTypeTakeProfitLimit:
+		if po.order.Side == SideTypeBuy {
+			return price <= po.stopPrice
+		}
+		return price >= po.stopPrice
+	}
+	return false
+}
+
+// required 订单需要的资产及数量, 买单为计价资产, 卖单为基础资产
+func (po *paperOrder) required() (asset string, amount float64) {
+	if po.order.Side == SideTypeSell {
+		quantity := po.quantity
+		if quantity == 0 && po.symbol.bid > 0 {
+			quantity = po.quoteQty / po.symbol.bid
+		}
+		return po.symbol.base, quantity
+	}
+	switch {
+	case po.quoteQty > 0:
+		return po.symbol.quote, po.quoteQty
+	case po.price > 0:
+		return po.symbol.quote, po.quantity * po.price
+	case po.stopPrice > 0:
+		return po.symbol.quote, po.quantity * po.stopPrice
+	}
+	return po.symbol.quote, po.quantity * po.symbol.ask
+}
+
+func (p *PaperExchange) balance(asset string) *paperBalance {
+	b, ok := p.balances[asset]
+	if !ok {
+		b = &paperBalance{}
+		p.balances[asset] = b
+	}
+	return b
+}
+
+// check 检查订单能否被接受
+func (p *PaperExchange) check(po *paperOrder) error {
+	if po.symbol.bid == 0 || po.symbol.ask == 0 {
+		return &common.APIError{Code: -2010, Message: "No market price for symbol."}
+	}
+	if po.order.ClientOrderID != "" {
+		if id, ok := p.clientOrders[po.order.ClientOrderID]; ok && p.isOpen(id) {
+			return &common.APIError{Code: -2010, Message: "Duplicate order sent."}
+		}
+	}
+	if po.order.Type == OrderTypeLimitMaker && po.marketable() {
+		return &common.APIError{Code: -2010, Message: "Order would immediately match and take."}
+	}
+	if po.stopPrice > 0 && po.triggers() {
+		return &common.APIError{Code: -2010, Message: "Stop price would trigger immediately."}
+	}
+	asset, amount := po.required()
+	if p.balance(asset).free < amount {
+		return &common.APIError{Code: -2010, Message: "Account has insufficient balance for requested action."}
+	}
+	return nil
+}
+
+// placeOrder 接受新订单, 可立即成交的按吃单成交, 其余冻结资产后挂单
+func (p *PaperExchange) placeOrder(po *paperOrder) error {
+	if err := p.check(po); err != nil {
+		return err
+	}
+	o := po.order
+	o.OrderID = p.nextOrderID
+	p.nextOrderID++
+	if o.ClientOrderID == "" {
+		o.ClientOrderID = fmt.Sprintf("paper%d", o.OrderID)
+	}
+	o.Status = OrderStatusTypeNew
+	o.Time = p.now
+	o.UpdateTime = p.now
+	o.WorkingTime = p.now
+	p.orders[o.OrderID] = po
+	p.clientOrders[o.ClientOrderID] = o.OrderID
+	p.sync(po)
+	p.orderEvent(po, OrderExecutionTypeNew, 0, 0, "", 0, false, 0)
+
+	switch o.Type {
+	case OrderTypeMarket:
+		p.fill(po, po.takerPrice(), false)
+		return nil
+	case OrderTypeLimit:
+		if po.marketable() {
+			p.fill(po, po.takerPrice(), false)
+			return nil
+		}
+		if o.TimeInForce == TimeInForceTypeIOC || o.TimeInForce == TimeInForceTypeFOK {
+			p.finish(po, OrderStatusTypeExpired, OrderExecutionTypeExpired)
+			return nil
+		}
+	}
+	asset, amount := po.required()
+	b := p.balance(asset)
+	b.free -= amount
+	b.locked += amount
+	po.locked = amount
+	o.IsWorking = o.Type == OrderTypeLimit || o.Type == OrderTypeLimitMaker
+	p.openOrders = append(p.openOrders, o.OrderID)
+	p.accountEvent(asset)
+	return nil
+}
+
+// match 按当前最优挂单撮合挂单
+func (p *PaperExchange) match(po *paperOrder) {
+	o := po.order
+	if po.stopPrice > 0 && !po.triggered {
+		if !po.triggers() {
+			return
+		}
+		po.triggered = true
+		o.IsWorking = true
+		if o.Type == OrderTypeStopLoss || o.Type == OrderTypeTakeProfit {
+			p.removeOpen(o.OrderID)
+			p.fill(po, po.takerPrice(), false)
+			return
+		}
+		if po.marketable() {
+			p.removeOpen(o.OrderID)
+			p.fill(po, po.takerPrice(), false)
+			return
+		}
+	}
+	if po.marketable() {
+		p.removeOpen(o.OrderID)
+		p.fill(po, po.price, true)
+	}
+}
+
+// unlock 释放订单冻结的资产
+func (p *PaperExchange) unlock(po *paperOrder) string {
+	asset := po.symbol.quote
+	if po.order.Side == SideTypeSell {
+		asset = po.symbol.base
+	}
+	b := p.balance(asset)
+	b.locked -= po.locked
+	b.free += po.locked
+	po.locked = 0
+	return asset
+}
+
+// fill 订单按 price 全部成交
+func (p *PaperExchange) fill(po *paperOrder, price float64, maker bool) {
+	o := po.order
+	p.unlock(po)
+	quantity := po.quantity - po.executed
+	if quantity <= 0 {
+		quantity = po.quoteQty / price
+	}
+	base, quote := p.balance(po.symbol.base), p.balance(po.symbol.quote)
+	rate := p.takerCommission
+	if maker {
+		rate = p.makerCommission
+	}
+	var fee float64
+	var feeAsset string
+	if o.Side == SideTypeBuy {
+		fee, feeAsset = quantity*rate, po.symbol.base
+		quote.free -= quantity * price
+		base.free += quantity - fee
+	} else {
+		fee, feeAsset = quantity*price*rate, po.symbol.quote
+		base.free -= quantity
+		quote.free += quantity*price - fee
+	}
+	po.executed += quantity
+	po.cumQuote += quantity * price
+	o.Status = OrderStatusTypeFilled
+	o.UpdateTime = p.now
+	o.IsWorking = false
+	p.sync(po)
+
+	tradeID := p.nextTradeID
+	p.nextTradeID++
+	po.fills = append(po.fills, &Fill{
+		TradeID:         int(tradeID),
+		Price:           formatFloat(price),
+		Quantity:        formatFloat(quantity),
+		Commission:      formatFloat(fee),
+		CommissionAsset: feeAsset,
+	})
+	p.trades = append(p.trades, &TradeV3{
+		ID:              tradeID,
+		Symbol:          o.Symbol,
+		OrderID:         o.OrderID,
+		OrderListId:     -1,
+		Price:           formatFloat(price),
+		Quantity:        formatFloat(quantity),
+		QuoteQuantity:   formatFloat(quantity * price),
+		Commission:      formatFloat(fee),
+		CommissionAsset: feeAsset,
+		Time:            p.now,
+		IsBuyer:         o.Side == SideTypeBuy,
+		IsMaker:         maker,
+		IsBestMatch:     true,
+	})
+	p.orderEvent(po, OrderExecutionTypeTrade, quantity, price, feeAsset, fee, maker, tradeID)
+	p.accountEvent(po.symbol.base, po.symbol.quote)
+}
+
+// finish 订单以撤销或过期结束
+func (p *PaperExchange) finish(po *paperOrder, status OrderStatusType, execution OrderExecutionType) {
+	p.removeOpen(po.order.OrderID)
+	asset := p.unlock(po)
+	po.order.Status = status
+	po.order.UpdateTime = p.now
+	po.order.IsWorking = false
+	p.orderEvent(po, execution, 0, 0, "", 0, false, 0)
+	p.accountEvent(asset)
+}
+
+// cancelOrder 撤销挂单
+func (p *PaperExchange) cancelOrder(po *paperOrder) error {
+	if !p.isOpen(po.order.OrderID) {
+		return &common.APIError{Code: -2011, Message: "Unknown order sent."}
+	}
+	p.finish(po, OrderStatusTypeCanceled, OrderExecutionTypeCanceled)
+	return nil
+}
+
+func (p *PaperExchange) isOpen(orderID int64) bool {
+	for _, id := range p.openOrders {
+		if id == orderID {
+			return true
+		}
+	}
+	return false
+}
+
+// removeOpen 从挂单列表中移除订单
+func (p *PaperExchange) removeOpen(orderID int64) {
+	for i, id := range p.openOrders {
+		if id == orderID {
+			p.openOrders = append(p.openOrders[:i], p.openOrders[i+1:]...)
+			return
+		}
+	}
+}
+
+// findOrder 按 orderId 或 origClientOrderId 查找订单
+func (p *PaperExchange) findOrder(values url.Values) (*paperOrder, error) {
+	id, _ := strconv.ParseInt(values.Get("orderId"), 10, 64)
+	if id == 0 {
+		id = p.clientOrders[values.Get("origClientOrderId")]
+	}
+	po, ok := p.orders[id]
+	if !ok || po.order.Symbol != values.Get("symbol") {
+		return nil, &common.APIError{Code: -2013, Message: "Order does not exist."}
+	}
+	return po, nil
+}
+
+// sync 将数值字段同步到订单
+func (p *PaperExchange) sync(po *paperOrder) {
+	o := po.order
+	o.Price = formatFloat(po.price)
+	o.StopPrice = formatFloat(po.stopPrice)
+	o.OrigQuantity = formatFloat(po.quantity)
+	o.OrigQuoteOrderQuantity = formatFloat(po.quoteQty)
+	o.ExecutedQuantity = formatFloat(po.executed)
+	o.CummulativeQuoteQuantity = formatFloat(po.cumQuote)
+	o.IcebergQuantity = "0"
+}
+
+func (p *PaperExchange) createResponse(po *paperOrder) *CreateOrderResponse {
+	o := po.order
+	return &CreateOrderResponse{
+		Symbol:                   o.Symbol,
+		OrderID:                  o.OrderID,
+		ClientOrderID:            o.ClientOrderID,
+		TransactTime:             p.now,
+		Price:                    o.Price,
+		OrigQuantity:             o.OrigQuantity,
+		ExecutedQuantity:         o.ExecutedQuantity,
+		CummulativeQuoteQuantity: o.CummulativeQuoteQuantity,
+		Status:                   o.Status,
+		TimeInForce:              o.TimeInForce,
+		Type:                     o.Type,
+		Side:                     o.Side,
+		WorkingTime:              o.WorkingTime,
+		SelfTradePreventionMode:  o.SelfTradePreventionMode,
+		StrategyID:               o.StrategyID,
+		StrategyType:             o.StrategyType,
+		WorkingFloor:             o.WorkingFloor,
+		UsedSor:                  o.UsedSor,
+		Fills:                    po.fills,
+	}
+}
+
+func (p *PaperExchange) cancelResponse(po *paperOrder) *CancelOrderResponse {
+	o := po.order
+	return &CancelOrderResponse{
+		Symbol:                   o.Symbol,
+		OrigClientOrderID:        o.ClientOrderID,
+		OrderID:                  o.OrderID,
+		OrderListID:              -1,
+		ClientOrderID:            o.ClientOrderID,
+		TransactTime:             p.now,
+		Price:                    o.Price,
+		OrigQuantity:             o.OrigQuantity,
+		ExecutedQuantity:         o.ExecutedQuantity,
+		CummulativeQuoteQuantity: o.CummulativeQuoteQuantity,
+		Status:                   o.Status,
+		TimeInForce:              o.TimeInForce,
+		Type:                     o.Type,
+		Side:                     o.Side,
+	}
+}
+
+// timeRange 解析 startTime/endTime/limit 查询参数
+func timeRange(values url.Values, defaultLimit int) (start, end int64, limit int) {
+	start, _ = strconv.ParseInt(values.Get("startTime"), 10, 64)
+	end, _ = strconv.ParseInt(values.Get("endTime"), 10, 64)
+	if end == 0 {
+		end = math.MaxInt64
+	}
+	limit, _ = strconv.Atoi(values.Get("limit"))
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	return start, end, limit
+}
+
+// allOrders 查询所有订单
+func (p *PaperExchange) allOrders(values url.Values) []*Order {
+	start, end, limit := timeRange(values, 500)
+	fromID, _ := strconv.ParseInt(values.Get("orderId"), 10, 64)
+	res := make([]*Order, 0)
+	for id := int64(1); id < p.nextOrderID && len(res) < limit; id++ {
+		po, ok := p.orders[id]
+		if !ok || id < fromID || po.order.Symbol != values.Get("symbol") || po.order.Time < start || po.order.Time > end {
+			continue
+		}
+		res = append(res, po.order)
+	}
+	return res
+}
+
+// myTrades 查询成交历史
+func (p *PaperExchange) myTrades(values url.Values) []*TradeV3 {
+	start, end, limit := timeRange(values, 500)
+	fromID, _ := strconv.ParseInt(values.Get("fromId"), 10, 64)
+	orderID, _ := strconv.ParseInt(values.Get("orderId"), 10, 64)
+	res := make([]*TradeV3, 0)
+	for _, t := range p.trades {
+		if len(res) >= limit {
+			break
+		}
+		if t.ID < fromID || t.Symbol != values.Get("symbol") || t.Time < start || t.Time > end || orderID > 0 && t.OrderID != orderID {
+			continue
+		}
+		res = append(res, t)
+	}
+	return res
+}
+
+// account 账户信息
+func (p *PaperExchange) account() *Account {
+	assets := make([]string, 0, len(p.balances))
+	for asset := range p.balances {
+		assets = append(assets, asset)
+	}
+	sort.Strings(assets)
+	balances := make([]Balance, 0, len(assets))
+	for _, asset := range assets {
+		b := p.balances[asset]
+		balances = append(balances, Balance{Asset: asset, Free: b.free, Locked: b.locked})
+	}
+	return &Account{
+		MakerCommission: int64(math.Round(p.makerCommission * 10000)),
+		TakerCommission: int64(math.Round(p.takerCommission * 10000)),
+		CanTrade:        true,
+		CanWithdraw:     true,
+		CanDeposit:      true,
+		UpdateTime:      uint64(p.now),
+		AccountType:     "SPOT",
+		Balances:        balances,
+		Permissions:     []string{"SPOT"},
+	}
+}
+
+// orderEvent 生成 executionReport 推送
+func (p *PaperExchange) orderEvent(po *paperOrder, execution OrderExecutionType, quantity, price float64, feeAsset string, fee float64, maker bool, tradeID int64) {
+	o := po.order
+	update := WsOrderUpdate{
+		Symbol:                  o.Symbol,
+		ClientOrderID:           o.ClientOrderID,
+		Side:                    o.Side,
+		Type:                    o.Type,
+		TimeInForce:             o.TimeInForce,
+		Volume:                  o.OrigQuantity,
+		Price:                   o.Price,
+		StopPrice:               o.StopPrice,
+		IceBergVolume:           o.IcebergQuantity,
+		OrderListID:             -1,
+		ExecutionType:           execution,
+		Status:                  o.Status,
+		RejectReason:            "NONE",
+		ID:                      o.OrderID,
+		LatestVolume:            formatFloat(quantity),
+		FilledVolume:            o.ExecutedQuantity,
+		LatestPrice:             formatFloat(price),
+		FeeCost:                 formatFloat(fee),
+		FeeAsset:                feeAsset,
+		TransactionTime:         p.now,
+		TradeID:                 -1,
+		IsInBook:                o.IsWorking,
+		IsMaker:                 maker,
+		CreateTime:              o.Time,
+		FilledQuoteVolume:       o.CummulativeQuoteQuantity,
+		LatestQuoteVolume:       formatFloat(quantity * price),
+		QuoteVolume:             o.OrigQuoteOrderQuantity,
+		WorkingTime:             o.WorkingTime,
+		StrategyID:              o.StrategyID,
+		StrategyType:            o.StrategyType,
+		SelfTradePreventionMode: o.SelfTradePreventionMode,
+	}
+	if execution == OrderExecutionTypeTrade {
+		update.TradeID = tradeID
+	}
+	if execution == OrderExecutionTypeCanceled {
+		update.OrigClientOrderID = o.ClientOrderID
+	}
+	p.events = append(p.events, &WsUserDataEvent{
+		Event:       UserDataEventTypeExecutionReport,
+		Time:        p.now,
+		OrderUpdate: update,
+	})
+}
+
+// commission 模拟盘手续费率, 只有标准费率, 不支持 BNB 抵扣
+func (p *PaperExchange) commission(symbol string) *AccountCommission {
+	maker, taker := formatFloat(p.makerCommission), formatFloat(p.takerCommission)
+	return &AccountCommission{
+		Symbol:             symbol,
+		StandardCommission: CommissionRate{Maker: maker, Taker: taker, Buyer: "0", Seller: "0"},
+		SpecialCommission:  CommissionRate{Maker: "0", Taker: "0", Buyer: "0", Seller: "0"},
+		TaxCommission:      CommissionRate{Maker: "0", Taker: "0", Buyer: "0", Seller: "0"},
+		Discount:           CommissionDiscount{DiscountAsset: "BNB", Discount: "0"},
+	}
+}
+
+// accountEvent 生成 outboundAccountPosition 推送
+func (p *PaperExchange) accountEvent(assets ...string) {
+	update := WsAccountUpdateList{AccountUpdateTime: p.now}
+	for _, asset := range assets {
+		b := p.balance(asset)
+		update.WsAccountUpdates = append(update.WsAccountUpdates, WsAccountUpdate{
+			Asset:  asset,
+			Free:   formatFloat(b.free),
+			Locked: formatFloat(b.locked),
+		})
+	}
+	p.events = append(p.events, &WsUserDataEvent{
+		Event:         UserDataEventTypeOutboundAccountPosition,
+		Time:          p.now,
+		AccountUpdate: update,
+	})
+}
+
+// flush 取出待推送事件, 需持有锁
+func (p *PaperExchange) flush() (events []*WsUserDataEvent) {
+	events, p.events = p.events, nil
+	return events
+}
+
+// dispatch 在锁外推送事件, 处理器可以继续调用 REST 接口
+func (p *PaperExchange) dispatch(events []*WsUserDataEvent) {
+	p.mu.Lock()
+	handlers := append([]WsUserDataHandler(nil), p.handlers...)
+	p.mu.Unlock()
+	for _, event := range events {
+		for _, handler := range handlers {
+			handler(event)
+		}
+	}
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
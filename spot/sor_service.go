@@ -0,0 +1,283 @@
+package spot
+
+import (
+	"context"
+	"net/http"
+)
+
+// CreateSOROrderService create order through Smart Order Routing, the order may be filled
+// on other books of the symbol's SOR group. Only LIMIT and MARKET orders are supported
+type CreateSOROrderService struct {
+	c                       *Client
+	symbol                  string
+	side                    SideType
+	orderType               OrderType
+	timeInForce             *TimeInForceType
+	quantity                string
+	price                   *string
+	newClientOrderID        *string
+	strategyID              *int64
+	strategyType            *int
+	icebergQuantity         *string
+	newOrderRespType        *NewOrderRespType
+	selfTradePreventionMode *SelfTradePreventionModeType
+}
+
+// SetSymbol set symbol
+func (s *CreateSOROrderService) SetSymbol(symbol string) *CreateSOROrderService {
+	s.symbol = symbol
+	return s
+}
+
+// SetSide set side
+func (s *CreateSOROrderService) SetSide(side SideType) *CreateSOROrderService {
+	s.side = side
+	return s
+}
+
+// SetType set type, LIMIT or MARKET
+func (s *CreateSOROrderService) SetType(orderType OrderType) *CreateSOROrderService {
+	s.orderType = orderType
+	return s
+}
+
+// SetTimeInForce set timeInForce
+func (s *CreateSOROrderService) SetTimeInForce(timeInForce TimeInForceType) *CreateSOROrderService {
+	s.timeInForce = &timeInForce
+	return s
+}
+
+// SetQuantity set quantity
+func (s *CreateSOROrderService) SetQuantity(quantity string) *CreateSOROrderService {
+	s.quantity = quantity
+	return s
+}
+
+// SetPrice set price
+func (s *CreateSOROrderService) SetPrice(price string) *CreateSOROrderService {
+	s.price = &price
+	return s
+}
+
+// SetNewClientOrderID set newClientOrderID
+func (s *CreateSOROrderService) SetNewClientOrderID(newClientOrderID string) *CreateSOROrderService {
+	s.newClientOrderID = &newClientOrderID
+	return s
+}
+
+// SetStrategyID set strategyID
+func (s *CreateSOROrderService) SetStrategyID(strategyID int64) *CreateSOROrderService {
+	s.strategyID = &strategyID
+	return s
+}
+
+// SetStrategyType set strategyType
+func (s *CreateSOROrderService) SetStrategyType(strategyType int) *CreateSOROrderService {
+	s.strategyType = &strategyType
+	return s
+}
+
+// SetIcebergQuantity set icebergQuantity
+func (s *CreateSOROrderService) SetIcebergQuantity(icebergQuantity string) *CreateSOROrderService {
+	s.icebergQuantity = &icebergQuantity
+	return s
+}
+
+// SetNewOrderRespType set newOrderRespType
+func (s *CreateSOROrderService) SetNewOrderRespType(newOrderRespType NewOrderRespType) *CreateSOROrderService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+// SetSelfTradePreventionMode set selfTradePreventionMode
+func (s *CreateSOROrderService) SetSelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionModeType) *CreateSOROrderService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
+func (s *CreateSOROrderService) createOrder(ctx context.Context, endpoint string, extra params, opts ...RequestOption) (data []byte, err error) {
+	r := &request{
+		method:   http.MethodPost,
+		endpoint: endpoint,
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"symbol":   s.symbol,
+		"side":     s.side,
+		"type":     s.orderType,
+		"quantity": s.quantity,
+	}
+	if s.timeInForce != nil {
+		m["timeInForce"] = *s.timeInForce
+	}
+	if s.price != nil {
+		m["price"] = *s.price
+	}
+	if s.newClientOrderID != nil {
+		m["newClientOrderId"] = *s.newClientOrderID
+	} else if s.c.OrderIDs != nil {
+		// 生成的订单号只用于本次请求, 不写回 service
+		m["newClientOrderId"] = s.c.OrderIDs.Next()
+	}
+	if s.strategyID != nil {
+		m["strategyId"] = *s.strategyID
+	}
+	if s.strategyType != nil {
+		m["strategyType"] = *s.strategyType
+	}
+	if s.icebergQuantity != nil {
+		m["icebergQty"] = *s.icebergQuantity
+	}
+	if s.newOrderRespType != nil {
+		m["newOrderRespType"] = *s.newOrderRespType
+	}
+	if s.selfTradePreventionMode != nil {
+		m["selfTradePreventionMode"] = *s.selfTradePreventionMode
+	}
+	for k, v := range extra {
+		m[k] = v
+	}
+	r.setFormParams(m)
+	data, err = s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+// Do send request
+func (s *CreateSOROrderService) Do(ctx context.Context, opts ...RequestOption) (res *CreateOrderResponse, err error) {
+	// POST /api/v3/sor/order | 使用智能订单路由 (SOR) 下单 (TRADE)
+	data, err := s.createOrder(ctx, "/api/v3/sor/order", nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CreateOrderResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Test send test api to check if the request is valid
+func (s *CreateSOROrderService) Test(ctx context.Context, opts ...RequestOption) (err error) {
+	_, err = s.createOrder(ctx, "/api/v3/sor/order/test", nil, opts...)
+	return err
+}
+
+// TestCommission send test api with computeCommissionRates=true, the response holds the commission the order would pay
+func (s *CreateSOROrderService) TestCommission(ctx context.Context, opts ...RequestOption) (res *OrderCommission, err error) {
+	// POST /api/v3/sor/order/test | 测试 SOR 下单并计算手续费 (TRADE)
+	data, err := s.createOrder(ctx, "/api/v3/sor/order/test", params{"computeCommissionRates": true}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(OrderCommission)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// MyAllocationsService list the allocations resulting from SOR order placement
+type MyAllocationsService struct {
+	c                *Client
+	symbol           string
+	startTime        *int64
+	endTime          *int64
+	fromAllocationID *int64
+	limit            *int // Default 500; max 1000.
+	orderID          *int64
+}
+
+// SetSymbol set symbol
+func (s *MyAllocationsService) SetSymbol(symbol string) *MyAllocationsService {
+	s.symbol = symbol
+	return s
+}
+
+// SetStartTime set startTime
+func (s *MyAllocationsService) SetStartTime(startTime int64) *MyAllocationsService {
+	s.startTime = &startTime
+	return s
+}
+
+// SetEndTime set endTime
+func (s *MyAllocationsService) SetEndTime(endTime int64) *MyAllocationsService {
+	s.endTime = &endTime
+	return s
+}
+
+// SetFromAllocationID set fromAllocationID
+func (s *MyAllocationsService) SetFromAllocationID(fromAllocationID int64) *MyAllocationsService {
+	s.fromAllocationID = &fromAllocationID
+	return s
+}
+
+// SetLimit set limit
+func (s *MyAllocationsService) SetLimit(limit int) *MyAllocationsService {
+	s.limit = &limit
+	return s
+}
+
+// SetOrderID set orderID
+func (s *MyAllocationsService) SetOrderID(orderID int64) *MyAllocationsService {
+	s.orderID = &orderID
+	return s
+}
+
+// Do send request
+func (s *MyAllocationsService) Do(ctx context.Context, opts ...RequestOption) (res []*Allocation, err error) {
+	// GET /api/v3/myAllocations | 查询 SOR 订单的分配结果
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/api/v3/myAllocations",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.fromAllocationID != nil {
+		r.setParam("fromAllocationId", *s.fromAllocationID)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	if s.orderID != nil {
+		r.setParam("orderId", *s.orderID)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*Allocation{}, err
+	}
+	res = make([]*Allocation, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*Allocation{}, err
+	}
+	return res, nil
+}
+
+// Allocation define a fill of a SOR order, which may come from another book of the SOR group
+type Allocation struct {
+	Symbol          string `json:"symbol"`
+	AllocationID    int64  `json:"allocationId"`
+	AllocationType  string `json:"allocationType"` // SOR
+	OrderID         int64  `json:"orderId"`
+	OrderListID     int64  `json:"orderListId"`
+	Price           string `json:"price"`
+	Quantity        string `json:"qty"`
+	QuoteQuantity   string `json:"quoteQty"`
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+	Time            int64  `json:"time"`
+	IsBuyer         bool   `json:"isBuyer"`
+	IsMaker         bool   `json:"isMaker"`
+	IsAllocator     bool   `json:"isAllocator"`
+}
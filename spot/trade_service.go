@@ -296,3 +296,91 @@ func (s *RecentTradesService) Do(ctx context.Context, opts ...RequestOption) (re
 	}
 	return res, nil
 }
+
+// ListPreventedMatchesService list orders expired by self-trade prevention
+type ListPreventedMatchesService struct {
+	c                    *Client
+	symbol               string
+	preventedMatchID     *int64
+	orderID              *int64
+	fromPreventedMatchID *int64
+	limit                *int
+}
+
+// SetSymbol set symbol
+func (s *ListPreventedMatchesService) SetSymbol(symbol string) *ListPreventedMatchesService {
+	s.symbol = symbol
+	return s
+}
+
+// SetPreventedMatchID set preventedMatchId
+func (s *ListPreventedMatchesService) SetPreventedMatchID(preventedMatchID int64) *ListPreventedMatchesService {
+	s.preventedMatchID = &preventedMatchID
+	return s
+}
+
+// SetOrderID set orderId
+func (s *ListPreventedMatchesService) SetOrderID(orderID int64) *ListPreventedMatchesService {
+	s.orderID = &orderID
+	return s
+}
+
+// SetFromPreventedMatchID set fromPreventedMatchId, used with orderId
+func (s *ListPreventedMatchesService) SetFromPreventedMatchID(fromPreventedMatchID int64) *ListPreventedMatchesService {
+	s.fromPreventedMatchID = &fromPreventedMatchID
+	return s
+}
+
+// SetLimit set limit, default 500, max 1000
+func (s *ListPreventedMatchesService) SetLimit(limit int) *ListPreventedMatchesService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *ListPreventedMatchesService) Do(ctx context.Context, opts ...RequestOption) (res []*PreventedMatch, err error) {
+	// GET /api/v3/myPreventedMatches | 获取因自成交保护而过期的订单 (USER_DATA)
+	// 按 preventedMatchId 查询, 或按 orderId 查询(可加 fromPreventedMatchId 和 limit)
+	r := &request{
+		method:   http.MethodGet,
+		endpoint: "/api/v3/myPreventedMatches",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.preventedMatchID != nil {
+		r.setParam("preventedMatchId", *s.preventedMatchID)
+	}
+	if s.orderID != nil {
+		r.setParam("orderId", *s.orderID)
+	}
+	if s.fromPreventedMatchID != nil {
+		r.setParam("fromPreventedMatchId", *s.fromPreventedMatchID)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*PreventedMatch{}, err
+	}
+	res = make([]*PreventedMatch, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*PreventedMatch{}, err
+	}
+	return res, nil
+}
+
+// PreventedMatch define a match prevented by self-trade prevention
+type PreventedMatch struct {
+	Symbol                  string `json:"symbol"`
+	PreventedMatchID        int64  `json:"preventedMatchId"`
+	TakerOrderID            int64  `json:"takerOrderId"`
+	MakerSymbol             string `json:"makerSymbol"`
+	MakerOrderID            int64  `json:"makerOrderId"`
+	TradeGroupID            int64  `json:"tradeGroupId"`
+	SelfTradePreventionMode string `json:"selfTradePreventionMode"`
+	Price                   string `json:"price"`
+	MakerPreventedQuantity  string `json:"makerPreventedQuantity"`
+	TransactTime            int64  `json:"transactTime"`
+}